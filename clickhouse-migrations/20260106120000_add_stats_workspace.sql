@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE stats_events ADD COLUMN workspace_id UInt64 DEFAULT 0 AFTER url_token;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE stats_events DROP COLUMN workspace_id;
+-- +goose StatementEnd
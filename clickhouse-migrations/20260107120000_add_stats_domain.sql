@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE stats_events ADD COLUMN domain String DEFAULT '' AFTER url_token;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE stats_events DROP COLUMN domain;
+-- +goose StatementEnd
@@ -7,17 +7,29 @@ import (
 	"io/fs"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
+	"url-shortening-service/internal/application/auth"
+	"url-shortening-service/internal/application/moderation"
+	"url-shortening-service/internal/application/ownership"
+	"url-shortening-service/internal/application/policy"
+	"url-shortening-service/internal/application/retention"
+	"url-shortening-service/internal/application/scanning"
+	"url-shortening-service/internal/application/shortdomains"
 	"url-shortening-service/internal/application/stats"
 	"url-shortening-service/internal/application/urlcases"
+	"url-shortening-service/internal/application/workspaces"
 	"url-shortening-service/internal/domain"
 	"url-shortening-service/internal/infrastructure/database"
 	"url-shortening-service/internal/infrastructure/http"
+	"url-shortening-service/internal/infrastructure/inmemory"
 	"url-shortening-service/internal/infrastructure/kafka/statsbus"
 	"url-shortening-service/internal/infrastructure/location"
 	rediswrap "url-shortening-service/internal/infrastructure/redis"
+	"url-shortening-service/internal/infrastructure/reputation"
+	"url-shortening-service/internal/infrastructure/snowflake"
 
 	clickhousemigrations "url-shortening-service/clickhouse-migrations"
 	postgresmigrations "url-shortening-service/migrations"
@@ -32,6 +44,29 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+const (
+	// passwordAttemptsPrefix is the Redis key prefix of password attempt counters.
+	passwordAttemptsPrefix = "password_attempts:"
+	// passwordMaxAttempts is the number of password attempts allowed per token within a window.
+	passwordMaxAttempts = 5
+	// passwordAttemptsWindow is the window password attempts are counted in.
+	passwordAttemptsWindow = 15 * time.Minute
+	// abuseReportsPrefix is the Redis key prefix of abuse report counters.
+	abuseReportsPrefix = "abuse_reports:"
+	// abuseMaxReports is the number of abuse reports allowed per client IP address within a window.
+	abuseMaxReports = 10
+	// abuseReportsWindow is the window abuse reports are counted in.
+	abuseReportsWindow = time.Hour
+	// cacheInvalidationChannel is the Redis pub/sub channel in-process cache invalidations are propagated on.
+	cacheInvalidationChannel = "cache_invalidation"
+	// rescanBatchSize is the number of mappings loaded at once when rescanning destination URLs.
+	rescanBatchSize = 500
+	// purgeBatchSize is the number of deleted mappings purged at once.
+	purgeBatchSize = 500
+	// workspaceRedirectsPrefix is the Redis key prefix of workspace redirect counters.
+	workspaceRedirectsPrefix = "workspace_redirects:"
+)
+
 func main() {
 	mainCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -39,7 +74,43 @@ func main() {
 	redisHost := "localhost"
 	redisPort := "6379"
 
+	cacheSettings := domain.CacheSettings{
+		TTL:               24 * time.Hour,
+		TTLJitter:         time.Hour,
+		SlidingExpiration: false,
+		NegativeTTL:       30 * time.Second,
+		LocalSize:         0,
+		LocalTTL:          30 * time.Second,
+		ReevictionDelay:   5 * time.Second,
+	}
+
+	tokenStrategy := string(domain.TokenStrategySequential)
+	tokenSecret := ""
+	tokenEncoding := string(domain.TokenEncodingBase62)
+	tokenMinLength := 0
+	tokenCheckDigit := false
+	tokenSuggestions := false
+
+	idGeneratorKind := string(domain.IdGeneratorRedis)
+	idSettings := domain.IdAllocationSettings{
+		LeaseSize:      0,
+		ReseedGap:      1_000_000,
+		WorkerId:       -1,
+		WorkerLeaseTTL: 30 * time.Second,
+	}
+
 	serverPort := "8080"
+	var shortDomains []string
+	publicBaseUrl := ""
+	var publicDomainBaseUrls []string
+	destinationPolicyRefresh := 30 * time.Second
+	urlScanList := ""
+	urlRescanInterval := time.Hour
+	deletedRetention := 30 * 24 * time.Hour
+	purgeInterval := time.Hour
+	adminApiKey := ""
+	workspaceRefresh := 30 * time.Second
+	shortDomainRefresh := 30 * time.Second
 
 	kafkaHost := "localhost"
 	kafkaPort := "9094"
@@ -61,7 +132,16 @@ func main() {
 
 	trySetEnvVariable(domain.RedisUrlEnv, &redisHost)
 	trySetEnvVariable(domain.RedisPortEnv, &redisPort)
+	trySetEnvVariable(domain.TokenStrategyEnv, &tokenStrategy)
+	trySetEnvVariable(domain.TokenSecretEnv, &tokenSecret)
+	trySetEnvVariable(domain.TokenEncodingEnv, &tokenEncoding)
+	trySetEnvVariable(domain.IdGeneratorEnv, &idGeneratorKind)
 	trySetEnvVariable(domain.ServerPortEnv, &serverPort)
+	trySetListEnvVariable(domain.ShortDomainsEnv, &shortDomains)
+	trySetEnvVariable(domain.PublicBaseUrlEnv, &publicBaseUrl)
+	trySetListEnvVariable(domain.PublicDomainBaseUrlsEnv, &publicDomainBaseUrls)
+	trySetEnvVariable(domain.UrlScanListEnv, &urlScanList)
+	trySetEnvVariable(domain.AdminApiKeyEnv, &adminApiKey)
 	trySetEnvVariable(domain.DatabaseUserEnv, &databaseSettings.User)
 	trySetEnvVariable(domain.DatabasePasswordEnv, &databaseSettings.Password)
 	trySetEnvVariable(domain.DatabaseHostEnv, &databaseSettings.Host)
@@ -82,6 +162,141 @@ func main() {
 		}
 	}
 
+	err := trySetDurationEnvVariable(domain.CacheTTLEnv, &cacheSettings.TTL)
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.CacheTTLJitterEnv, &cacheSettings.TTLJitter)
+	}
+	if err == nil {
+		err = trySetBoolEnvVariable(domain.CacheSlidingExpirationEnv, &cacheSettings.SlidingExpiration)
+	}
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.CacheNegativeTTLEnv, &cacheSettings.NegativeTTL)
+	}
+	if err == nil {
+		err = trySetIntEnvVariable(domain.CacheLocalSizeEnv, &cacheSettings.LocalSize)
+	}
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.CacheLocalTTLEnv, &cacheSettings.LocalTTL)
+	}
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.CacheReevictionDelayEnv, &cacheSettings.ReevictionDelay)
+	}
+	if err == nil {
+		err = cacheSettings.Validate()
+	}
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid cache configuration: %v", err))
+		return
+	}
+
+	err = trySetIntEnvVariable(domain.TokenMinLengthEnv, &tokenMinLength)
+	if err == nil {
+		err = trySetBoolEnvVariable(domain.TokenCheckDigitEnv, &tokenCheckDigit)
+	}
+	if err == nil {
+		err = trySetBoolEnvVariable(domain.TokenSuggestionsEnv, &tokenSuggestions)
+	}
+	var tokenEncoder *domain.AlphabetEncoder
+	if err == nil {
+		tokenEncoder, err = domain.NewTokenEncoder(domain.TokenEncoding(tokenEncoding), tokenMinLength, tokenCheckDigit)
+	}
+	var tokenGenerator domain.TokenGenerator
+	if err == nil {
+		tokenGenerator, err = domain.NewTokenGenerator(domain.TokenStrategy(tokenStrategy), tokenSecret, tokenEncoder)
+	}
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid token configuration: %v", err))
+		return
+	}
+
+	idSettings.Generator = domain.IdGeneratorKind(idGeneratorKind)
+	err = trySetIntEnvVariable(domain.IdLeaseSizeEnv, &idSettings.LeaseSize)
+	if err == nil {
+		err = trySetIntEnvVariable(domain.IdReseedGapEnv, &idSettings.ReseedGap)
+	}
+	if err == nil {
+		err = trySetIntEnvVariable(domain.SnowflakeWorkerIdEnv, &idSettings.WorkerId)
+	}
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.SnowflakeWorkerLeaseTTLEnv, &idSettings.WorkerLeaseTTL)
+	}
+	if err == nil {
+		err = idSettings.Validate()
+	}
+	if err == nil && idSettings.Generator == domain.IdGeneratorSnowflake && domain.TokenStrategy(tokenStrategy) == domain.TokenStrategyFeistel {
+		err = fmt.Errorf("the feistel token strategy does not support snowflake ids")
+	}
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid id allocation configuration: %v", err))
+		return
+	}
+
+	publicBaseUrls, err := domain.NewPublicBaseUrls(publicBaseUrl, publicDomainBaseUrls)
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid public base URL configuration: %v", err))
+		return
+	}
+
+	urlValidator, err := domain.NewDestinationValidator(append(shortDomains, publicBaseUrls.Hosts()...))
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.DestinationPolicyRefreshEnv, &destinationPolicyRefresh)
+	}
+	if err == nil && destinationPolicyRefresh <= 0 {
+		err = fmt.Errorf("destination policy refresh interval must be positive")
+	}
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.UrlRescanIntervalEnv, &urlRescanInterval)
+	}
+	if err == nil && urlRescanInterval <= 0 {
+		err = fmt.Errorf("URL rescan interval must be positive")
+	}
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid destination configuration: %v", err))
+		return
+	}
+
+	err = trySetDurationEnvVariable(domain.DeletedRetentionEnv, &deletedRetention)
+	if err == nil && deletedRetention <= 0 {
+		err = fmt.Errorf("deleted URL retention must be positive")
+	}
+	if err == nil {
+		err = trySetDurationEnvVariable(domain.PurgeIntervalEnv, &purgeInterval)
+	}
+	if err == nil && purgeInterval <= 0 {
+		err = fmt.Errorf("purge interval must be positive")
+	}
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid retention configuration: %v", err))
+		return
+	}
+
+	err = trySetDurationEnvVariable(domain.WorkspaceRefreshEnv, &workspaceRefresh)
+	if err == nil && workspaceRefresh <= 0 {
+		err = fmt.Errorf("workspace refresh interval must be positive")
+	}
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid workspace configuration: %v", err))
+		return
+	}
+
+	err = trySetDurationEnvVariable(domain.ShortDomainRefreshEnv, &shortDomainRefresh)
+	if err == nil && shortDomainRefresh <= 0 {
+		err = fmt.Errorf("short domain refresh interval must be positive")
+	}
+	if err != nil {
+		domain.StdoutLogger.Error(fmt.Sprintf("Invalid short domain configuration: %v", err))
+		return
+	}
+
+	var urlScanner domain.URLScanner = reputation.NopScanner{}
+	if urlScanList != "" {
+		urlScanner, err = reputation.LoadHashPrefixList(urlScanList)
+		if err != nil {
+			domain.StdoutLogger.Error(fmt.Sprintf("Invalid URL scan list: %v", err))
+			return
+		}
+	}
+
 	clickhouseSettings := &clickhouse.Options{
 		Addr: []string{fmt.Sprintf("%s:%s", clickhouseHost, clickhousePort)},
 		Auth: clickhouse.Auth{
@@ -105,7 +320,7 @@ func main() {
 	databaseUrl := databaseSettings.GetUrl()
 	kafkaUrl := kafkaHost + ":" + kafkaPort
 
-	err := migrateDatabase(databaseUrl, &postgresmigrations.PostgresMigrations, ".", "pgx", "postgres")
+	err = migrateDatabase(databaseUrl, &postgresmigrations.PostgresMigrations, ".", "pgx", "postgres")
 	if err != nil {
 		logger.Error(fmt.Sprintf("Database migration failed: %v", err))
 		return
@@ -145,23 +360,97 @@ func main() {
 
 	storage := database.NewPostgresStorage(dbpool, logger)
 	statsStorage := database.NewClickhouseStatsStorage(clickhouseConn)
-	cache := rediswrap.NewRedisStorage(redisClient, logger)
+	var cache domain.MappingCache = rediswrap.NewRedisStorage(redisClient, cacheSettings, logger)
+	var localCache *inmemory.LRUCache
+	if cacheSettings.LocalSize > 0 {
+		invalidationBus := rediswrap.NewRedisInvalidationBus(redisClient, cacheInvalidationChannel, logger)
+		localCache = inmemory.NewLRUCache(cache, invalidationBus, cacheSettings.LocalSize, cacheSettings.LocalTTL)
+		cache = localCache
+
+		invalidations := redisClient.Subscribe(mainCtx, cacheInvalidationChannel)
+		defer invalidations.Close()
+		go invalidationBus.Listen(mainCtx, invalidations.Channel(), localCache.Invalidate)
+	}
+	clickCounter := rediswrap.NewRedisClickCounter(redisClient)
+	passwordLimiter := rediswrap.NewRedisAttemptLimiter(redisClient, passwordAttemptsPrefix, passwordMaxAttempts, passwordAttemptsWindow)
+	abuseReportLimiter := rediswrap.NewRedisAttemptLimiter(redisClient, abuseReportsPrefix, abuseMaxReports, abuseReportsWindow)
+
+	destinationPolicy := policy.NewDestinationPolicyService(database.NewPostgresDestinationRuleStore(dbpool), urlValidator, logger)
+	err = destinationPolicy.Reload(mainCtx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load destination policy: %v", err))
+		return
+	}
+	go destinationPolicy.KeepReloading(mainCtx, destinationPolicyRefresh)
 
-	idGenerator, err := rediswrap.NewRedisIdGenerator(mainCtx, redisClient, storage)
+	workspaceRedirectLimiter := rediswrap.NewRedisRateLimiter(redisClient, workspaceRedirectsPrefix)
+	workspaceService := workspaces.NewWorkspaceService(database.NewPostgresWorkspaceStore(dbpool), storage, workspaceRedirectLimiter, logger)
+	err = workspaceService.Reload(mainCtx)
 	if err != nil {
-		logger.Error("Failed to create Redis ID generator")
+		logger.Error(fmt.Sprintf("Failed to load workspaces: %v", err))
 		return
 	}
+	go workspaceService.KeepReloading(mainCtx, workspaceRefresh)
+
+	shortDomainService := shortdomains.NewShortDomainService(database.NewPostgresShortDomainStore(dbpool), logger)
+	err = shortDomainService.Reload(mainCtx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load short domains: %v", err))
+		return
+	}
+	go shortDomainService.KeepReloading(mainCtx, shortDomainRefresh)
+
+	if urlScanList != "" {
+		rescanner := scanning.NewRescanner(storage, urlScanner, cache, rescanBatchSize, logger)
+		go rescanner.KeepRescanning(mainCtx, urlRescanInterval)
+	}
+
+	purger := retention.NewPurger(storage, deletedRetention, purgeBatchSize, logger)
+	go purger.KeepPurging(mainCtx, purgeInterval)
+
+	var idGenerator domain.IdGenerator
+	if idSettings.Generator == domain.IdGeneratorSnowflake {
+		var workerIdProvider domain.WorkerIdProvider = snowflake.StaticWorkerId(idSettings.WorkerId)
+		if idSettings.WorkerId < 0 {
+			hostname, _ := os.Hostname()
+			owner := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+			leaseStore := database.NewPostgresWorkerLeaseStore(dbpool)
+			workerLease, err := snowflake.AcquireWorkerLease(mainCtx, leaseStore, owner, idSettings.WorkerLeaseTTL, logger)
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to lease Snowflake worker ID: %v", err))
+				return
+			}
+			go workerLease.KeepAlive(mainCtx)
+			workerIdProvider = workerLease
+		}
+		idGenerator = snowflake.NewGenerator(workerIdProvider)
+	} else {
+		redisIdGenerator, err := rediswrap.NewRedisIdGenerator(mainCtx, redisClient, storage, int64(idSettings.ReseedGap), logger)
+		if err != nil {
+			logger.Error("Failed to create Redis ID generator")
+			return
+		}
+		idGenerator = redisIdGenerator
+		if idSettings.LeaseSize > 0 {
+			idGenerator = inmemory.NewIdLeaser(redisIdGenerator, int64(idSettings.LeaseSize), logger)
+		}
+	}
 
 	ipLocator := location.NewGeoIpLocator(geo2ipDb)
 
-	getUrlCase := urlcases.NewUrlGetter(cache, storage, logger)
-	shortenUrlCase := urlcases.NewUrlShortener(idGenerator, storage)
-	updateUrlCase := urlcases.NewUrlUpdater(cache, storage, logger)
-	deleteUrlCase := urlcases.NewUrlDeleter(cache, storage, logger)
+	ownershipAuthorizer := ownership.NewAuthorizer(storage)
+	getUrlCase := urlcases.NewUrlGetter(cache, storage, clickCounter, storage, passwordLimiter, workspaceService, logger)
+	shortenUrlCase := urlcases.NewUrlShortener(idGenerator, tokenGenerator, tokenEncoder, destinationPolicy, urlScanner, workspaceService, shortDomainService, storage, storage, cache, logger)
+	readUrlCase := urlcases.NewUrlReader(storage, ownershipAuthorizer)
+	updateUrlCase := urlcases.NewUrlUpdater(cache, storage, ownershipAuthorizer, destinationPolicy, urlScanner,
+		cacheSettings.ReevictionDelay, logger)
+	deleteUrlCase := urlcases.NewUrlDeleter(cache, storage, ownershipAuthorizer, logger)
+	restoreUrlCase := urlcases.NewUrlRestorer(cache, storage, ownershipAuthorizer, logger)
+	apiKeyService := auth.NewApiKeyService(database.NewPostgresApiKeyStore(dbpool), adminApiKey, logger)
+	moderationService := moderation.NewModerationService(database.NewPostgresAbuseReportStore(dbpool), storage, cache, abuseReportLimiter, logger)
 
 	statsProcessor := stats.NewRedirectStatsProcessor(statsStorage, ipLocator, logger)
-	statsCalculator := database.NewClickhouseStatsCalculator(clickhouseConn)
+	statsCalculator := stats.NewOwnedStatisticsCalculator(database.NewClickhouseStatsCalculator(clickhouseConn), ownershipAuthorizer)
 
 	topicId := "url_stats_events"
 	groupId := "url_stats_group"
@@ -182,8 +471,9 @@ func main() {
 
 	go eventConsumer.StartConsuming(mainCtx)
 
-	server := http.NewSimpleServer(shortenUrlCase, getUrlCase, updateUrlCase, deleteUrlCase,
-		eventProducer, statsCalculator, logger, serverPort)
+	server := http.NewSimpleServer(shortenUrlCase, getUrlCase, getUrlCase, getUrlCase, readUrlCase, tokenEncoder, tokenSuggestions, updateUrlCase, deleteUrlCase,
+		restoreUrlCase, eventProducer, statsCalculator, destinationPolicy, moderationService, moderationService,
+		apiKeyService, apiKeyService, workspaceService, shortDomainService, shortDomainService, publicBaseUrls, logger, serverPort)
 
 	logger.Info("Starting server")
 	go server.Start()
@@ -197,6 +487,12 @@ func main() {
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		logger.Error("Server shutdown failed: " + err.Error())
 	}
+
+	if localCache != nil {
+		cacheStats := localCache.Stats()
+		logger.Info(fmt.Sprintf("Local cache stats: %d hits, %d misses, %d mappings",
+			cacheStats.Hits, cacheStats.Misses, cacheStats.Size))
+	}
 }
 
 func trySetEnvVariable(envName string, val *string) {
@@ -205,6 +501,53 @@ func trySetEnvVariable(envName string, val *string) {
 	}
 }
 
+func trySetListEnvVariable(envName string, val *[]string) {
+	if envVal, found := os.LookupEnv(envName); found {
+		*val = nil
+		for _, item := range strings.Split(envVal, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*val = append(*val, item)
+			}
+		}
+	}
+}
+
+func trySetDurationEnvVariable(envName string, val *time.Duration) error {
+	if envVal, found := os.LookupEnv(envName); found {
+		duration, err := time.ParseDuration(envVal)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", envName, err)
+		}
+		*val = duration
+	}
+
+	return nil
+}
+
+func trySetIntEnvVariable(envName string, val *int) error {
+	if envVal, found := os.LookupEnv(envName); found {
+		number, err := strconv.Atoi(envVal)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", envName, err)
+		}
+		*val = number
+	}
+
+	return nil
+}
+
+func trySetBoolEnvVariable(envName string, val *bool) error {
+	if envVal, found := os.LookupEnv(envName); found {
+		flag, err := strconv.ParseBool(envVal)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", envName, err)
+		}
+		*val = flag
+	}
+
+	return nil
+}
+
 func migrateDatabase(databaseUrl string, migrations fs.FS, dir, driverName, dialect string) error {
 	db, err := sql.Open(driverName, databaseUrl)
 	if err != nil {
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"url-shortening-service/internal/domain"
+)
+
+// bootstrapApiKey is the key requests authenticated with the configured bootstrap key act as.
+var bootstrapApiKey = domain.ApiKey{Name: "bootstrap", Scopes: []domain.ApiKeyScope{domain.ScopeAdmin}}
+
+// ApiKeyService authenticates requests by their API key and administers the API keys.
+// An optional bootstrap key with the admin scope is accepted without being stored,
+// so the first keys can be created.
+type ApiKeyService struct {
+	store        domain.ApiKeyStore
+	bootstrapKey string
+	logger       domain.Logger
+}
+
+// NewApiKeyService creates a new ApiKeyService instance.
+// Parameters:
+//   - store: persistent storage of API keys (e.g., PostgreSQL)
+//   - bootstrapKey: key that is always accepted with the admin scope; empty disables it
+//   - logger: logger for recording info messages
+func NewApiKeyService(store domain.ApiKeyStore, bootstrapKey string, logger domain.Logger) *ApiKeyService {
+	return &ApiKeyService{
+		store:        store,
+		bootstrapKey: bootstrapKey,
+		logger:       logger,
+	}
+}
+
+// Authenticate returns the active API key matching the presented key.
+//
+// Returns an error if:
+//   - *domain.UnauthorizedError: the key is unknown or revoked
+//   - Storage operation fails
+func (s *ApiKeyService) Authenticate(ctx context.Context, key string) (domain.ApiKey, error) {
+	if s.bootstrapKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.bootstrapKey)) == 1 {
+		return bootstrapApiKey, nil
+	}
+
+	if !strings.HasPrefix(key, domain.ApiKeyPrefix) {
+		return domain.ApiKey{}, &domain.UnauthorizedError{Msg: "Malformed API key"}
+	}
+
+	apiKey, err := s.store.GetApiKeyByHash(ctx, domain.HashApiKey(key))
+	if errors.Is(err, &domain.ApiKeyNonExistingError{}) {
+		return domain.ApiKey{}, &domain.UnauthorizedError{Msg: "Unknown or revoked API key"}
+	} else if err != nil {
+		return domain.ApiKey{}, fmt.Errorf("looking up API key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// CreateKey creates a new API key with the name, owner, workspace and scopes of the given key.
+// Returns the created key together with the key itself, which is only stored as a hash
+// and cannot be retrieved again.
+//
+// Returns an error if:
+//   - *domain.InvalidApiKeyError: the name is missing or too long, the owner is too long, a scope is unknown,
+//     or a key of a workspace has the admin scope
+//   - *domain.WorkspaceNonExistingError: the workspace of the key does not exist
+//   - Generating or storing the key fails
+func (s *ApiKeyService) CreateKey(ctx context.Context, key domain.ApiKey) (domain.ApiKey, string, error) {
+	key, err := domain.NormalizeApiKey(key)
+	if err != nil {
+		return domain.ApiKey{}, "", err
+	}
+
+	rawKey, prefix, err := domain.GenerateApiKey()
+	if err != nil {
+		return domain.ApiKey{}, "", err
+	}
+	key.Prefix = prefix
+
+	created, err := s.store.AddApiKey(ctx, key, domain.HashApiKey(rawKey))
+	if err != nil {
+		return domain.ApiKey{}, "", err
+	}
+
+	s.logger.Info(fmt.Sprintf("Created API key %d (%s) with scopes %v", created.Id, created.Prefix, created.Scopes))
+	return created, rawKey, nil
+}
+
+// ListKeys returns all API keys, including revoked ones, oldest first.
+//
+// Returns an error if the storage operation fails.
+func (s *ApiKeyService) ListKeys(ctx context.Context) ([]domain.ApiKey, error) {
+	return s.store.ListApiKeys(ctx)
+}
+
+// RevokeKey revokes an active API key, so requests are no longer authenticated with it.
+//
+// Returns an error if:
+//   - *domain.ApiKeyNonExistingError: no active key with the ID exists
+//   - Storage operation fails
+func (s *ApiKeyService) RevokeKey(ctx context.Context, id int64) error {
+	err := s.store.RevokeApiKey(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info(fmt.Sprintf("Revoked API key %d", id))
+	return nil
+}
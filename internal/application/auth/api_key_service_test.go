@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const exampleRawKey = "usk_0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func newTestService(store domain.ApiKeyStore, bootstrapKey string) *ApiKeyService {
+	return NewApiKeyService(store, bootstrapKey, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestApiKeyService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	storedKey := domain.ApiKey{Id: 1, Name: "ci", Prefix: "usk_01234567", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}
+
+	type testCase struct {
+		name          string
+		key           string
+		bootstrapKey  string
+		expectedKey   domain.ApiKey
+		expectedError error
+
+		setupMocks func(store *mocks.MockApiKeyStore)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Success - stored key",
+			key:         exampleRawKey,
+			expectedKey: storedKey,
+			setupMocks: func(store *mocks.MockApiKeyStore) {
+				store.EXPECT().GetApiKeyByHash(gomock.Any(), domain.HashApiKey(exampleRawKey)).Return(storedKey, nil)
+			},
+		},
+		{
+			name:         "Success - bootstrap key",
+			key:          "bootstrap-secret",
+			bootstrapKey: "bootstrap-secret",
+			expectedKey:  bootstrapApiKey,
+			setupMocks:   func(store *mocks.MockApiKeyStore) {},
+		},
+		{
+			name:          "Error - malformed key",
+			key:           "not-a-key",
+			bootstrapKey:  "bootstrap-secret",
+			expectedError: &domain.UnauthorizedError{},
+			setupMocks:    func(store *mocks.MockApiKeyStore) {},
+		},
+		{
+			name:          "Error - empty key with bootstrap key disabled",
+			key:           "",
+			expectedError: &domain.UnauthorizedError{},
+			setupMocks:    func(store *mocks.MockApiKeyStore) {},
+		},
+		{
+			name:          "Error - unknown or revoked key",
+			key:           exampleRawKey,
+			expectedError: &domain.UnauthorizedError{},
+			setupMocks: func(store *mocks.MockApiKeyStore) {
+				store.EXPECT().GetApiKeyByHash(gomock.Any(), domain.HashApiKey(exampleRawKey)).Return(domain.ApiKey{}, &domain.ApiKeyNonExistingError{})
+			},
+		},
+		{
+			name:          "Error - storage failure",
+			key:           exampleRawKey,
+			expectedError: assert.AnError,
+			setupMocks: func(store *mocks.MockApiKeyStore) {
+				store.EXPECT().GetApiKeyByHash(gomock.Any(), domain.HashApiKey(exampleRawKey)).Return(domain.ApiKey{}, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := mocks.NewMockApiKeyStore(ctrl)
+			tt.setupMocks(store)
+
+			key, err := newTestService(store, tt.bootstrapKey).Authenticate(context.Background(), tt.key)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKey, key)
+			}
+		})
+	}
+}
+
+func TestApiKeyService_CreateKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockApiKeyStore(ctrl)
+	service := newTestService(store, "")
+
+	var storedHash string
+	store.EXPECT().AddApiKey(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key domain.ApiKey, keyHash string) (domain.ApiKey, error) {
+			assert.Equal(t, "ci", key.Name)
+			assert.Equal(t, "ci", key.Owner)
+			assert.Equal(t, []domain.ApiKeyScope{domain.ScopeLinksWrite, domain.ScopeStatsRead}, key.Scopes)
+			assert.True(t, strings.HasPrefix(key.Prefix, domain.ApiKeyPrefix))
+			storedHash = keyHash
+			key.Id = 1
+			return key, nil
+		})
+
+	created, rawKey, err := service.CreateKey(context.Background(), domain.ApiKey{
+		Name:   " ci ",
+		Scopes: []domain.ApiKeyScope{domain.ScopeStatsRead, domain.ScopeLinksWrite},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), created.Id)
+	assert.True(t, strings.HasPrefix(rawKey, created.Prefix))
+	assert.Equal(t, domain.HashApiKey(rawKey), storedHash)
+
+	_, _, err = service.CreateKey(context.Background(), domain.ApiKey{Name: "ci", Scopes: []domain.ApiKeyScope{"everything"}})
+	assert.ErrorIs(t, err, &domain.InvalidApiKeyError{})
+}
+
+func TestApiKeyService_RevokeKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockApiKeyStore(ctrl)
+	store.EXPECT().RevokeApiKey(gomock.Any(), int64(1)).Return(nil)
+	store.EXPECT().RevokeApiKey(gomock.Any(), int64(2)).Return(&domain.ApiKeyNonExistingError{})
+	service := newTestService(store, "")
+
+	assert.NoError(t, service.RevokeKey(context.Background(), 1))
+	assert.ErrorIs(t, service.RevokeKey(context.Background(), 2), &domain.ApiKeyNonExistingError{})
+}
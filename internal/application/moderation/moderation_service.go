@@ -0,0 +1,156 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"url-shortening-service/internal/domain"
+)
+
+// ModerationService collects abuse reports of short URLs and lets administrators review them
+// and take the reported links down. Taken down links keep their mapping and statistics,
+// but no longer redirect until they are reinstated.
+type ModerationService struct {
+	reports         domain.AbuseReportStore
+	disabler        domain.MappingDisabler
+	cache           domain.UrlTokenDeleter
+	reporterLimiter domain.AttemptLimiter
+	logger          domain.Logger
+}
+
+// NewModerationService creates a new ModerationService instance.
+// Parameters:
+//   - reports: persistent storage of abuse reports (e.g., PostgreSQL)
+//   - disabler: persistent storage the mappings are taken down in (e.g., PostgreSQL)
+//   - cache: cache storage stale mappings are evicted from after a takedown (e.g., Redis)
+//   - reporterLimiter: per-IP throttling of abuse reports
+//   - logger: logger for recording warnings and info messages
+func NewModerationService(reports domain.AbuseReportStore, disabler domain.MappingDisabler, cache domain.UrlTokenDeleter,
+	reporterLimiter domain.AttemptLimiter, logger domain.Logger) *ModerationService {
+	return &ModerationService{
+		reports:         reports,
+		disabler:        disabler,
+		cache:           cache,
+		reporterLimiter: reporterLimiter,
+		logger:          logger,
+	}
+}
+
+// ReportAbuse files an open abuse report of a short URL for review.
+// Reports are throttled per reporter IP address.
+// Returns the created AbuseReport.
+//
+// Returns an error if:
+//   - *domain.InvalidAbuseReportError: the reason is missing or the reason or contact is too long
+//   - *domain.TooManyAttemptsError: too many reports were made from the reporter IP address
+//   - *domain.TokenNonExistingError: the reported token does not exist
+//   - Registering the attempt or storing the report fails
+func (s *ModerationService) ReportAbuse(ctx context.Context, report domain.AbuseReport) (domain.AbuseReport, error) {
+	report, err := domain.NormalizeAbuseReport(report)
+	if err != nil {
+		return domain.AbuseReport{}, err
+	}
+
+	allowed, err := s.reporterLimiter.TryAttempt(ctx, report.ReporterIP)
+	if err != nil {
+		return domain.AbuseReport{}, fmt.Errorf("registering abuse report attempt: %w", err)
+	} else if !allowed {
+		return domain.AbuseReport{}, &domain.TooManyAttemptsError{Msg: fmt.Sprintf("too many abuse reports from %s", report.ReporterIP)}
+	}
+
+	created, err := s.reports.AddAbuseReport(ctx, report)
+	if err != nil {
+		return domain.AbuseReport{}, err
+	}
+
+	s.logger.Info(fmt.Sprintf("Abuse report %d filed for token: %s", created.Id, created.Token))
+	return created, nil
+}
+
+// ListReports returns all abuse reports with the given status, oldest first.
+//
+// Returns an error if:
+//   - *domain.InvalidAbuseReportError: the status is unknown
+//   - Storage operation fails
+func (s *ModerationService) ListReports(ctx context.Context, status domain.AbuseReportStatus) ([]domain.AbuseReport, error) {
+	if !status.IsValid() {
+		return nil, &domain.InvalidAbuseReportError{Msg: fmt.Sprintf("Unknown abuse report status: %q", status)}
+	}
+
+	return s.reports.ListAbuseReports(ctx, status)
+}
+
+// DismissReport resolves an open abuse report without taking the reported link down.
+//
+// Returns an error if:
+//   - *domain.AbuseReportNonExistingError: no open report with the ID exists
+//   - Storage operation fails
+func (s *ModerationService) DismissReport(ctx context.Context, id int64) error {
+	err := s.reports.ResolveAbuseReport(ctx, id, domain.AbuseReportDismissed)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info(fmt.Sprintf("Abuse report %d dismissed", id))
+	return nil
+}
+
+// TakeDown disables a short URL for the given reason, so it no longer redirects, and resolves
+// its open abuse reports as actioned. The cached mapping is evicted, so the takedown applies
+// to the next redirect. If the eviction fails, the takedown is already committed to storage
+// and the error is returned, so the caller can retry the (idempotent) takedown.
+//
+// Returns an error if:
+//   - *domain.InvalidAbuseReportError: the reason is missing or too long
+//   - *domain.TokenNonExistingError: the token does not exist
+//   - Storage operation or cache eviction fails
+func (s *ModerationService) TakeDown(ctx context.Context, urlToken string, reason string) error {
+	reason, err := domain.NormalizeAbuseReason(reason)
+	if err != nil {
+		return err
+	}
+
+	err = s.setDisabledReason(ctx, urlToken, reason)
+	if err != nil {
+		return err
+	}
+
+	err = s.reports.ResolveTokenAbuseReports(ctx, urlToken, domain.AbuseReportActioned)
+	if err != nil {
+		return fmt.Errorf("resolving abuse reports: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf("Took down token %s: %s", urlToken, reason))
+	return nil
+}
+
+// Reinstate lifts the takedown of a short URL, so it redirects again.
+// Reinstating a link that is not taken down has no effect.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist
+//   - Storage operation or cache eviction fails
+func (s *ModerationService) Reinstate(ctx context.Context, urlToken string) error {
+	err := s.setDisabledReason(ctx, urlToken, "")
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info(fmt.Sprintf("Reinstated token: %s", urlToken))
+	return nil
+}
+
+// setDisabledReason stores the takedown reason of the mapping and evicts the stale cached mapping.
+func (s *ModerationService) setDisabledReason(ctx context.Context, urlToken string, reason string) error {
+	err := s.disabler.DisableMapping(ctx, urlToken, reason)
+	if err != nil {
+		return err
+	}
+
+	err = s.cache.DeleteMapping(ctx, urlToken)
+	if err != nil && !errors.Is(err, &domain.TokenNonExistingError{}) {
+		return fmt.Errorf("evicting cached mapping: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package moderation
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type moderationMocks struct {
+	reports  *mocks.MockAbuseReportStore
+	disabler *mocks.MockMappingDisabler
+	cache    *mocks.MockUrlTokenDeleter
+	limiter  *mocks.MockAttemptLimiter
+}
+
+func newModerationMocks(ctrl *gomock.Controller) moderationMocks {
+	return moderationMocks{
+		reports:  mocks.NewMockAbuseReportStore(ctrl),
+		disabler: mocks.NewMockMappingDisabler(ctrl),
+		cache:    mocks.NewMockUrlTokenDeleter(ctrl),
+		limiter:  mocks.NewMockAttemptLimiter(ctrl),
+	}
+}
+
+func (m moderationMocks) newService() *ModerationService {
+	return NewModerationService(m.reports, m.disabler, m.cache, m.limiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestModerationService_ReportAbuse(t *testing.T) {
+	t.Parallel()
+
+	normalized := domain.AbuseReport{Token: "abc123", Reason: "phishing page", Contact: "me@example.com", ReporterIP: "203.0.113.7"}
+
+	type testCase struct {
+		name          string
+		report        domain.AbuseReport
+		expectedError error
+
+		setupMocks func(m moderationMocks)
+	}
+
+	testCases := []testCase{
+		{
+			name:   "Success - report filed",
+			report: domain.AbuseReport{Token: "abc123", Reason: " phishing page ", Contact: "me@example.com", ReporterIP: "203.0.113.7"},
+			setupMocks: func(m moderationMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "203.0.113.7").Return(true, nil)
+				created := normalized
+				created.Id = 1
+				created.Status = domain.AbuseReportOpen
+				m.reports.EXPECT().AddAbuseReport(gomock.Any(), normalized).Return(created, nil)
+			},
+		},
+		{
+			name:          "Error - missing reason",
+			report:        domain.AbuseReport{Token: "abc123", ReporterIP: "203.0.113.7"},
+			expectedError: &domain.InvalidAbuseReportError{},
+			setupMocks:    func(m moderationMocks) {},
+		},
+		{
+			name:          "Error - too many reports",
+			report:        normalized,
+			expectedError: &domain.TooManyAttemptsError{},
+			setupMocks: func(m moderationMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "203.0.113.7").Return(false, nil)
+			},
+		},
+		{
+			name:          "Error - limiter failure",
+			report:        normalized,
+			expectedError: assert.AnError,
+			setupMocks: func(m moderationMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "203.0.113.7").Return(false, assert.AnError)
+			},
+		},
+		{
+			name:          "Error - token not found",
+			report:        normalized,
+			expectedError: &domain.TokenNonExistingError{},
+			setupMocks: func(m moderationMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "203.0.113.7").Return(true, nil)
+				m.reports.EXPECT().AddAbuseReport(gomock.Any(), normalized).Return(domain.AbuseReport{}, &domain.TokenNonExistingError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newModerationMocks(ctrl)
+			tt.setupMocks(m)
+
+			created, err := m.newService().ReportAbuse(context.Background(), tt.report)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), created.Id)
+				assert.Equal(t, "phishing page", created.Reason)
+			}
+		})
+	}
+}
+
+func TestModerationService_ListReports(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newModerationMocks(ctrl)
+	reports := []domain.AbuseReport{{Id: 1, Token: "abc123", Reason: "spam", Status: domain.AbuseReportOpen}}
+	m.reports.EXPECT().ListAbuseReports(gomock.Any(), domain.AbuseReportOpen).Return(reports, nil)
+	service := m.newService()
+
+	listed, err := service.ListReports(context.Background(), domain.AbuseReportOpen)
+	assert.NoError(t, err)
+	assert.Equal(t, reports, listed)
+
+	_, err = service.ListReports(context.Background(), domain.AbuseReportStatus("closed"))
+	assert.ErrorIs(t, err, &domain.InvalidAbuseReportError{})
+}
+
+func TestModerationService_DismissReport(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newModerationMocks(ctrl)
+	m.reports.EXPECT().ResolveAbuseReport(gomock.Any(), int64(1), domain.AbuseReportDismissed).Return(nil)
+	m.reports.EXPECT().ResolveAbuseReport(gomock.Any(), int64(2), domain.AbuseReportDismissed).Return(&domain.AbuseReportNonExistingError{})
+	service := m.newService()
+
+	assert.NoError(t, service.DismissReport(context.Background(), 1))
+	assert.ErrorIs(t, service.DismissReport(context.Background(), 2), &domain.AbuseReportNonExistingError{})
+}
+
+func TestModerationService_TakeDown(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		reason        string
+		expectedError error
+
+		setupMocks func(m moderationMocks)
+	}
+
+	testCases := []testCase{
+		{
+			name:   "Success - link taken down and reports actioned",
+			reason: " confirmed phishing ",
+			setupMocks: func(m moderationMocks) {
+				m.disabler.EXPECT().DisableMapping(gomock.Any(), "abc123", "confirmed phishing").Return(nil)
+				m.cache.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+				m.reports.EXPECT().ResolveTokenAbuseReports(gomock.Any(), "abc123", domain.AbuseReportActioned).Return(nil)
+			},
+		},
+		{
+			name:   "Success - uncached link taken down",
+			reason: "spam",
+			setupMocks: func(m moderationMocks) {
+				m.disabler.EXPECT().DisableMapping(gomock.Any(), "abc123", "spam").Return(nil)
+				m.cache.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(&domain.TokenNonExistingError{})
+				m.reports.EXPECT().ResolveTokenAbuseReports(gomock.Any(), "abc123", domain.AbuseReportActioned).Return(nil)
+			},
+		},
+		{
+			name:          "Error - missing reason",
+			reason:        "",
+			expectedError: &domain.InvalidAbuseReportError{},
+			setupMocks:    func(m moderationMocks) {},
+		},
+		{
+			name:          "Error - token not found",
+			reason:        "spam",
+			expectedError: &domain.TokenNonExistingError{},
+			setupMocks: func(m moderationMocks) {
+				m.disabler.EXPECT().DisableMapping(gomock.Any(), "abc123", "spam").Return(&domain.TokenNonExistingError{})
+			},
+		},
+		{
+			name:          "Error - cache eviction fails",
+			reason:        "spam",
+			expectedError: assert.AnError,
+			setupMocks: func(m moderationMocks) {
+				m.disabler.EXPECT().DisableMapping(gomock.Any(), "abc123", "spam").Return(nil)
+				m.cache.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(assert.AnError)
+			},
+		},
+		{
+			name:          "Error - resolving reports fails",
+			reason:        "spam",
+			expectedError: assert.AnError,
+			setupMocks: func(m moderationMocks) {
+				m.disabler.EXPECT().DisableMapping(gomock.Any(), "abc123", "spam").Return(nil)
+				m.cache.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+				m.reports.EXPECT().ResolveTokenAbuseReports(gomock.Any(), "abc123", domain.AbuseReportActioned).Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newModerationMocks(ctrl)
+			tt.setupMocks(m)
+
+			err := m.newService().TakeDown(context.Background(), "abc123", tt.reason)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestModerationService_Reinstate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newModerationMocks(ctrl)
+	m.disabler.EXPECT().DisableMapping(gomock.Any(), "abc123", "").Return(nil)
+	m.cache.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+	m.disabler.EXPECT().DisableMapping(gomock.Any(), "missing", "").Return(&domain.TokenNonExistingError{})
+	service := m.newService()
+
+	assert.NoError(t, service.Reinstate(context.Background(), "abc123"))
+	assert.ErrorIs(t, service.Reinstate(context.Background(), "missing"), &domain.TokenNonExistingError{})
+}
@@ -0,0 +1,41 @@
+package ownership
+
+import (
+	"context"
+	"url-shortening-service/internal/domain"
+)
+
+// Authorizer restricts the management of short URLs to the API keys of their owner within their workspace and to admins.
+type Authorizer struct {
+	owners domain.MappingOwnerGetter
+}
+
+// NewAuthorizer creates a new Authorizer instance.
+// Parameters:
+//   - owners: persistent storage the owners of the mappings are looked up in (e.g., PostgreSQL)
+func NewAuthorizer(owners domain.MappingOwnerGetter) *Authorizer {
+	return &Authorizer{owners: owners}
+}
+
+// AuthorizeMappingOwner checks that the API key the request was authenticated with may manage
+// the short URL with the given token. Soft-deleted short URLs are checked as well,
+// so their owners can restore them.
+// Returns the owner of the short URL, so callers can apply the policies of its workspace.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist
+//   - *domain.ForbiddenError: the short URL is owned by another client and the key is not an admin key
+//   - Storage operation fails
+func (a *Authorizer) AuthorizeMappingOwner(ctx context.Context, urlToken string) (domain.MappingOwner, error) {
+	owner, err := a.owners.GetMappingOwner(ctx, urlToken)
+	if err != nil {
+		return domain.MappingOwner{}, err
+	}
+
+	err = domain.AuthorizeOwner(ctx, owner)
+	if err != nil {
+		return domain.MappingOwner{}, err
+	}
+
+	return owner, nil
+}
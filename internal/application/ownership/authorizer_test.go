@@ -0,0 +1,92 @@
+package ownership
+
+import (
+	"context"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizer_AuthorizeMappingOwner(t *testing.T) {
+	t.Parallel()
+
+	writer := domain.ApiKey{Name: "ci", Owner: "team-a", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}
+	admin := domain.ApiKey{Name: "ops", Owner: "ops", Scopes: []domain.ApiKeyScope{domain.ScopeAdmin}}
+	ownedByTeamA := domain.MappingOwner{OwnerId: "team-a"}
+	ownedByTeamB := domain.MappingOwner{WorkspaceId: 7, OwnerId: "team-b"}
+
+	type testCase struct {
+		name          string
+		key           domain.ApiKey
+		expectedOwner domain.MappingOwner
+		expectedError error
+
+		setupMocks func(owners *mocks.MockMappingOwnerGetter)
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Success - owner",
+			key:           writer,
+			expectedOwner: ownedByTeamA,
+			setupMocks: func(owners *mocks.MockMappingOwnerGetter) {
+				owners.EXPECT().GetMappingOwner(gomock.Any(), "abc123").Return(ownedByTeamA, nil)
+			},
+		},
+		{
+			name:          "Success - admin of other owner",
+			key:           admin,
+			expectedOwner: ownedByTeamB,
+			setupMocks: func(owners *mocks.MockMappingOwnerGetter) {
+				owners.EXPECT().GetMappingOwner(gomock.Any(), "abc123").Return(ownedByTeamB, nil)
+			},
+		},
+		{
+			name:          "Error - other owner",
+			key:           writer,
+			expectedError: &domain.ForbiddenError{},
+			setupMocks: func(owners *mocks.MockMappingOwnerGetter) {
+				owners.EXPECT().GetMappingOwner(gomock.Any(), "abc123").Return(ownedByTeamB, nil)
+			},
+		},
+		{
+			name:          "Error - token not found",
+			key:           admin,
+			expectedError: &domain.TokenNonExistingError{},
+			setupMocks: func(owners *mocks.MockMappingOwnerGetter) {
+				owners.EXPECT().GetMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, &domain.TokenNonExistingError{})
+			},
+		},
+		{
+			name:          "Error - storage failure",
+			key:           writer,
+			expectedError: assert.AnError,
+			setupMocks: func(owners *mocks.MockMappingOwnerGetter) {
+				owners.EXPECT().GetMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			owners := mocks.NewMockMappingOwnerGetter(ctrl)
+			tt.setupMocks(owners)
+
+			owner, err := NewAuthorizer(owners).AuthorizeMappingOwner(domain.WithApiKey(context.Background(), tt.key), "abc123")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOwner, owner)
+			}
+		})
+	}
+}
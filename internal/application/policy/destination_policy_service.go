@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// destinationPolicies are the compiled destination policies by workspace ID.
+// The policy of workspace 0 holds the global rules, which apply to every mapping.
+type destinationPolicies map[int64]*domain.DestinationPolicy
+
+// DestinationPolicyService enforces the destination policy on the destination URLs of mappings
+// and administers its rules. The rules are kept in persistent storage and reloaded periodically,
+// so rule changes made on any instance take effect on all instances without a restart.
+// Global rules apply to all mappings, while the rules of a workspace only apply to its own mappings.
+type DestinationPolicyService struct {
+	store        domain.DestinationRuleStore
+	urlValidator domain.UrlValidator
+	logger       domain.Logger
+	policies     atomic.Pointer[destinationPolicies]
+}
+
+// NewDestinationPolicyService creates a new DestinationPolicyService instance.
+// Until the rules are loaded, every destination accepted by urlValidator is allowed.
+// Parameters:
+//   - store: persistent storage of the destination rules (e.g., PostgreSQL)
+//   - urlValidator: validates destination URLs before the policy is applied
+//   - logger: logger for recording warnings and info messages
+func NewDestinationPolicyService(store domain.DestinationRuleStore, urlValidator domain.UrlValidator,
+	logger domain.Logger) *DestinationPolicyService {
+	service := &DestinationPolicyService{
+		store:        store,
+		urlValidator: urlValidator,
+		logger:       logger,
+	}
+	service.policies.Store(&destinationPolicies{})
+
+	return service
+}
+
+// ValidateURL validates the destination URL of a mapping outside of workspaces like ValidateWorkspaceURL.
+//
+// Returns an error if:
+//   - *domain.InvalidUrlError: the URL is rejected by the wrapped URL validator
+//   - *domain.DestinationBlockedError: the host is not allowed by the global destination policy
+func (s *DestinationPolicyService) ValidateURL(URL string) (string, error) {
+	return s.ValidateWorkspaceURL(0, URL)
+}
+
+// ValidateWorkspaceURL validates the destination URL with the wrapped URL validator and checks its host
+// against the current global destination policy and the current destination policy of the workspace.
+// Returns the URL with its host in ASCII form.
+//
+// Returns an error if:
+//   - *domain.InvalidUrlError: the URL is rejected by the wrapped URL validator
+//   - *domain.DestinationBlockedError: the host is not allowed by the global or the workspace destination policy
+func (s *DestinationPolicyService) ValidateWorkspaceURL(workspaceId int64, URL string) (string, error) {
+	validatedUrl, err := s.urlValidator.ValidateURL(URL)
+	if err != nil {
+		return "", err
+	}
+
+	parsedUrl, err := url.Parse(validatedUrl)
+	if err != nil {
+		return "", &domain.InvalidUrlError{Msg: fmt.Sprintf("Invalid url provided: %s", URL)}
+	}
+
+	policies := *s.policies.Load()
+	for _, id := range []int64{0, workspaceId} {
+		policy, found := policies[id]
+		if !found {
+			continue
+		}
+
+		err = policy.CheckHost(parsedUrl.Hostname())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return validatedUrl, nil
+}
+
+// Reload loads the destination rules from persistent storage and replaces the current policies.
+// The current policies are kept if loading fails.
+//
+// Returns an error if the rules cannot be loaded or compiled.
+func (s *DestinationPolicyService) Reload(ctx context.Context) error {
+	rules, err := s.store.ListDestinationRules(ctx)
+	if err != nil {
+		return fmt.Errorf("loading destination rules: %w", err)
+	}
+
+	workspaceRules := make(map[int64][]domain.DestinationRule)
+	for _, rule := range rules {
+		workspaceRules[rule.WorkspaceId] = append(workspaceRules[rule.WorkspaceId], rule)
+	}
+
+	policies := make(destinationPolicies, len(workspaceRules))
+	for workspaceId, rules := range workspaceRules {
+		policies[workspaceId], err = domain.NewDestinationPolicy(rules)
+		if err != nil {
+			return fmt.Errorf("compiling destination rules of workspace %d: %w", workspaceId, err)
+		}
+	}
+
+	s.policies.Store(&policies)
+	return nil
+}
+
+// KeepReloading reloads the destination rules every interval until ctx is done.
+// Failed reloads are logged and keep the current policies.
+func (s *DestinationPolicyService) KeepReloading(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Reload(ctx)
+			if err != nil {
+				s.logger.Warn(fmt.Sprintf("Failed to reload destination policy: %v", err))
+			}
+		}
+	}
+}
+
+// ListRules retrieves all destination rules from persistent storage.
+//
+// Returns an error if the storage operation fails.
+func (s *DestinationPolicyService) ListRules(ctx context.Context) ([]domain.DestinationRule, error) {
+	return s.store.ListDestinationRules(ctx)
+}
+
+// AddRule validates and stores a new destination rule and applies it on this instance right away.
+// Other instances apply it with their next reload.
+// Returns the created rule with its pattern in normalized form.
+//
+// Returns an error if:
+//   - *domain.InvalidDestinationRuleError: the rule is invalid
+//   - *domain.DestinationRuleExistingError: an identical rule already exists in the workspace
+//   - *domain.WorkspaceNonExistingError: the workspace of the rule does not exist
+//   - Storage operation fails
+func (s *DestinationPolicyService) AddRule(ctx context.Context, rule domain.DestinationRule) (domain.DestinationRule, error) {
+	rule, err := domain.NormalizeDestinationRule(rule)
+	if err != nil {
+		return domain.DestinationRule{}, err
+	}
+
+	created, err := s.store.AddDestinationRule(ctx, rule)
+	if err != nil {
+		return domain.DestinationRule{}, err
+	}
+
+	s.reloadAfterChange(ctx)
+	s.logger.Info(fmt.Sprintf("Added destination rule %d to workspace %d: %s %s %s", created.Id, created.WorkspaceId,
+		created.Action, created.Match, created.Pattern))
+	return created, nil
+}
+
+// DeleteRule removes a destination rule and stops applying it on this instance right away.
+// Other instances stop applying it with their next reload.
+//
+// Returns an error if:
+//   - *domain.DestinationRuleNonExistingError: the rule does not exist
+//   - Storage operation fails
+func (s *DestinationPolicyService) DeleteRule(ctx context.Context, id int64) error {
+	err := s.store.DeleteDestinationRule(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.reloadAfterChange(ctx)
+	s.logger.Info(fmt.Sprintf("Deleted destination rule %d", id))
+	return nil
+}
+
+// reloadAfterChange reloads the policy after a rule change. A failure is only logged,
+// as the change is already stored and is applied by the next periodic reload.
+func (s *DestinationPolicyService) reloadAfterChange(ctx context.Context) {
+	err := s.Reload(ctx)
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to reload destination policy after rule change: %v", err))
+	}
+}
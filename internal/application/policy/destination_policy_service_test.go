@@ -0,0 +1,317 @@
+package policy
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	blockEvilRule = domain.DestinationRule{Id: 1, Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com"}
+	allowCorpRule = domain.DestinationRule{Id: 2, Action: domain.DestinationAllow, Match: domain.DestinationMatchWildcard, Pattern: "*.corp.example"}
+	// allowWikiRule restricts workspace 7 to the wiki.
+	allowWikiRule = domain.DestinationRule{Id: 3, Action: domain.DestinationAllow, Match: domain.DestinationMatchExact, Pattern: "wiki.corp.example", WorkspaceId: 7}
+)
+
+func newTestService(t *testing.T, ctrl *gomock.Controller, rules []domain.DestinationRule) (*DestinationPolicyService, *mocks.MockDestinationRuleStore) {
+	store := mocks.NewMockDestinationRuleStore(ctrl)
+	validator, err := domain.NewDestinationValidator(nil)
+	require.NoError(t, err)
+
+	service := NewDestinationPolicyService(store, validator, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if rules != nil {
+		store.EXPECT().ListDestinationRules(gomock.Any()).Return(rules, nil)
+		require.NoError(t, service.Reload(context.Background()))
+	}
+
+	return service, store
+}
+
+func TestDestinationPolicyService_ValidateURL(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		rules         []domain.DestinationRule
+		url           string
+		expectedUrl   string
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Allowed before rules are loaded",
+			url:         "https://evil.com/a",
+			expectedUrl: "https://evil.com/a",
+		},
+		{
+			name:        "Allowed host in ASCII form",
+			rules:       []domain.DestinationRule{blockEvilRule},
+			url:         "https://Bücher.de/a",
+			expectedUrl: "https://xn--bcher-kva.de/a",
+		},
+		{
+			name:          "Blocked host",
+			rules:         []domain.DestinationRule{blockEvilRule},
+			url:           "https://EVIL.com/a",
+			expectedError: &domain.DestinationBlockedError{},
+		},
+		{
+			name:        "Allowlisted host",
+			rules:       []domain.DestinationRule{allowCorpRule},
+			url:         "https://wiki.corp.example/page",
+			expectedUrl: "https://wiki.corp.example/page",
+		},
+		{
+			name:          "Host not on allowlist",
+			rules:         []domain.DestinationRule{allowCorpRule},
+			url:           "https://example.com",
+			expectedError: &domain.DestinationBlockedError{},
+		},
+		{
+			name:          "Invalid URL rejected before the policy",
+			rules:         []domain.DestinationRule{allowCorpRule},
+			url:           "ftp://wiki.corp.example",
+			expectedError: &domain.InvalidUrlError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service, _ := newTestService(t, ctrl, tt.rules)
+			validatedUrl, err := service.ValidateURL(tt.url)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedUrl, validatedUrl)
+			}
+		})
+	}
+}
+
+func TestDestinationPolicyService_ValidateWorkspaceURL(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		workspaceId   int64
+		url           string
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{name: "Allowed by global and workspace rules", workspaceId: 7, url: "https://wiki.corp.example/page"},
+		{name: "Blocked by workspace rule", workspaceId: 7, url: "https://mail.corp.example", expectedError: &domain.DestinationBlockedError{}},
+		{name: "Blocked by global rule", workspaceId: 7, url: "https://evil.com", expectedError: &domain.DestinationBlockedError{}},
+		{name: "Workspace rule does not apply to other workspaces", workspaceId: 8, url: "https://mail.corp.example"},
+		{name: "Workspace rule does not apply outside of workspaces", workspaceId: 0, url: "https://mail.corp.example"},
+		{name: "Global rule applies to other workspaces", workspaceId: 8, url: "https://evil.com", expectedError: &domain.DestinationBlockedError{}},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service, _ := newTestService(t, ctrl, []domain.DestinationRule{blockEvilRule, allowWikiRule})
+			validatedUrl, err := service.ValidateWorkspaceURL(tt.workspaceId, tt.url)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.url, validatedUrl)
+			}
+		})
+	}
+}
+
+func TestDestinationPolicyService_Reload(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError bool
+
+		prepareMocks func(store *mocks.MockDestinationRuleStore)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - policy replaced",
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().ListDestinationRules(gomock.Any()).Return([]domain.DestinationRule{allowCorpRule}, nil)
+			},
+		},
+		{
+			name:          "Error - storage error keeps policy",
+			expectedError: true,
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().ListDestinationRules(gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "Error - invalid rule keeps policy",
+			expectedError: true,
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().ListDestinationRules(gomock.Any()).Return([]domain.DestinationRule{
+					{Action: domain.DestinationBlock, Match: domain.DestinationMatchRegex, Pattern: "("},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service, store := newTestService(t, ctrl, []domain.DestinationRule{blockEvilRule})
+			tt.prepareMocks(store)
+
+			err := service.Reload(context.Background())
+
+			_, blockedErr := service.ValidateURL("https://evil.com")
+			_, allowlistErr := service.ValidateURL("https://example.com")
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.ErrorIs(t, blockedErr, &domain.DestinationBlockedError{})
+				assert.NoError(t, allowlistErr)
+			} else {
+				assert.NoError(t, err)
+				assert.ErrorIs(t, blockedErr, &domain.DestinationBlockedError{})
+				assert.ErrorIs(t, allowlistErr, &domain.DestinationBlockedError{})
+			}
+		})
+	}
+}
+
+func TestDestinationPolicyService_AddRule(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		rule          domain.DestinationRule
+		expectedError error
+
+		prepareMocks func(store *mocks.MockDestinationRuleStore)
+	}
+
+	normalized := domain.DestinationRule{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com"}
+
+	testCases := []testCase{
+		{
+			name: "Success - rule applied right away",
+			rule: domain.DestinationRule{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "EVIL.com"},
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().AddDestinationRule(gomock.Any(), normalized).Return(blockEvilRule, nil)
+				store.EXPECT().ListDestinationRules(gomock.Any()).Return([]domain.DestinationRule{blockEvilRule}, nil)
+			},
+		},
+		{
+			name:          "Error - invalid rule",
+			rule:          domain.DestinationRule{Action: domain.DestinationBlock, Match: domain.DestinationMatchWildcard, Pattern: "evil.com"},
+			expectedError: &domain.InvalidDestinationRuleError{},
+			prepareMocks:  func(store *mocks.MockDestinationRuleStore) {},
+		},
+		{
+			name:          "Error - rule already exists",
+			rule:          normalized,
+			expectedError: &domain.DestinationRuleExistingError{},
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().AddDestinationRule(gomock.Any(), normalized).Return(domain.DestinationRule{}, &domain.DestinationRuleExistingError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service, store := newTestService(t, ctrl, nil)
+			tt.prepareMocks(store)
+
+			created, err := service.AddRule(context.Background(), tt.rule)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, blockEvilRule, created)
+				_, err = service.ValidateURL("https://evil.com")
+				assert.ErrorIs(t, err, &domain.DestinationBlockedError{})
+			}
+		})
+	}
+}
+
+func TestDestinationPolicyService_DeleteRule(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(store *mocks.MockDestinationRuleStore)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - rule lifted right away",
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().DeleteDestinationRule(gomock.Any(), int64(1)).Return(nil)
+				store.EXPECT().ListDestinationRules(gomock.Any()).Return([]domain.DestinationRule{}, nil)
+			},
+		},
+		{
+			name: "Success - failed reload is applied later",
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().DeleteDestinationRule(gomock.Any(), int64(1)).Return(nil)
+				store.EXPECT().ListDestinationRules(gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:          "Error - rule not found",
+			expectedError: &domain.DestinationRuleNonExistingError{},
+			prepareMocks: func(store *mocks.MockDestinationRuleStore) {
+				store.EXPECT().DeleteDestinationRule(gomock.Any(), int64(1)).Return(&domain.DestinationRuleNonExistingError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service, store := newTestService(t, ctrl, []domain.DestinationRule{blockEvilRule})
+			tt.prepareMocks(store)
+
+			err := service.DeleteRule(context.Background(), 1)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// Purger permanently removes deleted mappings once their retention period has passed.
+// Until then, deleted mappings can be restored. The tokens of purged mappings are retired
+// by the storage, so they are never issued again.
+type Purger struct {
+	store     domain.DeletedMappingPurger
+	retention time.Duration
+	batchSize int
+	logger    domain.Logger
+}
+
+// NewPurger creates a new Purger instance.
+// Parameters:
+//   - store: persistent storage the deleted mappings are purged from (e.g., PostgreSQL)
+//   - retention: time deleted mappings are kept restorable before they are purged
+//   - batchSize: number of mappings purged at once
+//   - logger: logger for recording warnings and info messages
+func NewPurger(store domain.DeletedMappingPurger, retention time.Duration, batchSize int, logger domain.Logger) *Purger {
+	return &Purger{
+		store:     store,
+		retention: retention,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// PurgeDeleted permanently removes all mappings deleted longer than the retention period ago,
+// in batches of batchSize mappings.
+//
+// Returns the number of purged mappings.
+//
+// Returns an error if purging a batch fails. Batches purged before the failure stay purged.
+func (p *Purger) PurgeDeleted(ctx context.Context) (int64, error) {
+	deletedBefore := time.Now().Add(-p.retention)
+	var purged int64
+
+	for {
+		batch, err := p.store.PurgeDeletedMappings(ctx, deletedBefore, p.batchSize)
+		if err != nil {
+			return purged, fmt.Errorf("purging deleted mappings: %w", err)
+		}
+
+		purged += batch
+		if batch < int64(p.batchSize) {
+			return purged, nil
+		}
+	}
+}
+
+// KeepPurging purges deleted mappings every interval until ctx is done.
+// Failed purges are logged and retried with the next interval.
+func (p *Purger) KeepPurging(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := p.PurgeDeleted(ctx)
+			if err != nil {
+				p.logger.Warn(fmt.Sprintf("Failed to purge deleted mappings: %v", err))
+			} else if purged > 0 {
+				p.logger.Info(fmt.Sprintf("Purged %d deleted mappings", purged))
+			}
+		}
+	}
+}
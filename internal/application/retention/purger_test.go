@@ -0,0 +1,98 @@
+package retention
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurger_PurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	const retention = 24 * time.Hour
+
+	type testCase struct {
+		name           string
+		expectedPurged int64
+		expectError    bool
+
+		setupMocks func(store *mocks.MockDeletedMappingPurger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success - nothing to purge",
+			expectedPurged: 0,
+			setupMocks: func(store *mocks.MockDeletedMappingPurger) {
+				store.EXPECT().PurgeDeletedMappings(gomock.Any(), gomock.Any(), 2).Return(int64(0), nil)
+			},
+		},
+		{
+			name:           "Success - purged in batches",
+			expectedPurged: 3,
+			setupMocks: func(store *mocks.MockDeletedMappingPurger) {
+				gomock.InOrder(
+					store.EXPECT().PurgeDeletedMappings(gomock.Any(), gomock.Any(), 2).Return(int64(2), nil),
+					store.EXPECT().PurgeDeletedMappings(gomock.Any(), gomock.Any(), 2).Return(int64(1), nil),
+				)
+			},
+		},
+		{
+			name:           "Error - batch fails after earlier batches",
+			expectedPurged: 2,
+			expectError:    true,
+			setupMocks: func(store *mocks.MockDeletedMappingPurger) {
+				gomock.InOrder(
+					store.EXPECT().PurgeDeletedMappings(gomock.Any(), gomock.Any(), 2).Return(int64(2), nil),
+					store.EXPECT().PurgeDeletedMappings(gomock.Any(), gomock.Any(), 2).Return(int64(0), assert.AnError),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := mocks.NewMockDeletedMappingPurger(ctrl)
+			tt.setupMocks(store)
+			purger := NewPurger(store, retention, 2, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			purged, err := purger.PurgeDeleted(context.Background())
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, assert.AnError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedPurged, purged)
+		})
+	}
+}
+
+func TestPurger_PurgeDeleted_RetentionCutoff(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockDeletedMappingPurger(ctrl)
+	purger := NewPurger(store, 24*time.Hour, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	before := time.Now().Add(-24 * time.Hour)
+	store.EXPECT().PurgeDeletedMappings(gomock.Any(), gomock.Any(), 10).
+		DoAndReturn(func(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+			assert.False(t, deletedBefore.Before(before))
+			assert.False(t, deletedBefore.After(time.Now().Add(-24*time.Hour)))
+			return 0, nil
+		})
+
+	_, err := purger.PurgeDeleted(context.Background())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,126 @@
+package scanning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// Rescanner periodically checks the original URLs of existing mappings against the reputation service
+// and flags the mappings whose destinations became malicious after they were created.
+// Mappings whose destinations are no longer reported as malicious are unflagged again.
+type Rescanner struct {
+	store     domain.MappingScanStore
+	scanner   domain.URLScanner
+	cache     domain.UrlTokenDeleter
+	batchSize int
+	logger    domain.Logger
+}
+
+// NewRescanner creates a new Rescanner instance.
+// Parameters:
+//   - store: persistent storage the mappings are listed from and flagged in (e.g., PostgreSQL)
+//   - scanner: reputation service the original URLs are checked against
+//   - cache: cache storage stale mappings are evicted from after their flag changed (e.g., Redis)
+//   - batchSize: number of mappings loaded from storage at once
+//   - logger: logger for recording warnings and info messages
+func NewRescanner(store domain.MappingScanStore, scanner domain.URLScanner, cache domain.UrlTokenDeleter,
+	batchSize int, logger domain.Logger) *Rescanner {
+	return &Rescanner{
+		store:     store,
+		scanner:   scanner,
+		cache:     cache,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// RescanAll checks the original URLs of all mappings once, walking them in batches ordered by ID.
+// Mappings whose verdict changed are flagged or unflagged and evicted from the cache, so the next
+// redirect reloads them. URLs the reputation service fails to scan keep their current flag.
+//
+// Returns the number of mappings whose flag changed.
+//
+// Returns an error if listing or flagging the mappings fails.
+func (r *Rescanner) RescanAll(ctx context.Context) (int, error) {
+	changed := 0
+	var afterId int64
+
+	for {
+		mappings, err := r.store.ListMappingsAfter(ctx, afterId, r.batchSize)
+		if err != nil {
+			return changed, fmt.Errorf("listing mappings: %w", err)
+		}
+
+		for _, mapping := range mappings {
+			flagChanged, err := r.rescan(ctx, mapping)
+			if err != nil {
+				return changed, err
+			} else if flagChanged {
+				changed++
+			}
+		}
+
+		if len(mappings) < r.batchSize {
+			return changed, nil
+		}
+		afterId = mappings[len(mappings)-1].Id
+	}
+}
+
+// KeepRescanning rescans all mappings every interval until ctx is done.
+// Failed rescans are logged and retried with the next interval.
+func (r *Rescanner) KeepRescanning(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.RescanAll(ctx)
+			if err != nil {
+				r.logger.Warn(fmt.Sprintf("Failed to rescan destination URLs: %v", err))
+			} else if changed > 0 {
+				r.logger.Info(fmt.Sprintf("Rescanned destination URLs, %d flags changed", changed))
+			}
+		}
+	}
+}
+
+// rescan checks the original URL of a single mapping and updates its flag if the verdict changed.
+// It reports whether the flag changed.
+func (r *Rescanner) rescan(ctx context.Context, mapping domain.MappingInfo) (bool, error) {
+	verdict, err := r.scanner.ScanURL(ctx, mapping.OriginalURL)
+	if err != nil {
+		r.logger.Warn(fmt.Sprintf("Failed to scan destination URL of token %s: %v", mapping.Key(), err))
+		return false, nil
+	}
+
+	threat := verdict.FlaggedThreat()
+	if threat == mapping.Threat {
+		return false, nil
+	}
+
+	err = r.store.FlagMapping(ctx, mapping.Key(), threat)
+	if errors.Is(err, &domain.TokenNonExistingError{}) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("flagging mapping %s: %w", mapping.Key(), err)
+	}
+
+	err = r.cache.DeleteMapping(ctx, mapping.Key())
+	if err != nil && !errors.Is(err, &domain.TokenNonExistingError{}) {
+		r.logger.Warn(fmt.Sprintf("Failed to evict rescanned mapping %s from cache: %v", mapping.Key(), err))
+	}
+
+	if threat != "" {
+		r.logger.Info(fmt.Sprintf("Flagged short URL %s as %s", mapping.Key(), threat))
+	} else {
+		r.logger.Info(fmt.Sprintf("Unflagged short URL %s", mapping.Key()))
+	}
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package scanning
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRescanner_RescanAll(t *testing.T) {
+	t.Parallel()
+
+	safe := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com", Token: "b"}
+	turnedMalicious := domain.MappingInfo{Id: 2, OriginalURL: "https://example.com/login", Token: "c"}
+	turnedSafe := domain.MappingInfo{Id: 3, OriginalURL: "https://example.org", Token: "d", Threat: "malware"}
+	stillMalicious := domain.MappingInfo{Id: 4, OriginalURL: "https://example.net", Token: "e", Threat: "malware"}
+
+	type testCase struct {
+		name            string
+		expectedChanged int
+		expectedError   bool
+
+		setupMocks func(ctrl *gomock.Controller) (domain.MappingScanStore, domain.URLScanner, domain.UrlTokenDeleter)
+	}
+
+	testCases := []testCase{
+		{
+			name:            "flags changed verdicts across batches",
+			expectedChanged: 2,
+			setupMocks: func(ctrl *gomock.Controller) (domain.MappingScanStore, domain.URLScanner, domain.UrlTokenDeleter) {
+				storeMock := mocks.NewMockMappingScanStore(ctrl)
+				scannerMock := mocks.NewMockURLScanner(ctrl)
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+
+				storeMock.EXPECT().ListMappingsAfter(gomock.Any(), int64(0), 2).Return([]domain.MappingInfo{safe, turnedMalicious}, nil)
+				storeMock.EXPECT().ListMappingsAfter(gomock.Any(), int64(2), 2).Return([]domain.MappingInfo{turnedSafe, stillMalicious}, nil)
+				storeMock.EXPECT().ListMappingsAfter(gomock.Any(), int64(4), 2).Return([]domain.MappingInfo{}, nil)
+
+				scannerMock.EXPECT().ScanURL(gomock.Any(), safe.OriginalURL).Return(domain.ScanVerdict{}, nil)
+				scannerMock.EXPECT().ScanURL(gomock.Any(), turnedMalicious.OriginalURL).Return(domain.ScanVerdict{Malicious: true, Threat: "phishing"}, nil)
+				scannerMock.EXPECT().ScanURL(gomock.Any(), turnedSafe.OriginalURL).Return(domain.ScanVerdict{}, nil)
+				scannerMock.EXPECT().ScanURL(gomock.Any(), stillMalicious.OriginalURL).Return(domain.ScanVerdict{Malicious: true, Threat: "malware"}, nil)
+
+				storeMock.EXPECT().FlagMapping(gomock.Any(), "c", "phishing").Return(nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "c").Return(nil)
+				storeMock.EXPECT().FlagMapping(gomock.Any(), "d", "").Return(nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "d").Return(&domain.TokenNonExistingError{})
+
+				return storeMock, scannerMock, cacheMock
+			},
+		},
+		{
+			name: "failed scans keep flags",
+			setupMocks: func(ctrl *gomock.Controller) (domain.MappingScanStore, domain.URLScanner, domain.UrlTokenDeleter) {
+				storeMock := mocks.NewMockMappingScanStore(ctrl)
+				scannerMock := mocks.NewMockURLScanner(ctrl)
+
+				storeMock.EXPECT().ListMappingsAfter(gomock.Any(), int64(0), 2).Return([]domain.MappingInfo{turnedSafe}, nil)
+				scannerMock.EXPECT().ScanURL(gomock.Any(), turnedSafe.OriginalURL).Return(domain.ScanVerdict{}, assert.AnError)
+
+				return storeMock, scannerMock, mocks.NewMockUrlTokenDeleter(ctrl)
+			},
+		},
+		{
+			name:          "listing error aborts the rescan",
+			expectedError: true,
+			setupMocks: func(ctrl *gomock.Controller) (domain.MappingScanStore, domain.URLScanner, domain.UrlTokenDeleter) {
+				storeMock := mocks.NewMockMappingScanStore(ctrl)
+				storeMock.EXPECT().ListMappingsAfter(gomock.Any(), int64(0), 2).Return(nil, assert.AnError)
+
+				return storeMock, mocks.NewMockURLScanner(ctrl), mocks.NewMockUrlTokenDeleter(ctrl)
+			},
+		},
+		{
+			name:          "flagging error aborts the rescan",
+			expectedError: true,
+			setupMocks: func(ctrl *gomock.Controller) (domain.MappingScanStore, domain.URLScanner, domain.UrlTokenDeleter) {
+				storeMock := mocks.NewMockMappingScanStore(ctrl)
+				scannerMock := mocks.NewMockURLScanner(ctrl)
+
+				storeMock.EXPECT().ListMappingsAfter(gomock.Any(), int64(0), 2).Return([]domain.MappingInfo{turnedMalicious}, nil)
+				scannerMock.EXPECT().ScanURL(gomock.Any(), turnedMalicious.OriginalURL).Return(domain.ScanVerdict{Malicious: true}, nil)
+				storeMock.EXPECT().FlagMapping(gomock.Any(), "c", domain.UnspecifiedThreat).Return(assert.AnError)
+
+				return storeMock, scannerMock, mocks.NewMockUrlTokenDeleter(ctrl)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			storeMock, scannerMock, cacheMock := tt.setupMocks(ctrl)
+			rescanner := NewRescanner(storeMock, scannerMock, cacheMock, 2, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			changed, err := rescanner.RescanAll(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedChanged, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package shortdomains
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// ShortDomainService resolves the custom short domain requests are made to, authorizes the use
+// of custom short domains for new short URLs and administers them.
+// The domains are kept in persistent storage and reloaded periodically, so domains registered
+// on any instance are served by all instances without a restart, and the redirect path never
+// waits for the storage.
+type ShortDomainService struct {
+	store        domain.ShortDomainStore
+	logger       domain.Logger
+	shortDomains atomic.Pointer[map[string]domain.ShortDomain]
+}
+
+// NewShortDomainService creates a new ShortDomainService instance.
+// Until the domains are loaded, all requests are served by the default domain.
+// Parameters:
+//   - store: persistent storage of the custom short domains (e.g., PostgreSQL)
+//   - logger: logger for recording warnings and info messages
+func NewShortDomainService(store domain.ShortDomainStore, logger domain.Logger) *ShortDomainService {
+	service := &ShortDomainService{
+		store:  store,
+		logger: logger,
+	}
+	service.shortDomains.Store(&map[string]domain.ShortDomain{})
+
+	return service
+}
+
+// ResolveShortDomain returns the custom short domain a request to the host is served by
+// and whether the host is a custom short domain at all. The host must not contain a port.
+// Hosts that are not custom short domains are served by the default domain.
+func (s *ShortDomainService) ResolveShortDomain(host string) (string, bool) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return "", false
+	}
+
+	_, found := (*s.shortDomains.Load())[host]
+	return host, found
+}
+
+// AuthorizeShortDomain checks that API keys of the workspace may create short URLs on the custom short domain
+// and returns the host name of the domain in the form short URLs are stored with.
+// Domains of a workspace may only be used by its API keys, domains outside of workspaces only by API keys
+// outside of workspaces.
+//
+// Returns an error if:
+//   - *domain.InvalidShortDomainError: the host name is not a valid host name
+//   - *domain.ShortDomainNonExistingError: the domain is not registered
+//   - *domain.ForbiddenError: the domain belongs to another workspace
+func (s *ShortDomainService) AuthorizeShortDomain(host string, workspaceId int64) (string, error) {
+	host, err := domain.NormalizeShortDomainHost(host)
+	if err != nil {
+		return "", err
+	}
+
+	shortDomain, found := (*s.shortDomains.Load())[host]
+	if !found {
+		return "", &domain.ShortDomainNonExistingError{Msg: fmt.Sprintf("Short domain is not registered: %s", host)}
+	} else if shortDomain.WorkspaceId != workspaceId {
+		return "", &domain.ForbiddenError{Msg: fmt.Sprintf("Short domain belongs to another workspace: %s", host)}
+	}
+
+	return host, nil
+}
+
+// Reload loads the custom short domains from persistent storage and replaces the current ones.
+// The current domains are kept if loading fails.
+//
+// Returns an error if the domains cannot be loaded.
+func (s *ShortDomainService) Reload(ctx context.Context) error {
+	list, err := s.store.ListShortDomains(ctx)
+	if err != nil {
+		return fmt.Errorf("loading short domains: %w", err)
+	}
+
+	shortDomains := make(map[string]domain.ShortDomain, len(list))
+	for _, shortDomain := range list {
+		shortDomains[shortDomain.Host] = shortDomain
+	}
+
+	s.shortDomains.Store(&shortDomains)
+	return nil
+}
+
+// KeepReloading reloads the custom short domains every interval until ctx is done.
+// Failed reloads are logged and keep the current domains.
+func (s *ShortDomainService) KeepReloading(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Reload(ctx)
+			if err != nil {
+				s.logger.Warn(fmt.Sprintf("Failed to reload short domains: %v", err))
+			}
+		}
+	}
+}
+
+// ListShortDomains retrieves all custom short domains from persistent storage.
+//
+// Returns an error if the storage operation fails.
+func (s *ShortDomainService) ListShortDomains(ctx context.Context) ([]domain.ShortDomain, error) {
+	return s.store.ListShortDomains(ctx)
+}
+
+// AddShortDomain validates and registers a new custom short domain and serves it on this instance right away.
+// Other instances serve it with their next reload.
+//
+// Returns an error if:
+//   - *domain.InvalidShortDomainError: the host name is not a valid host name
+//   - *domain.ShortDomainExistingError: the domain is already registered
+//   - *domain.WorkspaceNonExistingError: the workspace does not exist
+//   - Storage operation fails
+func (s *ShortDomainService) AddShortDomain(ctx context.Context, shortDomain domain.ShortDomain) (domain.ShortDomain, error) {
+	shortDomain, err := domain.NormalizeShortDomain(shortDomain)
+	if err != nil {
+		return domain.ShortDomain{}, err
+	}
+
+	created, err := s.store.AddShortDomain(ctx, shortDomain)
+	if err != nil {
+		return domain.ShortDomain{}, err
+	}
+
+	s.reloadAfterChange(ctx)
+	s.logger.Info(fmt.Sprintf("Registered short domain %s for workspace %d", created.Host, created.WorkspaceId))
+	return created, nil
+}
+
+// DeleteShortDomain removes a custom short domain that has no short URLs left and stops serving it
+// on this instance right away. Other instances stop with their next reload.
+//
+// Returns an error if:
+//   - *domain.InvalidShortDomainError: the host name is not a valid host name
+//   - *domain.ShortDomainNonExistingError: the domain is not registered
+//   - *domain.ShortDomainInUseError: short URLs still exist on the domain
+//   - Storage operation fails
+func (s *ShortDomainService) DeleteShortDomain(ctx context.Context, host string) error {
+	host, err := domain.NormalizeShortDomainHost(host)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteShortDomain(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	s.reloadAfterChange(ctx)
+	s.logger.Info(fmt.Sprintf("Deleted short domain %s", host))
+	return nil
+}
+
+// reloadAfterChange reloads the custom short domains after a change. A failure is only logged,
+// as the change is already stored and is applied by the next periodic reload.
+func (s *ShortDomainService) reloadAfterChange(ctx context.Context) {
+	err := s.Reload(ctx)
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to reload short domains after change: %v", err))
+	}
+}
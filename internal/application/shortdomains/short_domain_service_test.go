@@ -0,0 +1,240 @@
+package shortdomains
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	workspaceDomain = domain.ShortDomain{Host: "go.acme.com", WorkspaceId: 7}
+	sharedDomain    = domain.ShortDomain{Host: "s.example.io"}
+)
+
+// newLoadedService returns a service that has loaded the given domains.
+func newLoadedService(t *testing.T, store *mocks.MockShortDomainStore, shortDomains ...domain.ShortDomain) *ShortDomainService {
+	service := NewShortDomainService(store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	store.EXPECT().ListShortDomains(gomock.Any()).Return(shortDomains, nil)
+	require.NoError(t, service.Reload(context.Background()))
+
+	return service
+}
+
+func TestShortDomainService_ResolveShortDomain(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                string
+		host                string
+		expectedShortDomain string
+		expectedFound       bool
+	}
+
+	testCases := []testCase{
+		{name: "custom domain", host: "go.acme.com", expectedShortDomain: "go.acme.com", expectedFound: true},
+		{name: "custom domain in uppercase", host: "GO.Acme.com", expectedShortDomain: "go.acme.com", expectedFound: true},
+		{name: "custom domain with trailing dot", host: "go.acme.com.", expectedShortDomain: "go.acme.com", expectedFound: true},
+		{name: "default domain", host: "localhost", expectedShortDomain: "localhost"},
+		{name: "empty host", host: ""},
+	}
+
+	ctrl := gomock.NewController(t)
+	service := newLoadedService(t, mocks.NewMockShortDomainStore(ctrl), workspaceDomain, sharedDomain)
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			shortDomain, found := service.ResolveShortDomain(tt.host)
+
+			assert.Equal(t, tt.expectedFound, found)
+			if tt.expectedFound {
+				assert.Equal(t, tt.expectedShortDomain, shortDomain)
+			}
+		})
+	}
+}
+
+func TestShortDomainService_AuthorizeShortDomain(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                string
+		host                string
+		workspaceId         int64
+		expectedShortDomain string
+		expectedError       error
+	}
+
+	testCases := []testCase{
+		{name: "Success - domain of the workspace", host: " GO.acme.com ", workspaceId: 7, expectedShortDomain: "go.acme.com"},
+		{name: "Success - domain outside of workspaces", host: "s.example.io", expectedShortDomain: "s.example.io"},
+		{name: "Error - invalid host name", host: "https://go.acme.com", workspaceId: 7, expectedError: &domain.InvalidShortDomainError{}},
+		{name: "Error - domain not registered", host: "go.globex.com", workspaceId: 7, expectedError: &domain.ShortDomainNonExistingError{}},
+		{name: "Error - domain of another workspace", host: "go.acme.com", workspaceId: 8, expectedError: &domain.ForbiddenError{}},
+		{name: "Error - workspace domain without workspace", host: "go.acme.com", expectedError: &domain.ForbiddenError{}},
+		{name: "Error - shared domain from workspace", host: "s.example.io", workspaceId: 7, expectedError: &domain.ForbiddenError{}},
+	}
+
+	ctrl := gomock.NewController(t)
+	service := newLoadedService(t, mocks.NewMockShortDomainStore(ctrl), workspaceDomain, sharedDomain)
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			shortDomain, err := service.AuthorizeShortDomain(tt.host, tt.workspaceId)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedShortDomain, shortDomain)
+			}
+		})
+	}
+}
+
+func TestShortDomainService_Reload(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockShortDomainStore(ctrl)
+	service := newLoadedService(t, store, workspaceDomain)
+
+	store.EXPECT().ListShortDomains(gomock.Any()).Return(nil, assert.AnError)
+	assert.Error(t, service.Reload(context.Background()))
+
+	_, found := service.ResolveShortDomain("go.acme.com")
+	assert.True(t, found)
+}
+
+func TestShortDomainService_AddShortDomain(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		shortDomain   domain.ShortDomain
+		expectedError error
+
+		setupMocks func(store *mocks.MockShortDomainStore)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Success - domain served right away",
+			shortDomain: domain.ShortDomain{Host: "Go.Acme.com", WorkspaceId: 7},
+			setupMocks: func(store *mocks.MockShortDomainStore) {
+				store.EXPECT().AddShortDomain(gomock.Any(), workspaceDomain).Return(workspaceDomain, nil)
+				store.EXPECT().ListShortDomains(gomock.Any()).Return([]domain.ShortDomain{workspaceDomain}, nil)
+			},
+		},
+		{
+			name:          "Error - invalid host name",
+			shortDomain:   domain.ShortDomain{Host: "localhost"},
+			expectedError: &domain.InvalidShortDomainError{},
+			setupMocks:    func(store *mocks.MockShortDomainStore) {},
+		},
+		{
+			name:          "Error - domain already registered",
+			shortDomain:   workspaceDomain,
+			expectedError: &domain.ShortDomainExistingError{},
+			setupMocks: func(store *mocks.MockShortDomainStore) {
+				store.EXPECT().AddShortDomain(gomock.Any(), workspaceDomain).Return(domain.ShortDomain{}, &domain.ShortDomainExistingError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := mocks.NewMockShortDomainStore(ctrl)
+			service := newLoadedService(t, store)
+			tt.setupMocks(store)
+
+			created, err := service.AddShortDomain(context.Background(), tt.shortDomain)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, workspaceDomain, created)
+				_, found := service.ResolveShortDomain("go.acme.com")
+				assert.True(t, found)
+			}
+		})
+	}
+}
+
+func TestShortDomainService_DeleteShortDomain(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		host          string
+		expectedError error
+
+		setupMocks func(store *mocks.MockShortDomainStore)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - domain no longer served",
+			host: "GO.acme.com",
+			setupMocks: func(store *mocks.MockShortDomainStore) {
+				store.EXPECT().DeleteShortDomain(gomock.Any(), "go.acme.com").Return(nil)
+				store.EXPECT().ListShortDomains(gomock.Any()).Return([]domain.ShortDomain{}, nil)
+			},
+		},
+		{
+			name:          "Error - invalid host name",
+			host:          "go.acme.com/x",
+			expectedError: &domain.InvalidShortDomainError{},
+			setupMocks:    func(store *mocks.MockShortDomainStore) {},
+		},
+		{
+			name:          "Error - domain in use",
+			host:          "go.acme.com",
+			expectedError: &domain.ShortDomainInUseError{},
+			setupMocks: func(store *mocks.MockShortDomainStore) {
+				store.EXPECT().DeleteShortDomain(gomock.Any(), "go.acme.com").Return(&domain.ShortDomainInUseError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := mocks.NewMockShortDomainStore(ctrl)
+			service := newLoadedService(t, store, workspaceDomain)
+			tt.setupMocks(store)
+
+			err := service.DeleteShortDomain(context.Background(), tt.host)
+
+			_, found := service.ResolveShortDomain("go.acme.com")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.True(t, found)
+			} else {
+				assert.NoError(t, err)
+				assert.False(t, found)
+			}
+		})
+	}
+}
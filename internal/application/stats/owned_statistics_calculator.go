@@ -0,0 +1,41 @@
+package stats
+
+import (
+	"context"
+	"url-shortening-service/internal/domain"
+)
+
+// OwnedStatisticsCalculator restricts the statistics of short URLs to the API keys of their owner and to admins,
+// and scopes them to the workspace of the short URL.
+type OwnedStatisticsCalculator struct {
+	calculator domain.WorkspaceStatisticsCalculator
+	authorizer domain.MappingOwnershipAuthorizer
+}
+
+// NewOwnedStatisticsCalculator creates a new OwnedStatisticsCalculator instance.
+// Parameters:
+//   - calculator: calculator the statistics of authorized callers are calculated by within the workspace of the short URL
+//   - authorizer: checks that the caller owns the short URL
+func NewOwnedStatisticsCalculator(calculator domain.WorkspaceStatisticsCalculator, authorizer domain.MappingOwnershipAuthorizer) *OwnedStatisticsCalculator {
+	return &OwnedStatisticsCalculator{
+		calculator: calculator,
+		authorizer: authorizer,
+	}
+}
+
+// CalculateStatistics computes aggregated statistics for the given URL token
+// if the caller may manage the short URL. Only the events recorded for the workspace
+// of the short URL are counted.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist or has no statistics
+//   - *domain.ForbiddenError: the caller does not own the short URL
+//   - Storage operation or calculation fails
+func (c *OwnedStatisticsCalculator) CalculateStatistics(ctx context.Context, urlToken string) (domain.CalculatedStatistics, error) {
+	owner, err := c.authorizer.AuthorizeMappingOwner(ctx, urlToken)
+	if err != nil {
+		return domain.CalculatedStatistics{}, err
+	}
+
+	return c.calculator.CalculateWorkspaceStatistics(ctx, owner.WorkspaceId, urlToken)
+}
@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnedStatisticsCalculator_CalculateStatistics(t *testing.T) {
+	t.Parallel()
+
+	calculated := domain.CalculatedStatistics{UrlToken: "abc123", TotalClicks: 3}
+
+	type testCase struct {
+		name          string
+		expectedStats domain.CalculatedStatistics
+		expectedError error
+
+		setupMocks func(calculator *mocks.MockWorkspaceStatisticsCalculator, authorizer *mocks.MockMappingOwnershipAuthorizer)
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Success - owner",
+			expectedStats: calculated,
+			setupMocks: func(calculator *mocks.MockWorkspaceStatisticsCalculator, authorizer *mocks.MockMappingOwnershipAuthorizer) {
+				authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{WorkspaceId: 7, OwnerId: "team-a"}, nil)
+				calculator.EXPECT().CalculateWorkspaceStatistics(gomock.Any(), int64(7), "abc123").Return(calculated, nil)
+			},
+		},
+		{
+			name:          "Error - not owner",
+			expectedError: &domain.ForbiddenError{},
+			setupMocks: func(calculator *mocks.MockWorkspaceStatisticsCalculator, authorizer *mocks.MockMappingOwnershipAuthorizer) {
+				authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, &domain.ForbiddenError{})
+			},
+		},
+		{
+			name:          "Error - token not found",
+			expectedError: &domain.TokenNonExistingError{},
+			setupMocks: func(calculator *mocks.MockWorkspaceStatisticsCalculator, authorizer *mocks.MockMappingOwnershipAuthorizer) {
+				authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, &domain.TokenNonExistingError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			calculator := mocks.NewMockWorkspaceStatisticsCalculator(ctrl)
+			authorizer := mocks.NewMockMappingOwnershipAuthorizer(ctrl)
+			tt.setupMocks(calculator, authorizer)
+
+			stats, err := NewOwnedStatisticsCalculator(calculator, authorizer).CalculateStatistics(context.Background(), "abc123")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStats, stats)
+			}
+		})
+	}
+}
@@ -61,8 +61,9 @@ func (rsp *RedirectStatsProcessor) ProcessEvent(ctx context.Context, eventData [
 
 func (rsp *RedirectStatsProcessor) convertEvent(event domain.RawStatsEvent) domain.ProcessedStatsEvent {
 	processedEvent := domain.ProcessedStatsEvent{
-		UrlToken:  event.UrlToken,
-		Timestamp: event.Timestamp,
+		UrlToken:    event.UrlToken,
+		Timestamp:   event.Timestamp,
+		WorkspaceId: event.WorkspaceId,
 	}
 
 	ipLocation, err := rsp.ipLocator.LocateIP(event.IP)
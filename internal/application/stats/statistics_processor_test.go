@@ -214,17 +214,19 @@ func TestConvertEvent(t *testing.T) {
 		{
 			name: "event with mobile user agent",
 			input: domain.RawStatsEvent{
-				UrlToken:  "mobile123",
-				Timestamp: testTimestamp,
-				IP:        "192.168.1.1",
-				UserAgent: "Mozilla/5.0 (Linux; Android 10; SM-G973F) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.120 Mobile Safari/537.36",
-				Referrer:  "https://twitter.com",
+				UrlToken:    "mobile123",
+				Timestamp:   testTimestamp,
+				IP:          "192.168.1.1",
+				UserAgent:   "Mozilla/5.0 (Linux; Android 10; SM-G973F) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.120 Mobile Safari/537.36",
+				Referrer:    "https://twitter.com",
+				WorkspaceId: 7,
 			},
 			expected: domain.ProcessedStatsEvent{
-				UrlToken:   "mobile123",
-				Timestamp:  testTimestamp,
-				DeviceType: "Mobile",
-				Referrer:   "https://twitter.com",
+				UrlToken:    "mobile123",
+				Timestamp:   testTimestamp,
+				DeviceType:  "Mobile",
+				Referrer:    "https://twitter.com",
+				WorkspaceId: 7,
 			},
 			statsStorageFn: func(t *testing.T, ctrl *gomock.Controller) domain.StatsEventAdder {
 				return mocks.NewMockStatsEventAdder(ctrl)
@@ -312,6 +314,7 @@ func TestConvertEvent(t *testing.T) {
 			assert.Equal(t, tt.expected.Timestamp, res.Timestamp)
 			assert.Equal(t, tt.expected.DeviceType, res.DeviceType)
 			assert.Equal(t, tt.expected.Referrer, res.Referrer)
+			assert.Equal(t, tt.expected.WorkspaceId, res.WorkspaceId)
 		})
 	}
 }
@@ -8,22 +8,27 @@ import (
 
 // UrlDeleter handles URL mapping deletion operations.
 // It removes mappings from both persistent storage and cache.
+// Only the owner of the mapping and admins may delete it.
 type UrlDeleter struct {
-	cache   domain.UrlTokenDeleter
-	storage domain.MappingInfoDeleter
-	logger  domain.Logger
+	cache      domain.UrlTokenDeleter
+	storage    domain.MappingInfoDeleter
+	authorizer domain.MappingOwnershipAuthorizer
+	logger     domain.Logger
 }
 
 // NewUrlDeleter creates a new UrlDeleter instance.
 // Parameters:
 //   - cache: cache storage for URL mappings (e.g., Redis)
 //   - storage: persistent storage for URL mappings (e.g., PostgreSQL)
+//   - authorizer: checks that the caller owns the deleted mapping
 //   - logger: logger for recording warnings and errors
-func NewUrlDeleter(cache domain.UrlTokenDeleter, storage domain.MappingInfoDeleter, logger domain.Logger) *UrlDeleter {
+func NewUrlDeleter(cache domain.UrlTokenDeleter, storage domain.MappingInfoDeleter, authorizer domain.MappingOwnershipAuthorizer,
+	logger domain.Logger) *UrlDeleter {
 	return &UrlDeleter{
-		cache:   cache,
-		storage: storage,
-		logger:  logger,
+		cache:      cache,
+		storage:    storage,
+		authorizer: authorizer,
+		logger:     logger,
 	}
 }
 
@@ -33,9 +38,15 @@ func NewUrlDeleter(cache domain.UrlTokenDeleter, storage domain.MappingInfoDelet
 //
 // Returns an error if:
 //   - *domain.TokenNonExistingError: the token does not exist in storage
+//   - *domain.ForbiddenError: the caller does not own the mapping
 //   - Other errors from storage or cache operations
 func (ud *UrlDeleter) DeleteUrl(ctx context.Context, urlToken string) error {
-	err := ud.storage.DeleteMappingInfo(ctx, urlToken)
+	_, err := ud.authorizer.AuthorizeMappingOwner(ctx, urlToken)
+	if err != nil {
+		return err
+	}
+
+	err = ud.storage.DeleteMappingInfo(ctx, urlToken)
 	if err != nil {
 		return err
 	}
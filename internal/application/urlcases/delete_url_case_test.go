@@ -120,7 +120,7 @@ func TestUrlDeleter_DeleteUrl(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			cacheMock, storageMock, loggerMock := tt.setupMocks(t, ctrl)
-			urlDeleter := NewUrlDeleter(cacheMock, storageMock, loggerMock)
+			urlDeleter := NewUrlDeleter(cacheMock, storageMock, ownerAuthorizer(ctrl), loggerMock)
 
 			err := urlDeleter.DeleteUrl(context.Background(), tt.urlToken)
 			if tt.expectedError != nil {
@@ -131,3 +131,17 @@ func TestUrlDeleter_DeleteUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestUrlDeleter_NotOwner(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	authorizer := mocks.NewMockMappingOwnershipAuthorizer(ctrl)
+	authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, &domain.ForbiddenError{})
+
+	urlDeleter := NewUrlDeleter(mocks.NewMockUrlTokenDeleter(ctrl), mocks.NewMockMappingInfoDeleter(ctrl), authorizer, mocks.NewMockLogger(ctrl))
+
+	err := urlDeleter.DeleteUrl(context.Background(), "abc123")
+
+	assert.ErrorIs(t, err, &domain.ForbiddenError{})
+}
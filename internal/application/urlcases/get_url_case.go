@@ -2,51 +2,279 @@ package urlcases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 	"url-shortening-service/internal/domain"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // UrlGetter retrieves original URLs by their short token.
 // It implements a cache-aside pattern: first checking cache, then falling back to storage.
+// Tokens not found in storage are cached as missing, and concurrent cache misses of the same token
+// are coalesced into a single storage lookup.
 type UrlGetter struct {
-	cache  domain.MappedGetSetter
-	store  domain.MappingInfoGetter
-	logger domain.Logger
+	cache           domain.MappedGetSetter
+	store           domain.MappingInfoGetter
+	clickCounter    domain.ClickCounter
+	clickSaver      domain.ClickCountSaver
+	passwordLimiter domain.AttemptLimiter
+	redirectQuota   domain.RedirectQuotaChecker
+	logger          domain.Logger
+	lookups         singleflight.Group
 }
 
 // NewUrlGetter creates a new UrlGetter instance.
 // Parameters:
 //   - cache: cache storage supporting get and set operations (e.g., Redis)
 //   - store: persistent storage for retrieving mapping information
+//   - clickCounter: atomic counter of consumed clicks of click-limited mappings (e.g., Redis)
+//   - clickSaver: persistent storage the consumed clicks are reconciled into
+//   - passwordLimiter: per-token throttling of password attempts for protected mappings
+//   - redirectQuota: enforces the quota of redirects of the workspace of the mapping
 //   - logger: logger for recording warnings
-func NewUrlGetter(cache domain.MappedGetSetter, store domain.MappingInfoGetter, logger domain.Logger) *UrlGetter {
+func NewUrlGetter(cache domain.MappedGetSetter, store domain.MappingInfoGetter, clickCounter domain.ClickCounter,
+	clickSaver domain.ClickCountSaver, passwordLimiter domain.AttemptLimiter, redirectQuota domain.RedirectQuotaChecker,
+	logger domain.Logger) *UrlGetter {
 	return &UrlGetter{
-		cache:  cache,
-		store:  store,
-		logger: logger,
+		cache:           cache,
+		store:           store,
+		clickCounter:    clickCounter,
+		clickSaver:      clickSaver,
+		passwordLimiter: passwordLimiter,
+		redirectQuota:   redirectQuota,
+		logger:          logger,
 	}
 }
 
-// GetOriginalUrl retrieves the original URL for a given short URL token.
+// GetOriginalUrl retrieves the original URL and the workspace for a given short URL token.
 // It first checks the cache, and on cache miss, queries the persistent storage
-// and populates the cache for future requests.
+// and populates the cache for future requests. Expired mappings are never cached,
+// while cached mappings are evicted by the cache itself once they expire.
+// Tokens not found in storage are cached as missing, so repeated lookups of unknown tokens
+// do not reach the storage; concurrent lookups of the same uncached token share one storage query.
+// A failing cache is logged and bypassed, so redirects keep working from storage.
+// Every redirect counts against the redirect quota of the workspace of the mapping,
+// and every redirect of a click-limited mapping consumes one click.
+// Taken down mappings are never resolved.
+// Protected mappings are never resolved here and have to be unlocked with UnlockOriginalUrl.
+// Flagged mappings are never resolved here either and have to be resolved with GetFlaggedOriginalUrl
+// once their warning was acknowledged.
+//
+// Returns an error if:
+//   - *domain.UrlNonExistingError: the URL token was not found in storage
+//   - *domain.StorageUnavailableError: the URL token was not cached and the storage failed
+//   - *domain.UrlExpiredError: the mapping exists but has expired
+//   - *domain.UrlDisabledError: the mapping was taken down
+//   - *domain.UrlFlaggedError: the original URL is flagged as malicious
+//   - *domain.PasswordRequiredError: the mapping is protected by a password
+//   - *domain.QuotaExceededError: the workspace of the mapping has exceeded its redirects per second
+//   - *domain.ClickLimitReachedError: the mapping has consumed all of its allowed clicks
+//   - Counting the click fails
+func (u *UrlGetter) GetOriginalUrl(ctx context.Context, urlToken string) (domain.ResolvedUrl, error) {
+	return u.resolveOriginalUrl(ctx, urlToken, false)
+}
+
+// GetFlaggedOriginalUrl retrieves the original URL for a given short URL token like GetOriginalUrl,
+// but also resolves flagged mappings, as their warning was acknowledged.
+//
+// Returns an error if:
+//   - *domain.UrlNonExistingError: the URL token was not found in storage
+//   - *domain.StorageUnavailableError: the URL token was not cached and the storage failed
+//   - *domain.UrlExpiredError: the mapping exists but has expired
+//   - *domain.UrlDisabledError: the mapping was taken down
+//   - *domain.PasswordRequiredError: the mapping is protected by a password
+//   - *domain.QuotaExceededError: the workspace of the mapping has exceeded its redirects per second
+//   - *domain.ClickLimitReachedError: the mapping has consumed all of its allowed clicks
+//   - Counting the click fails
+func (u *UrlGetter) GetFlaggedOriginalUrl(ctx context.Context, urlToken string) (domain.ResolvedUrl, error) {
+	return u.resolveOriginalUrl(ctx, urlToken, true)
+}
+
+// resolveOriginalUrl retrieves the original URL of the token from the cache or the storage,
+// rejecting flagged mappings unless their warning was acknowledged.
+func (u *UrlGetter) resolveOriginalUrl(ctx context.Context, urlToken string, warningAcknowledged bool) (domain.ResolvedUrl, error) {
+	mappingInfo, lookup, err := u.cache.GetMapping(ctx, urlToken)
+	if err != nil {
+		u.logger.Warn(fmt.Sprintf("Cache unavailable, falling back to storage for token %s: %v", urlToken, err))
+	}
+
+	switch lookup {
+	case domain.CacheKnownMissing:
+		return domain.ResolvedUrl{}, nonExistingError(urlToken)
+	case domain.CacheMiss:
+		mappingInfo, err = u.loadAndCacheMapping(ctx, urlToken)
+		if err != nil {
+			return domain.ResolvedUrl{}, err
+		}
+	}
+
+	if mappingInfo.IsDisabled() {
+		return domain.ResolvedUrl{}, disabledError(mappingInfo)
+	}
+
+	if mappingInfo.IsFlagged() && !warningAcknowledged {
+		return domain.ResolvedUrl{}, &domain.UrlFlaggedError{Msg: fmt.Sprintf("short URL is flagged as %s: %s", mappingInfo.Threat, urlToken)}
+	}
+
+	if mappingInfo.Protected {
+		return domain.ResolvedUrl{}, &domain.PasswordRequiredError{Msg: fmt.Sprintf("short URL is password-protected: %s", urlToken)}
+	}
+
+	return u.redirectTo(ctx, mappingInfo)
+}
+
+// UnlockOriginalUrl retrieves the original URL of a password-protected short URL token.
+// The password is always verified against the hash in persistent storage, as caches never hold it.
+// Every attempt is throttled per token, and a successful attempt resets the throttling.
+// A successful unlock counts as a redirect against the redirect quota and the click limit.
+// Flagged mappings are unlocked as well, as the password form is only reached after their warning.
+// Mappings without a password are never resolved here, so their warning cannot be bypassed.
+// Taken down mappings are never unlocked.
 //
 // Returns an error if:
+//   - *domain.TooManyAttemptsError: too many password attempts were made for the token
 //   - *domain.UrlNonExistingError: the URL token was not found in storage
-func (u *UrlGetter) GetOriginalUrl(ctx context.Context, urlToken string) (string, error) {
-	if originalUrl, found := u.cache.GetOriginalUrl(ctx, urlToken); found {
-		return originalUrl, nil
+//   - *domain.StorageUnavailableError: the storage failed
+//   - *domain.UrlExpiredError: the mapping exists but has expired
+//   - *domain.UrlDisabledError: the mapping was taken down
+//   - *domain.UrlNotProtectedError: the mapping is not protected by a password
+//   - *domain.WrongPasswordError: the password does not match
+//   - *domain.QuotaExceededError: the workspace of the mapping has exceeded its redirects per second
+//   - *domain.ClickLimitReachedError: the mapping has consumed all of its allowed clicks
+//   - Registering the attempt, verifying the password or counting the click fails
+func (u *UrlGetter) UnlockOriginalUrl(ctx context.Context, urlToken string, password string) (domain.ResolvedUrl, error) {
+	allowed, err := u.passwordLimiter.TryAttempt(ctx, urlToken)
+	if err != nil {
+		return domain.ResolvedUrl{}, fmt.Errorf("registering password attempt: %w", err)
+	} else if !allowed {
+		return domain.ResolvedUrl{}, &domain.TooManyAttemptsError{Msg: fmt.Sprintf("too many password attempts for short URL: %s", urlToken)}
+	}
+
+	mappingInfo, err := u.loadMapping(ctx, urlToken)
+	if err != nil {
+		return domain.ResolvedUrl{}, err
+	}
+
+	if mappingInfo.IsDisabled() {
+		return domain.ResolvedUrl{}, disabledError(mappingInfo)
+	}
+
+	if !mappingInfo.Protected {
+		return domain.ResolvedUrl{}, &domain.UrlNotProtectedError{Msg: fmt.Sprintf("short URL is not password-protected: %s", urlToken)}
+	}
+
+	matches, err := domain.CheckPassword(mappingInfo.PasswordHash, password)
+	if err != nil {
+		return domain.ResolvedUrl{}, err
+	} else if !matches {
+		return domain.ResolvedUrl{}, &domain.WrongPasswordError{Msg: fmt.Sprintf("wrong password for short URL: %s", urlToken)}
+	}
+
+	err = u.passwordLimiter.ResetAttempts(ctx, urlToken)
+	if err != nil {
+		u.logger.Warn(fmt.Sprintf("Failed to reset password attempts for token %s: %v", urlToken, err))
+	}
+
+	return u.redirectTo(ctx, mappingInfo)
+}
+
+// loadAndCacheMapping retrieves a mapping from persistent storage and caches it, or caches the token
+// as missing if it was not found. Concurrent calls for the same token share a single storage lookup,
+// which is detached from the cancellation of the caller that started it.
+func (u *UrlGetter) loadAndCacheMapping(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
+	result, err, _ := u.lookups.Do(urlToken, func() (interface{}, error) {
+		lookupCtx := context.WithoutCancel(ctx)
+
+		mappingInfo, err := u.loadMapping(lookupCtx, urlToken)
+		if errors.Is(err, &domain.UrlNonExistingError{}) {
+			cacheErr := u.cache.SetMissing(lookupCtx, urlToken)
+			if cacheErr != nil {
+				u.logger.Warn(fmt.Sprintf("Failed to cache missing short URL %s: %v", urlToken, cacheErr))
+			}
+			return domain.MappingInfo{}, err
+		} else if err != nil {
+			return domain.MappingInfo{}, err
+		}
+
+		err = u.cache.SetMapping(lookupCtx, mappingInfo)
+		if err != nil {
+			u.logger.Warn("Failed to cache short URL for original URL")
+		}
+
+		return mappingInfo, nil
+	})
+	if err != nil {
+		return domain.MappingInfo{}, err
+	}
+
+	return result.(domain.MappingInfo), nil
+}
+
+// loadMapping retrieves a mapping that has not expired yet from persistent storage.
+func (u *UrlGetter) loadMapping(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
+	mappingInfo, err := u.store.GetMappingByToken(ctx, urlToken)
+	if errors.Is(err, &domain.TokenNonExistingError{}) {
+		return domain.MappingInfo{}, nonExistingError(urlToken)
+	} else if err != nil {
+		return domain.MappingInfo{}, &domain.StorageUnavailableError{Msg: fmt.Sprintf("resolving short URL %s: %v", urlToken, err)}
+	}
+
+	if mappingInfo.IsExpired(time.Now()) {
+		return domain.MappingInfo{}, &domain.UrlExpiredError{Msg: fmt.Sprintf("short URL has expired: %s", urlToken)}
 	}
 
-	mappingInfo, found := u.store.GetMappingByToken(ctx, urlToken)
-	if !found {
-		return "", &domain.UrlNonExistingError{Msg: fmt.Sprintf("short URL not found for original URL: %s", urlToken)}
+	return mappingInfo, nil
+}
+
+// redirectTo counts the redirect against the quota of the workspace of the mapping,
+// consumes a click of a click-limited mapping and returns its original URL.
+// Redirects rejected by the quota do not consume a click.
+func (u *UrlGetter) redirectTo(ctx context.Context, mappingInfo domain.MappingInfo) (domain.ResolvedUrl, error) {
+	err := u.redirectQuota.CheckRedirectQuota(ctx, mappingInfo.WorkspaceId)
+	if err != nil {
+		return domain.ResolvedUrl{}, err
 	}
 
-	err := u.cache.SetMapping(ctx, mappingInfo.OriginalURL, urlToken)
+	if mappingInfo.HasClickLimit() {
+		err = u.consumeClick(ctx, mappingInfo)
+		if err != nil {
+			return domain.ResolvedUrl{}, err
+		}
+	}
+
+	return domain.ResolvedUrl{OriginalURL: mappingInfo.OriginalURL, WorkspaceId: mappingInfo.WorkspaceId}, nil
+}
+
+// consumeClick atomically counts one click of a click-limited mapping.
+// The counter is seeded with the persisted click count, and every allowed click
+// is reconciled back into persistent storage, so the limit survives a counter loss.
+// Clicks beyond the limit are rejected and not persisted.
+func (u *UrlGetter) consumeClick(ctx context.Context, mappingInfo domain.MappingInfo) error {
+	count, err := u.clickCounter.IncrementClicks(ctx, mappingInfo.Key(), mappingInfo.ClickCount, mappingInfo.ExpiresAt)
 	if err != nil {
-		u.logger.Warn("Failed to cache short URL for original URL")
+		return fmt.Errorf("counting click: %w", err)
 	}
 
-	return mappingInfo.OriginalURL, nil
+	if count > *mappingInfo.MaxClicks {
+		return &domain.ClickLimitReachedError{Msg: fmt.Sprintf("short URL has reached its click limit: %s", mappingInfo.Key())}
+	}
+
+	err = u.clickSaver.SaveClickCount(ctx, mappingInfo.Key(), count)
+	if err != nil {
+		u.logger.Warn(fmt.Sprintf("Failed to save click count for token %s: %v", mappingInfo.Key(), err))
+	}
+
+	return nil
+}
+
+// nonExistingError reports that no mapping exists for the token.
+func nonExistingError(urlToken string) error {
+	return &domain.UrlNonExistingError{Msg: fmt.Sprintf("short URL not found for original URL: %s", urlToken)}
+}
+
+// disabledError reports that the mapping was taken down.
+func disabledError(mappingInfo domain.MappingInfo) error {
+	return &domain.UrlDisabledError{Msg: fmt.Sprintf("short URL was taken down (%s): %s", mappingInfo.DisabledReason, mappingInfo.Key())}
 }
@@ -2,6 +2,7 @@ package urlcases
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 	"url-shortening-service/internal/domain"
@@ -11,16 +12,46 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+type getterMocks struct {
+	cache        *mocks.MockMappedGetSetter
+	store        *mocks.MockMappingInfoGetter
+	clickCounter *mocks.MockClickCounter
+	clickSaver   *mocks.MockClickCountSaver
+	limiter      *mocks.MockAttemptLimiter
+	quota        *mocks.MockRedirectQuotaChecker
+	logger       *mocks.MockLogger
+}
+
+func newGetterMocks(ctrl *gomock.Controller) getterMocks {
+	return getterMocks{
+		cache:        mocks.NewMockMappedGetSetter(ctrl),
+		store:        mocks.NewMockMappingInfoGetter(ctrl),
+		clickCounter: mocks.NewMockClickCounter(ctrl),
+		clickSaver:   mocks.NewMockClickCountSaver(ctrl),
+		limiter:      mocks.NewMockAttemptLimiter(ctrl),
+		quota:        mocks.NewMockRedirectQuotaChecker(ctrl),
+		logger:       mocks.NewMockLogger(ctrl),
+	}
+}
+
+// newGetter returns a UrlGetter using the mocks. Redirects not expected otherwise pass the redirect quota.
+func (m getterMocks) newGetter() *UrlGetter {
+	m.quota.EXPECT().CheckRedirectQuota(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return NewUrlGetter(m.cache, m.store, m.clickCounter, m.clickSaver, m.limiter, m.quota, m.logger)
+}
+
 func TestUrlGetter_GetOriginalUrl(t *testing.T) {
 	t.Parallel()
 
+	maxClicks := int64(3)
+
 	type testCase struct {
 		name                string
 		urlToken            string
 		expectedOriginalUrl string
 		expectedError       error
 
-		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.MappedGetSetter, domain.MappingInfoGetter, domain.Logger)
+		setupMocks func(t *testing.T, m getterMocks)
 	}
 
 	testCases := []testCase{
@@ -29,14 +60,11 @@ func TestUrlGetter_GetOriginalUrl(t *testing.T) {
 			urlToken:            "abc123",
 			expectedOriginalUrl: "https://example.com/long-url",
 			expectedError:       nil,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappedGetSetter, domain.MappingInfoGetter, domain.Logger) {
-				cacheMock := mocks.NewMockMappedGetSetter(ctrl)
-				storeMock := mocks.NewMockMappingInfoGetter(ctrl)
-				loggerMock := mocks.NewMockLogger(ctrl)
-
-				cacheMock.EXPECT().GetOriginalUrl(gomock.Any(), "abc123").Return("https://example.com/long-url", true)
-
-				return cacheMock, storeMock, loggerMock
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "abc123").Return(domain.MappingInfo{
+					OriginalURL: "https://example.com/long-url",
+					Token:       "abc123",
+				}, domain.CacheHit, nil)
 			},
 		},
 		{
@@ -44,22 +72,17 @@ func TestUrlGetter_GetOriginalUrl(t *testing.T) {
 			urlToken:            "xyz789",
 			expectedOriginalUrl: "https://example.com/another-url",
 			expectedError:       nil,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappedGetSetter, domain.MappingInfoGetter, domain.Logger) {
-				cacheMock := mocks.NewMockMappedGetSetter(ctrl)
-				storeMock := mocks.NewMockMappingInfoGetter(ctrl)
-				loggerMock := mocks.NewMockLogger(ctrl)
-
-				cacheMock.EXPECT().GetOriginalUrl(gomock.Any(), "xyz789").Return("", false)
-				storeMock.EXPECT().GetMappingByToken(gomock.Any(), "xyz789").Return(domain.MappingInfo{
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := domain.MappingInfo{
 					Id:          1,
 					OriginalURL: "https://example.com/another-url",
 					Token:       "xyz789",
 					CreatedAt:   time.Now(),
 					UpdatedAt:   time.Now(),
-				}, true)
-				cacheMock.EXPECT().SetMapping(gomock.Any(), "https://example.com/another-url", "xyz789").Return(nil)
-
-				return cacheMock, storeMock, loggerMock
+				}
+				m.cache.EXPECT().GetMapping(gomock.Any(), "xyz789").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "xyz789").Return(mapping, nil)
+				m.cache.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
 			},
 		},
 		{
@@ -67,15 +90,54 @@ func TestUrlGetter_GetOriginalUrl(t *testing.T) {
 			urlToken:            "nonexistent",
 			expectedOriginalUrl: "",
 			expectedError:       &domain.UrlNonExistingError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappedGetSetter, domain.MappingInfoGetter, domain.Logger) {
-				cacheMock := mocks.NewMockMappedGetSetter(ctrl)
-				storeMock := mocks.NewMockMappingInfoGetter(ctrl)
-				loggerMock := mocks.NewMockLogger(ctrl)
-
-				cacheMock.EXPECT().GetOriginalUrl(gomock.Any(), "nonexistent").Return("", false)
-				storeMock.EXPECT().GetMappingByToken(gomock.Any(), "nonexistent").Return(domain.MappingInfo{}, false)
-
-				return cacheMock, storeMock, loggerMock
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "nonexistent").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "nonexistent").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: "token not found"})
+				m.cache.EXPECT().SetMissing(gomock.Any(), "nonexistent").Return(nil)
+			},
+		},
+		{
+			name:                "cached missing token returns error without storage lookup",
+			urlToken:            "scan42",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.UrlNonExistingError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "scan42").Return(domain.MappingInfo{}, domain.CacheKnownMissing, nil)
+			},
+		},
+		{
+			name:                "storage miss with failing negative cache logs warning and returns error",
+			urlToken:            "scan43",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.UrlNonExistingError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "scan43").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "scan43").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: "token not found"})
+				m.cache.EXPECT().SetMissing(gomock.Any(), "scan43").Return(assert.AnError)
+				m.logger.EXPECT().Warn(gomock.Any())
+			},
+		},
+		{
+			name:                "cache error falls back to storage and logs warning",
+			urlToken:            "down12",
+			expectedOriginalUrl: "https://example.com/fallback",
+			expectedError:       nil,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := domain.MappingInfo{Id: 6, OriginalURL: "https://example.com/fallback", Token: "down12"}
+				m.cache.EXPECT().GetMapping(gomock.Any(), "down12").Return(domain.MappingInfo{}, domain.CacheMiss, assert.AnError)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "down12").Return(mapping, nil)
+				m.cache.EXPECT().SetMapping(gomock.Any(), mapping).Return(assert.AnError)
+				m.logger.EXPECT().Warn(gomock.Any()).Times(2)
+			},
+		},
+		{
+			name:                "storage error returns storage unavailable error without negative caching",
+			urlToken:            "down34",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.StorageUnavailableError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "down34").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "down34").Return(domain.MappingInfo{}, assert.AnError)
 			},
 		},
 		{
@@ -83,23 +145,181 @@ func TestUrlGetter_GetOriginalUrl(t *testing.T) {
 			urlToken:            "def456",
 			expectedOriginalUrl: "https://example.com/cached-fail-url",
 			expectedError:       nil,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappedGetSetter, domain.MappingInfoGetter, domain.Logger) {
-				cacheMock := mocks.NewMockMappedGetSetter(ctrl)
-				storeMock := mocks.NewMockMappingInfoGetter(ctrl)
-				loggerMock := mocks.NewMockLogger(ctrl)
-
-				cacheMock.EXPECT().GetOriginalUrl(gomock.Any(), "def456").Return("", false)
-				storeMock.EXPECT().GetMappingByToken(gomock.Any(), "def456").Return(domain.MappingInfo{
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := domain.MappingInfo{
 					Id:          2,
 					OriginalURL: "https://example.com/cached-fail-url",
 					Token:       "def456",
 					CreatedAt:   time.Now(),
 					UpdatedAt:   time.Now(),
-				}, true)
-				cacheMock.EXPECT().SetMapping(gomock.Any(), "https://example.com/cached-fail-url", "def456").Return(assert.AnError)
-				loggerMock.EXPECT().Warn(gomock.Any()).AnyTimes()
-
-				return cacheMock, storeMock, loggerMock
+				}
+				m.cache.EXPECT().GetMapping(gomock.Any(), "def456").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "def456").Return(mapping, nil)
+				m.cache.EXPECT().SetMapping(gomock.Any(), mapping).Return(assert.AnError)
+				m.logger.EXPECT().Warn(gomock.Any()).AnyTimes()
+			},
+		},
+		{
+			name:                "cache miss then storage hit with future expiration",
+			urlToken:            "fut123",
+			expectedOriginalUrl: "https://example.com/future",
+			expectedError:       nil,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				expiresAt := time.Now().Add(time.Hour)
+				mapping := domain.MappingInfo{
+					Id:          3,
+					OriginalURL: "https://example.com/future",
+					Token:       "fut123",
+					ExpiresAt:   &expiresAt,
+				}
+				m.cache.EXPECT().GetMapping(gomock.Any(), "fut123").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "fut123").Return(mapping, nil)
+				m.cache.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
+			},
+		},
+		{
+			name:                "cache miss then storage hit with expired mapping returns error and skips cache",
+			urlToken:            "old123",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.UrlExpiredError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				expiresAt := time.Now().Add(-time.Minute)
+				m.cache.EXPECT().GetMapping(gomock.Any(), "old123").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "old123").Return(domain.MappingInfo{
+					Id:          4,
+					OriginalURL: "https://example.com/old",
+					Token:       "old123",
+					ExpiresAt:   &expiresAt,
+				}, nil)
+			},
+		},
+		{
+			name:                "cache hit with click limit consumes click and saves count",
+			urlToken:            "once12",
+			expectedOriginalUrl: "https://example.com/download",
+			expectedError:       nil,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "once12").Return(domain.MappingInfo{
+					OriginalURL: "https://example.com/download",
+					Token:       "once12",
+					MaxClicks:   &maxClicks,
+					ClickCount:  1,
+				}, domain.CacheHit, nil)
+				m.clickCounter.EXPECT().IncrementClicks(gomock.Any(), "once12", int64(1), nil).Return(int64(3), nil)
+				m.clickSaver.EXPECT().SaveClickCount(gomock.Any(), "once12", int64(3)).Return(nil)
+			},
+		},
+		{
+			name:                "storage hit with click limit consumes click",
+			urlToken:            "once34",
+			expectedOriginalUrl: "https://example.com/invite",
+			expectedError:       nil,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := domain.MappingInfo{
+					Id:          5,
+					OriginalURL: "https://example.com/invite",
+					Token:       "once34",
+					MaxClicks:   &maxClicks,
+				}
+				m.cache.EXPECT().GetMapping(gomock.Any(), "once34").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "once34").Return(mapping, nil)
+				m.cache.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
+				m.clickCounter.EXPECT().IncrementClicks(gomock.Any(), "once34", int64(0), nil).Return(int64(1), nil)
+				m.clickSaver.EXPECT().SaveClickCount(gomock.Any(), "once34", int64(1)).Return(nil)
+			},
+		},
+		{
+			name:                "click limit reached returns error without saving count",
+			urlToken:            "used12",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.ClickLimitReachedError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "used12").Return(domain.MappingInfo{
+					OriginalURL: "https://example.com/download",
+					Token:       "used12",
+					MaxClicks:   &maxClicks,
+				}, domain.CacheHit, nil)
+				m.clickCounter.EXPECT().IncrementClicks(gomock.Any(), "used12", int64(0), nil).Return(int64(4), nil)
+			},
+		},
+		{
+			name:                "click counter error returns error",
+			urlToken:            "once56",
+			expectedOriginalUrl: "",
+			expectedError:       assert.AnError,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "once56").Return(domain.MappingInfo{
+					OriginalURL: "https://example.com/download",
+					Token:       "once56",
+					MaxClicks:   &maxClicks,
+				}, domain.CacheHit, nil)
+				m.clickCounter.EXPECT().IncrementClicks(gomock.Any(), "once56", int64(0), nil).Return(int64(0), assert.AnError)
+			},
+		},
+		{
+			name:                "click count save error logs warning and returns url",
+			urlToken:            "once78",
+			expectedOriginalUrl: "https://example.com/download",
+			expectedError:       nil,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "once78").Return(domain.MappingInfo{
+					OriginalURL: "https://example.com/download",
+					Token:       "once78",
+					MaxClicks:   &maxClicks,
+				}, domain.CacheHit, nil)
+				m.clickCounter.EXPECT().IncrementClicks(gomock.Any(), "once78", int64(0), nil).Return(int64(2), nil)
+				m.clickSaver.EXPECT().SaveClickCount(gomock.Any(), "once78", int64(2)).Return(assert.AnError)
+				m.logger.EXPECT().Warn(gomock.Any())
+			},
+		},
+		{
+			name:                "cache hit with protected mapping requires password",
+			urlToken:            "lock12",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.PasswordRequiredError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "lock12").Return(domain.MappingInfo{
+					OriginalURL: "https://example.com/internal",
+					Token:       "lock12",
+					Protected:   true,
+				}, domain.CacheHit, nil)
+			},
+		},
+		{
+			name:          "cache hit of flagged mapping requires acknowledging the warning",
+			urlToken:      "flag01",
+			expectedError: &domain.UrlFlaggedError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "flag01").Return(domain.MappingInfo{
+					OriginalURL: "https://example.com/login",
+					Token:       "flag01",
+					Threat:      "phishing",
+				}, domain.CacheHit, nil)
+			},
+		},
+		{
+			name:          "cache hit of taken down mapping returns error even if flagged and protected",
+			urlToken:      "down01",
+			expectedError: &domain.UrlDisabledError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "down01").Return(domain.MappingInfo{
+					OriginalURL:    "https://example.com/login",
+					Token:          "down01",
+					Threat:         "phishing",
+					Protected:      true,
+					DisabledReason: "phishing",
+				}, domain.CacheHit, nil)
+			},
+		},
+		{
+			name:          "storage hit of taken down mapping is cached and returns error",
+			urlToken:      "down02",
+			expectedError: &domain.UrlDisabledError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := domain.MappingInfo{Id: 8, OriginalURL: "https://example.com/spam", Token: "down02", DisabledReason: "spam"}
+				m.cache.EXPECT().GetMapping(gomock.Any(), "down02").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "down02").Return(mapping, nil)
+				m.cache.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
 			},
 		},
 		{
@@ -107,15 +327,334 @@ func TestUrlGetter_GetOriginalUrl(t *testing.T) {
 			urlToken:            "",
 			expectedOriginalUrl: "",
 			expectedError:       &domain.UrlNonExistingError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappedGetSetter, domain.MappingInfoGetter, domain.Logger) {
-				cacheMock := mocks.NewMockMappedGetSetter(ctrl)
-				storeMock := mocks.NewMockMappingInfoGetter(ctrl)
-				loggerMock := mocks.NewMockLogger(ctrl)
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.cache.EXPECT().GetMapping(gomock.Any(), "").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: "token not found"})
+				m.cache.EXPECT().SetMissing(gomock.Any(), "").Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newGetterMocks(ctrl)
+			tt.setupMocks(t, m)
+			urlGetter := m.newGetter()
+
+			resolved, err := urlGetter.GetOriginalUrl(context.Background(), tt.urlToken)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOriginalUrl, resolved.OriginalURL)
+			}
+		})
+	}
+}
+
+func TestUrlGetter_GetFlaggedOriginalUrl(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                string
+		mapping             domain.MappingInfo
+		expectedOriginalUrl string
+		expectedError       error
+	}
+
+	testCases := []testCase{
+		{
+			name:                "flagged mapping resolved",
+			mapping:             domain.MappingInfo{OriginalURL: "https://example.com/login", Token: "flag01", Threat: "phishing"},
+			expectedOriginalUrl: "https://example.com/login",
+		},
+		{
+			name:                "unflagged mapping resolved",
+			mapping:             domain.MappingInfo{OriginalURL: "https://example.com", Token: "flag01"},
+			expectedOriginalUrl: "https://example.com",
+		},
+		{
+			name:          "flagged protected mapping still requires password",
+			mapping:       domain.MappingInfo{OriginalURL: "https://example.com/login", Token: "flag01", Threat: "phishing", Protected: true},
+			expectedError: &domain.PasswordRequiredError{},
+		},
+		{
+			name:          "taken down mapping not resolved",
+			mapping:       domain.MappingInfo{OriginalURL: "https://example.com/login", Token: "flag01", Threat: "phishing", DisabledReason: "phishing"},
+			expectedError: &domain.UrlDisabledError{},
+		},
+	}
 
-				cacheMock.EXPECT().GetOriginalUrl(gomock.Any(), "").Return("", false)
-				storeMock.EXPECT().GetMappingByToken(gomock.Any(), "").Return(domain.MappingInfo{}, false)
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newGetterMocks(ctrl)
+			m.cache.EXPECT().GetMapping(gomock.Any(), "flag01").Return(tt.mapping, domain.CacheHit, nil)
+			urlGetter := m.newGetter()
+
+			resolved, err := urlGetter.GetFlaggedOriginalUrl(context.Background(), "flag01")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOriginalUrl, resolved.OriginalURL)
+			}
+		})
+	}
+}
+
+func TestUrlGetter_GetOriginalUrl_CoalescesConcurrentMisses(t *testing.T) {
+	t.Parallel()
+
+	const callers = 10
+
+	ctrl := gomock.NewController(t)
+	m := newGetterMocks(ctrl)
+
+	release := make(chan struct{})
+	mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/popular", Token: "hot123"}
+
+	m.cache.EXPECT().GetMapping(gomock.Any(), "hot123").Return(domain.MappingInfo{}, domain.CacheMiss, nil).Times(callers)
+	m.store.EXPECT().GetMappingByToken(gomock.Any(), "hot123").DoAndReturn(func(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
+		<-release
+		return mapping, nil
+	}).Times(1)
+	m.cache.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil).Times(1)
+
+	urlGetter := m.newGetter()
+
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	results := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer done.Done()
+			started.Done()
+			resolved, err := urlGetter.GetOriginalUrl(context.Background(), "hot123")
+			assert.NoError(t, err)
+			results <- resolved.OriginalURL
+		}()
+	}
+
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+	close(results)
+
+	for originalUrl := range results {
+		assert.Equal(t, "https://example.com/popular", originalUrl)
+	}
+}
+
+func TestUrlGetter_GetOriginalUrl_WorkspaceQuota(t *testing.T) {
+	t.Parallel()
+
+	maxClicks := int64(3)
+	mapping := domain.MappingInfo{OriginalURL: "https://example.com/campaign", Token: "ws1234", WorkspaceId: 7, MaxClicks: &maxClicks}
+
+	type testCase struct {
+		name          string
+		quotaError    error
+		expectedError error
+
+		setupMocks func(m getterMocks)
+	}
+
+	testCases := []testCase{
+		{
+			name: "redirect within quota resolves workspace",
+			setupMocks: func(m getterMocks) {
+				m.clickCounter.EXPECT().IncrementClicks(gomock.Any(), "ws1234", int64(0), nil).Return(int64(1), nil)
+				m.clickSaver.EXPECT().SaveClickCount(gomock.Any(), "ws1234", int64(1)).Return(nil)
+			},
+		},
+		{
+			name:          "redirect over quota rejected without consuming a click",
+			quotaError:    &domain.QuotaExceededError{},
+			expectedError: &domain.QuotaExceededError{},
+			setupMocks:    func(m getterMocks) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newGetterMocks(ctrl)
+			m.cache.EXPECT().GetMapping(gomock.Any(), "ws1234").Return(mapping, domain.CacheHit, nil)
+			m.quota.EXPECT().CheckRedirectQuota(gomock.Any(), int64(7)).Return(tt.quotaError)
+			tt.setupMocks(m)
+
+			resolved, err := m.newGetter().GetOriginalUrl(context.Background(), "ws1234")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.ResolvedUrl{OriginalURL: "https://example.com/campaign", WorkspaceId: 7}, resolved)
+			}
+		})
+	}
+}
+
+func TestUrlGetter_UnlockOriginalUrl(t *testing.T) {
+	t.Parallel()
+
+	maxClicks := int64(1)
+	passwordHash, err := domain.HashPassword("s3cret")
+	if err != nil {
+		t.Fatalf("hashing password: %v", err)
+	}
 
-				return cacheMock, storeMock, loggerMock
+	protectedMapping := func(token string) domain.MappingInfo {
+		return domain.MappingInfo{
+			Id:           1,
+			OriginalURL:  "https://example.com/internal",
+			Token:        token,
+			Protected:    true,
+			PasswordHash: passwordHash,
+		}
+	}
+
+	type testCase struct {
+		name                string
+		urlToken            string
+		password            string
+		expectedOriginalUrl string
+		expectedError       error
+
+		setupMocks func(t *testing.T, m getterMocks)
+	}
+
+	testCases := []testCase{
+		{
+			name:                "correct password returns original url and resets attempts",
+			urlToken:            "lock12",
+			password:            "s3cret",
+			expectedOriginalUrl: "https://example.com/internal",
+			expectedError:       nil,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "lock12").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "lock12").Return(protectedMapping("lock12"), nil)
+				m.limiter.EXPECT().ResetAttempts(gomock.Any(), "lock12").Return(nil)
+			},
+		},
+		{
+			name:                "wrong password returns error",
+			urlToken:            "lock34",
+			password:            "guess",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.WrongPasswordError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "lock34").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "lock34").Return(protectedMapping("lock34"), nil)
+			},
+		},
+		{
+			name:                "too many attempts returns error without checking storage",
+			urlToken:            "lock56",
+			password:            "s3cret",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.TooManyAttemptsError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "lock56").Return(false, nil)
+			},
+		},
+		{
+			name:                "attempt limiter error returns error",
+			urlToken:            "lock78",
+			password:            "s3cret",
+			expectedOriginalUrl: "",
+			expectedError:       assert.AnError,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "lock78").Return(false, assert.AnError)
+			},
+		},
+		{
+			name:                "storage miss returns error",
+			urlToken:            "nonexistent",
+			password:            "s3cret",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.UrlNonExistingError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "nonexistent").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "nonexistent").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: "token not found"})
+			},
+		},
+		{
+			name:                "storage error returns storage unavailable error",
+			urlToken:            "lock11",
+			password:            "s3cret",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.StorageUnavailableError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "lock11").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "lock11").Return(domain.MappingInfo{}, assert.AnError)
+			},
+		},
+		{
+			name:          "taken down mapping is not unlocked even with correct password",
+			urlToken:      "lock99",
+			password:      "s3cret",
+			expectedError: &domain.UrlDisabledError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := protectedMapping("lock99")
+				mapping.DisabledReason = "illegal content"
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "lock99").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "lock99").Return(mapping, nil)
+			},
+		},
+		{
+			name:          "flagged mapping without password is not unlocked",
+			urlToken:      "flag12",
+			password:      "",
+			expectedError: &domain.UrlNotProtectedError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://malware.example.com", Token: "flag12", Threat: "malware"}
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "flag12").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "flag12").Return(mapping, nil)
+			},
+		},
+		{
+			name:                "reset failure logs warning and returns url",
+			urlToken:            "lock90",
+			password:            "s3cret",
+			expectedOriginalUrl: "https://example.com/internal",
+			expectedError:       nil,
+			setupMocks: func(t *testing.T, m getterMocks) {
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "lock90").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "lock90").Return(protectedMapping("lock90"), nil)
+				m.limiter.EXPECT().ResetAttempts(gomock.Any(), "lock90").Return(assert.AnError)
+				m.logger.EXPECT().Warn(gomock.Any())
+			},
+		},
+		{
+			name:                "correct password with click limit reached returns error",
+			urlToken:            "once12",
+			password:            "s3cret",
+			expectedOriginalUrl: "",
+			expectedError:       &domain.ClickLimitReachedError{},
+			setupMocks: func(t *testing.T, m getterMocks) {
+				mapping := protectedMapping("once12")
+				mapping.MaxClicks = &maxClicks
+				mapping.ClickCount = 1
+				m.limiter.EXPECT().TryAttempt(gomock.Any(), "once12").Return(true, nil)
+				m.store.EXPECT().GetMappingByToken(gomock.Any(), "once12").Return(mapping, nil)
+				m.limiter.EXPECT().ResetAttempts(gomock.Any(), "once12").Return(nil)
+				m.clickCounter.EXPECT().IncrementClicks(gomock.Any(), "once12", int64(1), nil).Return(int64(2), nil)
 			},
 		},
 	}
@@ -126,16 +665,17 @@ func TestUrlGetter_GetOriginalUrl(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 
-			cacheMock, storeMock, loggerMock := tt.setupMocks(t, ctrl)
-			urlGetter := NewUrlGetter(cacheMock, storeMock, loggerMock)
+			m := newGetterMocks(ctrl)
+			tt.setupMocks(t, m)
+			urlGetter := m.newGetter()
 
-			originalUrl, err := urlGetter.GetOriginalUrl(context.Background(), tt.urlToken)
+			resolved, err := urlGetter.UnlockOriginalUrl(context.Background(), tt.urlToken, tt.password)
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedOriginalUrl, originalUrl)
+				assert.Equal(t, tt.expectedOriginalUrl, resolved.OriginalURL)
 			}
 		})
 	}
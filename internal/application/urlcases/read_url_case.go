@@ -0,0 +1,51 @@
+package urlcases
+
+import (
+	"context"
+	"url-shortening-service/internal/domain"
+)
+
+// UrlReader reads the details of URL mappings.
+// Only the owner of a mapping and admins may read it, and callers only list the mappings of their own owner.
+type UrlReader struct {
+	storage    domain.MappingInfoGetLister
+	authorizer domain.MappingOwnershipAuthorizer
+}
+
+// NewUrlReader creates a new UrlReader instance.
+// Parameters:
+//   - storage: persistent storage for URL mappings (e.g., PostgreSQL)
+//   - authorizer: checks that the caller owns the read mapping
+func NewUrlReader(storage domain.MappingInfoGetLister, authorizer domain.MappingOwnershipAuthorizer) *UrlReader {
+	return &UrlReader{
+		storage:    storage,
+		authorizer: authorizer,
+	}
+}
+
+// GetUrlMapping retrieves the details of the mapping of the URL token from persistent storage,
+// so they are never served from a cache that lacks some of them. Deleted mappings are not found.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist in storage or its mapping is deleted
+//   - *domain.ForbiddenError: the caller does not own the mapping
+//   - Storage operation fails
+func (r *UrlReader) GetUrlMapping(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
+	_, err := r.authorizer.AuthorizeMappingOwner(ctx, urlToken)
+	if err != nil {
+		return domain.MappingInfo{}, err
+	}
+
+	return r.storage.GetMappingByToken(ctx, urlToken)
+}
+
+// ListUrlMappings retrieves up to limit undeleted mappings with an ID greater than afterId, ordered by ID,
+// that are owned by the owner of the API key in ctx within its workspace. Admin keys list their own mappings as well,
+// while requests without API key list the mappings without owner outside of workspaces.
+//
+// Returns an error if the storage operation fails.
+func (r *UrlReader) ListUrlMappings(ctx context.Context, afterId int64, limit int) ([]domain.MappingInfo, error) {
+	key, _ := domain.ApiKeyFromContext(ctx)
+
+	return r.storage.ListOwnedMappings(ctx, domain.MappingOwner{WorkspaceId: key.WorkspaceId, OwnerId: key.Owner}, afterId, limit)
+}
@@ -0,0 +1,129 @@
+package urlcases
+
+import (
+	"context"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUrlReader_GetUrlMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com", Token: "abc123", OwnerId: "team-a"}
+
+	type testCase struct {
+		name           string
+		urlToken       string
+		expectedResult domain.MappingInfo
+		expectedError  error
+
+		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.MappingInfoGetLister, domain.MappingOwnershipAuthorizer)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "mapping of owner read from storage",
+			urlToken:       "abc123",
+			expectedResult: mapping,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappingInfoGetLister, domain.MappingOwnershipAuthorizer) {
+				storageMock := mocks.NewMockMappingInfoGetLister(ctrl)
+				storageMock.EXPECT().GetMappingByToken(gomock.Any(), "abc123").Return(mapping, nil)
+
+				return storageMock, ownerAuthorizer(ctrl)
+			},
+		},
+		{
+			name:          "mapping of another owner is forbidden",
+			urlToken:      "abc123",
+			expectedError: &domain.ForbiddenError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappingInfoGetLister, domain.MappingOwnershipAuthorizer) {
+				authorizer := mocks.NewMockMappingOwnershipAuthorizer(ctrl)
+				authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, &domain.ForbiddenError{})
+
+				return mocks.NewMockMappingInfoGetLister(ctrl), authorizer
+			},
+		},
+		{
+			name:          "deleted mapping is not found",
+			urlToken:      "deleted",
+			expectedError: &domain.TokenNonExistingError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.MappingInfoGetLister, domain.MappingOwnershipAuthorizer) {
+				storageMock := mocks.NewMockMappingInfoGetLister(ctrl)
+				storageMock.EXPECT().GetMappingByToken(gomock.Any(), "deleted").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{})
+
+				return storageMock, ownerAuthorizer(ctrl)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			storageMock, authorizer := tt.setupMocks(t, ctrl)
+			urlReader := NewUrlReader(storageMock, authorizer)
+
+			result, err := urlReader.GetUrlMapping(context.Background(), tt.urlToken)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestUrlReader_ListUrlMappings(t *testing.T) {
+	t.Parallel()
+
+	mappings := []domain.MappingInfo{{Id: 11, OriginalURL: "https://example.com", Token: "b", OwnerId: "team-a", WorkspaceId: 7}}
+
+	type testCase struct {
+		name          string
+		ctx           context.Context
+		expectedOwner domain.MappingOwner
+	}
+
+	testCases := []testCase{
+		{
+			name:          "mappings of the owner and workspace of the API key",
+			ctx:           domain.WithApiKey(context.Background(), domain.ApiKey{Owner: "team-a", WorkspaceId: 7, Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}),
+			expectedOwner: domain.MappingOwner{WorkspaceId: 7, OwnerId: "team-a"},
+		},
+		{
+			name:          "admin keys list their own mappings",
+			ctx:           domain.WithApiKey(context.Background(), domain.ApiKey{Owner: "ops", Scopes: []domain.ApiKeyScope{domain.ScopeAdmin}}),
+			expectedOwner: domain.MappingOwner{OwnerId: "ops"},
+		},
+		{
+			name:          "requests without API key list mappings without owner",
+			ctx:           context.Background(),
+			expectedOwner: domain.MappingOwner{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			storageMock := mocks.NewMockMappingInfoGetLister(ctrl)
+			storageMock.EXPECT().ListOwnedMappings(gomock.Any(), tt.expectedOwner, int64(10), 50).Return(mappings, nil)
+
+			urlReader := NewUrlReader(storageMock, mocks.NewMockMappingOwnershipAuthorizer(ctrl))
+
+			result, err := urlReader.ListUrlMappings(tt.ctx, 10, 50)
+
+			assert.NoError(t, err)
+			assert.Equal(t, mappings, result)
+		})
+	}
+}
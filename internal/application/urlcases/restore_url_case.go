@@ -0,0 +1,60 @@
+package urlcases
+
+import (
+	"context"
+	"errors"
+	"url-shortening-service/internal/domain"
+)
+
+// UrlRestorer handles restoration of deleted URL mappings.
+// Deleted mappings can be restored until they are purged, by their owner and by admins.
+type UrlRestorer struct {
+	cache      domain.UrlTokenDeleter
+	storage    domain.MappingInfoRestorer
+	authorizer domain.MappingOwnershipAuthorizer
+	logger     domain.Logger
+}
+
+// NewUrlRestorer creates a new UrlRestorer instance.
+// Parameters:
+//   - cache: cache storage for URL mappings (e.g., Redis)
+//   - storage: persistent storage for URL mappings (e.g., PostgreSQL)
+//   - authorizer: checks that the caller owns the restored mapping
+//   - logger: logger for recording info messages
+func NewUrlRestorer(cache domain.UrlTokenDeleter, storage domain.MappingInfoRestorer, authorizer domain.MappingOwnershipAuthorizer,
+	logger domain.Logger) *UrlRestorer {
+	return &UrlRestorer{
+		cache:      cache,
+		storage:    storage,
+		authorizer: authorizer,
+		logger:     logger,
+	}
+}
+
+// RestoreUrl undoes the deletion of a URL mapping by its token.
+// The token is evicted from the cache afterwards, so a cached miss of the deleted mapping
+// does not outlive the restoration.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: no deleted mapping with the token exists in storage
+//   - *domain.ForbiddenError: the caller does not own the mapping
+//   - Other errors from storage or cache operations
+func (ur *UrlRestorer) RestoreUrl(ctx context.Context, urlToken string) error {
+	_, err := ur.authorizer.AuthorizeMappingOwner(ctx, urlToken)
+	if err != nil {
+		return err
+	}
+
+	err = ur.storage.RestoreMappingInfo(ctx, urlToken)
+	if err != nil {
+		return err
+	}
+
+	err = ur.cache.DeleteMapping(ctx, urlToken)
+	if err != nil && !errors.Is(err, &domain.TokenNonExistingError{}) {
+		return err
+	}
+
+	ur.logger.Info("Restored token: " + urlToken)
+	return nil
+}
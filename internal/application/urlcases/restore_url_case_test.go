@@ -0,0 +1,114 @@
+package urlcases
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUrlRestorer_RestoreUrl(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		urlToken      string
+		expectedError error
+
+		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoRestorer)
+	}
+
+	testCases := []testCase{
+		{
+			name:     "successful restoration evicts cached miss",
+			urlToken: "abc123",
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoRestorer) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoRestorer(ctrl)
+
+				storageMock.EXPECT().RestoreMappingInfo(gomock.Any(), "abc123").Return(nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+
+				return cacheMock, storageMock
+			},
+		},
+		{
+			name:     "successful restoration of uncached token",
+			urlToken: "abc123",
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoRestorer) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoRestorer(ctrl)
+
+				storageMock.EXPECT().RestoreMappingInfo(gomock.Any(), "abc123").Return(nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(&domain.TokenNonExistingError{Msg: "token not in cache"})
+
+				return cacheMock, storageMock
+			},
+		},
+		{
+			name:          "storage returns token not existing error",
+			urlToken:      "nonexistent",
+			expectedError: &domain.TokenNonExistingError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoRestorer) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoRestorer(ctrl)
+
+				storageMock.EXPECT().RestoreMappingInfo(gomock.Any(), "nonexistent").Return(&domain.TokenNonExistingError{Msg: "token not found"})
+
+				return cacheMock, storageMock
+			},
+		},
+		{
+			name:          "cache returns generic error",
+			urlToken:      "abc123",
+			expectedError: assert.AnError,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoRestorer) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoRestorer(ctrl)
+
+				storageMock.EXPECT().RestoreMappingInfo(gomock.Any(), "abc123").Return(nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(assert.AnError)
+
+				return cacheMock, storageMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			cacheMock, storageMock := tt.setupMocks(t, ctrl)
+			urlRestorer := NewUrlRestorer(cacheMock, storageMock, ownerAuthorizer(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			err := urlRestorer.RestoreUrl(context.Background(), tt.urlToken)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUrlRestorer_NotOwner(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	authorizer := mocks.NewMockMappingOwnershipAuthorizer(ctrl)
+	authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, &domain.ForbiddenError{})
+
+	urlRestorer := NewUrlRestorer(mocks.NewMockUrlTokenDeleter(ctrl), mocks.NewMockMappingInfoRestorer(ctrl), authorizer,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := urlRestorer.RestoreUrl(context.Background(), "abc123")
+
+	assert.ErrorIs(t, err, &domain.ForbiddenError{})
+}
@@ -2,47 +2,264 @@ package urlcases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 	"url-shortening-service/internal/domain"
 )
 
+// maxTokenGenerationAttempts limits how many IDs are tried when a generated token
+// is already taken by a custom alias or a token of another strategy, or the ID itself is already taken.
+const maxTokenGenerationAttempts = 10
+
+// errNoFreeToken is returned when every generated token candidate was already taken.
+var errNoFreeToken = errors.New("failed to generate a free token")
+
+// errNoFreeId is returned when every ID allocated for a custom alias was already taken.
+var errNoFreeId = errors.New("failed to allocate a free id")
+
 // UrlShortener handles URL shortening operations.
-// It generates unique tokens for URLs and stores the mappings.
+// It generates unique tokens for URLs, stores the mappings and caches them.
 type UrlShortener struct {
-	store       domain.MappingInfoAdder
-	idGenerator domain.IdGenerator
+	store          domain.MappingInfoAdder
+	finder         domain.ReusableMappingFinder
+	idGenerator    domain.IdGenerator
+	tokenGenerator domain.TokenGenerator
+	tokenValidator domain.TokenValidator
+	urlValidator   domain.WorkspaceUrlValidator
+	scanner        domain.URLScanner
+	linkQuota      domain.LinkQuotaChecker
+	shortDomains   domain.ShortDomainAuthorizer
+	cache          domain.UrlTokenSetter
+	logger         domain.Logger
 }
 
 // NewUrlShortener creates a new UrlShortener instance.
 // Parameters:
 //   - idGenerator: generates unique IDs for new URL mappings
+//   - tokenGenerator: derives the tokens of new URL mappings from their IDs
+//   - tokenValidator: rejects custom aliases redirects would take for mistyped tokens
+//   - urlValidator: rejects destination URLs that must not be shortened in the workspace of the mapping
+//   - scanner: reputation service destination URLs are checked against
+//   - linkQuota: enforces the quota of short URLs of the workspace of the mapping
+//   - shortDomains: checks that the workspace of the mapping may use its custom short domain
 //   - store: persistent storage for URL mappings
-func NewUrlShortener(idGenerator domain.IdGenerator, store domain.MappingInfoAdder) *UrlShortener {
+//   - finder: persistent storage existing mappings of the same URL are looked up in
+//   - cache: cache storage new mappings are written to (e.g., Redis)
+//   - logger: logger for recording warnings
+func NewUrlShortener(idGenerator domain.IdGenerator, tokenGenerator domain.TokenGenerator, tokenValidator domain.TokenValidator,
+	urlValidator domain.WorkspaceUrlValidator, scanner domain.URLScanner, linkQuota domain.LinkQuotaChecker,
+	shortDomains domain.ShortDomainAuthorizer, store domain.MappingInfoAdder, finder domain.ReusableMappingFinder, cache domain.UrlTokenSetter,
+	logger domain.Logger) *UrlShortener {
 	return &UrlShortener{
-		store:       store,
-		idGenerator: idGenerator,
+		store:          store,
+		finder:         finder,
+		idGenerator:    idGenerator,
+		tokenGenerator: tokenGenerator,
+		tokenValidator: tokenValidator,
+		urlValidator:   urlValidator,
+		scanner:        scanner,
+		linkQuota:      linkQuota,
+		shortDomains:   shortDomains,
+		cache:          cache,
+		logger:         logger,
 	}
 }
 
 // ShortenUrl creates a shortened URL for the given original URL.
 // It validates the URL, generates a unique ID and token, and stores the mapping.
+// Internationalized host names are stored in their ASCII form.
+// The URL is checked against the reputation service; if the service fails, the URL is accepted
+// and left to the background rescan.
+// If opts.Alias is set, it is validated and used as the token instead of a generated one.
+// Generated tokens that collide with existing tokens are skipped in favour of the next ID.
+// If opts.ExpiresAt is set, the mapping stops redirecting after that moment.
+// If opts.MaxClicks is set, the mapping stops redirecting after that many redirects.
+// If opts.Password is set, only its salted hash is stored and the mapping redirects only after it is submitted.
+// If opts.ReuseExisting is set and no other option is, an existing mapping without expiration time, click limit
+// and password whose URL normalizes to the same URL is returned instead of creating a new one.
+// Only mappings of opts.OwnerId in opts.WorkspaceId are reused. Concurrent requests for the same URL may still create one mapping each.
+// The created mapping is owned by opts.OwnerId and belongs to opts.WorkspaceId: its destination is subject to the destination policy
+// of the workspace, and it counts against the quota of short URLs of the workspace. Reused mappings do not count against the quota.
+// If opts.Domain is set, the mapping is served at that custom short domain, which must be registered for the workspace.
+// Tokens are unique per domain, so a custom alias may be taken on one domain and free on another; only mappings
+// of the same domain are reused.
+// The created mapping is written to the cache, replacing a negative cache entry left by earlier lookups
+// of the token, so the new token is never reported as missing. Cache failures are only logged.
 //
-// Returns the created MappingInfo containing the new short URL token.
+// Returns the created or reused MappingInfo containing the short URL token, and whether it was reused.
 //
 // Returns an error if:
-//   - *domain.InvalidUrlError: the URL format is invalid or scheme is unsupported
-//   - ID generation fails
+//   - *domain.ShortDomainNonExistingError: the custom short domain is not registered
+//   - *domain.ForbiddenError: the custom short domain belongs to another workspace
+//   - *domain.InvalidUrlError: the URL is rejected by the URL validator
+//   - *domain.DestinationBlockedError: the destination is not allowed by the global or the workspace destination policy
+//   - *domain.MaliciousUrlError: the reputation service reports the URL as malicious
+//   - *domain.InvalidExpirationError: the expiration time is not in the future
+//   - *domain.InvalidClickLimitError: the click limit is not positive
+//   - *domain.InvalidPasswordError: the password is too short or too long
+//   - Password hashing fails
+//   - *domain.InvalidAliasError: the custom alias has invalid format, is reserved or fails token validation
+//   - *domain.TokenExistingError: the custom alias is already taken on the short domain
+//   - Looking up an existing mapping fails
+//   - *domain.QuotaExceededError: the workspace has used up its short URLs of the month
+//   - Counting the short URLs of the workspace fails
+//   - ID or token generation fails
 //   - Storage operation fails
-func (u *UrlShortener) ShortenUrl(ctx context.Context, originalUrl string) (domain.MappingInfo, error) {
-	err := domain.ValidateURL(originalUrl)
+func (u *UrlShortener) ShortenUrl(ctx context.Context, originalUrl string, opts domain.ShortenOptions) (domain.MappingInfo, bool, error) {
+	var shortDomain string
+	if opts.Domain != "" {
+		var err error
+		shortDomain, err = u.shortDomains.AuthorizeShortDomain(opts.Domain, opts.WorkspaceId)
+		if err != nil {
+			return domain.MappingInfo{}, false, err
+		}
+	}
+
+	originalUrl, err := u.urlValidator.ValidateWorkspaceURL(opts.WorkspaceId, originalUrl)
 	if err != nil {
-		return domain.MappingInfo{}, err
+		return domain.MappingInfo{}, false, err
+	}
+
+	err = scanDestination(ctx, u.scanner, u.logger, originalUrl)
+	if err != nil {
+		return domain.MappingInfo{}, false, err
+	}
+
+	err = domain.ValidateExpiration(opts.ExpiresAt, time.Now())
+	if err != nil {
+		return domain.MappingInfo{}, false, err
+	}
+
+	err = domain.ValidateMaxClicks(opts.MaxClicks)
+	if err != nil {
+		return domain.MappingInfo{}, false, err
+	}
+
+	err = domain.ValidatePassword(opts.Password)
+	if err != nil {
+		return domain.MappingInfo{}, false, err
+	}
+
+	if opts.Reusable() {
+		owner := domain.MappingOwner{WorkspaceId: opts.WorkspaceId, OwnerId: opts.OwnerId}
+		existing, found, err := u.finder.FindReusableMapping(ctx, originalUrl, owner, shortDomain)
+		if err != nil {
+			return domain.MappingInfo{}, false, fmt.Errorf("looking up existing short URL: %w", err)
+		} else if found {
+			return existing, true, nil
+		}
+	}
+
+	err = u.linkQuota.CheckLinkQuota(ctx, opts.WorkspaceId)
+	if err != nil {
+		return domain.MappingInfo{}, false, err
+	}
+
+	mapping := domain.MappingInfo{
+		OriginalURL: originalUrl,
+		ExpiresAt:   opts.ExpiresAt,
+		MaxClicks:   opts.MaxClicks,
+		OwnerId:     opts.OwnerId,
+		WorkspaceId: opts.WorkspaceId,
+		Domain:      shortDomain,
 	}
 
-	id, err := u.idGenerator.GetNextId(ctx)
+	if opts.Password != "" {
+		mapping.PasswordHash, err = domain.HashPassword(opts.Password)
+		if err != nil {
+			return domain.MappingInfo{}, false, err
+		}
+	}
+
+	var mappingInfo domain.MappingInfo
+	if opts.Alias != "" {
+		mappingInfo, err = u.shortenWithAlias(ctx, mapping, opts.Alias)
+	} else {
+		mappingInfo, err = u.shortenWithGeneratedToken(ctx, mapping)
+	}
+	if err != nil {
+		return domain.MappingInfo{}, false, err
+	}
+
+	err = u.cache.SetMapping(ctx, mappingInfo)
+	if err != nil {
+		u.logger.Warn(fmt.Sprintf("Failed to cache new short URL %s: %v", mappingInfo.Key(), err))
+	}
+
+	return mappingInfo, false, nil
+}
+
+// shortenWithGeneratedToken stores a mapping under a token generated from the next free ID.
+// Generated tokens that collide with existing aliases, or with tokens generated by another
+// token strategy before it was switched, are skipped in favour of the next ID.
+// IDs the allocator handed out twice, e.g. after losing its counter, are skipped as well.
+func (u *UrlShortener) shortenWithGeneratedToken(ctx context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
+	for attempt := 0; attempt < maxTokenGenerationAttempts; attempt++ {
+		id, err := u.idGenerator.GetNextId(ctx)
+		if err != nil {
+			return domain.MappingInfo{}, err
+		}
+
+		mapping.Id = id
+		mapping.Token, err = u.tokenGenerator.GenerateToken(id)
+		if err != nil {
+			return domain.MappingInfo{}, fmt.Errorf("generating token: %w", err)
+		}
+
+		mappingInfo, err := u.store.AddNewMapping(ctx, mapping)
+		if errors.Is(err, &domain.TokenExistingError{}) || errors.Is(err, &domain.IdExistingError{}) {
+			continue
+		}
+
+		return mappingInfo, err
+	}
+
+	return domain.MappingInfo{}, fmt.Errorf("%w after %d attempts", errNoFreeToken, maxTokenGenerationAttempts)
+}
+
+// shortenWithAlias stores a mapping that uses the given custom alias as its token.
+// Aliases that fail token validation are rejected, as they would look like mistyped tokens.
+// The alias still consumes an ID so that mapping IDs stay unique; IDs that are already taken are skipped.
+func (u *UrlShortener) shortenWithAlias(ctx context.Context, mapping domain.MappingInfo, alias string) (domain.MappingInfo, error) {
+	err := domain.ValidateAlias(alias)
 	if err != nil {
 		return domain.MappingInfo{}, err
 	}
 
-	urlToken := domain.GenerateToken(id)
-	return u.store.AddNewMapping(ctx, id, originalUrl, urlToken)
+	err = u.tokenValidator.ValidateToken(alias)
+	if err != nil {
+		return domain.MappingInfo{}, &domain.InvalidAliasError{Msg: fmt.Sprintf("Alias looks like a mistyped short URL: %s", alias)}
+	}
+
+	mapping.Token = alias
+	for attempt := 0; attempt < maxTokenGenerationAttempts; attempt++ {
+		mapping.Id, err = u.idGenerator.GetNextId(ctx)
+		if err != nil {
+			return domain.MappingInfo{}, err
+		}
+
+		mappingInfo, err := u.store.AddNewMapping(ctx, mapping)
+		if errors.Is(err, &domain.IdExistingError{}) {
+			continue
+		}
+
+		return mappingInfo, err
+	}
+
+	return domain.MappingInfo{}, fmt.Errorf("%w after %d attempts", errNoFreeId, maxTokenGenerationAttempts)
+}
+
+// scanDestination checks the destination URL against the reputation service.
+// A failing reputation service is only logged, so link creation does not depend on its availability.
+func scanDestination(ctx context.Context, scanner domain.URLScanner, logger domain.Logger, URL string) error {
+	verdict, err := scanner.ScanURL(ctx, URL)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to scan destination URL %s: %v", URL, err))
+		return nil
+	} else if verdict.Malicious {
+		return &domain.MaliciousUrlError{Msg: fmt.Sprintf("Destination is reported as %s: %s", verdict.FlaggedThreat(), URL)}
+	}
+
+	return nil
 }
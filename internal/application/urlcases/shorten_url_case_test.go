@@ -2,27 +2,36 @@ package urlcases
 
 import (
 	"context"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
+	"url-shortening-service/internal/application/policy"
 	"url-shortening-service/internal/domain"
 	"url-shortening-service/internal/domain/mocks"
+	localmocks "url-shortening-service/internal/infrastructure/mocks"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUrlShortener_ShortenUrl(t *testing.T) {
 	t.Parallel()
 
 	fixedTime := time.Date(2025, 12, 17, 10, 0, 0, 0, time.UTC)
+	futureTime := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	maxClicks := int64(1)
+	zeroClicks := int64(0)
 
 	type testCase struct {
 		name                string
 		originalUrl         string
+		opts                domain.ShortenOptions
 		expectedMappingInfo domain.MappingInfo
 		expectedError       error
 
-		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder)
+		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter)
 	}
 
 	testCases := []testCase{
@@ -37,12 +46,13 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 				UpdatedAt:   fixedTime,
 			},
 			expectedError: nil,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
 				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(1), nil)
-				storeMock.EXPECT().AddNewMapping(gomock.Any(), int64(1), "https://example.com/very-long-url", "b").Return(domain.MappingInfo{
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/very-long-url", Token: "b"}).Return(domain.MappingInfo{
 					Id:          1,
 					OriginalURL: "https://example.com/very-long-url",
 					Token:       "b",
@@ -50,7 +60,9 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 					UpdatedAt:   fixedTime,
 				}, nil)
 
-				return idGenMock, storeMock
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 		{
@@ -64,12 +76,13 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 				UpdatedAt:   fixedTime,
 			},
 			expectedError: nil,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
 				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(100), nil)
-				storeMock.EXPECT().AddNewMapping(gomock.Any(), int64(100), "http://example.com/path", "bM").Return(domain.MappingInfo{
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 100, OriginalURL: "http://example.com/path", Token: "bM"}).Return(domain.MappingInfo{
 					Id:          100,
 					OriginalURL: "http://example.com/path",
 					Token:       "bM",
@@ -77,7 +90,9 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 					UpdatedAt:   fixedTime,
 				}, nil)
 
-				return idGenMock, storeMock
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 		{
@@ -85,11 +100,12 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 			originalUrl:         "not-a-valid-url",
 			expectedMappingInfo: domain.MappingInfo{},
 			expectedError:       &domain.InvalidUrlError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
-				return idGenMock, storeMock
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 		{
@@ -97,11 +113,12 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 			originalUrl:         "",
 			expectedMappingInfo: domain.MappingInfo{},
 			expectedError:       &domain.InvalidUrlError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
-				return idGenMock, storeMock
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 		{
@@ -109,11 +126,12 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 			originalUrl:         "ftp://example.com/file",
 			expectedMappingInfo: domain.MappingInfo{},
 			expectedError:       &domain.InvalidUrlError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
-				return idGenMock, storeMock
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 		{
@@ -121,13 +139,14 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 			originalUrl:         "https://example.com/valid-url",
 			expectedMappingInfo: domain.MappingInfo{},
 			expectedError:       assert.AnError,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
 				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(0), assert.AnError)
 
-				return idGenMock, storeMock
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 		{
@@ -135,14 +154,404 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 			originalUrl:         "https://example.com/another-url",
 			expectedMappingInfo: domain.MappingInfo{},
 			expectedError:       assert.AnError,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
 				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(5), nil)
-				storeMock.EXPECT().AddNewMapping(gomock.Any(), int64(5), "https://example.com/another-url", "f").Return(domain.MappingInfo{}, assert.AnError)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 5, OriginalURL: "https://example.com/another-url", Token: "f"}).Return(domain.MappingInfo{}, assert.AnError)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "generated token taken by alias retries with next id",
+			originalUrl: "https://example.com/retry-url",
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          12,
+				OriginalURL: "https://example.com/retry-url",
+				Token:       "m",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				gomock.InOrder(
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(11), nil),
+					storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 11, OriginalURL: "https://example.com/retry-url", Token: "l"}).Return(domain.MappingInfo{}, &domain.TokenExistingError{Msg: "token taken"}),
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(12), nil),
+					storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 12, OriginalURL: "https://example.com/retry-url", Token: "m"}).Return(domain.MappingInfo{
+						Id:          12,
+						OriginalURL: "https://example.com/retry-url",
+						Token:       "m",
+						CreatedAt:   fixedTime,
+						UpdatedAt:   fixedTime,
+					}, nil),
+				)
+
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:                "generated tokens always taken returns error",
+			originalUrl:         "https://example.com/busy-url",
+			expectedMappingInfo: domain.MappingInfo{},
+			expectedError:       errNoFreeToken,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(1), nil).Times(maxTokenGenerationAttempts)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/busy-url", Token: "b"}).Return(domain.MappingInfo{}, &domain.TokenExistingError{Msg: "token taken"}).Times(maxTokenGenerationAttempts)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "successful url shortening with alias",
+			originalUrl: "https://example.com/spring",
+			opts:        domain.ShortenOptions{Alias: "spring-sale"},
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          20,
+				OriginalURL: "https://example.com/spring",
+				Token:       "spring-sale",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(20), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 20, OriginalURL: "https://example.com/spring", Token: "spring-sale"}).Return(domain.MappingInfo{
+					Id:          20,
+					OriginalURL: "https://example.com/spring",
+					Token:       "spring-sale",
+					CreatedAt:   fixedTime,
+					UpdatedAt:   fixedTime,
+				}, nil)
+
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:                "invalid alias returns error",
+			originalUrl:         "https://example.com/spring",
+			opts:                domain.ShortenOptions{Alias: "shorten"},
+			expectedMappingInfo: domain.MappingInfo{},
+			expectedError:       &domain.InvalidAliasError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:                "taken alias returns error without retry",
+			originalUrl:         "https://example.com/spring",
+			opts:                domain.ShortenOptions{Alias: "spring-sale"},
+			expectedMappingInfo: domain.MappingInfo{},
+			expectedError:       &domain.TokenExistingError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(21), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 21, OriginalURL: "https://example.com/spring", Token: "spring-sale"}).Return(domain.MappingInfo{}, &domain.TokenExistingError{Msg: "token taken"})
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:                "id generator error with alias returns error",
+			originalUrl:         "https://example.com/spring",
+			opts:                domain.ShortenOptions{Alias: "spring-sale"},
+			expectedMappingInfo: domain.MappingInfo{},
+			expectedError:       assert.AnError,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(0), assert.AnError)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "successful url shortening with expiration",
+			originalUrl: "https://example.com/flash-sale",
+			opts:        domain.ShortenOptions{ExpiresAt: &futureTime},
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          30,
+				OriginalURL: "https://example.com/flash-sale",
+				Token:       "E",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+				ExpiresAt:   &futureTime,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(30), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 30, OriginalURL: "https://example.com/flash-sale", Token: "E", ExpiresAt: &futureTime}).Return(domain.MappingInfo{
+					Id:          30,
+					OriginalURL: "https://example.com/flash-sale",
+					Token:       "E",
+					CreatedAt:   fixedTime,
+					UpdatedAt:   fixedTime,
+					ExpiresAt:   &futureTime,
+				}, nil)
+
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:                "expiration in the past returns error",
+			originalUrl:         "https://example.com/flash-sale",
+			opts:                domain.ShortenOptions{ExpiresAt: &fixedTime},
+			expectedMappingInfo: domain.MappingInfo{},
+			expectedError:       &domain.InvalidExpirationError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "successful url shortening with click limit",
+			originalUrl: "https://example.com/invite",
+			opts:        domain.ShortenOptions{MaxClicks: &maxClicks},
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          31,
+				OriginalURL: "https://example.com/invite",
+				Token:       "F",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+				MaxClicks:   &maxClicks,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(31), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 31, OriginalURL: "https://example.com/invite", Token: "F", MaxClicks: &maxClicks}).Return(domain.MappingInfo{
+					Id:          31,
+					OriginalURL: "https://example.com/invite",
+					Token:       "F",
+					CreatedAt:   fixedTime,
+					UpdatedAt:   fixedTime,
+					MaxClicks:   &maxClicks,
+				}, nil)
+
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:                "non-positive click limit returns error",
+			originalUrl:         "https://example.com/invite",
+			opts:                domain.ShortenOptions{MaxClicks: &zeroClicks},
+			expectedMappingInfo: domain.MappingInfo{},
+			expectedError:       &domain.InvalidClickLimitError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
-				return idGenMock, storeMock
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "successful url shortening with password stores hash",
+			originalUrl: "https://example.com/internal",
+			opts:        domain.ShortenOptions{Password: "s3cret"},
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          32,
+				OriginalURL: "https://example.com/internal",
+				Token:       "G",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+				Protected:   true,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(32), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
+					assert.Equal(t, "G", mapping.Token)
+					assert.NotEmpty(t, mapping.PasswordHash)
+					assert.NotEqual(t, "s3cret", mapping.PasswordHash)
+
+					matches, err := domain.CheckPassword(mapping.PasswordHash, "s3cret")
+					assert.NoError(t, err)
+					assert.True(t, matches)
+
+					return domain.MappingInfo{
+						Id:          32,
+						OriginalURL: "https://example.com/internal",
+						Token:       "G",
+						CreatedAt:   fixedTime,
+						UpdatedAt:   fixedTime,
+						Protected:   true,
+					}, nil
+				})
+
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:                "too short password returns error",
+			originalUrl:         "https://example.com/internal",
+			opts:                domain.ShortenOptions{Password: "abc"},
+			expectedMappingInfo: domain.MappingInfo{},
+			expectedError:       &domain.InvalidPasswordError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "created mapping is cached replacing negative entries",
+			originalUrl: "https://example.com/fresh",
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          33,
+				OriginalURL: "https://example.com/fresh",
+				Token:       "H",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				created := domain.MappingInfo{
+					Id:          33,
+					OriginalURL: "https://example.com/fresh",
+					Token:       "H",
+					CreatedAt:   fixedTime,
+					UpdatedAt:   fixedTime,
+				}
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(33), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 33, OriginalURL: "https://example.com/fresh", Token: "H"}).Return(created, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), created).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "cache error still returns created mapping",
+			originalUrl: "https://example.com/fresh",
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          34,
+				OriginalURL: "https://example.com/fresh",
+				Token:       "I",
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(34), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 34, OriginalURL: "https://example.com/fresh", Token: "I"}).Return(domain.MappingInfo{
+					Id:          34,
+					OriginalURL: "https://example.com/fresh",
+					Token:       "I",
+				}, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "taken id is skipped in favour of the next id",
+			originalUrl: "https://example.com/fresh",
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          1501,
+				OriginalURL: "https://example.com/fresh",
+				Token:       "yn",
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				gomock.InOrder(
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(1), nil),
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(1501), nil),
+				)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/fresh", Token: "b"}).
+					Return(domain.MappingInfo{}, &domain.IdExistingError{Msg: "id already exists"})
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 1501, OriginalURL: "https://example.com/fresh", Token: "yn"}).
+					Return(domain.MappingInfo{Id: 1501, OriginalURL: "https://example.com/fresh", Token: "yn"}, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:        "taken id of an alias is skipped in favour of the next id",
+			originalUrl: "https://example.com/spring",
+			opts:        domain.ShortenOptions{Alias: "spring-sale"},
+			expectedMappingInfo: domain.MappingInfo{
+				Id:          1501,
+				OriginalURL: "https://example.com/spring",
+				Token:       "spring-sale",
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				gomock.InOrder(
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(1), nil),
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(1501), nil),
+				)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/spring", Token: "spring-sale"}).
+					Return(domain.MappingInfo{}, &domain.IdExistingError{Msg: "id already exists"})
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 1501, OriginalURL: "https://example.com/spring", Token: "spring-sale"}).
+					Return(domain.MappingInfo{Id: 1501, OriginalURL: "https://example.com/spring", Token: "spring-sale"}, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 		{
@@ -150,14 +559,15 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 			originalUrl:         "https://example.com/existing-url",
 			expectedMappingInfo: domain.MappingInfo{},
 			expectedError:       &domain.UrlExistingError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder) {
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
 				idGenMock := mocks.NewMockIdGenerator(ctrl)
 				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
 
 				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(10), nil)
-				storeMock.EXPECT().AddNewMapping(gomock.Any(), int64(10), "https://example.com/existing-url", "k").Return(domain.MappingInfo{}, &domain.UrlExistingError{Msg: "url already exists"})
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 10, OriginalURL: "https://example.com/existing-url", Token: "k"}).Return(domain.MappingInfo{}, &domain.UrlExistingError{Msg: "url already exists"})
 
-				return idGenMock, storeMock
+				return idGenMock, storeMock, cacheMock
 			},
 		},
 	}
@@ -168,10 +578,10 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 
-			idGenMock, storeMock := tt.setupMocks(t, ctrl)
-			urlShortener := NewUrlShortener(idGenMock, storeMock)
+			idGenMock, storeMock, cacheMock := tt.setupMocks(t, ctrl)
+			urlShortener := NewUrlShortener(idGenMock, domain.SequentialTokenGenerator{}, acceptingTokenValidator(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), unlimitedLinkQuota(ctrl), noShortDomains(ctrl), storeMock, mocks.NewMockReusableMappingFinder(ctrl), cacheMock, slog.New(slog.NewTextHandler(io.Discard, nil)))
 
-			mappingInfo, err := urlShortener.ShortenUrl(context.Background(), tt.originalUrl)
+			mappingInfo, _, err := urlShortener.ShortenUrl(context.Background(), tt.originalUrl, tt.opts)
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
@@ -182,3 +592,540 @@ func TestUrlShortener_ShortenUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestUrlShortener_NewTokenIsNotMaskedByNegativeCache(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cache := localmocks.NewLocalCache()
+	idGen := mocks.NewMockIdGenerator(ctrl)
+	adder := mocks.NewMockMappingInfoAdder(ctrl)
+	store := mocks.NewMockMappingInfoGetter(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	created := domain.MappingInfo{Id: 35, OriginalURL: "https://example.com/fresh", Token: "J"}
+	store.EXPECT().GetMappingByToken(gomock.Any(), "J").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: "token not found"})
+	idGen.EXPECT().GetNextId(gomock.Any()).Return(int64(35), nil)
+	adder.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).Return(created, nil)
+
+	urlGetter := NewUrlGetter(cache, store, mocks.NewMockClickCounter(ctrl), mocks.NewMockClickCountSaver(ctrl),
+		mocks.NewMockAttemptLimiter(ctrl), unlimitedRedirectQuota(ctrl), logger)
+	urlShortener := NewUrlShortener(idGen, domain.SequentialTokenGenerator{}, acceptingTokenValidator(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), unlimitedLinkQuota(ctrl), noShortDomains(ctrl), adder, mocks.NewMockReusableMappingFinder(ctrl), cache, logger)
+
+	_, err := urlGetter.GetOriginalUrl(context.Background(), "J")
+	assert.ErrorIs(t, err, &domain.UrlNonExistingError{})
+
+	_, _, err = urlShortener.ShortenUrl(context.Background(), "https://example.com/fresh", domain.ShortenOptions{})
+	assert.NoError(t, err)
+
+	resolved, err := urlGetter.GetOriginalUrl(context.Background(), "J")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/fresh", resolved.OriginalURL)
+}
+
+func TestUrlShortener_TokenGenerator(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedToken string
+		expectedError bool
+
+		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.TokenGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter)
+	}
+
+	testCases := []testCase{
+		{
+			name:          "token of another strategy is skipped",
+			expectedToken: "Xk3pQ9aZ",
+			expectedError: false,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.TokenGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				tokenGenMock := mocks.NewMockTokenGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				gomock.InOrder(
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil),
+					idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(8), nil),
+				)
+				tokenGenMock.EXPECT().GenerateToken(int64(7)).Return("crAB", nil)
+				tokenGenMock.EXPECT().GenerateToken(int64(8)).Return("Xk3pQ9aZ", nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 7, OriginalURL: "https://example.com", Token: "crAB"}).
+					Return(domain.MappingInfo{}, &domain.TokenExistingError{Msg: "token already exists"})
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), domain.MappingInfo{Id: 8, OriginalURL: "https://example.com", Token: "Xk3pQ9aZ"}).
+					Return(domain.MappingInfo{Id: 8, OriginalURL: "https://example.com", Token: "Xk3pQ9aZ"}, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil)
+
+				return idGenMock, tokenGenMock, storeMock, cacheMock
+			},
+		},
+		{
+			name:          "token generation error",
+			expectedError: true,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.IdGenerator, domain.TokenGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				tokenGenMock := mocks.NewMockTokenGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil)
+				tokenGenMock.EXPECT().GenerateToken(int64(7)).Return("", assert.AnError)
+
+				return idGenMock, tokenGenMock, storeMock, cacheMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			idGenMock, tokenGenMock, storeMock, cacheMock := tt.setupMocks(t, ctrl)
+			urlShortener := NewUrlShortener(idGenMock, tokenGenMock, acceptingTokenValidator(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), unlimitedLinkQuota(ctrl), noShortDomains(ctrl), storeMock, mocks.NewMockReusableMappingFinder(ctrl), cacheMock, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			mappingInfo, _, err := urlShortener.ShortenUrl(context.Background(), "https://example.com", domain.ShortenOptions{})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedToken, mappingInfo.Token)
+			}
+		})
+	}
+}
+
+func TestUrlShortener_AliasFailingTokenValidation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	tokenEncoder, err := domain.NewTokenEncoder(domain.TokenEncodingBase62, 6, true)
+	require.NoError(t, err)
+	checkedToken := tokenEncoder.Encode(543643)
+	mistypedToken := checkedToken[:5] + checkedToken[6:] + checkedToken[5:6]
+	require.Error(t, tokenEncoder.ValidateToken(mistypedToken))
+
+	urlShortener := NewUrlShortener(mocks.NewMockIdGenerator(ctrl), domain.SequentialTokenGenerator{}, tokenEncoder, defaultUrlValidator(),
+		safeUrlScanner(ctrl), unlimitedLinkQuota(ctrl), noShortDomains(ctrl), mocks.NewMockMappingInfoAdder(ctrl), mocks.NewMockReusableMappingFinder(ctrl), mocks.NewMockUrlTokenSetter(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, _, err = urlShortener.ShortenUrl(context.Background(), "https://example.com", domain.ShortenOptions{Alias: mistypedToken})
+
+	assert.ErrorIs(t, err, &domain.InvalidAliasError{})
+}
+
+func TestUrlShortener_ReuseExisting(t *testing.T) {
+	t.Parallel()
+
+	maxClicks := int64(5)
+	existing := domain.MappingInfo{Id: 3, OriginalURL: "https://example.com/", Token: "d"}
+	created := domain.MappingInfo{Id: 7, OriginalURL: "https://example.com", Token: "h"}
+
+	type testCase struct {
+		name                string
+		opts                domain.ShortenOptions
+		expectedMappingInfo domain.MappingInfo
+		expectedReused      bool
+		expectedError       bool
+
+		setupMocks func(ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter)
+	}
+
+	testCases := []testCase{
+		{
+			name:                "existing mapping reused",
+			opts:                domain.ShortenOptions{ReuseExisting: true},
+			expectedMappingInfo: existing,
+			expectedReused:      true,
+			setupMocks: func(ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				finderMock := mocks.NewMockReusableMappingFinder(ctrl)
+				finderMock.EXPECT().FindReusableMapping(gomock.Any(), "https://example.com", domain.MappingOwner{}, "").Return(existing, true, nil)
+
+				return mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), finderMock, mocks.NewMockUrlTokenSetter(ctrl)
+			},
+		},
+		{
+			name:                "no existing mapping",
+			opts:                domain.ShortenOptions{ReuseExisting: true},
+			expectedMappingInfo: created,
+			expectedReused:      false,
+			setupMocks: func(ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				finderMock := mocks.NewMockReusableMappingFinder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				finderMock.EXPECT().FindReusableMapping(gomock.Any(), "https://example.com", domain.MappingOwner{}, "").Return(domain.MappingInfo{}, false, nil)
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).Return(created, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), created).Return(nil)
+
+				return idGenMock, storeMock, finderMock, cacheMock
+			},
+		},
+		{
+			name:                "reuse not applicable to click-limited mappings",
+			opts:                domain.ShortenOptions{ReuseExisting: true, MaxClicks: &maxClicks},
+			expectedMappingInfo: created,
+			expectedReused:      false,
+			setupMocks: func(ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).Return(created, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), created).Return(nil)
+
+				return idGenMock, storeMock, mocks.NewMockReusableMappingFinder(ctrl), cacheMock
+			},
+		},
+		{
+			name:                "owned mapping created after reuse lookup of owner",
+			opts:                domain.ShortenOptions{ReuseExisting: true, OwnerId: "team-a"},
+			expectedMappingInfo: created,
+			expectedReused:      false,
+			setupMocks: func(ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				finderMock := mocks.NewMockReusableMappingFinder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				finderMock.EXPECT().FindReusableMapping(gomock.Any(), "https://example.com", domain.MappingOwner{OwnerId: "team-a"}, "").Return(domain.MappingInfo{}, false, nil)
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
+					assert.Equal(ctrl.T, "team-a", mapping.OwnerId)
+					return created, nil
+				})
+				cacheMock.EXPECT().SetMapping(gomock.Any(), created).Return(nil)
+
+				return idGenMock, storeMock, finderMock, cacheMock
+			},
+		},
+		{
+			name:          "lookup error",
+			opts:          domain.ShortenOptions{ReuseExisting: true},
+			expectedError: true,
+			setupMocks: func(ctrl *gomock.Controller) (domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				finderMock := mocks.NewMockReusableMappingFinder(ctrl)
+				finderMock.EXPECT().FindReusableMapping(gomock.Any(), "https://example.com", domain.MappingOwner{}, "").Return(domain.MappingInfo{}, false, assert.AnError)
+
+				return mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), finderMock, mocks.NewMockUrlTokenSetter(ctrl)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			idGenMock, storeMock, finderMock, cacheMock := tt.setupMocks(ctrl)
+			urlShortener := NewUrlShortener(idGenMock, domain.SequentialTokenGenerator{}, acceptingTokenValidator(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), unlimitedLinkQuota(ctrl), noShortDomains(ctrl), storeMock, finderMock, cacheMock, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			mappingInfo, reused, err := urlShortener.ShortenUrl(context.Background(), "https://example.com", tt.opts)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, assert.AnError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReused, reused)
+				assert.Equal(t, tt.expectedMappingInfo, mappingInfo)
+			}
+		})
+	}
+}
+
+func TestUrlShortener_WorkspaceQuota(t *testing.T) {
+	t.Parallel()
+
+	existing := domain.MappingInfo{Id: 3, OriginalURL: "https://example.com", Token: "d", WorkspaceId: 7}
+	created := domain.MappingInfo{Id: 7, OriginalURL: "https://example.com", Token: "h", WorkspaceId: 7}
+
+	type testCase struct {
+		name                string
+		opts                domain.ShortenOptions
+		expectedMappingInfo domain.MappingInfo
+		expectedError       error
+
+		setupMocks func(ctrl *gomock.Controller) (domain.LinkQuotaChecker, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter)
+	}
+
+	testCases := []testCase{
+		{
+			name:                "mapping created in workspace",
+			opts:                domain.ShortenOptions{WorkspaceId: 7, OwnerId: "team-a"},
+			expectedMappingInfo: created,
+			setupMocks: func(ctrl *gomock.Controller) (domain.LinkQuotaChecker, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				quotaMock := mocks.NewMockLinkQuotaChecker(ctrl)
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				quotaMock.EXPECT().CheckLinkQuota(gomock.Any(), int64(7)).Return(nil)
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
+					assert.Equal(ctrl.T, int64(7), mapping.WorkspaceId)
+					assert.Equal(ctrl.T, "team-a", mapping.OwnerId)
+					return created, nil
+				})
+				cacheMock.EXPECT().SetMapping(gomock.Any(), created).Return(nil)
+
+				return quotaMock, idGenMock, storeMock, mocks.NewMockReusableMappingFinder(ctrl), cacheMock
+			},
+		},
+		{
+			name:                "reused mapping not counted against quota",
+			opts:                domain.ShortenOptions{WorkspaceId: 7, ReuseExisting: true},
+			expectedMappingInfo: existing,
+			setupMocks: func(ctrl *gomock.Controller) (domain.LinkQuotaChecker, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				finderMock := mocks.NewMockReusableMappingFinder(ctrl)
+				finderMock.EXPECT().FindReusableMapping(gomock.Any(), "https://example.com", domain.MappingOwner{WorkspaceId: 7}, "").Return(existing, true, nil)
+
+				return mocks.NewMockLinkQuotaChecker(ctrl), mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), finderMock, mocks.NewMockUrlTokenSetter(ctrl)
+			},
+		},
+		{
+			name:          "quota exceeded",
+			opts:          domain.ShortenOptions{WorkspaceId: 7},
+			expectedError: &domain.QuotaExceededError{},
+			setupMocks: func(ctrl *gomock.Controller) (domain.LinkQuotaChecker, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				quotaMock := mocks.NewMockLinkQuotaChecker(ctrl)
+				quotaMock.EXPECT().CheckLinkQuota(gomock.Any(), int64(7)).Return(&domain.QuotaExceededError{})
+
+				return quotaMock, mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), mocks.NewMockReusableMappingFinder(ctrl), mocks.NewMockUrlTokenSetter(ctrl)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			quotaMock, idGenMock, storeMock, finderMock, cacheMock := tt.setupMocks(ctrl)
+			urlShortener := NewUrlShortener(idGenMock, domain.SequentialTokenGenerator{}, acceptingTokenValidator(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), quotaMock, noShortDomains(ctrl), storeMock, finderMock, cacheMock, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			mappingInfo, _, err := urlShortener.ShortenUrl(context.Background(), "https://example.com", tt.opts)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMappingInfo, mappingInfo)
+			}
+		})
+	}
+}
+
+func TestUrlShortener_ShortDomain(t *testing.T) {
+	t.Parallel()
+
+	existing := domain.MappingInfo{Id: 3, OriginalURL: "https://example.com", Token: "d", Domain: "go.acme.com", WorkspaceId: 7}
+	created := domain.MappingInfo{Id: 7, OriginalURL: "https://example.com", Token: "h", Domain: "go.acme.com", WorkspaceId: 7}
+
+	type testCase struct {
+		name                string
+		opts                domain.ShortenOptions
+		expectedMappingInfo domain.MappingInfo
+		expectedError       error
+
+		setupMocks func(ctrl *gomock.Controller) (domain.ShortDomainAuthorizer, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter)
+	}
+
+	testCases := []testCase{
+		{
+			name:                "mapping created on custom domain",
+			opts:                domain.ShortenOptions{WorkspaceId: 7, Domain: "GO.acme.com"},
+			expectedMappingInfo: created,
+			setupMocks: func(ctrl *gomock.Controller) (domain.ShortDomainAuthorizer, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				authorizerMock := mocks.NewMockShortDomainAuthorizer(ctrl)
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+
+				authorizerMock.EXPECT().AuthorizeShortDomain("GO.acme.com", int64(7)).Return("go.acme.com", nil)
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
+					assert.Equal(ctrl.T, "go.acme.com", mapping.Domain)
+					return created, nil
+				})
+				cacheMock.EXPECT().SetMapping(gomock.Any(), created).Return(nil)
+
+				return authorizerMock, idGenMock, storeMock, mocks.NewMockReusableMappingFinder(ctrl), cacheMock
+			},
+		},
+		{
+			name:                "mapping reused on the same custom domain",
+			opts:                domain.ShortenOptions{WorkspaceId: 7, Domain: "go.acme.com", ReuseExisting: true},
+			expectedMappingInfo: existing,
+			setupMocks: func(ctrl *gomock.Controller) (domain.ShortDomainAuthorizer, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				authorizerMock := mocks.NewMockShortDomainAuthorizer(ctrl)
+				finderMock := mocks.NewMockReusableMappingFinder(ctrl)
+
+				authorizerMock.EXPECT().AuthorizeShortDomain("go.acme.com", int64(7)).Return("go.acme.com", nil)
+				finderMock.EXPECT().FindReusableMapping(gomock.Any(), "https://example.com", domain.MappingOwner{WorkspaceId: 7}, "go.acme.com").Return(existing, true, nil)
+
+				return authorizerMock, mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), finderMock, mocks.NewMockUrlTokenSetter(ctrl)
+			},
+		},
+		{
+			name:          "unknown custom domain",
+			opts:          domain.ShortenOptions{WorkspaceId: 7, Domain: "go.globex.com"},
+			expectedError: &domain.ShortDomainNonExistingError{},
+			setupMocks: func(ctrl *gomock.Controller) (domain.ShortDomainAuthorizer, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				authorizerMock := mocks.NewMockShortDomainAuthorizer(ctrl)
+				authorizerMock.EXPECT().AuthorizeShortDomain("go.globex.com", int64(7)).Return("", &domain.ShortDomainNonExistingError{})
+
+				return authorizerMock, mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), mocks.NewMockReusableMappingFinder(ctrl), mocks.NewMockUrlTokenSetter(ctrl)
+			},
+		},
+		{
+			name:          "custom domain of another workspace",
+			opts:          domain.ShortenOptions{WorkspaceId: 8, Domain: "go.acme.com"},
+			expectedError: &domain.ForbiddenError{},
+			setupMocks: func(ctrl *gomock.Controller) (domain.ShortDomainAuthorizer, domain.IdGenerator, domain.MappingInfoAdder, domain.ReusableMappingFinder, domain.UrlTokenSetter) {
+				authorizerMock := mocks.NewMockShortDomainAuthorizer(ctrl)
+				authorizerMock.EXPECT().AuthorizeShortDomain("go.acme.com", int64(8)).Return("", &domain.ForbiddenError{})
+
+				return authorizerMock, mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), mocks.NewMockReusableMappingFinder(ctrl), mocks.NewMockUrlTokenSetter(ctrl)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			authorizerMock, idGenMock, storeMock, finderMock, cacheMock := tt.setupMocks(ctrl)
+			urlShortener := NewUrlShortener(idGenMock, domain.SequentialTokenGenerator{}, acceptingTokenValidator(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), unlimitedLinkQuota(ctrl), authorizerMock, storeMock, finderMock, cacheMock, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			mappingInfo, _, err := urlShortener.ShortenUrl(context.Background(), "https://example.com", tt.opts)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMappingInfo, mappingInfo)
+			}
+		})
+	}
+}
+
+func TestUrlShortener_ScanDestination(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		setupMocks func(ctrl *gomock.Controller) (domain.URLScanner, domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter, domain.Logger)
+	}
+
+	created := domain.MappingInfo{Id: 7, OriginalURL: "https://example.com", Token: "h"}
+
+	testCases := []testCase{
+		{
+			name:          "malicious destination rejected",
+			expectedError: &domain.MaliciousUrlError{},
+			setupMocks: func(ctrl *gomock.Controller) (domain.URLScanner, domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter, domain.Logger) {
+				scannerMock := mocks.NewMockURLScanner(ctrl)
+				scannerMock.EXPECT().ScanURL(gomock.Any(), "https://example.com").Return(domain.ScanVerdict{Malicious: true, Threat: "phishing"}, nil)
+
+				return scannerMock, mocks.NewMockIdGenerator(ctrl), mocks.NewMockMappingInfoAdder(ctrl), mocks.NewMockUrlTokenSetter(ctrl),
+					slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name: "failing scanner accepts destination",
+			setupMocks: func(ctrl *gomock.Controller) (domain.URLScanner, domain.IdGenerator, domain.MappingInfoAdder, domain.UrlTokenSetter, domain.Logger) {
+				scannerMock := mocks.NewMockURLScanner(ctrl)
+				idGenMock := mocks.NewMockIdGenerator(ctrl)
+				storeMock := mocks.NewMockMappingInfoAdder(ctrl)
+				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+				loggerMock := mocks.NewMockLogger(ctrl)
+
+				scannerMock.EXPECT().ScanURL(gomock.Any(), "https://example.com").Return(domain.ScanVerdict{}, assert.AnError)
+				loggerMock.EXPECT().Warn(gomock.Any())
+				idGenMock.EXPECT().GetNextId(gomock.Any()).Return(int64(7), nil)
+				storeMock.EXPECT().AddNewMapping(gomock.Any(), gomock.Any()).Return(created, nil)
+				cacheMock.EXPECT().SetMapping(gomock.Any(), created).Return(nil)
+
+				return scannerMock, idGenMock, storeMock, cacheMock, loggerMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			scannerMock, idGenMock, storeMock, cacheMock, logger := tt.setupMocks(ctrl)
+			urlShortener := NewUrlShortener(idGenMock, domain.SequentialTokenGenerator{}, acceptingTokenValidator(ctrl), defaultUrlValidator(), scannerMock,
+				unlimitedLinkQuota(ctrl), noShortDomains(ctrl), storeMock, mocks.NewMockReusableMappingFinder(ctrl), cacheMock, logger)
+
+			mappingInfo, _, err := urlShortener.ShortenUrl(context.Background(), "https://example.com", domain.ShortenOptions{})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, created, mappingInfo)
+			}
+		})
+	}
+}
+
+// defaultUrlValidator returns a URL validator of a service without own hosts and without destination rules.
+func defaultUrlValidator() domain.WorkspaceUrlValidator {
+	urlValidator, _ := domain.NewDestinationValidator(nil)
+	return policy.NewDestinationPolicyService(nil, urlValidator, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// unlimitedLinkQuota returns a link quota checker that lets every workspace create short URLs.
+func unlimitedLinkQuota(ctrl *gomock.Controller) domain.LinkQuotaChecker {
+	quota := mocks.NewMockLinkQuotaChecker(ctrl)
+	quota.EXPECT().CheckLinkQuota(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return quota
+}
+
+// noShortDomains returns a short domain authorizer that fails the test if a custom short domain is requested.
+func noShortDomains(ctrl *gomock.Controller) domain.ShortDomainAuthorizer {
+	return mocks.NewMockShortDomainAuthorizer(ctrl)
+}
+
+// unlimitedRedirectQuota returns a redirect quota checker that lets every workspace redirect.
+func unlimitedRedirectQuota(ctrl *gomock.Controller) domain.RedirectQuotaChecker {
+	quota := mocks.NewMockRedirectQuotaChecker(ctrl)
+	quota.EXPECT().CheckRedirectQuota(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return quota
+}
+
+// acceptingTokenValidator returns a token validator that accepts every token.
+func acceptingTokenValidator(ctrl *gomock.Controller) domain.TokenValidator {
+	tokenValidator := mocks.NewMockTokenValidator(ctrl)
+	tokenValidator.EXPECT().ValidateToken(gomock.Any()).Return(nil).AnyTimes()
+	return tokenValidator
+}
+
+// ownerAuthorizer returns an ownership authorizer that lets the caller manage every mapping.
+func ownerAuthorizer(ctrl *gomock.Controller) domain.MappingOwnershipAuthorizer {
+	authorizer := mocks.NewMockMappingOwnershipAuthorizer(ctrl)
+	authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), gomock.Any()).Return(domain.MappingOwner{}, nil).AnyTimes()
+	return authorizer
+}
+
+// safeUrlScanner returns a URL scanner that reports every URL as safe.
+func safeUrlScanner(ctrl *gomock.Controller) domain.URLScanner {
+	scanner := mocks.NewMockURLScanner(ctrl)
+	scanner.EXPECT().ScanURL(gomock.Any(), gomock.Any()).Return(domain.ScanVerdict{}, nil).AnyTimes()
+	return scanner
+}
@@ -2,51 +2,125 @@ package urlcases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 	"url-shortening-service/internal/domain"
 )
 
 // UrlUpdater handles URL mapping update operations.
-// It updates the original URL associated with an existing token.
+// It updates the original URL associated with an existing token and evicts the stale cached mapping.
+// Only the owner of the mapping and admins may update it.
 type UrlUpdater struct {
-	cache   domain.UrlTokenSetter
-	storage domain.MappingInfoUpdater
-	logger  domain.Logger
+	cache        domain.UrlTokenDeleter
+	storage      domain.MappingInfoUpdater
+	authorizer   domain.MappingOwnershipAuthorizer
+	urlValidator domain.WorkspaceUrlValidator
+	scanner      domain.URLScanner
+	// reevictionDelay is the delay of the second eviction of an updated mapping, zero disables it.
+	reevictionDelay time.Duration
+	logger          domain.Logger
 }
 
 // NewUrlUpdater creates a new UrlUpdater instance.
 // Parameters:
 //   - cache: cache storage for URL mappings (e.g., Redis)
 //   - storage: persistent storage for URL mappings (e.g., PostgreSQL)
-//   - logger: logger for recording info messages
-func NewUrlUpdater(cache domain.UrlTokenSetter, storage domain.MappingInfoUpdater, logger domain.Logger) *UrlUpdater {
+//   - authorizer: checks that the caller owns the updated mapping
+//   - urlValidator: rejects destination URLs that must not be shortened in the workspace of the mapping
+//   - scanner: reputation service destination URLs are checked against
+//   - reevictionDelay: delay of the second eviction of an updated mapping (zero disables it)
+//   - logger: logger for recording warnings and info messages
+func NewUrlUpdater(cache domain.UrlTokenDeleter, storage domain.MappingInfoUpdater, authorizer domain.MappingOwnershipAuthorizer,
+	urlValidator domain.WorkspaceUrlValidator, scanner domain.URLScanner, reevictionDelay time.Duration, logger domain.Logger) *UrlUpdater {
 	return &UrlUpdater{
-		cache:   cache,
-		storage: storage,
-		logger:  logger,
+		cache:           cache,
+		storage:         storage,
+		authorizer:      authorizer,
+		urlValidator:    urlValidator,
+		scanner:         scanner,
+		reevictionDelay: reevictionDelay,
+		logger:          logger,
 	}
 }
 
 // UpdateUrlMapping updates the original URL for an existing URL token.
-// It validates the new URL and updates the mapping in persistent storage.
+// It validates the new URL, storing internationalized host names in their ASCII form, updates the mapping in persistent storage and then evicts
+// the cached mapping, so the next redirect reloads the updated mapping from storage.
+// The new URL is subject to the destination policy of the workspace of the mapping.
+// The new URL is checked against the reputation service like new links, and a flag of the previous URL is cleared.
+// If opts.ExpiresAt is set, it replaces the expiration time of the mapping,
+// and if opts.ClearExpiration is set, the mapping no longer expires.
+//
+// The cache is evicted rather than overwritten, so once UpdateUrlMapping returns successfully,
+// redirects resolve to the new URL. If the eviction fails, the update is already committed
+// to storage and the error is returned, so the caller can retry the (idempotent) update.
+// A redirect that missed the cache and read the mapping from storage before the update may still cache
+// the old mapping after the eviction, so the mapping is evicted once more after the reeviction delay.
+// Until then, or until the TTL expires if the second eviction is disabled or fails, the old URL may be served.
 //
 // Returns the updated MappingInfo.
 //
 // Returns an error if:
-//   - *domain.InvalidUrlError: the new URL format is invalid or scheme is unsupported
 //   - *domain.TokenNonExistingError: the token does not exist in storage
-//   - Storage operation fails
-func (u *UrlUpdater) UpdateUrlMapping(ctx context.Context, urlToken, newOriginalUrl string) (domain.MappingInfo, error) {
-	err := domain.ValidateURL(newOriginalUrl)
+//   - *domain.ForbiddenError: the caller does not own the mapping
+//   - *domain.InvalidUrlError: the new URL is rejected by the URL validator
+//   - *domain.DestinationBlockedError: the destination is not allowed by the destination policy
+//   - *domain.MaliciousUrlError: the reputation service reports the new URL as malicious
+//   - *domain.InvalidExpirationError: the new expiration time is not in the future,
+//     or it is set while clearing the expiration time
+//   - Storage operation or cache eviction fails
+func (u *UrlUpdater) UpdateUrlMapping(ctx context.Context, urlToken, newOriginalUrl string, opts domain.UpdateOptions) (domain.MappingInfo, error) {
+	owner, err := u.authorizer.AuthorizeMappingOwner(ctx, urlToken)
+	if err != nil {
+		return domain.MappingInfo{}, err
+	}
+
+	newOriginalUrl, err = u.urlValidator.ValidateWorkspaceURL(owner.WorkspaceId, newOriginalUrl)
+	if err != nil {
+		return domain.MappingInfo{}, err
+	}
+
+	err = scanDestination(ctx, u.scanner, u.logger, newOriginalUrl)
+	if err != nil {
+		return domain.MappingInfo{}, err
+	}
+
+	if opts.ClearExpiration && opts.ExpiresAt != nil {
+		return domain.MappingInfo{}, &domain.InvalidExpirationError{Msg: "An expiration time cannot be set while clearing it"}
+	}
+
+	err = domain.ValidateExpiration(opts.ExpiresAt, time.Now())
 	if err != nil {
 		return domain.MappingInfo{}, err
 	}
 
-	newInfo, err := u.storage.UpdateOriginalUrl(ctx, urlToken, newOriginalUrl)
+	newInfo, err := u.storage.UpdateOriginalUrl(ctx, urlToken, newOriginalUrl, opts)
 	if err != nil {
 		return domain.MappingInfo{}, err
 	}
 
+	err = u.cache.DeleteMapping(ctx, urlToken)
+	if err != nil && !errors.Is(err, &domain.TokenNonExistingError{}) {
+		return domain.MappingInfo{}, fmt.Errorf("evicting cached mapping: %w", err)
+	}
+
+	if u.reevictionDelay > 0 {
+		evictCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(u.reevictionDelay, func() {
+			u.evictAgain(evictCtx, urlToken)
+		})
+	}
+
 	u.logger.Info(fmt.Sprintf("Updated URL mapping for token: %s", urlToken))
 	return newInfo, nil
 }
+
+// evictAgain evicts the cached mapping of an updated token once more, removing a stale mapping
+// cached by a concurrent cache miss after the first eviction. Failures are only logged.
+func (u *UrlUpdater) evictAgain(ctx context.Context, urlToken string) {
+	err := u.cache.DeleteMapping(ctx, urlToken)
+	if err != nil && !errors.Is(err, &domain.TokenNonExistingError{}) {
+		u.logger.Warn(fmt.Sprintf("Failed to evict cached mapping of updated token %s again: %v", urlToken, err))
+	}
+}
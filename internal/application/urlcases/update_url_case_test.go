@@ -2,10 +2,12 @@ package urlcases
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 	"url-shortening-service/internal/domain"
 	"url-shortening-service/internal/domain/mocks"
+	localmocks "url-shortening-service/internal/infrastructure/mocks"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -15,15 +17,17 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 	t.Parallel()
 
 	fixedTime := time.Date(2025, 12, 17, 10, 0, 0, 0, time.UTC)
+	futureTime := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
 
 	type testCase struct {
 		name           string
 		urlToken       string
 		newOriginalUrl string
+		opts           domain.UpdateOptions
 		expectedInfo   domain.MappingInfo
 		expectedError  error
 
-		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger)
+		setupMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger)
 	}
 
 	testCases := []testCase{
@@ -39,18 +43,19 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 				UpdatedAt:   fixedTime,
 			},
 			expectedError: nil,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger) {
-				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
 				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
 				loggerMock := mocks.NewMockLogger(ctrl)
 
-				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new-url").Return(domain.MappingInfo{
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new-url", domain.UpdateOptions{}).Return(domain.MappingInfo{
 					Id:          1,
 					OriginalURL: "https://example.com/new-url",
 					Token:       "abc123",
 					CreatedAt:   fixedTime,
 					UpdatedAt:   fixedTime,
 				}, nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
 				loggerMock.EXPECT().Info(gomock.Any()).AnyTimes()
 
 				return cacheMock, storageMock, loggerMock
@@ -68,31 +73,177 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 				UpdatedAt:   fixedTime,
 			},
 			expectedError: nil,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger) {
-				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
 				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
 				loggerMock := mocks.NewMockLogger(ctrl)
 
-				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "xyz789", "http://example.com/http-url").Return(domain.MappingInfo{
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "xyz789", "http://example.com/http-url", domain.UpdateOptions{}).Return(domain.MappingInfo{
 					Id:          2,
 					OriginalURL: "http://example.com/http-url",
 					Token:       "xyz789",
 					CreatedAt:   fixedTime,
 					UpdatedAt:   fixedTime,
 				}, nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "xyz789").Return(nil)
 				loggerMock.EXPECT().Info(gomock.Any()).AnyTimes()
 
 				return cacheMock, storageMock, loggerMock
 			},
 		},
+		{
+			name:           "successful url update with new expiration",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://example.com/new-url",
+			opts:           domain.UpdateOptions{ExpiresAt: &futureTime},
+			expectedInfo: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com/new-url",
+				Token:       "abc123",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+				ExpiresAt:   &futureTime,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
+				loggerMock := mocks.NewMockLogger(ctrl)
+
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new-url", domain.UpdateOptions{ExpiresAt: &futureTime}).Return(domain.MappingInfo{
+					Id:          1,
+					OriginalURL: "https://example.com/new-url",
+					Token:       "abc123",
+					CreatedAt:   fixedTime,
+					UpdatedAt:   fixedTime,
+					ExpiresAt:   &futureTime,
+				}, nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+				loggerMock.EXPECT().Info(gomock.Any()).AnyTimes()
+
+				return cacheMock, storageMock, loggerMock
+			},
+		},
+		{
+			name:           "successful url update clearing expiration",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://example.com/new-url",
+			opts:           domain.UpdateOptions{ClearExpiration: true},
+			expectedInfo: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com/new-url",
+				Token:       "abc123",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
+				loggerMock := mocks.NewMockLogger(ctrl)
+
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new-url", domain.UpdateOptions{ClearExpiration: true}).Return(domain.MappingInfo{
+					Id:          1,
+					OriginalURL: "https://example.com/new-url",
+					Token:       "abc123",
+					CreatedAt:   fixedTime,
+					UpdatedAt:   fixedTime,
+				}, nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+				loggerMock.EXPECT().Info(gomock.Any()).AnyTimes()
+
+				return cacheMock, storageMock, loggerMock
+			},
+		},
+		{
+			name:           "expiration set while clearing it returns error",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://example.com/new-url",
+			opts:           domain.UpdateOptions{ExpiresAt: &futureTime, ClearExpiration: true},
+			expectedInfo:   domain.MappingInfo{},
+			expectedError:  &domain.InvalidExpirationError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
+				loggerMock := mocks.NewMockLogger(ctrl)
+
+				return cacheMock, storageMock, loggerMock
+			},
+		},
+		{
+			name:           "uncached mapping is updated without eviction error",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://example.com/new-url",
+			expectedInfo: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com/new-url",
+				Token:       "abc123",
+				CreatedAt:   fixedTime,
+				UpdatedAt:   fixedTime,
+			},
+			expectedError: nil,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
+				loggerMock := mocks.NewMockLogger(ctrl)
+
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new-url", domain.UpdateOptions{}).Return(domain.MappingInfo{
+					Id:          1,
+					OriginalURL: "https://example.com/new-url",
+					Token:       "abc123",
+					CreatedAt:   fixedTime,
+					UpdatedAt:   fixedTime,
+				}, nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(&domain.TokenNonExistingError{Msg: "token not found"})
+				loggerMock.EXPECT().Info(gomock.Any()).AnyTimes()
+
+				return cacheMock, storageMock, loggerMock
+			},
+		},
+		{
+			name:           "cache eviction error returns error",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://example.com/new-url",
+			expectedInfo:   domain.MappingInfo{},
+			expectedError:  assert.AnError,
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
+				loggerMock := mocks.NewMockLogger(ctrl)
+
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new-url", domain.UpdateOptions{}).Return(domain.MappingInfo{
+					Id:          1,
+					OriginalURL: "https://example.com/new-url",
+					Token:       "abc123",
+				}, nil)
+				cacheMock.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(assert.AnError)
+
+				return cacheMock, storageMock, loggerMock
+			},
+		},
+		{
+			name:           "expiration in the past returns error",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://example.com/new-url",
+			opts:           domain.UpdateOptions{ExpiresAt: &fixedTime},
+			expectedInfo:   domain.MappingInfo{},
+			expectedError:  &domain.InvalidExpirationError{},
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
+				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
+				loggerMock := mocks.NewMockLogger(ctrl)
+
+				return cacheMock, storageMock, loggerMock
+			},
+		},
 		{
 			name:           "invalid new url returns error",
 			urlToken:       "abc123",
 			newOriginalUrl: "not-a-valid-url",
 			expectedInfo:   domain.MappingInfo{},
 			expectedError:  &domain.InvalidUrlError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger) {
-				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
 				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
 				loggerMock := mocks.NewMockLogger(ctrl)
 
@@ -105,8 +256,8 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 			newOriginalUrl: "",
 			expectedInfo:   domain.MappingInfo{},
 			expectedError:  &domain.InvalidUrlError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger) {
-				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
 				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
 				loggerMock := mocks.NewMockLogger(ctrl)
 
@@ -119,8 +270,8 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 			newOriginalUrl: "ftp://example.com/file",
 			expectedInfo:   domain.MappingInfo{},
 			expectedError:  &domain.InvalidUrlError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger) {
-				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
 				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
 				loggerMock := mocks.NewMockLogger(ctrl)
 
@@ -133,12 +284,12 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 			newOriginalUrl: "https://example.com/valid-url",
 			expectedInfo:   domain.MappingInfo{},
 			expectedError:  &domain.TokenNonExistingError{},
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger) {
-				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
 				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
 				loggerMock := mocks.NewMockLogger(ctrl)
 
-				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "nonexistent", "https://example.com/valid-url").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: "token not found"})
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "nonexistent", "https://example.com/valid-url", domain.UpdateOptions{}).Return(domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: "token not found"})
 
 				return cacheMock, storageMock, loggerMock
 			},
@@ -149,12 +300,12 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 			newOriginalUrl: "https://example.com/valid-url",
 			expectedInfo:   domain.MappingInfo{},
 			expectedError:  assert.AnError,
-			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenSetter, domain.MappingInfoUpdater, domain.Logger) {
-				cacheMock := mocks.NewMockUrlTokenSetter(ctrl)
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlTokenDeleter, domain.MappingInfoUpdater, domain.Logger) {
+				cacheMock := mocks.NewMockUrlTokenDeleter(ctrl)
 				storageMock := mocks.NewMockMappingInfoUpdater(ctrl)
 				loggerMock := mocks.NewMockLogger(ctrl)
 
-				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/valid-url").Return(domain.MappingInfo{}, assert.AnError)
+				storageMock.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/valid-url", domain.UpdateOptions{}).Return(domain.MappingInfo{}, assert.AnError)
 
 				return cacheMock, storageMock, loggerMock
 			},
@@ -168,12 +319,13 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			cacheMock, storageMock, loggerMock := tt.setupMocks(t, ctrl)
-			urlUpdater := NewUrlUpdater(cacheMock, storageMock, loggerMock)
+			urlUpdater := NewUrlUpdater(cacheMock, storageMock, ownerAuthorizer(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), 0, loggerMock)
 
 			actualInfo, actualError := urlUpdater.UpdateUrlMapping(
 				context.Background(),
 				tt.urlToken,
 				tt.newOriginalUrl,
+				tt.opts,
 			)
 
 			if tt.expectedError != nil {
@@ -185,3 +337,159 @@ func TestUrlUpdater_UpdateUrlMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestUrlUpdater_RedirectAfterUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cache := localmocks.NewLocalCache()
+	store := mocks.NewMockMappingInfoGetter(ctrl)
+	updater := mocks.NewMockMappingInfoUpdater(ctrl)
+	logger := mocks.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	oldMapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/old", Token: "abc123"}
+	newMapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/new", Token: "abc123"}
+
+	gomock.InOrder(
+		store.EXPECT().GetMappingByToken(gomock.Any(), "abc123").Return(oldMapping, nil),
+		updater.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new", domain.UpdateOptions{}).Return(newMapping, nil),
+		store.EXPECT().GetMappingByToken(gomock.Any(), "abc123").Return(newMapping, nil),
+	)
+
+	urlGetter := NewUrlGetter(cache, store, mocks.NewMockClickCounter(ctrl), mocks.NewMockClickCountSaver(ctrl),
+		mocks.NewMockAttemptLimiter(ctrl), unlimitedRedirectQuota(ctrl), logger)
+	urlUpdater := NewUrlUpdater(cache, updater, ownerAuthorizer(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), 0, logger)
+
+	resolved, err := urlGetter.GetOriginalUrl(context.Background(), "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/old", resolved.OriginalURL)
+
+	resolved, err = urlGetter.GetOriginalUrl(context.Background(), "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/old", resolved.OriginalURL)
+
+	_, err = urlUpdater.UpdateUrlMapping(context.Background(), "abc123", "https://example.com/new", domain.UpdateOptions{})
+	assert.NoError(t, err)
+
+	resolved, err = urlGetter.GetOriginalUrl(context.Background(), "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/new", resolved.OriginalURL)
+}
+
+// evictionSignalingCache is a local cache safe for concurrent use that reports every eviction.
+type evictionSignalingCache struct {
+	mu        sync.Mutex
+	cache     *localmocks.LocalCache
+	evictions chan struct{}
+}
+
+func (c *evictionSignalingCache) GetMapping(ctx context.Context, urlToken string) (domain.MappingInfo, domain.CacheLookup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.GetMapping(ctx, urlToken)
+}
+
+func (c *evictionSignalingCache) SetMapping(ctx context.Context, mapping domain.MappingInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.SetMapping(ctx, mapping)
+}
+
+func (c *evictionSignalingCache) SetMissing(ctx context.Context, urlToken string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.SetMissing(ctx, urlToken)
+}
+
+func (c *evictionSignalingCache) DeleteMapping(ctx context.Context, urlToken string) error {
+	c.mu.Lock()
+	err := c.cache.DeleteMapping(ctx, urlToken)
+	c.mu.Unlock()
+	c.evictions <- struct{}{}
+	return err
+}
+
+func TestUrlUpdater_StaleMappingCachedDuringUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	cache := &evictionSignalingCache{cache: localmocks.NewLocalCache(), evictions: make(chan struct{}, 2)}
+	store := mocks.NewMockMappingInfoGetter(ctrl)
+	updater := mocks.NewMockMappingInfoUpdater(ctrl)
+	logger := mocks.NewMockLogger(ctrl)
+	logger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	oldMapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/old", Token: "abc123"}
+	newMapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/new", Token: "abc123"}
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	store.EXPECT().GetMappingByToken(gomock.Any(), "abc123").DoAndReturn(func(_ context.Context, _ string) (domain.MappingInfo, error) {
+		close(loading)
+		<-release
+		return oldMapping, nil
+	})
+	updater.EXPECT().UpdateOriginalUrl(gomock.Any(), "abc123", "https://example.com/new", domain.UpdateOptions{}).Return(newMapping, nil)
+
+	urlGetter := NewUrlGetter(cache, store, mocks.NewMockClickCounter(ctrl), mocks.NewMockClickCountSaver(ctrl),
+		mocks.NewMockAttemptLimiter(ctrl), unlimitedRedirectQuota(ctrl), logger)
+	urlUpdater := NewUrlUpdater(cache, updater, ownerAuthorizer(ctrl), defaultUrlValidator(), safeUrlScanner(ctrl), 100*time.Millisecond, logger)
+
+	redirected := make(chan domain.ResolvedUrl)
+	go func() {
+		resolved, _ := urlGetter.GetOriginalUrl(context.Background(), "abc123")
+		redirected <- resolved
+	}()
+
+	// The redirect reads the old mapping from storage before the update and caches it after the first eviction.
+	<-loading
+	_, err := urlUpdater.UpdateUrlMapping(context.Background(), "abc123", "https://example.com/new", domain.UpdateOptions{})
+	assert.NoError(t, err)
+	<-cache.evictions
+	close(release)
+	assert.Equal(t, "https://example.com/old", (<-redirected).OriginalURL)
+
+	mapping, lookup, _ := cache.GetMapping(context.Background(), "abc123")
+	assert.Equal(t, domain.CacheHit, lookup)
+	assert.Equal(t, oldMapping, mapping)
+
+	select {
+	case <-cache.evictions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("updated mapping was not evicted again")
+	}
+
+	_, lookup, _ = cache.GetMapping(context.Background(), "abc123")
+	assert.Equal(t, domain.CacheMiss, lookup)
+}
+
+func TestUrlUpdater_MaliciousDestination(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	scanner := mocks.NewMockURLScanner(ctrl)
+	scanner.EXPECT().ScanURL(gomock.Any(), "https://example.com/malware").Return(domain.ScanVerdict{Malicious: true, Threat: "malware"}, nil)
+
+	urlUpdater := NewUrlUpdater(mocks.NewMockUrlTokenDeleter(ctrl), mocks.NewMockMappingInfoUpdater(ctrl), ownerAuthorizer(ctrl), defaultUrlValidator(), scanner, 0,
+		mocks.NewMockLogger(ctrl))
+
+	_, err := urlUpdater.UpdateUrlMapping(context.Background(), "abc123", "https://example.com/malware", domain.UpdateOptions{})
+
+	assert.ErrorIs(t, err, &domain.MaliciousUrlError{})
+}
+
+func TestUrlUpdater_NotOwner(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	authorizer := mocks.NewMockMappingOwnershipAuthorizer(ctrl)
+	authorizer.EXPECT().AuthorizeMappingOwner(gomock.Any(), "abc123").Return(domain.MappingOwner{}, &domain.ForbiddenError{})
+
+	urlUpdater := NewUrlUpdater(mocks.NewMockUrlTokenDeleter(ctrl), mocks.NewMockMappingInfoUpdater(ctrl), authorizer, defaultUrlValidator(),
+		mocks.NewMockURLScanner(ctrl), 0, mocks.NewMockLogger(ctrl))
+
+	_, err := urlUpdater.UpdateUrlMapping(context.Background(), "abc123", "https://example.com/new", domain.UpdateOptions{})
+
+	assert.ErrorIs(t, err, &domain.ForbiddenError{})
+}
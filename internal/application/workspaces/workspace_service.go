@@ -0,0 +1,209 @@
+package workspaces
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// redirectQuotaWindow is the window the RedirectsPerSecond quota of workspaces is counted in.
+const redirectQuotaWindow = time.Second
+
+// WorkspaceService enforces the quotas of workspaces and administers them.
+// The workspaces are kept in persistent storage and reloaded periodically, so quota changes made
+// on any instance take effect on all instances without a restart, and the redirect path never
+// waits for the storage.
+type WorkspaceService struct {
+	store           domain.WorkspaceStore
+	linkCounter     domain.MappingCreationCounter
+	redirectLimiter domain.RateLimiter
+	logger          domain.Logger
+	workspaces      atomic.Pointer[map[int64]domain.Workspace]
+	now             func() time.Time
+}
+
+// NewWorkspaceService creates a new WorkspaceService instance.
+// Until the workspaces are loaded, no quotas are enforced.
+// Parameters:
+//   - store: persistent storage of the workspaces (e.g., PostgreSQL)
+//   - linkCounter: persistent storage the short URLs created by the workspaces are counted in (e.g., PostgreSQL)
+//   - redirectLimiter: counts the redirects of the workspaces across all instances (e.g., Redis)
+//   - logger: logger for recording warnings and info messages
+func NewWorkspaceService(store domain.WorkspaceStore, linkCounter domain.MappingCreationCounter, redirectLimiter domain.RateLimiter,
+	logger domain.Logger) *WorkspaceService {
+	service := &WorkspaceService{
+		store:           store,
+		linkCounter:     linkCounter,
+		redirectLimiter: redirectLimiter,
+		logger:          logger,
+		now:             time.Now,
+	}
+	service.workspaces.Store(&map[int64]domain.Workspace{})
+
+	return service
+}
+
+// CheckLinkQuota checks that the workspace may create another short URL in the current calendar month.
+// Concurrent requests are not serialized, so a workspace may briefly exceed its quota by a few short URLs.
+// Mappings outside of workspaces and workspaces this instance has not loaded yet are not limited.
+//
+// Returns an error if:
+//   - *domain.QuotaExceededError: the workspace has created LinksPerMonth short URLs this month
+//   - Counting the short URLs of the workspace fails
+func (s *WorkspaceService) CheckLinkQuota(ctx context.Context, workspaceId int64) error {
+	workspace, found := s.workspace(workspaceId)
+	if !found || workspace.LinksPerMonth == 0 {
+		return nil
+	}
+
+	created, err := s.linkCounter.CountMappingsCreatedSince(ctx, workspaceId, domain.LinkQuotaPeriodStart(s.now()))
+	if err != nil {
+		return fmt.Errorf("counting short URLs of workspace %d: %w", workspaceId, err)
+	}
+
+	if created >= workspace.LinksPerMonth {
+		return &domain.QuotaExceededError{
+			Msg: fmt.Sprintf("Workspace %d has reached its quota of %d short URLs per month", workspaceId, workspace.LinksPerMonth),
+		}
+	}
+
+	return nil
+}
+
+// CheckRedirectQuota registers a redirect of a short URL of the workspace and checks that it is within
+// the RedirectsPerSecond quota of the workspace.
+// A failing limiter is only logged and the redirect allowed, so redirects do not depend on its availability.
+// Mappings outside of workspaces and workspaces this instance has not loaded yet are not limited.
+//
+// Returns *domain.QuotaExceededError if the workspace has exceeded its redirects per second.
+func (s *WorkspaceService) CheckRedirectQuota(ctx context.Context, workspaceId int64) error {
+	workspace, found := s.workspace(workspaceId)
+	if !found || workspace.RedirectsPerSecond == 0 {
+		return nil
+	}
+
+	allowed, err := s.redirectLimiter.Allow(ctx, strconv.FormatInt(workspaceId, 10), workspace.RedirectsPerSecond, redirectQuotaWindow)
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to count redirect of workspace %d: %v", workspaceId, err))
+		return nil
+	} else if !allowed {
+		return &domain.QuotaExceededError{
+			Msg: fmt.Sprintf("Workspace %d has exceeded its quota of %d redirects per second", workspaceId, workspace.RedirectsPerSecond),
+		}
+	}
+
+	return nil
+}
+
+// workspace returns the loaded workspace with the given ID and whether it was found.
+func (s *WorkspaceService) workspace(workspaceId int64) (domain.Workspace, bool) {
+	if workspaceId == 0 {
+		return domain.Workspace{}, false
+	}
+
+	workspace, found := (*s.workspaces.Load())[workspaceId]
+	return workspace, found
+}
+
+// Reload loads the workspaces from persistent storage and replaces the current ones.
+// The current workspaces are kept if loading fails.
+//
+// Returns an error if the workspaces cannot be loaded.
+func (s *WorkspaceService) Reload(ctx context.Context) error {
+	list, err := s.store.ListWorkspaces(ctx)
+	if err != nil {
+		return fmt.Errorf("loading workspaces: %w", err)
+	}
+
+	workspaces := make(map[int64]domain.Workspace, len(list))
+	for _, workspace := range list {
+		workspaces[workspace.Id] = workspace
+	}
+
+	s.workspaces.Store(&workspaces)
+	return nil
+}
+
+// KeepReloading reloads the workspaces every interval until ctx is done.
+// Failed reloads are logged and keep the current workspaces.
+func (s *WorkspaceService) KeepReloading(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Reload(ctx)
+			if err != nil {
+				s.logger.Warn(fmt.Sprintf("Failed to reload workspaces: %v", err))
+			}
+		}
+	}
+}
+
+// ListWorkspaces retrieves all workspaces from persistent storage.
+//
+// Returns an error if the storage operation fails.
+func (s *WorkspaceService) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
+	return s.store.ListWorkspaces(ctx)
+}
+
+// CreateWorkspace validates and stores a new workspace and enforces its quotas on this instance right away.
+// Other instances enforce them with their next reload.
+//
+// Returns an error if:
+//   - *domain.InvalidWorkspaceError: the name is missing or too long, or a quota is negative
+//   - Storage operation fails
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	workspace, err := domain.NormalizeWorkspace(workspace)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+
+	created, err := s.store.AddWorkspace(ctx, workspace)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+
+	s.reloadAfterChange(ctx)
+	s.logger.Info(fmt.Sprintf("Created workspace %d (%s)", created.Id, created.Name))
+	return created, nil
+}
+
+// UpdateWorkspace validates and stores the new name and quotas of a workspace and enforces the quotas
+// on this instance right away. Other instances enforce them with their next reload.
+//
+// Returns an error if:
+//   - *domain.InvalidWorkspaceError: the name is missing or too long, or a quota is negative
+//   - *domain.WorkspaceNonExistingError: the workspace does not exist
+//   - Storage operation fails
+func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	workspace, err := domain.NormalizeWorkspace(workspace)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+
+	updated, err := s.store.UpdateWorkspace(ctx, workspace)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+
+	s.reloadAfterChange(ctx)
+	s.logger.Info(fmt.Sprintf("Updated quotas of workspace %d: %d links per month, %d redirects per second",
+		updated.Id, updated.LinksPerMonth, updated.RedirectsPerSecond))
+	return updated, nil
+}
+
+// reloadAfterChange reloads the workspaces after a change. A failure is only logged,
+// as the change is already stored and is applied by the next periodic reload.
+func (s *WorkspaceService) reloadAfterChange(ctx context.Context) {
+	err := s.Reload(ctx)
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to reload workspaces after change: %v", err))
+	}
+}
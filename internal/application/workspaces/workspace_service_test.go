@@ -0,0 +1,324 @@
+package workspaces
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	limitedWorkspace   = domain.Workspace{Id: 7, Name: "acme", LinksPerMonth: 100, RedirectsPerSecond: 50}
+	unlimitedWorkspace = domain.Workspace{Id: 8, Name: "globex"}
+	testNow            = time.Date(2026, 3, 17, 15, 4, 5, 0, time.UTC)
+	testPeriodStart    = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type workspaceMocks struct {
+	store   *mocks.MockWorkspaceStore
+	counter *mocks.MockMappingCreationCounter
+	limiter *mocks.MockRateLimiter
+}
+
+func newWorkspaceMocks(ctrl *gomock.Controller) workspaceMocks {
+	return workspaceMocks{
+		store:   mocks.NewMockWorkspaceStore(ctrl),
+		counter: mocks.NewMockMappingCreationCounter(ctrl),
+		limiter: mocks.NewMockRateLimiter(ctrl),
+	}
+}
+
+// newLoadedService returns a service that has loaded the given workspaces.
+func (m workspaceMocks) newLoadedService(t *testing.T, workspaces ...domain.Workspace) *WorkspaceService {
+	service := NewWorkspaceService(m.store, m.counter, m.limiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	service.now = func() time.Time { return testNow }
+
+	m.store.EXPECT().ListWorkspaces(gomock.Any()).Return(workspaces, nil)
+	require.NoError(t, service.Reload(context.Background()))
+
+	return service
+}
+
+func TestWorkspaceService_CheckLinkQuota(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		workspaceId   int64
+		expectedError error
+
+		setupMocks func(m workspaceMocks)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Success - below quota",
+			workspaceId: 7,
+			setupMocks: func(m workspaceMocks) {
+				m.counter.EXPECT().CountMappingsCreatedSince(gomock.Any(), int64(7), testPeriodStart).Return(int64(99), nil)
+			},
+		},
+		{
+			name:        "Success - unlimited workspace",
+			workspaceId: 8,
+			setupMocks:  func(m workspaceMocks) {},
+		},
+		{
+			name:        "Success - no workspace",
+			workspaceId: 0,
+			setupMocks:  func(m workspaceMocks) {},
+		},
+		{
+			name:        "Success - workspace not loaded yet",
+			workspaceId: 9,
+			setupMocks:  func(m workspaceMocks) {},
+		},
+		{
+			name:          "Error - quota reached",
+			workspaceId:   7,
+			expectedError: &domain.QuotaExceededError{},
+			setupMocks: func(m workspaceMocks) {
+				m.counter.EXPECT().CountMappingsCreatedSince(gomock.Any(), int64(7), testPeriodStart).Return(int64(100), nil)
+			},
+		},
+		{
+			name:          "Error - counting fails",
+			workspaceId:   7,
+			expectedError: assert.AnError,
+			setupMocks: func(m workspaceMocks) {
+				m.counter.EXPECT().CountMappingsCreatedSince(gomock.Any(), int64(7), testPeriodStart).Return(int64(0), assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newWorkspaceMocks(ctrl)
+			service := m.newLoadedService(t, limitedWorkspace, unlimitedWorkspace)
+			tt.setupMocks(m)
+
+			err := service.CheckLinkQuota(context.Background(), tt.workspaceId)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkspaceService_CheckRedirectQuota(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		workspaceId   int64
+		expectedError error
+
+		setupMocks func(m workspaceMocks)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Success - within quota",
+			workspaceId: 7,
+			setupMocks: func(m workspaceMocks) {
+				m.limiter.EXPECT().Allow(gomock.Any(), "7", int64(50), time.Second).Return(true, nil)
+			},
+		},
+		{
+			name:        "Success - unlimited workspace",
+			workspaceId: 8,
+			setupMocks:  func(m workspaceMocks) {},
+		},
+		{
+			name:        "Success - no workspace",
+			workspaceId: 0,
+			setupMocks:  func(m workspaceMocks) {},
+		},
+		{
+			name:        "Success - limiter failure allows redirect",
+			workspaceId: 7,
+			setupMocks: func(m workspaceMocks) {
+				m.limiter.EXPECT().Allow(gomock.Any(), "7", int64(50), time.Second).Return(false, assert.AnError)
+			},
+		},
+		{
+			name:          "Error - quota exceeded",
+			workspaceId:   7,
+			expectedError: &domain.QuotaExceededError{},
+			setupMocks: func(m workspaceMocks) {
+				m.limiter.EXPECT().Allow(gomock.Any(), "7", int64(50), time.Second).Return(false, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newWorkspaceMocks(ctrl)
+			service := m.newLoadedService(t, limitedWorkspace, unlimitedWorkspace)
+			tt.setupMocks(m)
+
+			err := service.CheckRedirectQuota(context.Background(), tt.workspaceId)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkspaceService_Reload(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newWorkspaceMocks(ctrl)
+	service := m.newLoadedService(t, limitedWorkspace)
+
+	m.store.EXPECT().ListWorkspaces(gomock.Any()).Return(nil, assert.AnError)
+	assert.Error(t, service.Reload(context.Background()))
+
+	m.limiter.EXPECT().Allow(gomock.Any(), "7", int64(50), time.Second).Return(false, nil)
+	assert.ErrorIs(t, service.CheckRedirectQuota(context.Background(), 7), &domain.QuotaExceededError{})
+}
+
+func TestWorkspaceService_CreateWorkspace(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		workspace     domain.Workspace
+		expectedError error
+
+		setupMocks func(m workspaceMocks)
+	}
+
+	normalized := domain.Workspace{Name: "acme", LinksPerMonth: 100, RedirectsPerSecond: 50}
+
+	testCases := []testCase{
+		{
+			name:      "Success - quotas enforced right away",
+			workspace: domain.Workspace{Name: " acme ", LinksPerMonth: 100, RedirectsPerSecond: 50},
+			setupMocks: func(m workspaceMocks) {
+				m.store.EXPECT().AddWorkspace(gomock.Any(), normalized).Return(limitedWorkspace, nil)
+				m.store.EXPECT().ListWorkspaces(gomock.Any()).Return([]domain.Workspace{limitedWorkspace}, nil)
+				m.limiter.EXPECT().Allow(gomock.Any(), "7", int64(50), time.Second).Return(false, nil)
+			},
+		},
+		{
+			name:          "Error - invalid workspace",
+			workspace:     domain.Workspace{Name: "acme", LinksPerMonth: -1},
+			expectedError: &domain.InvalidWorkspaceError{},
+			setupMocks:    func(m workspaceMocks) {},
+		},
+		{
+			name:          "Error - storage error",
+			workspace:     normalized,
+			expectedError: assert.AnError,
+			setupMocks: func(m workspaceMocks) {
+				m.store.EXPECT().AddWorkspace(gomock.Any(), normalized).Return(domain.Workspace{}, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newWorkspaceMocks(ctrl)
+			service := m.newLoadedService(t)
+			tt.setupMocks(m)
+
+			created, err := service.CreateWorkspace(context.Background(), tt.workspace)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, limitedWorkspace, created)
+				assert.ErrorIs(t, service.CheckRedirectQuota(context.Background(), 7), &domain.QuotaExceededError{})
+			}
+		})
+	}
+}
+
+func TestWorkspaceService_UpdateWorkspace(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		workspace     domain.Workspace
+		expectedError error
+
+		setupMocks func(m workspaceMocks)
+	}
+
+	unlimited := domain.Workspace{Id: 7, Name: "acme"}
+
+	testCases := []testCase{
+		{
+			name:      "Success - quotas lifted right away",
+			workspace: unlimited,
+			setupMocks: func(m workspaceMocks) {
+				m.store.EXPECT().UpdateWorkspace(gomock.Any(), unlimited).Return(unlimited, nil)
+				m.store.EXPECT().ListWorkspaces(gomock.Any()).Return([]domain.Workspace{unlimited}, nil)
+			},
+		},
+		{
+			name:          "Error - invalid workspace",
+			workspace:     domain.Workspace{Id: 7},
+			expectedError: &domain.InvalidWorkspaceError{},
+			setupMocks:    func(m workspaceMocks) {},
+		},
+		{
+			name:          "Error - workspace not found",
+			workspace:     unlimited,
+			expectedError: &domain.WorkspaceNonExistingError{},
+			setupMocks: func(m workspaceMocks) {
+				m.store.EXPECT().UpdateWorkspace(gomock.Any(), unlimited).Return(domain.Workspace{}, &domain.WorkspaceNonExistingError{})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newWorkspaceMocks(ctrl)
+			service := m.newLoadedService(t, limitedWorkspace)
+			tt.setupMocks(m)
+
+			updated, err := service.UpdateWorkspace(context.Background(), tt.workspace)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, unlimited, updated)
+				assert.NoError(t, service.CheckRedirectQuota(context.Background(), 7))
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxAbuseReasonLength is the maximum length in characters of the reason of an abuse report or a takedown.
+	MaxAbuseReasonLength = 1000
+	// MaxAbuseContactLength is the maximum length in characters of the contact of an abuse reporter.
+	MaxAbuseContactLength = 254
+)
+
+// AbuseReportStatus is the state of the review of an abuse report.
+type AbuseReportStatus string
+
+const (
+	// AbuseReportOpen means the report awaits review.
+	AbuseReportOpen AbuseReportStatus = "open"
+	// AbuseReportDismissed means the report was reviewed and the link kept redirecting.
+	AbuseReportDismissed AbuseReportStatus = "dismissed"
+	// AbuseReportActioned means the reported link was taken down.
+	AbuseReportActioned AbuseReportStatus = "actioned"
+)
+
+// IsValid reports whether the status is one of the known abuse report statuses.
+func (s AbuseReportStatus) IsValid() bool {
+	return s == AbuseReportOpen || s == AbuseReportDismissed || s == AbuseReportActioned
+}
+
+// AbuseReport is a report of a short URL being used for abuse, e.g. phishing, spam or illegal content.
+type AbuseReport struct {
+	// Id is the unique identifier of the report.
+	Id int64 `json:"id"`
+	// Token is the link key of the short URL the report is about, see LinkKey.
+	// Short URLs of custom short domains are prefixed with their domain, e.g. go.example.com/abc.
+	Token string `json:"url_token"`
+	// Reason is the description of the abuse given by the reporter.
+	Reason string `json:"reason"`
+	// Contact is the optional contact of the reporter, e.g. an email address.
+	Contact string `json:"contact,omitempty"`
+	// ReporterIP is the IP address the report was sent from.
+	ReporterIP string `json:"reporter_ip,omitempty"`
+	// Status is the state of the review of the report.
+	Status AbuseReportStatus `json:"status"`
+	// CreatedAt is the timestamp when the report was made.
+	CreatedAt time.Time `json:"created_at"`
+	// ResolvedAt is the timestamp when the report was dismissed or actioned.
+	// A nil ResolvedAt means the report is open.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// NormalizeAbuseReport validates the reason and contact of an abuse report and returns them trimmed of surrounding whitespace.
+//
+// Returns *InvalidAbuseReportError if:
+//   - The reason is empty or longer than MaxAbuseReasonLength
+//   - The contact is longer than MaxAbuseContactLength
+func NormalizeAbuseReport(report AbuseReport) (AbuseReport, error) {
+	reason, err := NormalizeAbuseReason(report.Reason)
+	if err != nil {
+		return AbuseReport{}, err
+	}
+
+	contact := strings.TrimSpace(report.Contact)
+	if utf8.RuneCountInString(contact) > MaxAbuseContactLength {
+		return AbuseReport{}, &InvalidAbuseReportError{
+			Msg: fmt.Sprintf("Abuse report contact must be at most %d characters", MaxAbuseContactLength),
+		}
+	}
+
+	report.Reason = reason
+	report.Contact = contact
+	return report, nil
+}
+
+// NormalizeAbuseReason validates the reason of an abuse report or a takedown and returns it trimmed of surrounding whitespace.
+//
+// Returns *InvalidAbuseReportError if the reason is empty or longer than MaxAbuseReasonLength.
+func NormalizeAbuseReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > MaxAbuseReasonLength {
+		return "", &InvalidAbuseReportError{
+			Msg: fmt.Sprintf("Reason must be between 1 and %d characters", MaxAbuseReasonLength),
+		}
+	}
+
+	return reason, nil
+}
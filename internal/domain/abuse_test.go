@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAbuseReport(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		report         AbuseReport
+		expectedReport AbuseReport
+		expectedError  bool
+	}
+
+	testCases := []testCase{
+		{
+			name:           "trims reason and contact",
+			report:         AbuseReport{Token: "abc", Reason: "  phishing page  ", Contact: " abuse@example.com "},
+			expectedReport: AbuseReport{Token: "abc", Reason: "phishing page", Contact: "abuse@example.com"},
+		},
+		{
+			name:           "contact is optional",
+			report:         AbuseReport{Token: "abc", Reason: "spam"},
+			expectedReport: AbuseReport{Token: "abc", Reason: "spam"},
+		},
+		{
+			name:           "reason of maximum length",
+			report:         AbuseReport{Token: "abc", Reason: strings.Repeat("ü", MaxAbuseReasonLength)},
+			expectedReport: AbuseReport{Token: "abc", Reason: strings.Repeat("ü", MaxAbuseReasonLength)},
+		},
+		{
+			name:          "blank reason",
+			report:        AbuseReport{Token: "abc", Reason: "   "},
+			expectedError: true,
+		},
+		{
+			name:          "reason too long",
+			report:        AbuseReport{Token: "abc", Reason: strings.Repeat("a", MaxAbuseReasonLength+1)},
+			expectedError: true,
+		},
+		{
+			name:          "contact too long",
+			report:        AbuseReport{Token: "abc", Reason: "spam", Contact: strings.Repeat("a", MaxAbuseContactLength+1)},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := NormalizeAbuseReport(tt.report)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, &InvalidAbuseReportError{})
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReport, report)
+			}
+		})
+	}
+}
+
+func TestAbuseReportStatus_IsValid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, AbuseReportOpen.IsValid())
+	assert.True(t, AbuseReportDismissed.IsValid())
+	assert.True(t, AbuseReportActioned.IsValid())
+	assert.False(t, AbuseReportStatus("closed").IsValid())
+	assert.False(t, AbuseReportStatus("").IsValid())
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MinAliasLength is the minimum number of characters allowed in a custom alias.
+	MinAliasLength = 3
+	// MaxAliasLength is the maximum number of characters allowed in a custom alias.
+	MaxAliasLength = 64
+)
+
+// reservedAliases contains words that cannot be used as custom aliases
+// because they clash with service routes or are likely to be misleading.
+var reservedAliases = map[string]bool{
+	"shorten": true,
+	"stats":   true,
+	"api":     true,
+	"admin":   true,
+	"health":  true,
+}
+
+// ValidateAlias checks if the provided custom alias can be used as a URL token.
+// Aliases may contain latin letters, digits, '-' and '_', must start and end
+// with a letter or digit and must not be a reserved word (case-insensitive).
+//
+// Returns *InvalidAliasError if:
+//   - The alias length is outside [MinAliasLength, MaxAliasLength]
+//   - The alias contains unsupported characters
+//   - The alias starts or ends with '-' or '_'
+//   - The alias is a reserved word
+//
+// Returns nil if the alias is valid.
+func ValidateAlias(alias string) error {
+	if len(alias) < MinAliasLength || len(alias) > MaxAliasLength {
+		return &InvalidAliasError{Msg: fmt.Sprintf("Alias length must be between %d and %d characters", MinAliasLength, MaxAliasLength)}
+	}
+
+	for _, r := range alias {
+		if !isAliasRune(r) {
+			return &InvalidAliasError{Msg: fmt.Sprintf("Alias contains unsupported character: %q", r)}
+		}
+	}
+
+	if isAliasSeparator(rune(alias[0])) || isAliasSeparator(rune(alias[len(alias)-1])) {
+		return &InvalidAliasError{Msg: "Alias must start and end with a letter or digit"}
+	}
+
+	if reservedAliases[strings.ToLower(alias)] {
+		return &InvalidAliasError{Msg: fmt.Sprintf("Alias is reserved: %s", alias)}
+	}
+
+	return nil
+}
+
+// isAliasRune reports whether r is allowed inside a custom alias.
+func isAliasRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || isAliasSeparator(r)
+}
+
+// isAliasSeparator reports whether r is a word separator allowed inside a custom alias.
+func isAliasSeparator(r rune) bool {
+	return r == '-' || r == '_'
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAlias(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		alias       string
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name:        "valid alias with dash",
+			alias:       "spring-sale",
+			expectedErr: nil,
+		},
+		{
+			name:        "valid alias with underscore and digits",
+			alias:       "Promo_2025",
+			expectedErr: nil,
+		},
+		{
+			name:        "alias named like an internal redis key",
+			alias:       "mapping_count",
+			expectedErr: nil,
+		},
+		{
+			name:        "minimum length alias",
+			alias:       "abc",
+			expectedErr: nil,
+		},
+		{
+			name:        "maximum length alias",
+			alias:       strings.Repeat("a", MaxAliasLength),
+			expectedErr: nil,
+		},
+		{
+			name:        "too short alias",
+			alias:       "ab",
+			expectedErr: &InvalidAliasError{},
+		},
+		{
+			name:        "too long alias",
+			alias:       strings.Repeat("a", MaxAliasLength+1),
+			expectedErr: &InvalidAliasError{},
+		},
+		{
+			name:        "unsupported character",
+			alias:       "spring/sale",
+			expectedErr: &InvalidAliasError{},
+		},
+		{
+			name:        "non latin character",
+			alias:       "привет",
+			expectedErr: &InvalidAliasError{},
+		},
+		{
+			name:        "starts with separator",
+			alias:       "-sale",
+			expectedErr: &InvalidAliasError{},
+		},
+		{
+			name:        "ends with separator",
+			alias:       "sale_",
+			expectedErr: &InvalidAliasError{},
+		},
+		{
+			name:        "reserved word",
+			alias:       "shorten",
+			expectedErr: &InvalidAliasError{},
+		},
+		{
+			name:        "reserved word in different case",
+			alias:       "Stats",
+			expectedErr: &InvalidAliasError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateAlias(tt.alias)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
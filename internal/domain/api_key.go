@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// ApiKeyPrefix starts every API key, so leaked keys are easy to recognize.
+	ApiKeyPrefix = "usk_"
+	// apiKeySecretBytes is the number of random bytes of an API key.
+	apiKeySecretBytes = 24
+	// apiKeyDisplayLength is the length of the start of an API key that is stored in plain text
+	// to identify the key in listings.
+	apiKeyDisplayLength = len(ApiKeyPrefix) + 8
+	// MaxApiKeyNameLength is the maximum length of the name of an API key.
+	MaxApiKeyNameLength = 100
+	// MaxApiKeyOwnerLength is the maximum length of the owner of an API key.
+	MaxApiKeyOwnerLength = 100
+)
+
+// ApiKeyScope grants an API key access to a group of endpoints.
+type ApiKeyScope string
+
+const (
+	// ScopeLinksWrite allows creating, updating, deleting and restoring short URLs.
+	ScopeLinksWrite ApiKeyScope = "links:write"
+	// ScopeLinksRead allows reading and listing the details of short URLs.
+	ScopeLinksRead ApiKeyScope = "links:read"
+	// ScopeStatsRead allows reading the statistics of short URLs.
+	ScopeStatsRead ApiKeyScope = "stats:read"
+	// ScopeAdmin allows the administration endpoints, including the management of API keys.
+	ScopeAdmin ApiKeyScope = "admin"
+)
+
+// IsValid reports whether the scope is a known API key scope.
+func (s ApiKeyScope) IsValid() bool {
+	switch s {
+	case ScopeLinksWrite, ScopeLinksRead, ScopeStatsRead, ScopeAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// ApiKey is a credential clients authenticate to the management endpoints with.
+// Only the hash of the key is stored, so the key itself is shown once when it is created.
+type ApiKey struct {
+	// Id is the unique identifier of the key.
+	Id int64 `json:"id"`
+	// Name describes what the key is used for.
+	Name string `json:"name"`
+	// Owner identifies the principal the key acts for. Short URLs created with the key are owned by it,
+	// and only keys of the same owner may manage them. Keys can be rotated without losing access
+	// by creating the new key with the same owner.
+	Owner string `json:"owner"`
+	// WorkspaceId is the workspace the key belongs to. Short URLs created with the key belong to the workspace
+	// and count against its quotas. Zero means the key belongs to no workspace.
+	WorkspaceId int64 `json:"workspace_id,omitempty"`
+	// Prefix is the start of the key, which identifies it without revealing it.
+	Prefix string `json:"prefix"`
+	// Scopes are the groups of endpoints the key grants access to.
+	Scopes []ApiKeyScope `json:"scopes"`
+	// CreatedAt is the timestamp when the key was created.
+	CreatedAt time.Time `json:"created_at"`
+	// RevokedAt is the timestamp when the key was revoked, nil while the key is active.
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// HasScope reports whether the key grants the scope. The admin scope grants every scope.
+func (k ApiKey) HasScope(scope ApiKeyScope) bool {
+	return slices.Contains(k.Scopes, scope) || slices.Contains(k.Scopes, ScopeAdmin)
+}
+
+// NormalizeApiKey validates the name, owner and scopes of an API key to be created and returns it
+// with its name and owner trimmed and its scopes deduplicated in a stable order.
+// A key without owner is owned by its name.
+//
+// Returns *InvalidApiKeyError if:
+//   - The name is empty or longer than MaxApiKeyNameLength
+//   - The owner is longer than MaxApiKeyOwnerLength
+//   - No scope is given or a scope is unknown
+//   - The key belongs to a workspace and has the admin scope, which would let it manage other workspaces
+func NormalizeApiKey(key ApiKey) (ApiKey, error) {
+	key.Name = strings.TrimSpace(key.Name)
+	if key.Name == "" || len(key.Name) > MaxApiKeyNameLength {
+		return ApiKey{}, &InvalidApiKeyError{Msg: fmt.Sprintf("API key name must be between 1 and %d bytes long", MaxApiKeyNameLength)}
+	}
+
+	key.Owner = strings.TrimSpace(key.Owner)
+	if key.Owner == "" {
+		key.Owner = key.Name
+	} else if len(key.Owner) > MaxApiKeyOwnerLength {
+		return ApiKey{}, &InvalidApiKeyError{Msg: fmt.Sprintf("API key owner must be at most %d bytes long", MaxApiKeyOwnerLength)}
+	}
+
+	if len(key.Scopes) == 0 {
+		return ApiKey{}, &InvalidApiKeyError{Msg: "API key must have at least one scope"}
+	}
+	for _, scope := range key.Scopes {
+		if !scope.IsValid() {
+			return ApiKey{}, &InvalidApiKeyError{Msg: fmt.Sprintf("Unknown API key scope: %q", scope)}
+		}
+	}
+
+	if key.WorkspaceId != 0 && slices.Contains(key.Scopes, ScopeAdmin) {
+		return ApiKey{}, &InvalidApiKeyError{Msg: "API keys of workspaces must not have the admin scope"}
+	}
+
+	scopes := slices.Clone(key.Scopes)
+	slices.Sort(scopes)
+	key.Scopes = slices.Compact(scopes)
+
+	return key, nil
+}
+
+// GenerateApiKey returns a new random API key together with its display prefix.
+//
+// Returns an error if the random source fails.
+func GenerateApiKey() (key string, prefix string, err error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("generating API key: %w", err)
+	}
+
+	key = ApiKeyPrefix + hex.EncodeToString(secret)
+	return key, key[:apiKeyDisplayLength], nil
+}
+
+// HashApiKey returns the hash an API key is stored and looked up by.
+// API keys are long random secrets, so an unsalted SHA-256 hash is sufficient and keeps them indexable.
+func HashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// apiKeyContextKey is the context key the authenticated API key is stored under.
+type apiKeyContextKey struct{}
+
+// WithApiKey returns a copy of ctx carrying the API key the request was authenticated with.
+func WithApiKey(ctx context.Context, key ApiKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// ApiKeyFromContext returns the API key the request was authenticated with
+// and false if the request was not authenticated.
+func ApiKeyFromContext(ctx context.Context) (ApiKey, bool) {
+	key, ok := ctx.Value(apiKeyContextKey{}).(ApiKey)
+	return key, ok
+}
+
+// AuthorizeOwner checks that the API key the request was authenticated with may manage
+// a short URL of the given owner. Keys with the admin scope may manage every short URL,
+// other keys only the short URLs of their owner within their workspace. Short URLs without owner,
+// created before owners were recorded, may only be managed by admins.
+// Requests without API key in the context are not subject to ownership and are allowed.
+//
+// Returns *ForbiddenError if the key may not manage the short URL.
+func AuthorizeOwner(ctx context.Context, owner MappingOwner) error {
+	key, ok := ApiKeyFromContext(ctx)
+	if !ok || key.HasScope(ScopeAdmin) {
+		return nil
+	}
+
+	if owner.OwnerId == "" || owner.OwnerId != key.Owner || owner.WorkspaceId != key.WorkspaceId {
+		return &ForbiddenError{Msg: "Short URL is owned by another client"}
+	}
+
+	return nil
+}
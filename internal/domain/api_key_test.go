@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeApiKey(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		key           ApiKey
+		expectedKey   ApiKey
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "trims name and deduplicates scopes",
+			key:         ApiKey{Name: " ci ", Scopes: []ApiKeyScope{ScopeStatsRead, ScopeLinksWrite, ScopeStatsRead}},
+			expectedKey: ApiKey{Name: "ci", Owner: "ci", Scopes: []ApiKeyScope{ScopeLinksWrite, ScopeStatsRead}},
+		},
+		{
+			name:        "admin scope",
+			key:         ApiKey{Name: "ops", Scopes: []ApiKeyScope{ScopeAdmin}},
+			expectedKey: ApiKey{Name: "ops", Owner: "ops", Scopes: []ApiKeyScope{ScopeAdmin}},
+		},
+		{
+			name:        "trims owner",
+			key:         ApiKey{Name: "ci", Owner: " team-a ", Scopes: []ApiKeyScope{ScopeLinksWrite}},
+			expectedKey: ApiKey{Name: "ci", Owner: "team-a", Scopes: []ApiKeyScope{ScopeLinksWrite}},
+		},
+		{
+			name:        "workspace key",
+			key:         ApiKey{Name: "ci", WorkspaceId: 7, Scopes: []ApiKeyScope{ScopeLinksWrite}},
+			expectedKey: ApiKey{Name: "ci", Owner: "ci", WorkspaceId: 7, Scopes: []ApiKeyScope{ScopeLinksWrite}},
+		},
+		{
+			name:          "admin scope in workspace",
+			key:           ApiKey{Name: "ops", WorkspaceId: 7, Scopes: []ApiKeyScope{ScopeAdmin}},
+			expectedError: true,
+		},
+		{
+			name:          "owner too long",
+			key:           ApiKey{Name: "ci", Owner: strings.Repeat("a", MaxApiKeyOwnerLength+1), Scopes: []ApiKeyScope{ScopeLinksWrite}},
+			expectedError: true,
+		},
+		{
+			name:          "blank name",
+			key:           ApiKey{Name: "  ", Scopes: []ApiKeyScope{ScopeLinksRead}},
+			expectedError: true,
+		},
+		{
+			name:          "name too long",
+			key:           ApiKey{Name: strings.Repeat("a", MaxApiKeyNameLength+1), Scopes: []ApiKeyScope{ScopeLinksRead}},
+			expectedError: true,
+		},
+		{
+			name:          "no scopes",
+			key:           ApiKey{Name: "ci"},
+			expectedError: true,
+		},
+		{
+			name:          "unknown scope",
+			key:           ApiKey{Name: "ci", Scopes: []ApiKeyScope{"links:delete"}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := NormalizeApiKey(tt.key)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, &InvalidApiKeyError{})
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKey, key)
+			}
+		})
+	}
+}
+
+func TestApiKey_HasScope(t *testing.T) {
+	t.Parallel()
+
+	writer := ApiKey{Scopes: []ApiKeyScope{ScopeLinksWrite}}
+	assert.True(t, writer.HasScope(ScopeLinksWrite))
+	assert.False(t, writer.HasScope(ScopeLinksRead))
+	assert.False(t, writer.HasScope(ScopeStatsRead))
+	assert.False(t, writer.HasScope(ScopeAdmin))
+
+	reader := ApiKey{Scopes: []ApiKeyScope{ScopeLinksRead}}
+	assert.True(t, reader.HasScope(ScopeLinksRead))
+	assert.False(t, reader.HasScope(ScopeLinksWrite))
+
+	admin := ApiKey{Scopes: []ApiKeyScope{ScopeAdmin}}
+	assert.True(t, admin.HasScope(ScopeLinksWrite))
+	assert.True(t, admin.HasScope(ScopeLinksRead))
+	assert.True(t, admin.HasScope(ScopeStatsRead))
+}
+
+func TestGenerateApiKey(t *testing.T) {
+	t.Parallel()
+
+	key, prefix, err := GenerateApiKey()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, ApiKeyPrefix))
+	assert.Len(t, key, len(ApiKeyPrefix)+2*apiKeySecretBytes)
+	assert.Equal(t, key[:apiKeyDisplayLength], prefix)
+
+	other, _, err := GenerateApiKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+	assert.NotEqual(t, HashApiKey(key), HashApiKey(other))
+	assert.Equal(t, HashApiKey(key), HashApiKey(key))
+}
+
+func TestApiKeyFromContext(t *testing.T) {
+	t.Parallel()
+
+	_, ok := ApiKeyFromContext(context.Background())
+	assert.False(t, ok)
+
+	key := ApiKey{Id: 1, Name: "ci"}
+	stored, ok := ApiKeyFromContext(WithApiKey(context.Background(), key))
+	assert.True(t, ok)
+	assert.Equal(t, key, stored)
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		ctx           context.Context
+		owner         MappingOwner
+		expectedError bool
+	}
+
+	writer := ApiKey{Name: "ci", Owner: "team-a", Scopes: []ApiKeyScope{ScopeLinksWrite}}
+	workspaceWriter := ApiKey{Name: "ci", Owner: "team-a", WorkspaceId: 7, Scopes: []ApiKeyScope{ScopeLinksWrite}}
+	admin := ApiKey{Name: "ops", Owner: "ops", Scopes: []ApiKeyScope{ScopeAdmin}}
+
+	testCases := []testCase{
+		{name: "owner", ctx: WithApiKey(context.Background(), writer), owner: MappingOwner{OwnerId: "team-a"}},
+		{name: "other owner", ctx: WithApiKey(context.Background(), writer), owner: MappingOwner{OwnerId: "team-b"}, expectedError: true},
+		{name: "no owner", ctx: WithApiKey(context.Background(), writer), owner: MappingOwner{}, expectedError: true},
+		{name: "owner in workspace", ctx: WithApiKey(context.Background(), workspaceWriter), owner: MappingOwner{WorkspaceId: 7, OwnerId: "team-a"}},
+		{
+			name: "same owner in other workspace", ctx: WithApiKey(context.Background(), workspaceWriter),
+			owner: MappingOwner{WorkspaceId: 8, OwnerId: "team-a"}, expectedError: true,
+		},
+		{
+			name: "same owner outside of workspace", ctx: WithApiKey(context.Background(), workspaceWriter),
+			owner: MappingOwner{OwnerId: "team-a"}, expectedError: true,
+		},
+		{name: "admin of other owner", ctx: WithApiKey(context.Background(), admin), owner: MappingOwner{WorkspaceId: 8, OwnerId: "team-b"}},
+		{name: "admin of no owner", ctx: WithApiKey(context.Background(), admin), owner: MappingOwner{}},
+		{name: "unauthenticated", ctx: context.Background(), owner: MappingOwner{OwnerId: "team-b"}},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := AuthorizeOwner(tt.ctx, tt.owner)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, &ForbiddenError{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
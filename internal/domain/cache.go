@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CacheSettings contains configuration parameters of the URL mapping cache.
+type CacheSettings struct {
+	// TTL is the time a mapping stays cached after it was stored. Zero disables the TTL,
+	// so mappings without expiration stay cached until they are evicted.
+	TTL time.Duration
+	// TTLJitter is the upper bound of a random duration added to TTL,
+	// so mappings cached at the same time do not expire at the same time.
+	TTLJitter time.Duration
+	// SlidingExpiration renews the TTL of a cached mapping on every read, keeping hot mappings cached.
+	SlidingExpiration bool
+	// NegativeTTL is the time a token that was not found in storage stays cached as not existing.
+	// Zero disables negative caching.
+	NegativeTTL time.Duration
+	// LocalSize is the maximum number of mappings kept in the in-process cache in front of Redis.
+	// Zero disables the in-process cache.
+	LocalSize int
+	// LocalTTL is the time a mapping stays in the in-process cache. It bounds how long a replica
+	// can serve a stale mapping if it misses an invalidation.
+	LocalTTL time.Duration
+	// ReevictionDelay is the time after which a mapping evicted on update is evicted once more,
+	// removing a stale mapping a concurrent cache miss loaded before the update and cached after the first eviction.
+	// It must exceed the duration of a storage lookup. Zero disables the second eviction,
+	// so such a stale mapping stays cached until its TTL expires.
+	ReevictionDelay time.Duration
+}
+
+// Validate checks that the cache settings are consistent.
+//
+// Returns an error if:
+//   - TTL, TTLJitter, NegativeTTL or ReevictionDelay is negative
+//   - TTLJitter or SlidingExpiration is set without a TTL
+//   - LocalSize is negative, or the in-process cache is enabled without a positive LocalTTL
+func (c CacheSettings) Validate() error {
+	if c.TTL < 0 {
+		return fmt.Errorf("cache TTL must not be negative: %s", c.TTL)
+	}
+	if c.TTLJitter < 0 {
+		return fmt.Errorf("cache TTL jitter must not be negative: %s", c.TTLJitter)
+	}
+	if c.NegativeTTL < 0 {
+		return fmt.Errorf("negative cache TTL must not be negative: %s", c.NegativeTTL)
+	}
+	if c.ReevictionDelay < 0 {
+		return fmt.Errorf("cache reeviction delay must not be negative: %s", c.ReevictionDelay)
+	}
+	if c.TTL == 0 && (c.TTLJitter > 0 || c.SlidingExpiration) {
+		return fmt.Errorf("cache TTL jitter and sliding expiration require a cache TTL")
+	}
+	if c.LocalSize < 0 {
+		return fmt.Errorf("local cache size must not be negative: %d", c.LocalSize)
+	}
+	if c.LocalSize > 0 && c.LocalTTL <= 0 {
+		return fmt.Errorf("local cache TTL must be positive: %s", c.LocalTTL)
+	}
+
+	return nil
+}
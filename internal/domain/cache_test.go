@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheSettings_Validate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		settings CacheSettings
+		wantErr  bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "no ttl",
+			settings: CacheSettings{},
+			wantErr:  false,
+		},
+		{
+			name:     "ttl with jitter and sliding expiration",
+			settings: CacheSettings{TTL: 24 * time.Hour, TTLJitter: time.Hour, SlidingExpiration: true, NegativeTTL: 30 * time.Second},
+			wantErr:  false,
+		},
+		{
+			name:     "local cache with ttl",
+			settings: CacheSettings{LocalSize: 10000, LocalTTL: 30 * time.Second},
+			wantErr:  false,
+		},
+		{
+			name:     "negative local cache size",
+			settings: CacheSettings{LocalSize: -1, LocalTTL: 30 * time.Second},
+			wantErr:  true,
+		},
+		{
+			name:     "local cache without ttl",
+			settings: CacheSettings{LocalSize: 10000},
+			wantErr:  true,
+		},
+		{
+			name:     "negative ttl",
+			settings: CacheSettings{TTL: -time.Minute},
+			wantErr:  true,
+		},
+		{
+			name:     "negative negative cache ttl",
+			settings: CacheSettings{NegativeTTL: -time.Second},
+			wantErr:  true,
+		},
+		{
+			name:     "negative reeviction delay",
+			settings: CacheSettings{ReevictionDelay: -time.Second},
+			wantErr:  true,
+		},
+		{
+			name:     "negative jitter",
+			settings: CacheSettings{TTL: time.Hour, TTLJitter: -time.Minute},
+			wantErr:  true,
+		},
+		{
+			name:     "jitter without ttl",
+			settings: CacheSettings{TTLJitter: time.Minute},
+			wantErr:  true,
+		},
+		{
+			name:     "sliding expiration without ttl",
+			settings: CacheSettings{SlidingExpiration: true},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.settings.Validate()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
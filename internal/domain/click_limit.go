@@ -0,0 +1,15 @@
+package domain
+
+import "fmt"
+
+// ValidateMaxClicks checks that the optional click limit allows at least one redirect.
+// A nil maxClicks is always valid and means the number of redirects is unlimited.
+//
+// Returns *InvalidClickLimitError if maxClicks is not positive.
+func ValidateMaxClicks(maxClicks *int64) error {
+	if maxClicks != nil && *maxClicks <= 0 {
+		return &InvalidClickLimitError{Msg: fmt.Sprintf("Max clicks must be positive: %d", *maxClicks)}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMaxClicks(t *testing.T) {
+	t.Parallel()
+
+	one := int64(1)
+	zero := int64(0)
+	negative := int64(-5)
+
+	type testCase struct {
+		name        string
+		maxClicks   *int64
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name:        "no click limit",
+			maxClicks:   nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "single click",
+			maxClicks:   &one,
+			expectedErr: nil,
+		},
+		{
+			name:        "zero clicks",
+			maxClicks:   &zero,
+			expectedErr: &InvalidClickLimitError{},
+		},
+		{
+			name:        "negative clicks",
+			maxClicks:   &negative,
+			expectedErr: &InvalidClickLimitError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateMaxClicks(tt.maxClicks)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxDestinationPatternLength is the maximum length of the pattern of a destination policy rule.
+const MaxDestinationPatternLength = 256
+
+// DestinationAction decides whether the hosts matched by a destination policy rule may be shortened.
+type DestinationAction string
+
+const (
+	// DestinationAllow restricts shortening to the matched hosts. As soon as one allow rule exists,
+	// hosts matched by no allow rule are rejected.
+	DestinationAllow DestinationAction = "allow"
+	// DestinationBlock rejects the matched hosts, even if an allow rule matches them too.
+	DestinationBlock DestinationAction = "block"
+)
+
+// DestinationMatch selects how the pattern of a destination policy rule is matched against hosts.
+type DestinationMatch string
+
+const (
+	// DestinationMatchExact matches the host that equals the pattern, e.g. example.com.
+	DestinationMatchExact DestinationMatch = "exact"
+	// DestinationMatchWildcard matches all subdomains of the pattern, e.g. *.example.com
+	// matches www.example.com and a.b.example.com, but not example.com itself.
+	DestinationMatchWildcard DestinationMatch = "wildcard"
+	// DestinationMatchRegex matches hosts the whole of which match the regular expression of the pattern.
+	DestinationMatchRegex DestinationMatch = "regex"
+)
+
+// DestinationRule is a rule of the destination policy, which decides what hosts may be shortened.
+// Patterns are matched against the lowercase ASCII form of hosts, with internationalized names in Punycode.
+type DestinationRule struct {
+	// Id is the unique identifier of the rule.
+	Id int64 `json:"id"`
+	// Action decides whether the matched hosts are allowed or blocked.
+	Action DestinationAction `json:"action"`
+	// Match selects how Pattern is matched against hosts.
+	Match DestinationMatch `json:"match"`
+	// Pattern is the host, the wildcard domain or the regular expression of the rule.
+	Pattern string `json:"pattern"`
+	// WorkspaceId is the workspace whose mappings the rule applies to.
+	// Zero means the rule applies to all mappings, including those of every workspace.
+	WorkspaceId int64 `json:"workspace_id,omitempty"`
+	// CreatedAt is the timestamp when the rule was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NormalizeDestinationRule validates a destination policy rule and returns it with its pattern
+// in the form it is matched in: exact hosts and wildcard domains in lowercase ASCII form.
+//
+// Returns *InvalidDestinationRuleError if:
+//   - The action or match type is unknown
+//   - The pattern is empty or longer than MaxDestinationPatternLength
+//   - The pattern is not a valid host, wildcard domain or regular expression
+func NormalizeDestinationRule(rule DestinationRule) (DestinationRule, error) {
+	if rule.Action != DestinationAllow && rule.Action != DestinationBlock {
+		return DestinationRule{}, &InvalidDestinationRuleError{Msg: fmt.Sprintf("Unknown destination rule action: %q", rule.Action)}
+	}
+
+	pattern := strings.TrimSpace(rule.Pattern)
+	if pattern == "" || len(pattern) > MaxDestinationPatternLength {
+		return DestinationRule{}, &InvalidDestinationRuleError{
+			Msg: fmt.Sprintf("Destination rule pattern must be between 1 and %d characters", MaxDestinationPatternLength),
+		}
+	}
+
+	switch rule.Match {
+	case DestinationMatchExact:
+		host, err := ToASCIIHost(pattern)
+		if err != nil {
+			return DestinationRule{}, &InvalidDestinationRuleError{Msg: fmt.Sprintf("Invalid destination rule host: %v", err)}
+		}
+		pattern = host
+	case DestinationMatchWildcard:
+		parent, found := strings.CutPrefix(pattern, "*.")
+		if !found {
+			return DestinationRule{}, &InvalidDestinationRuleError{Msg: "Wildcard destination rule must start with *."}
+		}
+		host, err := ToASCIIHost(parent)
+		if err != nil || strings.Contains(host, "*") {
+			return DestinationRule{}, &InvalidDestinationRuleError{Msg: fmt.Sprintf("Invalid wildcard destination rule: %s", pattern)}
+		}
+		pattern = "*." + host
+	case DestinationMatchRegex:
+		_, err := compileHostRegex(pattern)
+		if err != nil {
+			return DestinationRule{}, &InvalidDestinationRuleError{Msg: fmt.Sprintf("Invalid destination rule regex: %v", err)}
+		}
+	default:
+		return DestinationRule{}, &InvalidDestinationRuleError{Msg: fmt.Sprintf("Unknown destination rule match type: %q", rule.Match)}
+	}
+
+	rule.Pattern = pattern
+	return rule, nil
+}
+
+// DestinationPolicy decides whether hosts may be shortened according to a set of destination rules.
+// Block rules take precedence over allow rules, and hosts matched by no rule are allowed unless allow rules exist.
+// A DestinationPolicy is immutable and safe for concurrent use.
+type DestinationPolicy struct {
+	allow []hostMatcher
+	block []hostMatcher
+}
+
+// hostMatcher is a compiled destination rule.
+type hostMatcher struct {
+	rule    DestinationRule
+	matches func(host string) bool
+}
+
+// NewDestinationPolicy compiles the destination rules into a policy.
+// An empty set of rules allows every host.
+//
+// Returns *InvalidDestinationRuleError if one of the rules is invalid.
+func NewDestinationPolicy(rules []DestinationRule) (*DestinationPolicy, error) {
+	policy := &DestinationPolicy{}
+	for _, rule := range rules {
+		rule, err := NormalizeDestinationRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		matcher := hostMatcher{rule: rule}
+		switch rule.Match {
+		case DestinationMatchExact:
+			matcher.matches = func(host string) bool { return host == rule.Pattern }
+		case DestinationMatchWildcard:
+			suffix := strings.TrimPrefix(rule.Pattern, "*")
+			matcher.matches = func(host string) bool { return strings.HasSuffix(host, suffix) }
+		case DestinationMatchRegex:
+			regex, _ := compileHostRegex(rule.Pattern)
+			matcher.matches = regex.MatchString
+		}
+
+		if rule.Action == DestinationAllow {
+			policy.allow = append(policy.allow, matcher)
+		} else {
+			policy.block = append(policy.block, matcher)
+		}
+	}
+
+	return policy, nil
+}
+
+// CheckHost checks whether the host may be shortened.
+// The host is expected in the lowercase ASCII form returned by ToASCIIHost.
+//
+// Returns *DestinationBlockedError if:
+//   - A block rule matches the host
+//   - Allow rules exist and none of them matches the host
+func (p *DestinationPolicy) CheckHost(host string) error {
+	for _, matcher := range p.block {
+		if matcher.matches(host) {
+			return &DestinationBlockedError{Msg: fmt.Sprintf("Destination is blocked: %s", host)}
+		}
+	}
+
+	if len(p.allow) == 0 {
+		return nil
+	}
+	for _, matcher := range p.allow {
+		if matcher.matches(host) {
+			return nil
+		}
+	}
+
+	return &DestinationBlockedError{Msg: fmt.Sprintf("Destination is not on the allowlist: %s", host)}
+}
+
+// compileHostRegex compiles a regular expression that has to match a whole host.
+func compileHostRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
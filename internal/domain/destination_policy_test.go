@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDestinationRule(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name            string
+		rule            DestinationRule
+		expectedPattern string
+		expectedError   bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Exact host lowercased",
+			rule:            DestinationRule{Action: DestinationBlock, Match: DestinationMatchExact, Pattern: " Evil.Example.COM "},
+			expectedPattern: "evil.example.com",
+		},
+		{
+			name:            "Exact internationalized host in punycode",
+			rule:            DestinationRule{Action: DestinationBlock, Match: DestinationMatchExact, Pattern: "bücher.de"},
+			expectedPattern: "xn--bcher-kva.de",
+		},
+		{
+			name:            "Wildcard domain",
+			rule:            DestinationRule{Action: DestinationAllow, Match: DestinationMatchWildcard, Pattern: "*.Example.com"},
+			expectedPattern: "*.example.com",
+		},
+		{
+			name:            "Regex kept as is",
+			rule:            DestinationRule{Action: DestinationBlock, Match: DestinationMatchRegex, Pattern: `.*\.(zip|mov)`},
+			expectedPattern: `.*\.(zip|mov)`,
+		},
+		{
+			name:          "Unknown action",
+			rule:          DestinationRule{Action: "deny", Match: DestinationMatchExact, Pattern: "example.com"},
+			expectedError: true,
+		},
+		{
+			name:          "Unknown match type",
+			rule:          DestinationRule{Action: DestinationBlock, Match: "prefix", Pattern: "example.com"},
+			expectedError: true,
+		},
+		{
+			name:          "Empty pattern",
+			rule:          DestinationRule{Action: DestinationBlock, Match: DestinationMatchExact, Pattern: "  "},
+			expectedError: true,
+		},
+		{
+			name:          "Wildcard without prefix",
+			rule:          DestinationRule{Action: DestinationBlock, Match: DestinationMatchWildcard, Pattern: "example.com"},
+			expectedError: true,
+		},
+		{
+			name:          "Nested wildcard",
+			rule:          DestinationRule{Action: DestinationBlock, Match: DestinationMatchWildcard, Pattern: "*.*.example.com"},
+			expectedError: true,
+		},
+		{
+			name:          "Invalid regex",
+			rule:          DestinationRule{Action: DestinationBlock, Match: DestinationMatchRegex, Pattern: "(example"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := NormalizeDestinationRule(tt.rule)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, &InvalidDestinationRuleError{})
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPattern, rule.Pattern)
+				assert.Equal(t, tt.rule.Action, rule.Action)
+				assert.Equal(t, tt.rule.Match, rule.Match)
+			}
+		})
+	}
+}
+
+func TestDestinationPolicy_CheckHost(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		rules         []DestinationRule
+		host          string
+		expectBlocked bool
+	}
+
+	blockEvil := DestinationRule{Action: DestinationBlock, Match: DestinationMatchExact, Pattern: "evil.com"}
+	blockTracker := DestinationRule{Action: DestinationBlock, Match: DestinationMatchWildcard, Pattern: "*.tracker.net"}
+	blockZip := DestinationRule{Action: DestinationBlock, Match: DestinationMatchRegex, Pattern: `[a-z0-9-]+\.zip`}
+	allowCorp := DestinationRule{Action: DestinationAllow, Match: DestinationMatchWildcard, Pattern: "*.corp.example"}
+	allowDocs := DestinationRule{Action: DestinationAllow, Match: DestinationMatchExact, Pattern: "docs.example.org"}
+
+	testCases := []testCase{
+		{name: "No rules allow every host", host: "anything.com"},
+		{name: "Exact block", rules: []DestinationRule{blockEvil}, host: "evil.com", expectBlocked: true},
+		{name: "Exact block ignores subdomains", rules: []DestinationRule{blockEvil}, host: "www.evil.com"},
+		{name: "Wildcard block matches subdomain", rules: []DestinationRule{blockTracker}, host: "a.b.tracker.net", expectBlocked: true},
+		{name: "Wildcard block ignores parent domain", rules: []DestinationRule{blockTracker}, host: "tracker.net"},
+		{name: "Wildcard block ignores lookalike suffix", rules: []DestinationRule{blockTracker}, host: "eviltracker.net"},
+		{name: "Regex block matches whole host", rules: []DestinationRule{blockZip}, host: "invoice.zip", expectBlocked: true},
+		{name: "Regex block is anchored", rules: []DestinationRule{blockZip}, host: "invoice.zip.example.com"},
+		{name: "Allowlist allows matched host", rules: []DestinationRule{allowCorp, allowDocs}, host: "wiki.corp.example"},
+		{name: "Allowlist rejects unmatched host", rules: []DestinationRule{allowCorp, allowDocs}, host: "example.com", expectBlocked: true},
+		{
+			name:          "Block takes precedence over allow",
+			rules:         []DestinationRule{allowCorp, {Action: DestinationBlock, Match: DestinationMatchExact, Pattern: "hr.corp.example"}},
+			host:          "hr.corp.example",
+			expectBlocked: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := NewDestinationPolicy(tt.rules)
+			require.NoError(t, err)
+
+			err = policy.CheckHost(tt.host)
+
+			if tt.expectBlocked {
+				assert.ErrorIs(t, err, &DestinationBlockedError{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewDestinationPolicy_InvalidRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewDestinationPolicy([]DestinationRule{{Action: DestinationBlock, Match: DestinationMatchRegex, Pattern: "("}})
+
+	assert.ErrorIs(t, err, &InvalidDestinationRuleError{})
+}
@@ -4,7 +4,39 @@ const (
 	RedisUrlEnv  = "REDIS_URL"
 	RedisPortEnv = "REDIS_PORT"
 
-	ServerPortEnv = "SERVER_PORT"
+	CacheTTLEnv               = "CACHE_TTL"
+	CacheTTLJitterEnv         = "CACHE_TTL_JITTER"
+	CacheSlidingExpirationEnv = "CACHE_SLIDING_EXPIRATION"
+	CacheNegativeTTLEnv       = "CACHE_NEGATIVE_TTL"
+	CacheLocalSizeEnv         = "CACHE_LOCAL_SIZE"
+	CacheLocalTTLEnv          = "CACHE_LOCAL_TTL"
+	CacheReevictionDelayEnv   = "CACHE_REEVICTION_DELAY"
+
+	TokenStrategyEnv    = "TOKEN_STRATEGY"
+	TokenSecretEnv      = "TOKEN_SECRET"
+	TokenEncodingEnv    = "TOKEN_ENCODING"
+	TokenMinLengthEnv   = "TOKEN_MIN_LENGTH"
+	TokenCheckDigitEnv  = "TOKEN_CHECK_DIGIT"
+	TokenSuggestionsEnv = "TOKEN_SUGGESTIONS"
+
+	IdGeneratorEnv             = "ID_GENERATOR"
+	IdLeaseSizeEnv             = "ID_LEASE_SIZE"
+	IdReseedGapEnv             = "ID_RESEED_GAP"
+	SnowflakeWorkerIdEnv       = "SNOWFLAKE_WORKER_ID"
+	SnowflakeWorkerLeaseTTLEnv = "SNOWFLAKE_WORKER_LEASE_TTL"
+
+	ServerPortEnv               = "SERVER_PORT"
+	ShortDomainsEnv             = "SHORT_DOMAINS"
+	PublicBaseUrlEnv            = "PUBLIC_BASE_URL"
+	PublicDomainBaseUrlsEnv     = "PUBLIC_DOMAIN_BASE_URLS"
+	DestinationPolicyRefreshEnv = "DESTINATION_POLICY_REFRESH"
+	UrlScanListEnv              = "URL_SCAN_LIST"
+	UrlRescanIntervalEnv        = "URL_RESCAN_INTERVAL"
+	DeletedRetentionEnv         = "DELETED_RETENTION"
+	PurgeIntervalEnv            = "PURGE_INTERVAL"
+	AdminApiKeyEnv              = "ADMIN_API_KEY"
+	WorkspaceRefreshEnv         = "WORKSPACE_REFRESH"
+	ShortDomainRefreshEnv       = "SHORT_DOMAIN_REFRESH"
 
 	DatabaseUserEnv     = "DB_USER"
 	DatabasePasswordEnv = "DB_PASSWORD"
@@ -71,3 +71,634 @@ func (e *TokenNonExistingError) Is(target error) bool {
 }
 
 //endregion
+
+//region TokenExistingError
+
+// TokenExistingError is returned when attempting to create a mapping with a token that is already taken.
+type TokenExistingError struct {
+	Msg string
+}
+
+func (e *TokenExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *TokenExistingError) Is(target error) bool {
+	_, ok := target.(*TokenExistingError)
+	return ok
+}
+
+//endregion
+
+//region IdExistingError
+
+// IdExistingError is returned when attempting to create a mapping with an ID that is already taken,
+// which happens when the ID allocator handed out an ID twice.
+type IdExistingError struct {
+	Msg string
+}
+
+func (e *IdExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *IdExistingError) Is(target error) bool {
+	_, ok := target.(*IdExistingError)
+	return ok
+}
+
+//endregion
+
+//region InvalidAliasError
+
+// InvalidAliasError is returned when a custom alias has invalid format or is a reserved word.
+type InvalidAliasError struct {
+	Msg string
+}
+
+func (e *InvalidAliasError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidAliasError) Is(target error) bool {
+	_, ok := target.(*InvalidAliasError)
+	return ok
+}
+
+//endregion
+
+//region UrlExpiredError
+
+// UrlExpiredError is returned when a requested URL mapping exists but its lifetime has ended.
+type UrlExpiredError struct {
+	Msg string
+}
+
+func (e *UrlExpiredError) Error() string {
+	return e.Msg
+}
+
+func (e *UrlExpiredError) Is(target error) bool {
+	_, ok := target.(*UrlExpiredError)
+	return ok
+}
+
+//endregion
+
+//region InvalidExpirationError
+
+// InvalidExpirationError is returned when the requested expiration settings are invalid.
+type InvalidExpirationError struct {
+	Msg string
+}
+
+func (e *InvalidExpirationError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidExpirationError) Is(target error) bool {
+	_, ok := target.(*InvalidExpirationError)
+	return ok
+}
+
+//endregion
+
+//region ClickLimitReachedError
+
+// ClickLimitReachedError is returned when a URL mapping has consumed all of its allowed clicks.
+type ClickLimitReachedError struct {
+	Msg string
+}
+
+func (e *ClickLimitReachedError) Error() string {
+	return e.Msg
+}
+
+func (e *ClickLimitReachedError) Is(target error) bool {
+	_, ok := target.(*ClickLimitReachedError)
+	return ok
+}
+
+//endregion
+
+//region InvalidClickLimitError
+
+// InvalidClickLimitError is returned when the requested click limit is invalid.
+type InvalidClickLimitError struct {
+	Msg string
+}
+
+func (e *InvalidClickLimitError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidClickLimitError) Is(target error) bool {
+	_, ok := target.(*InvalidClickLimitError)
+	return ok
+}
+
+//endregion
+
+//region InvalidPasswordError
+
+// InvalidPasswordError is returned when the requested link password is invalid.
+type InvalidPasswordError struct {
+	Msg string
+}
+
+func (e *InvalidPasswordError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidPasswordError) Is(target error) bool {
+	_, ok := target.(*InvalidPasswordError)
+	return ok
+}
+
+//endregion
+
+//region PasswordRequiredError
+
+// PasswordRequiredError is returned when a URL mapping is password-protected and must be unlocked before redirecting.
+type PasswordRequiredError struct {
+	Msg string
+}
+
+func (e *PasswordRequiredError) Error() string {
+	return e.Msg
+}
+
+func (e *PasswordRequiredError) Is(target error) bool {
+	_, ok := target.(*PasswordRequiredError)
+	return ok
+}
+
+//endregion
+
+//region WrongPasswordError
+
+// WrongPasswordError is returned when the submitted password does not match the password of a URL mapping.
+type WrongPasswordError struct {
+	Msg string
+}
+
+func (e *WrongPasswordError) Error() string {
+	return e.Msg
+}
+
+func (e *WrongPasswordError) Is(target error) bool {
+	_, ok := target.(*WrongPasswordError)
+	return ok
+}
+
+//endregion
+
+//region TooManyAttemptsError
+
+// TooManyAttemptsError is returned when too many password attempts were made for a URL mapping.
+type TooManyAttemptsError struct {
+	Msg string
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return e.Msg
+}
+
+func (e *TooManyAttemptsError) Is(target error) bool {
+	_, ok := target.(*TooManyAttemptsError)
+	return ok
+}
+
+//endregion
+
+//region StorageUnavailableError
+
+// StorageUnavailableError is returned when a URL mapping cannot be resolved because the persistent storage failed.
+type StorageUnavailableError struct {
+	Msg string
+}
+
+func (e *StorageUnavailableError) Error() string {
+	return e.Msg
+}
+
+func (e *StorageUnavailableError) Is(target error) bool {
+	_, ok := target.(*StorageUnavailableError)
+	return ok
+}
+
+//endregion
+
+//region MalformedTokenError
+
+// MalformedTokenError is returned when a token cannot have been produced by the configured token encoder.
+type MalformedTokenError struct {
+	Msg string
+}
+
+func (e *MalformedTokenError) Error() string {
+	return e.Msg
+}
+
+func (e *MalformedTokenError) Is(target error) bool {
+	_, ok := target.(*MalformedTokenError)
+	return ok
+}
+
+//endregion
+
+//region WorkerLeaseLostError
+
+// WorkerLeaseLostError is returned when the lease of a Snowflake worker ID is no longer held by the instance.
+type WorkerLeaseLostError struct {
+	Msg string
+}
+
+func (e *WorkerLeaseLostError) Error() string {
+	return e.Msg
+}
+
+func (e *WorkerLeaseLostError) Is(target error) bool {
+	_, ok := target.(*WorkerLeaseLostError)
+	return ok
+}
+
+//endregion
+
+//region DestinationBlockedError
+
+// DestinationBlockedError is returned when the destination URL of a mapping is not allowed by the destination policy.
+type DestinationBlockedError struct {
+	Msg string
+}
+
+func (e *DestinationBlockedError) Error() string {
+	return e.Msg
+}
+
+func (e *DestinationBlockedError) Is(target error) bool {
+	_, ok := target.(*DestinationBlockedError)
+	return ok
+}
+
+//endregion
+
+//region InvalidDestinationRuleError
+
+// InvalidDestinationRuleError is returned when a destination policy rule has an unknown action or match type, or an invalid pattern.
+type InvalidDestinationRuleError struct {
+	Msg string
+}
+
+func (e *InvalidDestinationRuleError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidDestinationRuleError) Is(target error) bool {
+	_, ok := target.(*InvalidDestinationRuleError)
+	return ok
+}
+
+//endregion
+
+//region DestinationRuleExistingError
+
+// DestinationRuleExistingError is returned when an identical destination policy rule already exists.
+type DestinationRuleExistingError struct {
+	Msg string
+}
+
+func (e *DestinationRuleExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *DestinationRuleExistingError) Is(target error) bool {
+	_, ok := target.(*DestinationRuleExistingError)
+	return ok
+}
+
+//endregion
+
+//region DestinationRuleNonExistingError
+
+// DestinationRuleNonExistingError is returned when a destination policy rule does not exist.
+type DestinationRuleNonExistingError struct {
+	Msg string
+}
+
+func (e *DestinationRuleNonExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *DestinationRuleNonExistingError) Is(target error) bool {
+	_, ok := target.(*DestinationRuleNonExistingError)
+	return ok
+}
+
+//endregion
+
+//region MaliciousUrlError
+
+// MaliciousUrlError is returned when the reputation service reports a destination URL as malicious.
+type MaliciousUrlError struct {
+	Msg string
+}
+
+func (e *MaliciousUrlError) Error() string {
+	return e.Msg
+}
+
+func (e *MaliciousUrlError) Is(target error) bool {
+	_, ok := target.(*MaliciousUrlError)
+	return ok
+}
+
+//endregion
+
+//region UrlFlaggedError
+
+// UrlFlaggedError is returned when a short URL is flagged as malicious and redirects only after its warning is acknowledged.
+type UrlFlaggedError struct {
+	Msg string
+}
+
+func (e *UrlFlaggedError) Error() string {
+	return e.Msg
+}
+
+func (e *UrlFlaggedError) Is(target error) bool {
+	_, ok := target.(*UrlFlaggedError)
+	return ok
+}
+
+//endregion
+
+//region UrlDisabledError
+
+// UrlDisabledError is returned when a short URL was taken down and no longer redirects.
+type UrlDisabledError struct {
+	Msg string
+}
+
+func (e *UrlDisabledError) Error() string {
+	return e.Msg
+}
+
+func (e *UrlDisabledError) Is(target error) bool {
+	_, ok := target.(*UrlDisabledError)
+	return ok
+}
+
+//endregion
+
+//region InvalidAbuseReportError
+
+// InvalidAbuseReportError is returned when an abuse report or a takedown has no reason or exceeds the allowed length.
+type InvalidAbuseReportError struct {
+	Msg string
+}
+
+func (e *InvalidAbuseReportError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidAbuseReportError) Is(target error) bool {
+	_, ok := target.(*InvalidAbuseReportError)
+	return ok
+}
+
+//endregion
+
+//region AbuseReportNonExistingError
+
+// AbuseReportNonExistingError is returned when an abuse report does not exist or was already resolved.
+type AbuseReportNonExistingError struct {
+	Msg string
+}
+
+func (e *AbuseReportNonExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *AbuseReportNonExistingError) Is(target error) bool {
+	_, ok := target.(*AbuseReportNonExistingError)
+	return ok
+}
+
+//endregion
+
+//region InvalidApiKeyError
+
+// InvalidApiKeyError is returned when an API key to be created has a missing or too long name or unknown scopes.
+type InvalidApiKeyError struct {
+	Msg string
+}
+
+func (e *InvalidApiKeyError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidApiKeyError) Is(target error) bool {
+	_, ok := target.(*InvalidApiKeyError)
+	return ok
+}
+
+//endregion
+
+//region ApiKeyNonExistingError
+
+// ApiKeyNonExistingError is returned when an API key does not exist or was revoked.
+type ApiKeyNonExistingError struct {
+	Msg string
+}
+
+func (e *ApiKeyNonExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *ApiKeyNonExistingError) Is(target error) bool {
+	_, ok := target.(*ApiKeyNonExistingError)
+	return ok
+}
+
+//endregion
+
+//region UnauthorizedError
+
+// UnauthorizedError is returned when a request carries no valid API key.
+type UnauthorizedError struct {
+	Msg string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Msg
+}
+
+func (e *UnauthorizedError) Is(target error) bool {
+	_, ok := target.(*UnauthorizedError)
+	return ok
+}
+
+//endregion
+
+//region ForbiddenError
+
+// ForbiddenError is returned when the authenticated caller is not allowed to access a resource, e.g. a short URL owned by someone else.
+type ForbiddenError struct {
+	Msg string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Msg
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	_, ok := target.(*ForbiddenError)
+	return ok
+}
+
+//endregion
+
+//region InvalidWorkspaceError
+
+// InvalidWorkspaceError is returned when a workspace to be created or updated has a missing or too long name or negative quotas.
+type InvalidWorkspaceError struct {
+	Msg string
+}
+
+func (e *InvalidWorkspaceError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidWorkspaceError) Is(target error) bool {
+	_, ok := target.(*InvalidWorkspaceError)
+	return ok
+}
+
+//endregion
+
+//region WorkspaceNonExistingError
+
+// WorkspaceNonExistingError is returned when a workspace does not exist.
+type WorkspaceNonExistingError struct {
+	Msg string
+}
+
+func (e *WorkspaceNonExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *WorkspaceNonExistingError) Is(target error) bool {
+	_, ok := target.(*WorkspaceNonExistingError)
+	return ok
+}
+
+//endregion
+
+//region QuotaExceededError
+
+// QuotaExceededError is returned when a workspace has used up one of its quotas, e.g. its short URLs per month.
+type QuotaExceededError struct {
+	Msg string
+}
+
+func (e *QuotaExceededError) Error() string {
+	return e.Msg
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	_, ok := target.(*QuotaExceededError)
+	return ok
+}
+
+//endregion
+
+//region InvalidShortDomainError
+
+// InvalidShortDomainError is returned when the host name of a custom short domain is invalid.
+type InvalidShortDomainError struct {
+	Msg string
+}
+
+func (e *InvalidShortDomainError) Error() string {
+	return e.Msg
+}
+
+func (e *InvalidShortDomainError) Is(target error) bool {
+	_, ok := target.(*InvalidShortDomainError)
+	return ok
+}
+
+//endregion
+
+//region ShortDomainExistingError
+
+// ShortDomainExistingError is returned when a custom short domain is already registered.
+type ShortDomainExistingError struct {
+	Msg string
+}
+
+func (e *ShortDomainExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *ShortDomainExistingError) Is(target error) bool {
+	_, ok := target.(*ShortDomainExistingError)
+	return ok
+}
+
+//endregion
+
+//region ShortDomainNonExistingError
+
+// ShortDomainNonExistingError is returned when a custom short domain is not registered.
+type ShortDomainNonExistingError struct {
+	Msg string
+}
+
+func (e *ShortDomainNonExistingError) Error() string {
+	return e.Msg
+}
+
+func (e *ShortDomainNonExistingError) Is(target error) bool {
+	_, ok := target.(*ShortDomainNonExistingError)
+	return ok
+}
+
+//endregion
+
+//region ShortDomainInUseError
+
+// ShortDomainInUseError is returned when a custom short domain cannot be removed because short URLs still use it.
+type ShortDomainInUseError struct {
+	Msg string
+}
+
+func (e *ShortDomainInUseError) Error() string {
+	return e.Msg
+}
+
+func (e *ShortDomainInUseError) Is(target error) bool {
+	_, ok := target.(*ShortDomainInUseError)
+	return ok
+}
+
+//endregion
+
+//region UrlNotProtectedError
+
+// UrlNotProtectedError is returned when a password is submitted for a URL mapping that is not password-protected.
+type UrlNotProtectedError struct {
+	Msg string
+}
+
+func (e *UrlNotProtectedError) Error() string {
+	return e.Msg
+}
+
+func (e *UrlNotProtectedError) Is(target error) bool {
+	_, ok := target.(*UrlNotProtectedError)
+	return ok
+}
+
+//endregion
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxTTLSeconds is the longest relative lifetime a mapping can be given, ten years.
+// It keeps ttl_seconds well within the range of time.Duration.
+const MaxTTLSeconds = 10 * 365 * 24 * 60 * 60
+
+// ValidateExpiration checks that the optional expiration time lies in the future.
+// A nil expiresAt is always valid and means the mapping never expires.
+//
+// Returns *InvalidExpirationError if expiresAt is not after now.
+func ValidateExpiration(expiresAt *time.Time, now time.Time) error {
+	if expiresAt != nil && !expiresAt.After(now) {
+		return &InvalidExpirationError{Msg: fmt.Sprintf("Expiration time must be in the future: %s", expiresAt.Format(time.RFC3339))}
+	}
+
+	return nil
+}
+
+// ResolveExpiration combines an absolute expiration time and a relative lifetime in seconds
+// into a single absolute expiration time. At most one of them may be set;
+// a zero ttlSeconds means no relative lifetime was requested.
+//
+// Returns nil and no error if neither value is set.
+//
+// Returns *InvalidExpirationError if:
+//   - Both expiresAt and ttlSeconds are set
+//   - ttlSeconds is negative or above MaxTTLSeconds
+func ResolveExpiration(expiresAt *time.Time, ttlSeconds int64, now time.Time) (*time.Time, error) {
+	if ttlSeconds < 0 {
+		return nil, &InvalidExpirationError{Msg: fmt.Sprintf("TTL must be positive: %d", ttlSeconds)}
+	} else if ttlSeconds > MaxTTLSeconds {
+		return nil, &InvalidExpirationError{Msg: fmt.Sprintf("TTL must not exceed %d seconds: %d", MaxTTLSeconds, ttlSeconds)}
+	} else if ttlSeconds == 0 {
+		return expiresAt, nil
+	} else if expiresAt != nil {
+		return nil, &InvalidExpirationError{Msg: "Only one of expires_at and ttl_seconds can be set"}
+	}
+
+	resolved := now.Add(time.Duration(ttlSeconds) * time.Second)
+	return &resolved, nil
+}
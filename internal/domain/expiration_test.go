@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExpiration(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 24, 10, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	type testCase struct {
+		name        string
+		expiresAt   *time.Time
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name:        "no expiration",
+			expiresAt:   nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "future expiration",
+			expiresAt:   &future,
+			expectedErr: nil,
+		},
+		{
+			name:        "past expiration",
+			expiresAt:   &past,
+			expectedErr: &InvalidExpirationError{},
+		},
+		{
+			name:        "expiration equal to now",
+			expiresAt:   &now,
+			expectedErr: &InvalidExpirationError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateExpiration(tt.expiresAt, now)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResolveExpiration(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 24, 10, 0, 0, 0, time.UTC)
+	absolute := now.Add(24 * time.Hour)
+	relative := now.Add(90 * time.Second)
+	maxRelative := now.Add(MaxTTLSeconds * time.Second)
+
+	type testCase struct {
+		name        string
+		expiresAt   *time.Time
+		ttlSeconds  int64
+		expectedRes *time.Time
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name:        "nothing set",
+			expiresAt:   nil,
+			ttlSeconds:  0,
+			expectedRes: nil,
+		},
+		{
+			name:        "absolute expiration",
+			expiresAt:   &absolute,
+			ttlSeconds:  0,
+			expectedRes: &absolute,
+		},
+		{
+			name:        "relative expiration",
+			expiresAt:   nil,
+			ttlSeconds:  90,
+			expectedRes: &relative,
+		},
+		{
+			name:        "both set",
+			expiresAt:   &absolute,
+			ttlSeconds:  90,
+			expectedErr: &InvalidExpirationError{},
+		},
+		{
+			name:        "negative ttl",
+			expiresAt:   nil,
+			ttlSeconds:  -5,
+			expectedErr: &InvalidExpirationError{},
+		},
+		{
+			name:        "maximum ttl",
+			expiresAt:   nil,
+			ttlSeconds:  MaxTTLSeconds,
+			expectedRes: &maxRelative,
+		},
+		{
+			name:        "ttl above maximum",
+			expiresAt:   nil,
+			ttlSeconds:  MaxTTLSeconds + 1,
+			expectedErr: &InvalidExpirationError{},
+		},
+		{
+			name:        "ttl overflowing duration",
+			expiresAt:   nil,
+			ttlSeconds:  math.MaxInt64 / 1000,
+			expectedErr: &InvalidExpirationError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := ResolveExpiration(tt.expiresAt, tt.ttlSeconds, now)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRes, res)
+			}
+		})
+	}
+}
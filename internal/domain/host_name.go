@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// MaxHostLength is the maximum length of a host name in its ASCII form.
+const MaxHostLength = 253
+
+// hostProfile converts host names like idna.Lookup, the profile browsers use before resolving a host,
+// and additionally enforces the length limits of DNS names and their labels.
+var hostProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true))
+
+// ToASCIIHost converts an internationalized host name to its ASCII form, like browsers do before resolving it.
+// The host is mapped as defined by UTS #46, so look-alike full-width characters collapse into their
+// ASCII counterparts and upper case letters are lowercased, and every non-ASCII label is encoded with Punycode.
+// A trailing dot is removed. IP addresses are returned unchanged.
+//
+// Returns an error if the host contains an empty label, a disallowed character or a label that is too long,
+// or the host is longer than MaxHostLength.
+func ToASCIIHost(host string) (string, error) {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return host, nil
+	}
+
+	asciiHost, err := hostProfile.ToASCII(strings.TrimSuffix(host, "."))
+	if err != nil {
+		return "", fmt.Errorf("invalid host name %q: %w", host, err)
+	}
+
+	return asciiHost, nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToASCIIHost(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		host          string
+		expected      string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{name: "ASCII host", host: "Example.COM", expected: "example.com"},
+		{name: "trailing dot", host: "example.com.", expected: "example.com"},
+		{name: "internationalized host", host: "bücher.de", expected: "xn--bcher-kva.de"},
+		{name: "full-width characters", host: "ｅｘａｍｐｌｅ．ｃｏｍ", expected: "example.com"},
+		{name: "IPv6 address", host: "::1", expected: "::1"},
+		{name: "empty label", host: "example..com", expectedError: true},
+		{name: "disallowed character", host: "exa mple.com", expectedError: true},
+		{name: "too long label", host: strings.Repeat("a", 64) + ".com", expectedError: true},
+		{name: "too long host", host: strings.Repeat("a.", 127) + "com", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			asciiHost, err := ToASCIIHost(tt.host)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, asciiHost)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// IdGeneratorKind selects how mapping IDs are generated.
+type IdGeneratorKind string
+
+const (
+	// IdGeneratorRedis takes mapping IDs from a counter in Redis.
+	IdGeneratorRedis IdGeneratorKind = "redis"
+	// IdGeneratorSnowflake composes mapping IDs from a timestamp, the worker ID of the instance
+	// and a per-millisecond sequence, without a shared counter.
+	IdGeneratorSnowflake IdGeneratorKind = "snowflake"
+)
+
+const (
+	// SnowflakeWorkerBits is the number of bits of the worker ID in a Snowflake ID.
+	SnowflakeWorkerBits = 10
+	// SnowflakeSequenceBits is the number of bits of the per-millisecond sequence in a Snowflake ID.
+	SnowflakeSequenceBits = 12
+	// MaxSnowflakeWorkerId is the largest worker ID of a Snowflake ID.
+	MaxSnowflakeWorkerId = 1<<SnowflakeWorkerBits - 1
+)
+
+// IdAllocationSettings contains configuration parameters of the mapping ID allocation.
+type IdAllocationSettings struct {
+	// Generator selects how mapping IDs are generated.
+	Generator IdGeneratorKind
+	// LeaseSize is the number of IDs an instance reserves from the shared counter at once and hands out
+	// locally, so it keeps creating mappings during short counter outages. Zero disables leasing,
+	// so every ID is taken from the shared counter. Only supported by the Redis generator.
+	LeaseSize int
+	// ReseedGap is the number of IDs skipped when the shared counter is found behind the IDs already
+	// handed out and is re-seeded, so IDs leased or in flight on other instances are not handed out again.
+	ReseedGap int
+	// WorkerId is the Snowflake worker ID of the instance. A negative WorkerId leases a free worker ID
+	// from persistent storage instead.
+	WorkerId int
+	// WorkerLeaseTTL is the time a leased worker ID stays reserved without being renewed.
+	WorkerLeaseTTL time.Duration
+}
+
+// Validate checks that the ID allocation settings are consistent.
+//
+// Returns an error if:
+//   - The generator is unknown
+//   - LeaseSize or ReseedGap is negative
+//   - ReseedGap is smaller than LeaseSize
+//   - LeaseSize is set for the Snowflake generator
+//   - WorkerId is greater than MaxSnowflakeWorkerId
+//   - The worker ID is leased without a positive WorkerLeaseTTL
+func (s IdAllocationSettings) Validate() error {
+	if s.Generator != IdGeneratorRedis && s.Generator != IdGeneratorSnowflake {
+		return fmt.Errorf("unknown id generator: %q", s.Generator)
+	}
+	if s.LeaseSize < 0 {
+		return fmt.Errorf("id lease size must not be negative: %d", s.LeaseSize)
+	}
+	if s.ReseedGap < 0 {
+		return fmt.Errorf("id reseed gap must not be negative: %d", s.ReseedGap)
+	}
+	if s.ReseedGap < s.LeaseSize {
+		return fmt.Errorf("id reseed gap %d must not be smaller than the id lease size %d", s.ReseedGap, s.LeaseSize)
+	}
+	if s.Generator == IdGeneratorSnowflake && s.LeaseSize > 0 {
+		return fmt.Errorf("id leases are not supported by the snowflake id generator")
+	}
+	if s.WorkerId > MaxSnowflakeWorkerId {
+		return fmt.Errorf("worker id must not be greater than %d: %d", MaxSnowflakeWorkerId, s.WorkerId)
+	}
+	if s.Generator == IdGeneratorSnowflake && s.WorkerId < 0 && s.WorkerLeaseTTL <= 0 {
+		return fmt.Errorf("worker lease TTL must be positive: %s", s.WorkerLeaseTTL)
+	}
+
+	return nil
+}
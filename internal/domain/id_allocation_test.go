@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdAllocationSettings_Validate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		settings IdAllocationSettings
+		wantErr  bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "no leasing",
+			settings: IdAllocationSettings{Generator: IdGeneratorRedis, ReseedGap: 1000},
+			wantErr:  false,
+		},
+		{
+			name:     "leasing",
+			settings: IdAllocationSettings{Generator: IdGeneratorRedis, LeaseSize: 100, ReseedGap: 1000},
+			wantErr:  false,
+		},
+		{
+			name:     "unknown generator",
+			settings: IdAllocationSettings{Generator: "uuid"},
+			wantErr:  true,
+		},
+		{
+			name:     "negative lease size",
+			settings: IdAllocationSettings{Generator: IdGeneratorRedis, LeaseSize: -1, ReseedGap: 1000},
+			wantErr:  true,
+		},
+		{
+			name:     "negative reseed gap",
+			settings: IdAllocationSettings{Generator: IdGeneratorRedis, ReseedGap: -1},
+			wantErr:  true,
+		},
+		{
+			name:     "reseed gap smaller than lease size",
+			settings: IdAllocationSettings{Generator: IdGeneratorRedis, LeaseSize: 1000, ReseedGap: 100},
+			wantErr:  true,
+		},
+		{
+			name:     "snowflake with configured worker id",
+			settings: IdAllocationSettings{Generator: IdGeneratorSnowflake, WorkerId: 7},
+			wantErr:  false,
+		},
+		{
+			name:     "snowflake with leased worker id",
+			settings: IdAllocationSettings{Generator: IdGeneratorSnowflake, WorkerId: -1, WorkerLeaseTTL: 30 * time.Second},
+			wantErr:  false,
+		},
+		{
+			name:     "snowflake with leased worker id without ttl",
+			settings: IdAllocationSettings{Generator: IdGeneratorSnowflake, WorkerId: -1},
+			wantErr:  true,
+		},
+		{
+			name:     "snowflake with too large worker id",
+			settings: IdAllocationSettings{Generator: IdGeneratorSnowflake, WorkerId: MaxSnowflakeWorkerId + 1},
+			wantErr:  true,
+		},
+		{
+			name:     "snowflake with id leases",
+			settings: IdAllocationSettings{Generator: IdGeneratorSnowflake, LeaseSize: 100, ReseedGap: 1000},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.settings.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
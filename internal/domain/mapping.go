@@ -9,10 +9,82 @@ type MappingInfo struct {
 	Id int64 `json:"id"`
 	// OriginalURL is the full original URL that was shortened.
 	OriginalURL string `json:"original_url"`
-	// Token is the short unique string used to identify this mapping.
+	// Token is the short string used to identify this mapping, unique within its short domain.
 	Token string `json:"url_token"`
+	// Domain is the custom short domain the mapping is served at, empty for the default domain.
+	Domain string `json:"domain,omitempty"`
 	// CreatedAt is the timestamp when the mapping was created.
 	CreatedAt time.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the mapping was last modified.
 	UpdatedAt time.Time `json:"updated_at"`
+	// ExpiresAt is the moment after which the mapping no longer redirects.
+	// A nil ExpiresAt means the mapping never expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// MaxClicks is the number of redirects after which the mapping stops redirecting.
+	// A nil MaxClicks means the number of redirects is unlimited.
+	MaxClicks *int64 `json:"max_clicks,omitempty"`
+	// ClickCount is the number of consumed redirects of a click-limited mapping.
+	// Clicks of unlimited mappings are tracked by the statistics pipeline instead.
+	ClickCount int64 `json:"click_count,omitempty"`
+	// Protected reports whether the mapping requires a password before redirecting.
+	// It is kept alongside cached mappings, so protected links are never redirected straight from cache.
+	Protected bool `json:"protected,omitempty"`
+	// PasswordHash is the salted hash of the mapping password.
+	// It is never serialized, so it stays out of API responses and caches.
+	PasswordHash string `json:"-"`
+	// Threat is the kind of threat the reputation service reported for the original URL, e.g. "malware".
+	// An empty Threat means the mapping is not flagged. It is kept alongside cached mappings,
+	// so flagged links are never redirected straight from cache.
+	Threat string `json:"threat,omitempty"`
+	// DisabledReason is the reason the mapping was taken down for, e.g. a confirmed abuse report.
+	// An empty DisabledReason means the mapping is not taken down. It is kept alongside cached mappings,
+	// so taken down links are never redirected straight from cache.
+	DisabledReason string `json:"disabled_reason,omitempty"`
+	// OwnerId is the owner of the API key the mapping was created with.
+	// Only API keys of the same owner and admins may manage the mapping.
+	OwnerId string `json:"owner_id,omitempty"`
+	// WorkspaceId is the workspace of the API key the mapping was created with, 0 for mappings outside of workspaces.
+	// It is kept alongside cached mappings, so redirects are counted against the quota of the workspace.
+	WorkspaceId int64 `json:"workspace_id,omitempty"`
+}
+
+// MappingOwner identifies who may manage a mapping.
+type MappingOwner struct {
+	// WorkspaceId is the workspace of the mapping, 0 for mappings outside of workspaces.
+	WorkspaceId int64
+	// OwnerId is the owner of the mapping within its workspace, empty for mappings without owner.
+	OwnerId string
+}
+
+// ResolvedUrl is the destination a short URL redirects to.
+type ResolvedUrl struct {
+	// OriginalURL is the URL the short URL redirects to.
+	OriginalURL string
+	// WorkspaceId is the workspace of the short URL, 0 for short URLs outside of workspaces.
+	WorkspaceId int64
+}
+
+// Key returns the link key identifying the mapping across all short domains, see LinkKey.
+func (m MappingInfo) Key() string {
+	return LinkKey(m.Domain, m.Token)
+}
+
+// IsExpired reports whether the mapping has an expiration time that is not after now.
+func (m MappingInfo) IsExpired(now time.Time) bool {
+	return m.ExpiresAt != nil && !m.ExpiresAt.After(now)
+}
+
+// HasClickLimit reports whether the number of redirects of the mapping is limited.
+func (m MappingInfo) HasClickLimit() bool {
+	return m.MaxClicks != nil
+}
+
+// IsFlagged reports whether the reputation service flagged the original URL of the mapping.
+func (m MappingInfo) IsFlagged() bool {
+	return m.Threat != ""
+}
+
+// IsDisabled reports whether the mapping was taken down and no longer redirects.
+func (m MappingInfo) IsDisabled() bool {
+	return m.DisabledReason != ""
 }
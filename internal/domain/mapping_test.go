@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMappingInfo_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 24, 10, 0, 0, 0, time.UTC)
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	type testCase struct {
+		name        string
+		mapping     MappingInfo
+		expectedRes bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "no expiration",
+			mapping:     MappingInfo{Token: "abc"},
+			expectedRes: false,
+		},
+		{
+			name:        "expires in future",
+			mapping:     MappingInfo{Token: "abc", ExpiresAt: &future},
+			expectedRes: false,
+		},
+		{
+			name:        "expired in past",
+			mapping:     MappingInfo{Token: "abc", ExpiresAt: &past},
+			expectedRes: true,
+		},
+		{
+			name:        "expires exactly now",
+			mapping:     MappingInfo{Token: "abc", ExpiresAt: &now},
+			expectedRes: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expectedRes, tt.mapping.IsExpired(now))
+		})
+	}
+}
+
+func TestMappingInfo_HasClickLimit(t *testing.T) {
+	t.Parallel()
+
+	maxClicks := int64(1)
+
+	assert.False(t, MappingInfo{Token: "abc"}.HasClickLimit())
+	assert.True(t, MappingInfo{Token: "abc", MaxClicks: &maxClicks}.HasClickLimit())
+}
@@ -49,6 +49,43 @@ func (mr *MockUrlDeleterMockRecorder) DeleteUrl(ctx, urlToken interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUrl", reflect.TypeOf((*MockUrlDeleter)(nil).DeleteUrl), ctx, urlToken)
 }
 
+// MockUrlRestorer is a mock of UrlRestorer interface.
+type MockUrlRestorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUrlRestorerMockRecorder
+}
+
+// MockUrlRestorerMockRecorder is the mock recorder for MockUrlRestorer.
+type MockUrlRestorerMockRecorder struct {
+	mock *MockUrlRestorer
+}
+
+// NewMockUrlRestorer creates a new mock instance.
+func NewMockUrlRestorer(ctrl *gomock.Controller) *MockUrlRestorer {
+	mock := &MockUrlRestorer{ctrl: ctrl}
+	mock.recorder = &MockUrlRestorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUrlRestorer) EXPECT() *MockUrlRestorerMockRecorder {
+	return m.recorder
+}
+
+// RestoreUrl mocks base method.
+func (m *MockUrlRestorer) RestoreUrl(ctx context.Context, urlToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUrl", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUrl indicates an expected call of RestoreUrl.
+func (mr *MockUrlRestorerMockRecorder) RestoreUrl(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUrl", reflect.TypeOf((*MockUrlRestorer)(nil).RestoreUrl), ctx, urlToken)
+}
+
 // MockUrlGetter is a mock of UrlGetter interface.
 type MockUrlGetter struct {
 	ctrl     *gomock.Controller
@@ -73,10 +110,10 @@ func (m *MockUrlGetter) EXPECT() *MockUrlGetterMockRecorder {
 }
 
 // GetOriginalUrl mocks base method.
-func (m *MockUrlGetter) GetOriginalUrl(ctx context.Context, urlToken string) (string, error) {
+func (m *MockUrlGetter) GetOriginalUrl(ctx context.Context, urlToken string) (domain.ResolvedUrl, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOriginalUrl", ctx, urlToken)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(domain.ResolvedUrl)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -87,6 +124,133 @@ func (mr *MockUrlGetterMockRecorder) GetOriginalUrl(ctx, urlToken interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginalUrl", reflect.TypeOf((*MockUrlGetter)(nil).GetOriginalUrl), ctx, urlToken)
 }
 
+// MockFlaggedUrlGetter is a mock of FlaggedUrlGetter interface.
+type MockFlaggedUrlGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlaggedUrlGetterMockRecorder
+}
+
+// MockFlaggedUrlGetterMockRecorder is the mock recorder for MockFlaggedUrlGetter.
+type MockFlaggedUrlGetterMockRecorder struct {
+	mock *MockFlaggedUrlGetter
+}
+
+// NewMockFlaggedUrlGetter creates a new mock instance.
+func NewMockFlaggedUrlGetter(ctrl *gomock.Controller) *MockFlaggedUrlGetter {
+	mock := &MockFlaggedUrlGetter{ctrl: ctrl}
+	mock.recorder = &MockFlaggedUrlGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlaggedUrlGetter) EXPECT() *MockFlaggedUrlGetterMockRecorder {
+	return m.recorder
+}
+
+// GetFlaggedOriginalUrl mocks base method.
+func (m *MockFlaggedUrlGetter) GetFlaggedOriginalUrl(ctx context.Context, urlToken string) (domain.ResolvedUrl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlaggedOriginalUrl", ctx, urlToken)
+	ret0, _ := ret[0].(domain.ResolvedUrl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlaggedOriginalUrl indicates an expected call of GetFlaggedOriginalUrl.
+func (mr *MockFlaggedUrlGetterMockRecorder) GetFlaggedOriginalUrl(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlaggedOriginalUrl", reflect.TypeOf((*MockFlaggedUrlGetter)(nil).GetFlaggedOriginalUrl), ctx, urlToken)
+}
+
+// MockUrlUnlocker is a mock of UrlUnlocker interface.
+type MockUrlUnlocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockUrlUnlockerMockRecorder
+}
+
+// MockUrlUnlockerMockRecorder is the mock recorder for MockUrlUnlocker.
+type MockUrlUnlockerMockRecorder struct {
+	mock *MockUrlUnlocker
+}
+
+// NewMockUrlUnlocker creates a new mock instance.
+func NewMockUrlUnlocker(ctrl *gomock.Controller) *MockUrlUnlocker {
+	mock := &MockUrlUnlocker{ctrl: ctrl}
+	mock.recorder = &MockUrlUnlockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUrlUnlocker) EXPECT() *MockUrlUnlockerMockRecorder {
+	return m.recorder
+}
+
+// UnlockOriginalUrl mocks base method.
+func (m *MockUrlUnlocker) UnlockOriginalUrl(ctx context.Context, urlToken, password string) (domain.ResolvedUrl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockOriginalUrl", ctx, urlToken, password)
+	ret0, _ := ret[0].(domain.ResolvedUrl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockOriginalUrl indicates an expected call of UnlockOriginalUrl.
+func (mr *MockUrlUnlockerMockRecorder) UnlockOriginalUrl(ctx, urlToken, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockOriginalUrl", reflect.TypeOf((*MockUrlUnlocker)(nil).UnlockOriginalUrl), ctx, urlToken, password)
+}
+
+// MockTokenValidator is a mock of TokenValidator interface.
+type MockTokenValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenValidatorMockRecorder
+}
+
+// MockTokenValidatorMockRecorder is the mock recorder for MockTokenValidator.
+type MockTokenValidatorMockRecorder struct {
+	mock *MockTokenValidator
+}
+
+// NewMockTokenValidator creates a new mock instance.
+func NewMockTokenValidator(ctrl *gomock.Controller) *MockTokenValidator {
+	mock := &MockTokenValidator{ctrl: ctrl}
+	mock.recorder = &MockTokenValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenValidator) EXPECT() *MockTokenValidatorMockRecorder {
+	return m.recorder
+}
+
+// SuggestTokens mocks base method.
+func (m *MockTokenValidator) SuggestTokens(token string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestTokens", token)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// SuggestTokens indicates an expected call of SuggestTokens.
+func (mr *MockTokenValidatorMockRecorder) SuggestTokens(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestTokens", reflect.TypeOf((*MockTokenValidator)(nil).SuggestTokens), token)
+}
+
+// ValidateToken mocks base method.
+func (m *MockTokenValidator) ValidateToken(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateToken indicates an expected call of ValidateToken.
+func (mr *MockTokenValidatorMockRecorder) ValidateToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockTokenValidator)(nil).ValidateToken), token)
+}
+
 // MockUrlShortener is a mock of UrlShortener interface.
 type MockUrlShortener struct {
 	ctrl     *gomock.Controller
@@ -111,54 +275,874 @@ func (m *MockUrlShortener) EXPECT() *MockUrlShortenerMockRecorder {
 }
 
 // ShortenUrl mocks base method.
-func (m *MockUrlShortener) ShortenUrl(ctx context.Context, originalUrl string) (domain.MappingInfo, error) {
+func (m *MockUrlShortener) ShortenUrl(ctx context.Context, originalUrl string, opts domain.ShortenOptions) (domain.MappingInfo, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShortenUrl", ctx, originalUrl)
+	ret := m.ctrl.Call(m, "ShortenUrl", ctx, originalUrl, opts)
 	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ShortenUrl indicates an expected call of ShortenUrl.
+func (mr *MockUrlShortenerMockRecorder) ShortenUrl(ctx, originalUrl, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortenUrl", reflect.TypeOf((*MockUrlShortener)(nil).ShortenUrl), ctx, originalUrl, opts)
+}
+
+// MockUrlValidator is a mock of UrlValidator interface.
+type MockUrlValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockUrlValidatorMockRecorder
+}
+
+// MockUrlValidatorMockRecorder is the mock recorder for MockUrlValidator.
+type MockUrlValidatorMockRecorder struct {
+	mock *MockUrlValidator
+}
+
+// NewMockUrlValidator creates a new mock instance.
+func NewMockUrlValidator(ctrl *gomock.Controller) *MockUrlValidator {
+	mock := &MockUrlValidator{ctrl: ctrl}
+	mock.recorder = &MockUrlValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUrlValidator) EXPECT() *MockUrlValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateURL mocks base method.
+func (m *MockUrlValidator) ValidateURL(URL string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateURL", URL)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ShortenUrl indicates an expected call of ShortenUrl.
-func (mr *MockUrlShortenerMockRecorder) ShortenUrl(ctx, originalUrl interface{}) *gomock.Call {
+// ValidateURL indicates an expected call of ValidateURL.
+func (mr *MockUrlValidatorMockRecorder) ValidateURL(URL interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortenUrl", reflect.TypeOf((*MockUrlShortener)(nil).ShortenUrl), ctx, originalUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateURL", reflect.TypeOf((*MockUrlValidator)(nil).ValidateURL), URL)
 }
 
-// MockUrlUpdater is a mock of UrlUpdater interface.
-type MockUrlUpdater struct {
+// MockWorkspaceUrlValidator is a mock of WorkspaceUrlValidator interface.
+type MockWorkspaceUrlValidator struct {
 	ctrl     *gomock.Controller
-	recorder *MockUrlUpdaterMockRecorder
+	recorder *MockWorkspaceUrlValidatorMockRecorder
 }
 
-// MockUrlUpdaterMockRecorder is the mock recorder for MockUrlUpdater.
-type MockUrlUpdaterMockRecorder struct {
-	mock *MockUrlUpdater
+// MockWorkspaceUrlValidatorMockRecorder is the mock recorder for MockWorkspaceUrlValidator.
+type MockWorkspaceUrlValidatorMockRecorder struct {
+	mock *MockWorkspaceUrlValidator
 }
 
-// NewMockUrlUpdater creates a new mock instance.
-func NewMockUrlUpdater(ctrl *gomock.Controller) *MockUrlUpdater {
-	mock := &MockUrlUpdater{ctrl: ctrl}
-	mock.recorder = &MockUrlUpdaterMockRecorder{mock}
+// NewMockWorkspaceUrlValidator creates a new mock instance.
+func NewMockWorkspaceUrlValidator(ctrl *gomock.Controller) *MockWorkspaceUrlValidator {
+	mock := &MockWorkspaceUrlValidator{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceUrlValidatorMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUrlUpdater) EXPECT() *MockUrlUpdaterMockRecorder {
+func (m *MockWorkspaceUrlValidator) EXPECT() *MockWorkspaceUrlValidatorMockRecorder {
 	return m.recorder
 }
 
-// UpdateUrlMapping mocks base method.
-func (m *MockUrlUpdater) UpdateUrlMapping(ctx context.Context, urlToken, newOriginalUrl string) (domain.MappingInfo, error) {
+// ValidateWorkspaceURL mocks base method.
+func (m *MockWorkspaceUrlValidator) ValidateWorkspaceURL(workspaceId int64, URL string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUrlMapping", ctx, urlToken, newOriginalUrl)
-	ret0, _ := ret[0].(domain.MappingInfo)
+	ret := m.ctrl.Call(m, "ValidateWorkspaceURL", workspaceId, URL)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUrlMapping indicates an expected call of UpdateUrlMapping.
-func (mr *MockUrlUpdaterMockRecorder) UpdateUrlMapping(ctx, urlToken, newOriginalUrl interface{}) *gomock.Call {
+// ValidateWorkspaceURL indicates an expected call of ValidateWorkspaceURL.
+func (mr *MockWorkspaceUrlValidatorMockRecorder) ValidateWorkspaceURL(workspaceId, URL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateWorkspaceURL", reflect.TypeOf((*MockWorkspaceUrlValidator)(nil).ValidateWorkspaceURL), workspaceId, URL)
+}
+
+// MockLinkQuotaChecker is a mock of LinkQuotaChecker interface.
+type MockLinkQuotaChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkQuotaCheckerMockRecorder
+}
+
+// MockLinkQuotaCheckerMockRecorder is the mock recorder for MockLinkQuotaChecker.
+type MockLinkQuotaCheckerMockRecorder struct {
+	mock *MockLinkQuotaChecker
+}
+
+// NewMockLinkQuotaChecker creates a new mock instance.
+func NewMockLinkQuotaChecker(ctrl *gomock.Controller) *MockLinkQuotaChecker {
+	mock := &MockLinkQuotaChecker{ctrl: ctrl}
+	mock.recorder = &MockLinkQuotaCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkQuotaChecker) EXPECT() *MockLinkQuotaCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckLinkQuota mocks base method.
+func (m *MockLinkQuotaChecker) CheckLinkQuota(ctx context.Context, workspaceId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLinkQuota", ctx, workspaceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckLinkQuota indicates an expected call of CheckLinkQuota.
+func (mr *MockLinkQuotaCheckerMockRecorder) CheckLinkQuota(ctx, workspaceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLinkQuota", reflect.TypeOf((*MockLinkQuotaChecker)(nil).CheckLinkQuota), ctx, workspaceId)
+}
+
+// MockRedirectQuotaChecker is a mock of RedirectQuotaChecker interface.
+type MockRedirectQuotaChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedirectQuotaCheckerMockRecorder
+}
+
+// MockRedirectQuotaCheckerMockRecorder is the mock recorder for MockRedirectQuotaChecker.
+type MockRedirectQuotaCheckerMockRecorder struct {
+	mock *MockRedirectQuotaChecker
+}
+
+// NewMockRedirectQuotaChecker creates a new mock instance.
+func NewMockRedirectQuotaChecker(ctrl *gomock.Controller) *MockRedirectQuotaChecker {
+	mock := &MockRedirectQuotaChecker{ctrl: ctrl}
+	mock.recorder = &MockRedirectQuotaCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedirectQuotaChecker) EXPECT() *MockRedirectQuotaCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckRedirectQuota mocks base method.
+func (m *MockRedirectQuotaChecker) CheckRedirectQuota(ctx context.Context, workspaceId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRedirectQuota", ctx, workspaceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckRedirectQuota indicates an expected call of CheckRedirectQuota.
+func (mr *MockRedirectQuotaCheckerMockRecorder) CheckRedirectQuota(ctx, workspaceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRedirectQuota", reflect.TypeOf((*MockRedirectQuotaChecker)(nil).CheckRedirectQuota), ctx, workspaceId)
+}
+
+// MockWorkspaceManager is a mock of WorkspaceManager interface.
+type MockWorkspaceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceManagerMockRecorder
+}
+
+// MockWorkspaceManagerMockRecorder is the mock recorder for MockWorkspaceManager.
+type MockWorkspaceManagerMockRecorder struct {
+	mock *MockWorkspaceManager
+}
+
+// NewMockWorkspaceManager creates a new mock instance.
+func NewMockWorkspaceManager(ctrl *gomock.Controller) *MockWorkspaceManager {
+	mock := &MockWorkspaceManager{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceManager) EXPECT() *MockWorkspaceManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockWorkspaceManager) CreateWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", ctx, workspace)
+	ret0, _ := ret[0].(domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockWorkspaceManagerMockRecorder) CreateWorkspace(ctx, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockWorkspaceManager)(nil).CreateWorkspace), ctx, workspace)
+}
+
+// ListWorkspaces mocks base method.
+func (m *MockWorkspaceManager) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaces", ctx)
+	ret0, _ := ret[0].([]domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaces indicates an expected call of ListWorkspaces.
+func (mr *MockWorkspaceManagerMockRecorder) ListWorkspaces(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaces", reflect.TypeOf((*MockWorkspaceManager)(nil).ListWorkspaces), ctx)
+}
+
+// UpdateWorkspace mocks base method.
+func (m *MockWorkspaceManager) UpdateWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", ctx, workspace)
+	ret0, _ := ret[0].(domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockWorkspaceManagerMockRecorder) UpdateWorkspace(ctx, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockWorkspaceManager)(nil).UpdateWorkspace), ctx, workspace)
+}
+
+// MockShortDomainResolver is a mock of ShortDomainResolver interface.
+type MockShortDomainResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortDomainResolverMockRecorder
+}
+
+// MockShortDomainResolverMockRecorder is the mock recorder for MockShortDomainResolver.
+type MockShortDomainResolverMockRecorder struct {
+	mock *MockShortDomainResolver
+}
+
+// NewMockShortDomainResolver creates a new mock instance.
+func NewMockShortDomainResolver(ctrl *gomock.Controller) *MockShortDomainResolver {
+	mock := &MockShortDomainResolver{ctrl: ctrl}
+	mock.recorder = &MockShortDomainResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortDomainResolver) EXPECT() *MockShortDomainResolverMockRecorder {
+	return m.recorder
+}
+
+// ResolveShortDomain mocks base method.
+func (m *MockShortDomainResolver) ResolveShortDomain(host string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveShortDomain", host)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ResolveShortDomain indicates an expected call of ResolveShortDomain.
+func (mr *MockShortDomainResolverMockRecorder) ResolveShortDomain(host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveShortDomain", reflect.TypeOf((*MockShortDomainResolver)(nil).ResolveShortDomain), host)
+}
+
+// MockShortUrlFormatter is a mock of ShortUrlFormatter interface.
+type MockShortUrlFormatter struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortUrlFormatterMockRecorder
+}
+
+// MockShortUrlFormatterMockRecorder is the mock recorder for MockShortUrlFormatter.
+type MockShortUrlFormatterMockRecorder struct {
+	mock *MockShortUrlFormatter
+}
+
+// NewMockShortUrlFormatter creates a new mock instance.
+func NewMockShortUrlFormatter(ctrl *gomock.Controller) *MockShortUrlFormatter {
+	mock := &MockShortUrlFormatter{ctrl: ctrl}
+	mock.recorder = &MockShortUrlFormatterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortUrlFormatter) EXPECT() *MockShortUrlFormatterMockRecorder {
+	return m.recorder
+}
+
+// ShortUrl mocks base method.
+func (m *MockShortUrlFormatter) ShortUrl(mapping domain.MappingInfo) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortUrl", mapping)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ShortUrl indicates an expected call of ShortUrl.
+func (mr *MockShortUrlFormatterMockRecorder) ShortUrl(mapping interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortUrl", reflect.TypeOf((*MockShortUrlFormatter)(nil).ShortUrl), mapping)
+}
+
+// MockShortDomainAuthorizer is a mock of ShortDomainAuthorizer interface.
+type MockShortDomainAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortDomainAuthorizerMockRecorder
+}
+
+// MockShortDomainAuthorizerMockRecorder is the mock recorder for MockShortDomainAuthorizer.
+type MockShortDomainAuthorizerMockRecorder struct {
+	mock *MockShortDomainAuthorizer
+}
+
+// NewMockShortDomainAuthorizer creates a new mock instance.
+func NewMockShortDomainAuthorizer(ctrl *gomock.Controller) *MockShortDomainAuthorizer {
+	mock := &MockShortDomainAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockShortDomainAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortDomainAuthorizer) EXPECT() *MockShortDomainAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeShortDomain mocks base method.
+func (m *MockShortDomainAuthorizer) AuthorizeShortDomain(host string, workspaceId int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeShortDomain", host, workspaceId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeShortDomain indicates an expected call of AuthorizeShortDomain.
+func (mr *MockShortDomainAuthorizerMockRecorder) AuthorizeShortDomain(host, workspaceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeShortDomain", reflect.TypeOf((*MockShortDomainAuthorizer)(nil).AuthorizeShortDomain), host, workspaceId)
+}
+
+// MockShortDomainManager is a mock of ShortDomainManager interface.
+type MockShortDomainManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortDomainManagerMockRecorder
+}
+
+// MockShortDomainManagerMockRecorder is the mock recorder for MockShortDomainManager.
+type MockShortDomainManagerMockRecorder struct {
+	mock *MockShortDomainManager
+}
+
+// NewMockShortDomainManager creates a new mock instance.
+func NewMockShortDomainManager(ctrl *gomock.Controller) *MockShortDomainManager {
+	mock := &MockShortDomainManager{ctrl: ctrl}
+	mock.recorder = &MockShortDomainManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortDomainManager) EXPECT() *MockShortDomainManagerMockRecorder {
+	return m.recorder
+}
+
+// AddShortDomain mocks base method.
+func (m *MockShortDomainManager) AddShortDomain(ctx context.Context, shortDomain domain.ShortDomain) (domain.ShortDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShortDomain", ctx, shortDomain)
+	ret0, _ := ret[0].(domain.ShortDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddShortDomain indicates an expected call of AddShortDomain.
+func (mr *MockShortDomainManagerMockRecorder) AddShortDomain(ctx, shortDomain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShortDomain", reflect.TypeOf((*MockShortDomainManager)(nil).AddShortDomain), ctx, shortDomain)
+}
+
+// DeleteShortDomain mocks base method.
+func (m *MockShortDomainManager) DeleteShortDomain(ctx context.Context, host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShortDomain", ctx, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShortDomain indicates an expected call of DeleteShortDomain.
+func (mr *MockShortDomainManagerMockRecorder) DeleteShortDomain(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShortDomain", reflect.TypeOf((*MockShortDomainManager)(nil).DeleteShortDomain), ctx, host)
+}
+
+// ListShortDomains mocks base method.
+func (m *MockShortDomainManager) ListShortDomains(ctx context.Context) ([]domain.ShortDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShortDomains", ctx)
+	ret0, _ := ret[0].([]domain.ShortDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShortDomains indicates an expected call of ListShortDomains.
+func (mr *MockShortDomainManagerMockRecorder) ListShortDomains(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShortDomains", reflect.TypeOf((*MockShortDomainManager)(nil).ListShortDomains), ctx)
+}
+
+// MockURLScanner is a mock of URLScanner interface.
+type MockURLScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLScannerMockRecorder
+}
+
+// MockURLScannerMockRecorder is the mock recorder for MockURLScanner.
+type MockURLScannerMockRecorder struct {
+	mock *MockURLScanner
+}
+
+// NewMockURLScanner creates a new mock instance.
+func NewMockURLScanner(ctrl *gomock.Controller) *MockURLScanner {
+	mock := &MockURLScanner{ctrl: ctrl}
+	mock.recorder = &MockURLScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLScanner) EXPECT() *MockURLScannerMockRecorder {
+	return m.recorder
+}
+
+// ScanURL mocks base method.
+func (m *MockURLScanner) ScanURL(ctx context.Context, URL string) (domain.ScanVerdict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanURL", ctx, URL)
+	ret0, _ := ret[0].(domain.ScanVerdict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanURL indicates an expected call of ScanURL.
+func (mr *MockURLScannerMockRecorder) ScanURL(ctx, URL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanURL", reflect.TypeOf((*MockURLScanner)(nil).ScanURL), ctx, URL)
+}
+
+// MockDestinationRuleManager is a mock of DestinationRuleManager interface.
+type MockDestinationRuleManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockDestinationRuleManagerMockRecorder
+}
+
+// MockDestinationRuleManagerMockRecorder is the mock recorder for MockDestinationRuleManager.
+type MockDestinationRuleManagerMockRecorder struct {
+	mock *MockDestinationRuleManager
+}
+
+// NewMockDestinationRuleManager creates a new mock instance.
+func NewMockDestinationRuleManager(ctrl *gomock.Controller) *MockDestinationRuleManager {
+	mock := &MockDestinationRuleManager{ctrl: ctrl}
+	mock.recorder = &MockDestinationRuleManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDestinationRuleManager) EXPECT() *MockDestinationRuleManagerMockRecorder {
+	return m.recorder
+}
+
+// AddRule mocks base method.
+func (m *MockDestinationRuleManager) AddRule(ctx context.Context, rule domain.DestinationRule) (domain.DestinationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRule", ctx, rule)
+	ret0, _ := ret[0].(domain.DestinationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRule indicates an expected call of AddRule.
+func (mr *MockDestinationRuleManagerMockRecorder) AddRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRule", reflect.TypeOf((*MockDestinationRuleManager)(nil).AddRule), ctx, rule)
+}
+
+// DeleteRule mocks base method.
+func (m *MockDestinationRuleManager) DeleteRule(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockDestinationRuleManagerMockRecorder) DeleteRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockDestinationRuleManager)(nil).DeleteRule), ctx, id)
+}
+
+// ListRules mocks base method.
+func (m *MockDestinationRuleManager) ListRules(ctx context.Context) ([]domain.DestinationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx)
+	ret0, _ := ret[0].([]domain.DestinationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockDestinationRuleManagerMockRecorder) ListRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockDestinationRuleManager)(nil).ListRules), ctx)
+}
+
+// MockAbuseReporter is a mock of AbuseReporter interface.
+type MockAbuseReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbuseReporterMockRecorder
+}
+
+// MockAbuseReporterMockRecorder is the mock recorder for MockAbuseReporter.
+type MockAbuseReporterMockRecorder struct {
+	mock *MockAbuseReporter
+}
+
+// NewMockAbuseReporter creates a new mock instance.
+func NewMockAbuseReporter(ctrl *gomock.Controller) *MockAbuseReporter {
+	mock := &MockAbuseReporter{ctrl: ctrl}
+	mock.recorder = &MockAbuseReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbuseReporter) EXPECT() *MockAbuseReporterMockRecorder {
+	return m.recorder
+}
+
+// ReportAbuse mocks base method.
+func (m *MockAbuseReporter) ReportAbuse(ctx context.Context, report domain.AbuseReport) (domain.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportAbuse", ctx, report)
+	ret0, _ := ret[0].(domain.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportAbuse indicates an expected call of ReportAbuse.
+func (mr *MockAbuseReporterMockRecorder) ReportAbuse(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportAbuse", reflect.TypeOf((*MockAbuseReporter)(nil).ReportAbuse), ctx, report)
+}
+
+// MockAbuseModerator is a mock of AbuseModerator interface.
+type MockAbuseModerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbuseModeratorMockRecorder
+}
+
+// MockAbuseModeratorMockRecorder is the mock recorder for MockAbuseModerator.
+type MockAbuseModeratorMockRecorder struct {
+	mock *MockAbuseModerator
+}
+
+// NewMockAbuseModerator creates a new mock instance.
+func NewMockAbuseModerator(ctrl *gomock.Controller) *MockAbuseModerator {
+	mock := &MockAbuseModerator{ctrl: ctrl}
+	mock.recorder = &MockAbuseModeratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbuseModerator) EXPECT() *MockAbuseModeratorMockRecorder {
+	return m.recorder
+}
+
+// DismissReport mocks base method.
+func (m *MockAbuseModerator) DismissReport(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissReport", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissReport indicates an expected call of DismissReport.
+func (mr *MockAbuseModeratorMockRecorder) DismissReport(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReport", reflect.TypeOf((*MockAbuseModerator)(nil).DismissReport), ctx, id)
+}
+
+// ListReports mocks base method.
+func (m *MockAbuseModerator) ListReports(ctx context.Context, status domain.AbuseReportStatus) ([]domain.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReports", ctx, status)
+	ret0, _ := ret[0].([]domain.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReports indicates an expected call of ListReports.
+func (mr *MockAbuseModeratorMockRecorder) ListReports(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReports", reflect.TypeOf((*MockAbuseModerator)(nil).ListReports), ctx, status)
+}
+
+// Reinstate mocks base method.
+func (m *MockAbuseModerator) Reinstate(ctx context.Context, urlToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reinstate", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reinstate indicates an expected call of Reinstate.
+func (mr *MockAbuseModeratorMockRecorder) Reinstate(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reinstate", reflect.TypeOf((*MockAbuseModerator)(nil).Reinstate), ctx, urlToken)
+}
+
+// TakeDown mocks base method.
+func (m *MockAbuseModerator) TakeDown(ctx context.Context, urlToken, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeDown", ctx, urlToken, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeDown indicates an expected call of TakeDown.
+func (mr *MockAbuseModeratorMockRecorder) TakeDown(ctx, urlToken, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeDown", reflect.TypeOf((*MockAbuseModerator)(nil).TakeDown), ctx, urlToken, reason)
+}
+
+// MockApiKeyAuthenticator is a mock of ApiKeyAuthenticator interface.
+type MockApiKeyAuthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyAuthenticatorMockRecorder
+}
+
+// MockApiKeyAuthenticatorMockRecorder is the mock recorder for MockApiKeyAuthenticator.
+type MockApiKeyAuthenticatorMockRecorder struct {
+	mock *MockApiKeyAuthenticator
+}
+
+// NewMockApiKeyAuthenticator creates a new mock instance.
+func NewMockApiKeyAuthenticator(ctrl *gomock.Controller) *MockApiKeyAuthenticator {
+	mock := &MockApiKeyAuthenticator{ctrl: ctrl}
+	mock.recorder = &MockApiKeyAuthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyAuthenticator) EXPECT() *MockApiKeyAuthenticatorMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockApiKeyAuthenticator) Authenticate(ctx context.Context, key string) (domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, key)
+	ret0, _ := ret[0].(domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockApiKeyAuthenticatorMockRecorder) Authenticate(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockApiKeyAuthenticator)(nil).Authenticate), ctx, key)
+}
+
+// MockApiKeyManager is a mock of ApiKeyManager interface.
+type MockApiKeyManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyManagerMockRecorder
+}
+
+// MockApiKeyManagerMockRecorder is the mock recorder for MockApiKeyManager.
+type MockApiKeyManagerMockRecorder struct {
+	mock *MockApiKeyManager
+}
+
+// NewMockApiKeyManager creates a new mock instance.
+func NewMockApiKeyManager(ctrl *gomock.Controller) *MockApiKeyManager {
+	mock := &MockApiKeyManager{ctrl: ctrl}
+	mock.recorder = &MockApiKeyManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyManager) EXPECT() *MockApiKeyManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateKey mocks base method.
+func (m *MockApiKeyManager) CreateKey(ctx context.Context, key domain.ApiKey) (domain.ApiKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKey", ctx, key)
+	ret0, _ := ret[0].(domain.ApiKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockApiKeyManagerMockRecorder) CreateKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockApiKeyManager)(nil).CreateKey), ctx, key)
+}
+
+// ListKeys mocks base method.
+func (m *MockApiKeyManager) ListKeys(ctx context.Context) ([]domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys", ctx)
+	ret0, _ := ret[0].([]domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockApiKeyManagerMockRecorder) ListKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockApiKeyManager)(nil).ListKeys), ctx)
+}
+
+// RevokeKey mocks base method.
+func (m *MockApiKeyManager) RevokeKey(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockApiKeyManagerMockRecorder) RevokeKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockApiKeyManager)(nil).RevokeKey), ctx, id)
+}
+
+// MockMappingOwnershipAuthorizer is a mock of MappingOwnershipAuthorizer interface.
+type MockMappingOwnershipAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMappingOwnershipAuthorizerMockRecorder
+}
+
+// MockMappingOwnershipAuthorizerMockRecorder is the mock recorder for MockMappingOwnershipAuthorizer.
+type MockMappingOwnershipAuthorizerMockRecorder struct {
+	mock *MockMappingOwnershipAuthorizer
+}
+
+// NewMockMappingOwnershipAuthorizer creates a new mock instance.
+func NewMockMappingOwnershipAuthorizer(ctrl *gomock.Controller) *MockMappingOwnershipAuthorizer {
+	mock := &MockMappingOwnershipAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockMappingOwnershipAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMappingOwnershipAuthorizer) EXPECT() *MockMappingOwnershipAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeMappingOwner mocks base method.
+func (m *MockMappingOwnershipAuthorizer) AuthorizeMappingOwner(ctx context.Context, urlToken string) (domain.MappingOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeMappingOwner", ctx, urlToken)
+	ret0, _ := ret[0].(domain.MappingOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeMappingOwner indicates an expected call of AuthorizeMappingOwner.
+func (mr *MockMappingOwnershipAuthorizerMockRecorder) AuthorizeMappingOwner(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeMappingOwner", reflect.TypeOf((*MockMappingOwnershipAuthorizer)(nil).AuthorizeMappingOwner), ctx, urlToken)
+}
+
+// MockUrlUpdater is a mock of UrlUpdater interface.
+type MockUrlUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockUrlUpdaterMockRecorder
+}
+
+// MockUrlUpdaterMockRecorder is the mock recorder for MockUrlUpdater.
+type MockUrlUpdaterMockRecorder struct {
+	mock *MockUrlUpdater
+}
+
+// NewMockUrlUpdater creates a new mock instance.
+func NewMockUrlUpdater(ctrl *gomock.Controller) *MockUrlUpdater {
+	mock := &MockUrlUpdater{ctrl: ctrl}
+	mock.recorder = &MockUrlUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUrlUpdater) EXPECT() *MockUrlUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateUrlMapping mocks base method.
+func (m *MockUrlUpdater) UpdateUrlMapping(ctx context.Context, urlToken, newOriginalUrl string, opts domain.UpdateOptions) (domain.MappingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUrlMapping", ctx, urlToken, newOriginalUrl, opts)
+	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUrlMapping indicates an expected call of UpdateUrlMapping.
+func (mr *MockUrlUpdaterMockRecorder) UpdateUrlMapping(ctx, urlToken, newOriginalUrl, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUrlMapping", reflect.TypeOf((*MockUrlUpdater)(nil).UpdateUrlMapping), ctx, urlToken, newOriginalUrl, opts)
+}
+
+// MockUrlReader is a mock of UrlReader interface.
+type MockUrlReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockUrlReaderMockRecorder
+}
+
+// MockUrlReaderMockRecorder is the mock recorder for MockUrlReader.
+type MockUrlReaderMockRecorder struct {
+	mock *MockUrlReader
+}
+
+// NewMockUrlReader creates a new mock instance.
+func NewMockUrlReader(ctrl *gomock.Controller) *MockUrlReader {
+	mock := &MockUrlReader{ctrl: ctrl}
+	mock.recorder = &MockUrlReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUrlReader) EXPECT() *MockUrlReaderMockRecorder {
+	return m.recorder
+}
+
+// GetUrlMapping mocks base method.
+func (m *MockUrlReader) GetUrlMapping(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUrlMapping", ctx, urlToken)
+	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUrlMapping indicates an expected call of GetUrlMapping.
+func (mr *MockUrlReaderMockRecorder) GetUrlMapping(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUrlMapping", reflect.TypeOf((*MockUrlReader)(nil).GetUrlMapping), ctx, urlToken)
+}
+
+// ListUrlMappings mocks base method.
+func (m *MockUrlReader) ListUrlMappings(ctx context.Context, afterId int64, limit int) ([]domain.MappingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUrlMappings", ctx, afterId, limit)
+	ret0, _ := ret[0].([]domain.MappingInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUrlMappings indicates an expected call of ListUrlMappings.
+func (mr *MockUrlReaderMockRecorder) ListUrlMappings(ctx, afterId, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUrlMapping", reflect.TypeOf((*MockUrlUpdater)(nil).UpdateUrlMapping), ctx, urlToken, newOriginalUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUrlMappings", reflect.TypeOf((*MockUrlReader)(nil).ListUrlMappings), ctx, afterId, limit)
 }
@@ -125,6 +125,44 @@ func (mr *MockStatisticsCalculatorMockRecorder) CalculateStatistics(ctx, urlToke
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateStatistics", reflect.TypeOf((*MockStatisticsCalculator)(nil).CalculateStatistics), ctx, urlToken)
 }
 
+// MockWorkspaceStatisticsCalculator is a mock of WorkspaceStatisticsCalculator interface.
+type MockWorkspaceStatisticsCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceStatisticsCalculatorMockRecorder
+}
+
+// MockWorkspaceStatisticsCalculatorMockRecorder is the mock recorder for MockWorkspaceStatisticsCalculator.
+type MockWorkspaceStatisticsCalculatorMockRecorder struct {
+	mock *MockWorkspaceStatisticsCalculator
+}
+
+// NewMockWorkspaceStatisticsCalculator creates a new mock instance.
+func NewMockWorkspaceStatisticsCalculator(ctrl *gomock.Controller) *MockWorkspaceStatisticsCalculator {
+	mock := &MockWorkspaceStatisticsCalculator{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceStatisticsCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceStatisticsCalculator) EXPECT() *MockWorkspaceStatisticsCalculatorMockRecorder {
+	return m.recorder
+}
+
+// CalculateWorkspaceStatistics mocks base method.
+func (m *MockWorkspaceStatisticsCalculator) CalculateWorkspaceStatistics(ctx context.Context, workspaceId int64, urlToken string) (domain.CalculatedStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateWorkspaceStatistics", ctx, workspaceId, urlToken)
+	ret0, _ := ret[0].(domain.CalculatedStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateWorkspaceStatistics indicates an expected call of CalculateWorkspaceStatistics.
+func (mr *MockWorkspaceStatisticsCalculatorMockRecorder) CalculateWorkspaceStatistics(ctx, workspaceId, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateWorkspaceStatistics", reflect.TypeOf((*MockWorkspaceStatisticsCalculator)(nil).CalculateWorkspaceStatistics), ctx, workspaceId, urlToken)
+}
+
 // MockStatisticsSender is a mock of StatisticsSender interface.
 type MockStatisticsSender struct {
 	ctrl     *gomock.Controller
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: postgres_storage.go
+// Source: storage.go
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -37,33 +37,129 @@ func (m *MockMappedGetSetter) EXPECT() *MockMappedGetSetterMockRecorder {
 	return m.recorder
 }
 
-// GetOriginalUrl mocks base method.
-func (m *MockMappedGetSetter) GetOriginalUrl(ctx context.Context, shortUrl string) (string, bool) {
+// GetMapping mocks base method.
+func (m *MockMappedGetSetter) GetMapping(ctx context.Context, urlToken string) (domain.MappingInfo, domain.CacheLookup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOriginalUrl", ctx, shortUrl)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(bool)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetMapping", ctx, urlToken)
+	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(domain.CacheLookup)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMapping indicates an expected call of GetMapping.
+func (mr *MockMappedGetSetterMockRecorder) GetMapping(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMapping", reflect.TypeOf((*MockMappedGetSetter)(nil).GetMapping), ctx, urlToken)
+}
+
+// SetMapping mocks base method.
+func (m *MockMappedGetSetter) SetMapping(ctx context.Context, mapping domain.MappingInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMapping", ctx, mapping)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMapping indicates an expected call of SetMapping.
+func (mr *MockMappedGetSetterMockRecorder) SetMapping(ctx, mapping interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMapping", reflect.TypeOf((*MockMappedGetSetter)(nil).SetMapping), ctx, mapping)
+}
+
+// SetMissing mocks base method.
+func (m *MockMappedGetSetter) SetMissing(ctx context.Context, urlToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMissing", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMissing indicates an expected call of SetMissing.
+func (mr *MockMappedGetSetterMockRecorder) SetMissing(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMissing", reflect.TypeOf((*MockMappedGetSetter)(nil).SetMissing), ctx, urlToken)
+}
+
+// MockMappingCache is a mock of MappingCache interface.
+type MockMappingCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockMappingCacheMockRecorder
+}
+
+// MockMappingCacheMockRecorder is the mock recorder for MockMappingCache.
+type MockMappingCacheMockRecorder struct {
+	mock *MockMappingCache
+}
+
+// NewMockMappingCache creates a new mock instance.
+func NewMockMappingCache(ctrl *gomock.Controller) *MockMappingCache {
+	mock := &MockMappingCache{ctrl: ctrl}
+	mock.recorder = &MockMappingCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMappingCache) EXPECT() *MockMappingCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteMapping mocks base method.
+func (m *MockMappingCache) DeleteMapping(ctx context.Context, urlToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMapping", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMapping indicates an expected call of DeleteMapping.
+func (mr *MockMappingCacheMockRecorder) DeleteMapping(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMapping", reflect.TypeOf((*MockMappingCache)(nil).DeleteMapping), ctx, urlToken)
 }
 
-// GetOriginalUrl indicates an expected call of GetOriginalUrl.
-func (mr *MockMappedGetSetterMockRecorder) GetOriginalUrl(ctx, shortUrl interface{}) *gomock.Call {
+// GetMapping mocks base method.
+func (m *MockMappingCache) GetMapping(ctx context.Context, urlToken string) (domain.MappingInfo, domain.CacheLookup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMapping", ctx, urlToken)
+	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(domain.CacheLookup)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMapping indicates an expected call of GetMapping.
+func (mr *MockMappingCacheMockRecorder) GetMapping(ctx, urlToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginalUrl", reflect.TypeOf((*MockMappedGetSetter)(nil).GetOriginalUrl), ctx, shortUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMapping", reflect.TypeOf((*MockMappingCache)(nil).GetMapping), ctx, urlToken)
 }
 
 // SetMapping mocks base method.
-func (m *MockMappedGetSetter) SetMapping(ctx context.Context, originalUrl, urlToken string) error {
+func (m *MockMappingCache) SetMapping(ctx context.Context, mapping domain.MappingInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetMapping", ctx, originalUrl, urlToken)
+	ret := m.ctrl.Call(m, "SetMapping", ctx, mapping)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetMapping indicates an expected call of SetMapping.
-func (mr *MockMappedGetSetterMockRecorder) SetMapping(ctx, originalUrl, urlToken interface{}) *gomock.Call {
+func (mr *MockMappingCacheMockRecorder) SetMapping(ctx, mapping interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMapping", reflect.TypeOf((*MockMappingCache)(nil).SetMapping), ctx, mapping)
+}
+
+// SetMissing mocks base method.
+func (m *MockMappingCache) SetMissing(ctx context.Context, urlToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMissing", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMissing indicates an expected call of SetMissing.
+func (mr *MockMappingCacheMockRecorder) SetMissing(ctx, urlToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMapping", reflect.TypeOf((*MockMappedGetSetter)(nil).SetMapping), ctx, originalUrl, urlToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMissing", reflect.TypeOf((*MockMappingCache)(nil).SetMissing), ctx, urlToken)
 }
 
 // MockMappingInfoGetAdder is a mock of MappingInfoGetAdder interface.
@@ -90,26 +186,26 @@ func (m *MockMappingInfoGetAdder) EXPECT() *MockMappingInfoGetAdderMockRecorder
 }
 
 // AddNewMapping mocks base method.
-func (m *MockMappingInfoGetAdder) AddNewMapping(ctx context.Context, id int64, originalUrl, shortUrl string) (domain.MappingInfo, error) {
+func (m *MockMappingInfoGetAdder) AddNewMapping(ctx context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddNewMapping", ctx, id, originalUrl, shortUrl)
+	ret := m.ctrl.Call(m, "AddNewMapping", ctx, mapping)
 	ret0, _ := ret[0].(domain.MappingInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddNewMapping indicates an expected call of AddNewMapping.
-func (mr *MockMappingInfoGetAdderMockRecorder) AddNewMapping(ctx, id, originalUrl, shortUrl interface{}) *gomock.Call {
+func (mr *MockMappingInfoGetAdderMockRecorder) AddNewMapping(ctx, mapping interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewMapping", reflect.TypeOf((*MockMappingInfoGetAdder)(nil).AddNewMapping), ctx, id, originalUrl, shortUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewMapping", reflect.TypeOf((*MockMappingInfoGetAdder)(nil).AddNewMapping), ctx, mapping)
 }
 
 // GetMappingByToken mocks base method.
-func (m *MockMappingInfoGetAdder) GetMappingByToken(ctx context.Context, urlToken string) (domain.MappingInfo, bool) {
+func (m *MockMappingInfoGetAdder) GetMappingByToken(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMappingByToken", ctx, urlToken)
 	ret0, _ := ret[0].(domain.MappingInfo)
-	ret1, _ := ret[1].(bool)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
@@ -119,42 +215,133 @@ func (mr *MockMappingInfoGetAdderMockRecorder) GetMappingByToken(ctx, urlToken i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMappingByToken", reflect.TypeOf((*MockMappingInfoGetAdder)(nil).GetMappingByToken), ctx, urlToken)
 }
 
-// MockOriginalUrlGetter is a mock of OriginalUrlGetter interface.
-type MockOriginalUrlGetter struct {
+// MockMappingInfoGetLister is a mock of MappingInfoGetLister interface.
+type MockMappingInfoGetLister struct {
 	ctrl     *gomock.Controller
-	recorder *MockOriginalUrlGetterMockRecorder
+	recorder *MockMappingInfoGetListerMockRecorder
 }
 
-// MockOriginalUrlGetterMockRecorder is the mock recorder for MockOriginalUrlGetter.
-type MockOriginalUrlGetterMockRecorder struct {
-	mock *MockOriginalUrlGetter
+// MockMappingInfoGetListerMockRecorder is the mock recorder for MockMappingInfoGetLister.
+type MockMappingInfoGetListerMockRecorder struct {
+	mock *MockMappingInfoGetLister
 }
 
-// NewMockOriginalUrlGetter creates a new mock instance.
-func NewMockOriginalUrlGetter(ctrl *gomock.Controller) *MockOriginalUrlGetter {
-	mock := &MockOriginalUrlGetter{ctrl: ctrl}
-	mock.recorder = &MockOriginalUrlGetterMockRecorder{mock}
+// NewMockMappingInfoGetLister creates a new mock instance.
+func NewMockMappingInfoGetLister(ctrl *gomock.Controller) *MockMappingInfoGetLister {
+	mock := &MockMappingInfoGetLister{ctrl: ctrl}
+	mock.recorder = &MockMappingInfoGetListerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockOriginalUrlGetter) EXPECT() *MockOriginalUrlGetterMockRecorder {
+func (m *MockMappingInfoGetLister) EXPECT() *MockMappingInfoGetListerMockRecorder {
 	return m.recorder
 }
 
-// GetOriginalUrl mocks base method.
-func (m *MockOriginalUrlGetter) GetOriginalUrl(ctx context.Context, shortUrl string) (string, bool) {
+// GetMappingByToken mocks base method.
+func (m *MockMappingInfoGetLister) GetMappingByToken(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOriginalUrl", ctx, shortUrl)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(bool)
+	ret := m.ctrl.Call(m, "GetMappingByToken", ctx, urlToken)
+	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMappingByToken indicates an expected call of GetMappingByToken.
+func (mr *MockMappingInfoGetListerMockRecorder) GetMappingByToken(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMappingByToken", reflect.TypeOf((*MockMappingInfoGetLister)(nil).GetMappingByToken), ctx, urlToken)
+}
+
+// ListOwnedMappings mocks base method.
+func (m *MockMappingInfoGetLister) ListOwnedMappings(ctx context.Context, owner domain.MappingOwner, afterId int64, limit int) ([]domain.MappingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnedMappings", ctx, owner, afterId, limit)
+	ret0, _ := ret[0].([]domain.MappingInfo)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOriginalUrl indicates an expected call of GetOriginalUrl.
-func (mr *MockOriginalUrlGetterMockRecorder) GetOriginalUrl(ctx, shortUrl interface{}) *gomock.Call {
+// ListOwnedMappings indicates an expected call of ListOwnedMappings.
+func (mr *MockMappingInfoGetListerMockRecorder) ListOwnedMappings(ctx, owner, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnedMappings", reflect.TypeOf((*MockMappingInfoGetLister)(nil).ListOwnedMappings), ctx, owner, afterId, limit)
+}
+
+// MockCachedMappingGetter is a mock of CachedMappingGetter interface.
+type MockCachedMappingGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCachedMappingGetterMockRecorder
+}
+
+// MockCachedMappingGetterMockRecorder is the mock recorder for MockCachedMappingGetter.
+type MockCachedMappingGetterMockRecorder struct {
+	mock *MockCachedMappingGetter
+}
+
+// NewMockCachedMappingGetter creates a new mock instance.
+func NewMockCachedMappingGetter(ctrl *gomock.Controller) *MockCachedMappingGetter {
+	mock := &MockCachedMappingGetter{ctrl: ctrl}
+	mock.recorder = &MockCachedMappingGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCachedMappingGetter) EXPECT() *MockCachedMappingGetterMockRecorder {
+	return m.recorder
+}
+
+// GetMapping mocks base method.
+func (m *MockCachedMappingGetter) GetMapping(ctx context.Context, urlToken string) (domain.MappingInfo, domain.CacheLookup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMapping", ctx, urlToken)
+	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(domain.CacheLookup)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMapping indicates an expected call of GetMapping.
+func (mr *MockCachedMappingGetterMockRecorder) GetMapping(ctx, urlToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginalUrl", reflect.TypeOf((*MockOriginalUrlGetter)(nil).GetOriginalUrl), ctx, shortUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMapping", reflect.TypeOf((*MockCachedMappingGetter)(nil).GetMapping), ctx, urlToken)
+}
+
+// MockMissingMappingSetter is a mock of MissingMappingSetter interface.
+type MockMissingMappingSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMissingMappingSetterMockRecorder
+}
+
+// MockMissingMappingSetterMockRecorder is the mock recorder for MockMissingMappingSetter.
+type MockMissingMappingSetterMockRecorder struct {
+	mock *MockMissingMappingSetter
+}
+
+// NewMockMissingMappingSetter creates a new mock instance.
+func NewMockMissingMappingSetter(ctrl *gomock.Controller) *MockMissingMappingSetter {
+	mock := &MockMissingMappingSetter{ctrl: ctrl}
+	mock.recorder = &MockMissingMappingSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMissingMappingSetter) EXPECT() *MockMissingMappingSetterMockRecorder {
+	return m.recorder
+}
+
+// SetMissing mocks base method.
+func (m *MockMissingMappingSetter) SetMissing(ctx context.Context, urlToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMissing", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMissing indicates an expected call of SetMissing.
+func (mr *MockMissingMappingSetterMockRecorder) SetMissing(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMissing", reflect.TypeOf((*MockMissingMappingSetter)(nil).SetMissing), ctx, urlToken)
 }
 
 // MockUrlTokenSetter is a mock of UrlTokenSetter interface.
@@ -181,17 +368,17 @@ func (m *MockUrlTokenSetter) EXPECT() *MockUrlTokenSetterMockRecorder {
 }
 
 // SetMapping mocks base method.
-func (m *MockUrlTokenSetter) SetMapping(ctx context.Context, originalUrl, urlToken string) error {
+func (m *MockUrlTokenSetter) SetMapping(ctx context.Context, mapping domain.MappingInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetMapping", ctx, originalUrl, urlToken)
+	ret := m.ctrl.Call(m, "SetMapping", ctx, mapping)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetMapping indicates an expected call of SetMapping.
-func (mr *MockUrlTokenSetterMockRecorder) SetMapping(ctx, originalUrl, urlToken interface{}) *gomock.Call {
+func (mr *MockUrlTokenSetterMockRecorder) SetMapping(ctx, mapping interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMapping", reflect.TypeOf((*MockUrlTokenSetter)(nil).SetMapping), ctx, originalUrl, urlToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMapping", reflect.TypeOf((*MockUrlTokenSetter)(nil).SetMapping), ctx, mapping)
 }
 
 // MockUrlTokenDeleter is a mock of UrlTokenDeleter interface.
@@ -255,11 +442,11 @@ func (m *MockMappingInfoGetter) EXPECT() *MockMappingInfoGetterMockRecorder {
 }
 
 // GetMappingByToken mocks base method.
-func (m *MockMappingInfoGetter) GetMappingByToken(ctx context.Context, urlToken string) (domain.MappingInfo, bool) {
+func (m *MockMappingInfoGetter) GetMappingByToken(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMappingByToken", ctx, urlToken)
 	ret0, _ := ret[0].(domain.MappingInfo)
-	ret1, _ := ret[1].(bool)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
@@ -331,18 +518,133 @@ func (m *MockMappingInfoAdder) EXPECT() *MockMappingInfoAdderMockRecorder {
 }
 
 // AddNewMapping mocks base method.
-func (m *MockMappingInfoAdder) AddNewMapping(ctx context.Context, id int64, originalUrl, shortUrl string) (domain.MappingInfo, error) {
+func (m *MockMappingInfoAdder) AddNewMapping(ctx context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddNewMapping", ctx, id, originalUrl, shortUrl)
+	ret := m.ctrl.Call(m, "AddNewMapping", ctx, mapping)
 	ret0, _ := ret[0].(domain.MappingInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddNewMapping indicates an expected call of AddNewMapping.
-func (mr *MockMappingInfoAdderMockRecorder) AddNewMapping(ctx, id, originalUrl, shortUrl interface{}) *gomock.Call {
+func (mr *MockMappingInfoAdderMockRecorder) AddNewMapping(ctx, mapping interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewMapping", reflect.TypeOf((*MockMappingInfoAdder)(nil).AddNewMapping), ctx, mapping)
+}
+
+// MockReusableMappingFinder is a mock of ReusableMappingFinder interface.
+type MockReusableMappingFinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReusableMappingFinderMockRecorder
+}
+
+// MockReusableMappingFinderMockRecorder is the mock recorder for MockReusableMappingFinder.
+type MockReusableMappingFinderMockRecorder struct {
+	mock *MockReusableMappingFinder
+}
+
+// NewMockReusableMappingFinder creates a new mock instance.
+func NewMockReusableMappingFinder(ctrl *gomock.Controller) *MockReusableMappingFinder {
+	mock := &MockReusableMappingFinder{ctrl: ctrl}
+	mock.recorder = &MockReusableMappingFinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReusableMappingFinder) EXPECT() *MockReusableMappingFinderMockRecorder {
+	return m.recorder
+}
+
+// FindReusableMapping mocks base method.
+func (m *MockReusableMappingFinder) FindReusableMapping(ctx context.Context, originalUrl string, owner domain.MappingOwner, shortDomain string) (domain.MappingInfo, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReusableMapping", ctx, originalUrl, owner, shortDomain)
+	ret0, _ := ret[0].(domain.MappingInfo)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindReusableMapping indicates an expected call of FindReusableMapping.
+func (mr *MockReusableMappingFinderMockRecorder) FindReusableMapping(ctx, originalUrl, owner, shortDomain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReusableMapping", reflect.TypeOf((*MockReusableMappingFinder)(nil).FindReusableMapping), ctx, originalUrl, owner, shortDomain)
+}
+
+// MockMappingOwnerGetter is a mock of MappingOwnerGetter interface.
+type MockMappingOwnerGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMappingOwnerGetterMockRecorder
+}
+
+// MockMappingOwnerGetterMockRecorder is the mock recorder for MockMappingOwnerGetter.
+type MockMappingOwnerGetterMockRecorder struct {
+	mock *MockMappingOwnerGetter
+}
+
+// NewMockMappingOwnerGetter creates a new mock instance.
+func NewMockMappingOwnerGetter(ctrl *gomock.Controller) *MockMappingOwnerGetter {
+	mock := &MockMappingOwnerGetter{ctrl: ctrl}
+	mock.recorder = &MockMappingOwnerGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMappingOwnerGetter) EXPECT() *MockMappingOwnerGetterMockRecorder {
+	return m.recorder
+}
+
+// GetMappingOwner mocks base method.
+func (m *MockMappingOwnerGetter) GetMappingOwner(ctx context.Context, urlToken string) (domain.MappingOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMappingOwner", ctx, urlToken)
+	ret0, _ := ret[0].(domain.MappingOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMappingOwner indicates an expected call of GetMappingOwner.
+func (mr *MockMappingOwnerGetterMockRecorder) GetMappingOwner(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMappingOwner", reflect.TypeOf((*MockMappingOwnerGetter)(nil).GetMappingOwner), ctx, urlToken)
+}
+
+// MockMappingCreationCounter is a mock of MappingCreationCounter interface.
+type MockMappingCreationCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMappingCreationCounterMockRecorder
+}
+
+// MockMappingCreationCounterMockRecorder is the mock recorder for MockMappingCreationCounter.
+type MockMappingCreationCounterMockRecorder struct {
+	mock *MockMappingCreationCounter
+}
+
+// NewMockMappingCreationCounter creates a new mock instance.
+func NewMockMappingCreationCounter(ctrl *gomock.Controller) *MockMappingCreationCounter {
+	mock := &MockMappingCreationCounter{ctrl: ctrl}
+	mock.recorder = &MockMappingCreationCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMappingCreationCounter) EXPECT() *MockMappingCreationCounterMockRecorder {
+	return m.recorder
+}
+
+// CountMappingsCreatedSince mocks base method.
+func (m *MockMappingCreationCounter) CountMappingsCreatedSince(ctx context.Context, workspaceId int64, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMappingsCreatedSince", ctx, workspaceId, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMappingsCreatedSince indicates an expected call of CountMappingsCreatedSince.
+func (mr *MockMappingCreationCounterMockRecorder) CountMappingsCreatedSince(ctx, workspaceId, since interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewMapping", reflect.TypeOf((*MockMappingInfoAdder)(nil).AddNewMapping), ctx, id, originalUrl, shortUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMappingsCreatedSince", reflect.TypeOf((*MockMappingCreationCounter)(nil).CountMappingsCreatedSince), ctx, workspaceId, since)
 }
 
 // MockMappingInfoUpdater is a mock of MappingInfoUpdater interface.
@@ -369,18 +671,18 @@ func (m *MockMappingInfoUpdater) EXPECT() *MockMappingInfoUpdaterMockRecorder {
 }
 
 // UpdateOriginalUrl mocks base method.
-func (m *MockMappingInfoUpdater) UpdateOriginalUrl(ctx context.Context, urlToken, newOriginalUrl string) (domain.MappingInfo, error) {
+func (m *MockMappingInfoUpdater) UpdateOriginalUrl(ctx context.Context, urlToken, newOriginalUrl string, opts domain.UpdateOptions) (domain.MappingInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOriginalUrl", ctx, urlToken, newOriginalUrl)
+	ret := m.ctrl.Call(m, "UpdateOriginalUrl", ctx, urlToken, newOriginalUrl, opts)
 	ret0, _ := ret[0].(domain.MappingInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOriginalUrl indicates an expected call of UpdateOriginalUrl.
-func (mr *MockMappingInfoUpdaterMockRecorder) UpdateOriginalUrl(ctx, urlToken, newOriginalUrl interface{}) *gomock.Call {
+func (mr *MockMappingInfoUpdaterMockRecorder) UpdateOriginalUrl(ctx, urlToken, newOriginalUrl, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOriginalUrl", reflect.TypeOf((*MockMappingInfoUpdater)(nil).UpdateOriginalUrl), ctx, urlToken, newOriginalUrl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOriginalUrl", reflect.TypeOf((*MockMappingInfoUpdater)(nil).UpdateOriginalUrl), ctx, urlToken, newOriginalUrl, opts)
 }
 
 // MockMappingInfoDeleter is a mock of MappingInfoDeleter interface.
@@ -420,153 +722,1065 @@ func (mr *MockMappingInfoDeleterMockRecorder) DeleteMappingInfo(ctx, urlToken in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMappingInfo", reflect.TypeOf((*MockMappingInfoDeleter)(nil).DeleteMappingInfo), ctx, urlToken)
 }
 
-// MockIdGenerator is a mock of IdGenerator interface.
-type MockIdGenerator struct {
+// MockMappingInfoRestorer is a mock of MappingInfoRestorer interface.
+type MockMappingInfoRestorer struct {
 	ctrl     *gomock.Controller
-	recorder *MockIdGeneratorMockRecorder
+	recorder *MockMappingInfoRestorerMockRecorder
 }
 
-// MockIdGeneratorMockRecorder is the mock recorder for MockIdGenerator.
-type MockIdGeneratorMockRecorder struct {
-	mock *MockIdGenerator
+// MockMappingInfoRestorerMockRecorder is the mock recorder for MockMappingInfoRestorer.
+type MockMappingInfoRestorerMockRecorder struct {
+	mock *MockMappingInfoRestorer
 }
 
-// NewMockIdGenerator creates a new mock instance.
-func NewMockIdGenerator(ctrl *gomock.Controller) *MockIdGenerator {
-	mock := &MockIdGenerator{ctrl: ctrl}
-	mock.recorder = &MockIdGeneratorMockRecorder{mock}
+// NewMockMappingInfoRestorer creates a new mock instance.
+func NewMockMappingInfoRestorer(ctrl *gomock.Controller) *MockMappingInfoRestorer {
+	mock := &MockMappingInfoRestorer{ctrl: ctrl}
+	mock.recorder = &MockMappingInfoRestorerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIdGenerator) EXPECT() *MockIdGeneratorMockRecorder {
+func (m *MockMappingInfoRestorer) EXPECT() *MockMappingInfoRestorerMockRecorder {
 	return m.recorder
 }
 
-// GetNextId mocks base method.
-func (m *MockIdGenerator) GetNextId(ctx context.Context) (int64, error) {
+// RestoreMappingInfo mocks base method.
+func (m *MockMappingInfoRestorer) RestoreMappingInfo(ctx context.Context, urlToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNextId", ctx)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RestoreMappingInfo", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetNextId indicates an expected call of GetNextId.
-func (mr *MockIdGeneratorMockRecorder) GetNextId(ctx interface{}) *gomock.Call {
+// RestoreMappingInfo indicates an expected call of RestoreMappingInfo.
+func (mr *MockMappingInfoRestorerMockRecorder) RestoreMappingInfo(ctx, urlToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextId", reflect.TypeOf((*MockIdGenerator)(nil).GetNextId), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMappingInfo", reflect.TypeOf((*MockMappingInfoRestorer)(nil).RestoreMappingInfo), ctx, urlToken)
 }
 
-// MockKeyStorage is a mock of KeyStorage interface.
-type MockKeyStorage struct {
+// MockDeletedMappingPurger is a mock of DeletedMappingPurger interface.
+type MockDeletedMappingPurger struct {
 	ctrl     *gomock.Controller
-	recorder *MockKeyStorageMockRecorder
+	recorder *MockDeletedMappingPurgerMockRecorder
 }
 
-// MockKeyStorageMockRecorder is the mock recorder for MockKeyStorage.
-type MockKeyStorageMockRecorder struct {
-	mock *MockKeyStorage
+// MockDeletedMappingPurgerMockRecorder is the mock recorder for MockDeletedMappingPurger.
+type MockDeletedMappingPurgerMockRecorder struct {
+	mock *MockDeletedMappingPurger
 }
 
-// NewMockKeyStorage creates a new mock instance.
-func NewMockKeyStorage(ctrl *gomock.Controller) *MockKeyStorage {
-	mock := &MockKeyStorage{ctrl: ctrl}
-	mock.recorder = &MockKeyStorageMockRecorder{mock}
+// NewMockDeletedMappingPurger creates a new mock instance.
+func NewMockDeletedMappingPurger(ctrl *gomock.Controller) *MockDeletedMappingPurger {
+	mock := &MockDeletedMappingPurger{ctrl: ctrl}
+	mock.recorder = &MockDeletedMappingPurgerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockKeyStorage) EXPECT() *MockKeyStorageMockRecorder {
+func (m *MockDeletedMappingPurger) EXPECT() *MockDeletedMappingPurgerMockRecorder {
 	return m.recorder
 }
 
-// Del mocks base method.
-func (m *MockKeyStorage) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+// PurgeDeletedMappings mocks base method.
+func (m *MockDeletedMappingPurger) PurgeDeletedMappings(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx}
-	for _, a := range keys {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "Del", varargs...)
-	ret0, _ := ret[0].(*redis.IntCmd)
-	return ret0
+	ret := m.ctrl.Call(m, "PurgeDeletedMappings", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Del indicates an expected call of Del.
-func (mr *MockKeyStorageMockRecorder) Del(ctx interface{}, keys ...interface{}) *gomock.Call {
+// PurgeDeletedMappings indicates an expected call of PurgeDeletedMappings.
+func (mr *MockDeletedMappingPurgerMockRecorder) PurgeDeletedMappings(ctx, deletedBefore, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx}, keys...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockKeyStorage)(nil).Del), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedMappings", reflect.TypeOf((*MockDeletedMappingPurger)(nil).PurgeDeletedMappings), ctx, deletedBefore, limit)
 }
 
-// Get mocks base method.
-func (m *MockKeyStorage) Get(ctx context.Context, key string) *redis.StringCmd {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, key)
-	ret0, _ := ret[0].(*redis.StringCmd)
-	return ret0
+// MockOwnedMappingLister is a mock of OwnedMappingLister interface.
+type MockOwnedMappingLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockOwnedMappingListerMockRecorder
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockKeyStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeyStorage)(nil).Get), ctx, key)
+// MockOwnedMappingListerMockRecorder is the mock recorder for MockOwnedMappingLister.
+type MockOwnedMappingListerMockRecorder struct {
+	mock *MockOwnedMappingLister
 }
 
-// Set mocks base method.
-func (m *MockKeyStorage) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+// NewMockOwnedMappingLister creates a new mock instance.
+func NewMockOwnedMappingLister(ctrl *gomock.Controller) *MockOwnedMappingLister {
+	mock := &MockOwnedMappingLister{ctrl: ctrl}
+	mock.recorder = &MockOwnedMappingListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOwnedMappingLister) EXPECT() *MockOwnedMappingListerMockRecorder {
+	return m.recorder
+}
+
+// ListOwnedMappings mocks base method.
+func (m *MockOwnedMappingLister) ListOwnedMappings(ctx context.Context, owner domain.MappingOwner, afterId int64, limit int) ([]domain.MappingInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Set", ctx, key, value, expiration)
-	ret0, _ := ret[0].(*redis.StatusCmd)
-	return ret0
+	ret := m.ctrl.Call(m, "ListOwnedMappings", ctx, owner, afterId, limit)
+	ret0, _ := ret[0].([]domain.MappingInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Set indicates an expected call of Set.
-func (mr *MockKeyStorageMockRecorder) Set(ctx, key, value, expiration interface{}) *gomock.Call {
+// ListOwnedMappings indicates an expected call of ListOwnedMappings.
+func (mr *MockOwnedMappingListerMockRecorder) ListOwnedMappings(ctx, owner, afterId, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockKeyStorage)(nil).Set), ctx, key, value, expiration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnedMappings", reflect.TypeOf((*MockOwnedMappingLister)(nil).ListOwnedMappings), ctx, owner, afterId, limit)
 }
 
-// MockKeySetIncrementer is a mock of KeySetIncrementer interface.
-type MockKeySetIncrementer struct {
+// MockMappingScanStore is a mock of MappingScanStore interface.
+type MockMappingScanStore struct {
 	ctrl     *gomock.Controller
-	recorder *MockKeySetIncrementerMockRecorder
+	recorder *MockMappingScanStoreMockRecorder
 }
 
-// MockKeySetIncrementerMockRecorder is the mock recorder for MockKeySetIncrementer.
-type MockKeySetIncrementerMockRecorder struct {
-	mock *MockKeySetIncrementer
+// MockMappingScanStoreMockRecorder is the mock recorder for MockMappingScanStore.
+type MockMappingScanStoreMockRecorder struct {
+	mock *MockMappingScanStore
 }
 
-// NewMockKeySetIncrementer creates a new mock instance.
-func NewMockKeySetIncrementer(ctrl *gomock.Controller) *MockKeySetIncrementer {
-	mock := &MockKeySetIncrementer{ctrl: ctrl}
-	mock.recorder = &MockKeySetIncrementerMockRecorder{mock}
+// NewMockMappingScanStore creates a new mock instance.
+func NewMockMappingScanStore(ctrl *gomock.Controller) *MockMappingScanStore {
+	mock := &MockMappingScanStore{ctrl: ctrl}
+	mock.recorder = &MockMappingScanStoreMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockKeySetIncrementer) EXPECT() *MockKeySetIncrementerMockRecorder {
+func (m *MockMappingScanStore) EXPECT() *MockMappingScanStoreMockRecorder {
 	return m.recorder
 }
 
-// Incr mocks base method.
-func (m *MockKeySetIncrementer) Incr(ctx context.Context, key string) *redis.IntCmd {
+// FlagMapping mocks base method.
+func (m *MockMappingScanStore) FlagMapping(ctx context.Context, urlToken, threat string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagMapping", ctx, urlToken, threat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagMapping indicates an expected call of FlagMapping.
+func (mr *MockMappingScanStoreMockRecorder) FlagMapping(ctx, urlToken, threat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagMapping", reflect.TypeOf((*MockMappingScanStore)(nil).FlagMapping), ctx, urlToken, threat)
+}
+
+// ListMappingsAfter mocks base method.
+func (m *MockMappingScanStore) ListMappingsAfter(ctx context.Context, afterId int64, limit int) ([]domain.MappingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMappingsAfter", ctx, afterId, limit)
+	ret0, _ := ret[0].([]domain.MappingInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMappingsAfter indicates an expected call of ListMappingsAfter.
+func (mr *MockMappingScanStoreMockRecorder) ListMappingsAfter(ctx, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMappingsAfter", reflect.TypeOf((*MockMappingScanStore)(nil).ListMappingsAfter), ctx, afterId, limit)
+}
+
+// MockMappingDisabler is a mock of MappingDisabler interface.
+type MockMappingDisabler struct {
+	ctrl     *gomock.Controller
+	recorder *MockMappingDisablerMockRecorder
+}
+
+// MockMappingDisablerMockRecorder is the mock recorder for MockMappingDisabler.
+type MockMappingDisablerMockRecorder struct {
+	mock *MockMappingDisabler
+}
+
+// NewMockMappingDisabler creates a new mock instance.
+func NewMockMappingDisabler(ctrl *gomock.Controller) *MockMappingDisabler {
+	mock := &MockMappingDisabler{ctrl: ctrl}
+	mock.recorder = &MockMappingDisablerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMappingDisabler) EXPECT() *MockMappingDisablerMockRecorder {
+	return m.recorder
+}
+
+// DisableMapping mocks base method.
+func (m *MockMappingDisabler) DisableMapping(ctx context.Context, urlToken, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMapping", ctx, urlToken, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableMapping indicates an expected call of DisableMapping.
+func (mr *MockMappingDisablerMockRecorder) DisableMapping(ctx, urlToken, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMapping", reflect.TypeOf((*MockMappingDisabler)(nil).DisableMapping), ctx, urlToken, reason)
+}
+
+// MockAbuseReportStore is a mock of AbuseReportStore interface.
+type MockAbuseReportStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbuseReportStoreMockRecorder
+}
+
+// MockAbuseReportStoreMockRecorder is the mock recorder for MockAbuseReportStore.
+type MockAbuseReportStoreMockRecorder struct {
+	mock *MockAbuseReportStore
+}
+
+// NewMockAbuseReportStore creates a new mock instance.
+func NewMockAbuseReportStore(ctrl *gomock.Controller) *MockAbuseReportStore {
+	mock := &MockAbuseReportStore{ctrl: ctrl}
+	mock.recorder = &MockAbuseReportStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbuseReportStore) EXPECT() *MockAbuseReportStoreMockRecorder {
+	return m.recorder
+}
+
+// AddAbuseReport mocks base method.
+func (m *MockAbuseReportStore) AddAbuseReport(ctx context.Context, report domain.AbuseReport) (domain.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAbuseReport", ctx, report)
+	ret0, _ := ret[0].(domain.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAbuseReport indicates an expected call of AddAbuseReport.
+func (mr *MockAbuseReportStoreMockRecorder) AddAbuseReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAbuseReport", reflect.TypeOf((*MockAbuseReportStore)(nil).AddAbuseReport), ctx, report)
+}
+
+// ListAbuseReports mocks base method.
+func (m *MockAbuseReportStore) ListAbuseReports(ctx context.Context, status domain.AbuseReportStatus) ([]domain.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbuseReports", ctx, status)
+	ret0, _ := ret[0].([]domain.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbuseReports indicates an expected call of ListAbuseReports.
+func (mr *MockAbuseReportStoreMockRecorder) ListAbuseReports(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbuseReports", reflect.TypeOf((*MockAbuseReportStore)(nil).ListAbuseReports), ctx, status)
+}
+
+// ResolveAbuseReport mocks base method.
+func (m *MockAbuseReportStore) ResolveAbuseReport(ctx context.Context, id int64, status domain.AbuseReportStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAbuseReport", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveAbuseReport indicates an expected call of ResolveAbuseReport.
+func (mr *MockAbuseReportStoreMockRecorder) ResolveAbuseReport(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAbuseReport", reflect.TypeOf((*MockAbuseReportStore)(nil).ResolveAbuseReport), ctx, id, status)
+}
+
+// ResolveTokenAbuseReports mocks base method.
+func (m *MockAbuseReportStore) ResolveTokenAbuseReports(ctx context.Context, urlToken string, status domain.AbuseReportStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTokenAbuseReports", ctx, urlToken, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveTokenAbuseReports indicates an expected call of ResolveTokenAbuseReports.
+func (mr *MockAbuseReportStoreMockRecorder) ResolveTokenAbuseReports(ctx, urlToken, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTokenAbuseReports", reflect.TypeOf((*MockAbuseReportStore)(nil).ResolveTokenAbuseReports), ctx, urlToken, status)
+}
+
+// MockClickCounter is a mock of ClickCounter interface.
+type MockClickCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockClickCounterMockRecorder
+}
+
+// MockClickCounterMockRecorder is the mock recorder for MockClickCounter.
+type MockClickCounterMockRecorder struct {
+	mock *MockClickCounter
+}
+
+// NewMockClickCounter creates a new mock instance.
+func NewMockClickCounter(ctrl *gomock.Controller) *MockClickCounter {
+	mock := &MockClickCounter{ctrl: ctrl}
+	mock.recorder = &MockClickCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClickCounter) EXPECT() *MockClickCounterMockRecorder {
+	return m.recorder
+}
+
+// IncrementClicks mocks base method.
+func (m *MockClickCounter) IncrementClicks(ctx context.Context, urlToken string, seed int64, expiresAt *time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementClicks", ctx, urlToken, seed, expiresAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementClicks indicates an expected call of IncrementClicks.
+func (mr *MockClickCounterMockRecorder) IncrementClicks(ctx, urlToken, seed, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementClicks", reflect.TypeOf((*MockClickCounter)(nil).IncrementClicks), ctx, urlToken, seed, expiresAt)
+}
+
+// MockClickCountSaver is a mock of ClickCountSaver interface.
+type MockClickCountSaver struct {
+	ctrl     *gomock.Controller
+	recorder *MockClickCountSaverMockRecorder
+}
+
+// MockClickCountSaverMockRecorder is the mock recorder for MockClickCountSaver.
+type MockClickCountSaverMockRecorder struct {
+	mock *MockClickCountSaver
+}
+
+// NewMockClickCountSaver creates a new mock instance.
+func NewMockClickCountSaver(ctrl *gomock.Controller) *MockClickCountSaver {
+	mock := &MockClickCountSaver{ctrl: ctrl}
+	mock.recorder = &MockClickCountSaverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClickCountSaver) EXPECT() *MockClickCountSaverMockRecorder {
+	return m.recorder
+}
+
+// SaveClickCount mocks base method.
+func (m *MockClickCountSaver) SaveClickCount(ctx context.Context, urlToken string, count int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveClickCount", ctx, urlToken, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveClickCount indicates an expected call of SaveClickCount.
+func (mr *MockClickCountSaverMockRecorder) SaveClickCount(ctx, urlToken, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveClickCount", reflect.TypeOf((*MockClickCountSaver)(nil).SaveClickCount), ctx, urlToken, count)
+}
+
+// MockDestinationRuleStore is a mock of DestinationRuleStore interface.
+type MockDestinationRuleStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDestinationRuleStoreMockRecorder
+}
+
+// MockDestinationRuleStoreMockRecorder is the mock recorder for MockDestinationRuleStore.
+type MockDestinationRuleStoreMockRecorder struct {
+	mock *MockDestinationRuleStore
+}
+
+// NewMockDestinationRuleStore creates a new mock instance.
+func NewMockDestinationRuleStore(ctrl *gomock.Controller) *MockDestinationRuleStore {
+	mock := &MockDestinationRuleStore{ctrl: ctrl}
+	mock.recorder = &MockDestinationRuleStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDestinationRuleStore) EXPECT() *MockDestinationRuleStoreMockRecorder {
+	return m.recorder
+}
+
+// AddDestinationRule mocks base method.
+func (m *MockDestinationRuleStore) AddDestinationRule(ctx context.Context, rule domain.DestinationRule) (domain.DestinationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDestinationRule", ctx, rule)
+	ret0, _ := ret[0].(domain.DestinationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDestinationRule indicates an expected call of AddDestinationRule.
+func (mr *MockDestinationRuleStoreMockRecorder) AddDestinationRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDestinationRule", reflect.TypeOf((*MockDestinationRuleStore)(nil).AddDestinationRule), ctx, rule)
+}
+
+// DeleteDestinationRule mocks base method.
+func (m *MockDestinationRuleStore) DeleteDestinationRule(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDestinationRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDestinationRule indicates an expected call of DeleteDestinationRule.
+func (mr *MockDestinationRuleStoreMockRecorder) DeleteDestinationRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDestinationRule", reflect.TypeOf((*MockDestinationRuleStore)(nil).DeleteDestinationRule), ctx, id)
+}
+
+// ListDestinationRules mocks base method.
+func (m *MockDestinationRuleStore) ListDestinationRules(ctx context.Context) ([]domain.DestinationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDestinationRules", ctx)
+	ret0, _ := ret[0].([]domain.DestinationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDestinationRules indicates an expected call of ListDestinationRules.
+func (mr *MockDestinationRuleStoreMockRecorder) ListDestinationRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDestinationRules", reflect.TypeOf((*MockDestinationRuleStore)(nil).ListDestinationRules), ctx)
+}
+
+// MockApiKeyStore is a mock of ApiKeyStore interface.
+type MockApiKeyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyStoreMockRecorder
+}
+
+// MockApiKeyStoreMockRecorder is the mock recorder for MockApiKeyStore.
+type MockApiKeyStoreMockRecorder struct {
+	mock *MockApiKeyStore
+}
+
+// NewMockApiKeyStore creates a new mock instance.
+func NewMockApiKeyStore(ctrl *gomock.Controller) *MockApiKeyStore {
+	mock := &MockApiKeyStore{ctrl: ctrl}
+	mock.recorder = &MockApiKeyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyStore) EXPECT() *MockApiKeyStoreMockRecorder {
+	return m.recorder
+}
+
+// AddApiKey mocks base method.
+func (m *MockApiKeyStore) AddApiKey(ctx context.Context, key domain.ApiKey, keyHash string) (domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddApiKey", ctx, key, keyHash)
+	ret0, _ := ret[0].(domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockApiKeyStoreMockRecorder) AddApiKey(ctx, key, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockApiKeyStore)(nil).AddApiKey), ctx, key, keyHash)
+}
+
+// GetApiKeyByHash mocks base method.
+func (m *MockApiKeyStore) GetApiKeyByHash(ctx context.Context, keyHash string) (domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeyByHash indicates an expected call of GetApiKeyByHash.
+func (mr *MockApiKeyStoreMockRecorder) GetApiKeyByHash(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByHash", reflect.TypeOf((*MockApiKeyStore)(nil).GetApiKeyByHash), ctx, keyHash)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockApiKeyStore) ListApiKeys(ctx context.Context) ([]domain.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeys", ctx)
+	ret0, _ := ret[0].([]domain.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockApiKeyStoreMockRecorder) ListApiKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockApiKeyStore)(nil).ListApiKeys), ctx)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockApiKeyStore) RevokeApiKey(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockApiKeyStoreMockRecorder) RevokeApiKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockApiKeyStore)(nil).RevokeApiKey), ctx, id)
+}
+
+// MockWorkspaceStore is a mock of WorkspaceStore interface.
+type MockWorkspaceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceStoreMockRecorder
+}
+
+// MockWorkspaceStoreMockRecorder is the mock recorder for MockWorkspaceStore.
+type MockWorkspaceStoreMockRecorder struct {
+	mock *MockWorkspaceStore
+}
+
+// NewMockWorkspaceStore creates a new mock instance.
+func NewMockWorkspaceStore(ctrl *gomock.Controller) *MockWorkspaceStore {
+	mock := &MockWorkspaceStore{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceStore) EXPECT() *MockWorkspaceStoreMockRecorder {
+	return m.recorder
+}
+
+// AddWorkspace mocks base method.
+func (m *MockWorkspaceStore) AddWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkspace", ctx, workspace)
+	ret0, _ := ret[0].(domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkspace indicates an expected call of AddWorkspace.
+func (mr *MockWorkspaceStoreMockRecorder) AddWorkspace(ctx, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkspace", reflect.TypeOf((*MockWorkspaceStore)(nil).AddWorkspace), ctx, workspace)
+}
+
+// ListWorkspaces mocks base method.
+func (m *MockWorkspaceStore) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaces", ctx)
+	ret0, _ := ret[0].([]domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaces indicates an expected call of ListWorkspaces.
+func (mr *MockWorkspaceStoreMockRecorder) ListWorkspaces(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaces", reflect.TypeOf((*MockWorkspaceStore)(nil).ListWorkspaces), ctx)
+}
+
+// UpdateWorkspace mocks base method.
+func (m *MockWorkspaceStore) UpdateWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", ctx, workspace)
+	ret0, _ := ret[0].(domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockWorkspaceStoreMockRecorder) UpdateWorkspace(ctx, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockWorkspaceStore)(nil).UpdateWorkspace), ctx, workspace)
+}
+
+// MockShortDomainStore is a mock of ShortDomainStore interface.
+type MockShortDomainStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortDomainStoreMockRecorder
+}
+
+// MockShortDomainStoreMockRecorder is the mock recorder for MockShortDomainStore.
+type MockShortDomainStoreMockRecorder struct {
+	mock *MockShortDomainStore
+}
+
+// NewMockShortDomainStore creates a new mock instance.
+func NewMockShortDomainStore(ctrl *gomock.Controller) *MockShortDomainStore {
+	mock := &MockShortDomainStore{ctrl: ctrl}
+	mock.recorder = &MockShortDomainStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortDomainStore) EXPECT() *MockShortDomainStoreMockRecorder {
+	return m.recorder
+}
+
+// AddShortDomain mocks base method.
+func (m *MockShortDomainStore) AddShortDomain(ctx context.Context, shortDomain domain.ShortDomain) (domain.ShortDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShortDomain", ctx, shortDomain)
+	ret0, _ := ret[0].(domain.ShortDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddShortDomain indicates an expected call of AddShortDomain.
+func (mr *MockShortDomainStoreMockRecorder) AddShortDomain(ctx, shortDomain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShortDomain", reflect.TypeOf((*MockShortDomainStore)(nil).AddShortDomain), ctx, shortDomain)
+}
+
+// DeleteShortDomain mocks base method.
+func (m *MockShortDomainStore) DeleteShortDomain(ctx context.Context, host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShortDomain", ctx, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShortDomain indicates an expected call of DeleteShortDomain.
+func (mr *MockShortDomainStoreMockRecorder) DeleteShortDomain(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShortDomain", reflect.TypeOf((*MockShortDomainStore)(nil).DeleteShortDomain), ctx, host)
+}
+
+// ListShortDomains mocks base method.
+func (m *MockShortDomainStore) ListShortDomains(ctx context.Context) ([]domain.ShortDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShortDomains", ctx)
+	ret0, _ := ret[0].([]domain.ShortDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShortDomains indicates an expected call of ListShortDomains.
+func (mr *MockShortDomainStoreMockRecorder) ListShortDomains(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShortDomains", reflect.TypeOf((*MockShortDomainStore)(nil).ListShortDomains), ctx)
+}
+
+// MockRateLimiter is a mock of RateLimiter interface.
+type MockRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimiterMockRecorder
+}
+
+// MockRateLimiterMockRecorder is the mock recorder for MockRateLimiter.
+type MockRateLimiterMockRecorder struct {
+	mock *MockRateLimiter
+}
+
+// NewMockRateLimiter creates a new mock instance.
+func NewMockRateLimiter(ctrl *gomock.Controller) *MockRateLimiter {
+	mock := &MockRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimiter) EXPECT() *MockRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimiter) Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, key, limit, window)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimiterMockRecorder) Allow(ctx, key, limit, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimiter)(nil).Allow), ctx, key, limit, window)
+}
+
+// MockAttemptLimiter is a mock of AttemptLimiter interface.
+type MockAttemptLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttemptLimiterMockRecorder
+}
+
+// MockAttemptLimiterMockRecorder is the mock recorder for MockAttemptLimiter.
+type MockAttemptLimiterMockRecorder struct {
+	mock *MockAttemptLimiter
+}
+
+// NewMockAttemptLimiter creates a new mock instance.
+func NewMockAttemptLimiter(ctrl *gomock.Controller) *MockAttemptLimiter {
+	mock := &MockAttemptLimiter{ctrl: ctrl}
+	mock.recorder = &MockAttemptLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttemptLimiter) EXPECT() *MockAttemptLimiterMockRecorder {
+	return m.recorder
+}
+
+// ResetAttempts mocks base method.
+func (m *MockAttemptLimiter) ResetAttempts(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAttempts", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAttempts indicates an expected call of ResetAttempts.
+func (mr *MockAttemptLimiterMockRecorder) ResetAttempts(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockAttemptLimiter)(nil).ResetAttempts), ctx, key)
+}
+
+// TryAttempt mocks base method.
+func (m *MockAttemptLimiter) TryAttempt(ctx context.Context, key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAttempt", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryAttempt indicates an expected call of TryAttempt.
+func (mr *MockAttemptLimiterMockRecorder) TryAttempt(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAttempt", reflect.TypeOf((*MockAttemptLimiter)(nil).TryAttempt), ctx, key)
+}
+
+// MockInvalidationPublisher is a mock of InvalidationPublisher interface.
+type MockInvalidationPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvalidationPublisherMockRecorder
+}
+
+// MockInvalidationPublisherMockRecorder is the mock recorder for MockInvalidationPublisher.
+type MockInvalidationPublisherMockRecorder struct {
+	mock *MockInvalidationPublisher
+}
+
+// NewMockInvalidationPublisher creates a new mock instance.
+func NewMockInvalidationPublisher(ctrl *gomock.Controller) *MockInvalidationPublisher {
+	mock := &MockInvalidationPublisher{ctrl: ctrl}
+	mock.recorder = &MockInvalidationPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvalidationPublisher) EXPECT() *MockInvalidationPublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishInvalidation mocks base method.
+func (m *MockInvalidationPublisher) PublishInvalidation(ctx context.Context, urlToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishInvalidation", ctx, urlToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishInvalidation indicates an expected call of PublishInvalidation.
+func (mr *MockInvalidationPublisherMockRecorder) PublishInvalidation(ctx, urlToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishInvalidation", reflect.TypeOf((*MockInvalidationPublisher)(nil).PublishInvalidation), ctx, urlToken)
+}
+
+// MockIdGenerator is a mock of IdGenerator interface.
+type MockIdGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdGeneratorMockRecorder
+}
+
+// MockIdGeneratorMockRecorder is the mock recorder for MockIdGenerator.
+type MockIdGeneratorMockRecorder struct {
+	mock *MockIdGenerator
+}
+
+// NewMockIdGenerator creates a new mock instance.
+func NewMockIdGenerator(ctrl *gomock.Controller) *MockIdGenerator {
+	mock := &MockIdGenerator{ctrl: ctrl}
+	mock.recorder = &MockIdGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdGenerator) EXPECT() *MockIdGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GetNextId mocks base method.
+func (m *MockIdGenerator) GetNextId(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextId", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextId indicates an expected call of GetNextId.
+func (mr *MockIdGeneratorMockRecorder) GetNextId(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextId", reflect.TypeOf((*MockIdGenerator)(nil).GetNextId), ctx)
+}
+
+// MockIdRangeReserver is a mock of IdRangeReserver interface.
+type MockIdRangeReserver struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdRangeReserverMockRecorder
+}
+
+// MockIdRangeReserverMockRecorder is the mock recorder for MockIdRangeReserver.
+type MockIdRangeReserverMockRecorder struct {
+	mock *MockIdRangeReserver
+}
+
+// NewMockIdRangeReserver creates a new mock instance.
+func NewMockIdRangeReserver(ctrl *gomock.Controller) *MockIdRangeReserver {
+	mock := &MockIdRangeReserver{ctrl: ctrl}
+	mock.recorder = &MockIdRangeReserverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdRangeReserver) EXPECT() *MockIdRangeReserverMockRecorder {
+	return m.recorder
+}
+
+// ReserveIds mocks base method.
+func (m *MockIdRangeReserver) ReserveIds(ctx context.Context, count int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveIds", ctx, count)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveIds indicates an expected call of ReserveIds.
+func (mr *MockIdRangeReserverMockRecorder) ReserveIds(ctx, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveIds", reflect.TypeOf((*MockIdRangeReserver)(nil).ReserveIds), ctx, count)
+}
+
+// MockWorkerIdProvider is a mock of WorkerIdProvider interface.
+type MockWorkerIdProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkerIdProviderMockRecorder
+}
+
+// MockWorkerIdProviderMockRecorder is the mock recorder for MockWorkerIdProvider.
+type MockWorkerIdProviderMockRecorder struct {
+	mock *MockWorkerIdProvider
+}
+
+// NewMockWorkerIdProvider creates a new mock instance.
+func NewMockWorkerIdProvider(ctrl *gomock.Controller) *MockWorkerIdProvider {
+	mock := &MockWorkerIdProvider{ctrl: ctrl}
+	mock.recorder = &MockWorkerIdProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkerIdProvider) EXPECT() *MockWorkerIdProviderMockRecorder {
+	return m.recorder
+}
+
+// WorkerId mocks base method.
+func (m *MockWorkerIdProvider) WorkerId() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerId")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkerId indicates an expected call of WorkerId.
+func (mr *MockWorkerIdProviderMockRecorder) WorkerId() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerId", reflect.TypeOf((*MockWorkerIdProvider)(nil).WorkerId))
+}
+
+// MockWorkerLeaseStore is a mock of WorkerLeaseStore interface.
+type MockWorkerLeaseStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkerLeaseStoreMockRecorder
+}
+
+// MockWorkerLeaseStoreMockRecorder is the mock recorder for MockWorkerLeaseStore.
+type MockWorkerLeaseStoreMockRecorder struct {
+	mock *MockWorkerLeaseStore
+}
+
+// NewMockWorkerLeaseStore creates a new mock instance.
+func NewMockWorkerLeaseStore(ctrl *gomock.Controller) *MockWorkerLeaseStore {
+	mock := &MockWorkerLeaseStore{ctrl: ctrl}
+	mock.recorder = &MockWorkerLeaseStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkerLeaseStore) EXPECT() *MockWorkerLeaseStoreMockRecorder {
+	return m.recorder
+}
+
+// AcquireWorkerId mocks base method.
+func (m *MockWorkerLeaseStore) AcquireWorkerId(ctx context.Context, owner string, maxWorkerId int64, ttl time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireWorkerId", ctx, owner, maxWorkerId, ttl)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireWorkerId indicates an expected call of AcquireWorkerId.
+func (mr *MockWorkerLeaseStoreMockRecorder) AcquireWorkerId(ctx, owner, maxWorkerId, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireWorkerId", reflect.TypeOf((*MockWorkerLeaseStore)(nil).AcquireWorkerId), ctx, owner, maxWorkerId, ttl)
+}
+
+// ReleaseWorkerId mocks base method.
+func (m *MockWorkerLeaseStore) ReleaseWorkerId(ctx context.Context, workerId int64, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseWorkerId", ctx, workerId, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseWorkerId indicates an expected call of ReleaseWorkerId.
+func (mr *MockWorkerLeaseStoreMockRecorder) ReleaseWorkerId(ctx, workerId, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseWorkerId", reflect.TypeOf((*MockWorkerLeaseStore)(nil).ReleaseWorkerId), ctx, workerId, owner)
+}
+
+// RenewWorkerId mocks base method.
+func (m *MockWorkerLeaseStore) RenewWorkerId(ctx context.Context, workerId int64, owner string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewWorkerId", ctx, workerId, owner, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewWorkerId indicates an expected call of RenewWorkerId.
+func (mr *MockWorkerLeaseStoreMockRecorder) RenewWorkerId(ctx, workerId, owner, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewWorkerId", reflect.TypeOf((*MockWorkerLeaseStore)(nil).RenewWorkerId), ctx, workerId, owner, ttl)
+}
+
+// MockTokenGenerator is a mock of TokenGenerator interface.
+type MockTokenGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenGeneratorMockRecorder
+}
+
+// MockTokenGeneratorMockRecorder is the mock recorder for MockTokenGenerator.
+type MockTokenGeneratorMockRecorder struct {
+	mock *MockTokenGenerator
+}
+
+// NewMockTokenGenerator creates a new mock instance.
+func NewMockTokenGenerator(ctrl *gomock.Controller) *MockTokenGenerator {
+	mock := &MockTokenGenerator{ctrl: ctrl}
+	mock.recorder = &MockTokenGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenGenerator) EXPECT() *MockTokenGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateToken mocks base method.
+func (m *MockTokenGenerator) GenerateToken(id int64) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Incr", ctx, key)
+	ret := m.ctrl.Call(m, "GenerateToken", id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateToken indicates an expected call of GenerateToken.
+func (mr *MockTokenGeneratorMockRecorder) GenerateToken(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockTokenGenerator)(nil).GenerateToken), id)
+}
+
+// MockKeyStorage is a mock of KeyStorage interface.
+type MockKeyStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyStorageMockRecorder
+}
+
+// MockKeyStorageMockRecorder is the mock recorder for MockKeyStorage.
+type MockKeyStorageMockRecorder struct {
+	mock *MockKeyStorage
+}
+
+// NewMockKeyStorage creates a new mock instance.
+func NewMockKeyStorage(ctrl *gomock.Controller) *MockKeyStorage {
+	mock := &MockKeyStorage{ctrl: ctrl}
+	mock.recorder = &MockKeyStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyStorage) EXPECT() *MockKeyStorageMockRecorder {
+	return m.recorder
+}
+
+// Del mocks base method.
+func (m *MockKeyStorage) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Del", varargs...)
 	ret0, _ := ret[0].(*redis.IntCmd)
 	return ret0
 }
 
-// Incr indicates an expected call of Incr.
-func (mr *MockKeySetIncrementerMockRecorder) Incr(ctx, key interface{}) *gomock.Call {
+// Del indicates an expected call of Del.
+func (mr *MockKeyStorageMockRecorder) Del(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockKeyStorage)(nil).Del), varargs...)
+}
+
+// Expire mocks base method.
+func (m *MockKeyStorage) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, key, expiration)
+	ret0, _ := ret[0].(*redis.BoolCmd)
+	return ret0
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockKeyStorageMockRecorder) Expire(ctx, key, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockKeyStorage)(nil).Expire), ctx, key, expiration)
+}
+
+// Get mocks base method.
+func (m *MockKeyStorage) Get(ctx context.Context, key string) *redis.StringCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*redis.StringCmd)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKeyStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockKeySetIncrementer)(nil).Incr), ctx, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeyStorage)(nil).Get), ctx, key)
 }
 
 // Set mocks base method.
-func (m *MockKeySetIncrementer) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+func (m *MockKeyStorage) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Set", ctx, key, value, expiration)
 	ret0, _ := ret[0].(*redis.StatusCmd)
@@ -574,9 +1788,195 @@ func (m *MockKeySetIncrementer) Set(ctx context.Context, key string, value inter
 }
 
 // Set indicates an expected call of Set.
-func (mr *MockKeySetIncrementerMockRecorder) Set(ctx, key, value, expiration interface{}) *gomock.Call {
+func (mr *MockKeyStorageMockRecorder) Set(ctx, key, value, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockKeyStorage)(nil).Set), ctx, key, value, expiration)
+}
+
+// SetNX mocks base method.
+func (m *MockKeyStorage) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, expiration)
+	ret0, _ := ret[0].(*redis.BoolCmd)
+	return ret0
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockKeyStorageMockRecorder) SetNX(ctx, key, value, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockKeyStorage)(nil).SetNX), ctx, key, value, expiration)
+}
+
+// MockKeyCounter is a mock of KeyCounter interface.
+type MockKeyCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyCounterMockRecorder
+}
+
+// MockKeyCounterMockRecorder is the mock recorder for MockKeyCounter.
+type MockKeyCounterMockRecorder struct {
+	mock *MockKeyCounter
+}
+
+// NewMockKeyCounter creates a new mock instance.
+func NewMockKeyCounter(ctrl *gomock.Controller) *MockKeyCounter {
+	mock := &MockKeyCounter{ctrl: ctrl}
+	mock.recorder = &MockKeyCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyCounter) EXPECT() *MockKeyCounterMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockKeyCounter) Get(ctx context.Context, key string) *redis.StringCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*redis.StringCmd)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKeyCounterMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeyCounter)(nil).Get), ctx, key)
+}
+
+// IncrBy mocks base method.
+func (m *MockKeyCounter) IncrBy(ctx context.Context, key string, value int64) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrBy", ctx, key, value)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// IncrBy indicates an expected call of IncrBy.
+func (mr *MockKeyCounterMockRecorder) IncrBy(ctx, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrBy", reflect.TypeOf((*MockKeyCounter)(nil).IncrBy), ctx, key, value)
+}
+
+// MockKeySetNxIncrementer is a mock of KeySetNxIncrementer interface.
+type MockKeySetNxIncrementer struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeySetNxIncrementerMockRecorder
+}
+
+// MockKeySetNxIncrementerMockRecorder is the mock recorder for MockKeySetNxIncrementer.
+type MockKeySetNxIncrementerMockRecorder struct {
+	mock *MockKeySetNxIncrementer
+}
+
+// NewMockKeySetNxIncrementer creates a new mock instance.
+func NewMockKeySetNxIncrementer(ctrl *gomock.Controller) *MockKeySetNxIncrementer {
+	mock := &MockKeySetNxIncrementer{ctrl: ctrl}
+	mock.recorder = &MockKeySetNxIncrementerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeySetNxIncrementer) EXPECT() *MockKeySetNxIncrementerMockRecorder {
+	return m.recorder
+}
+
+// Incr mocks base method.
+func (m *MockKeySetNxIncrementer) Incr(ctx context.Context, key string) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, key)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockKeySetNxIncrementerMockRecorder) Incr(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockKeySetNxIncrementer)(nil).Incr), ctx, key)
+}
+
+// SetNX mocks base method.
+func (m *MockKeySetNxIncrementer) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, expiration)
+	ret0, _ := ret[0].(*redis.BoolCmd)
+	return ret0
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockKeySetNxIncrementerMockRecorder) SetNX(ctx, key, value, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockKeySetNxIncrementer)(nil).SetNX), ctx, key, value, expiration)
+}
+
+// MockKeyAttemptCounter is a mock of KeyAttemptCounter interface.
+type MockKeyAttemptCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyAttemptCounterMockRecorder
+}
+
+// MockKeyAttemptCounterMockRecorder is the mock recorder for MockKeyAttemptCounter.
+type MockKeyAttemptCounterMockRecorder struct {
+	mock *MockKeyAttemptCounter
+}
+
+// NewMockKeyAttemptCounter creates a new mock instance.
+func NewMockKeyAttemptCounter(ctrl *gomock.Controller) *MockKeyAttemptCounter {
+	mock := &MockKeyAttemptCounter{ctrl: ctrl}
+	mock.recorder = &MockKeyAttemptCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyAttemptCounter) EXPECT() *MockKeyAttemptCounterMockRecorder {
+	return m.recorder
+}
+
+// Del mocks base method.
+func (m *MockKeyAttemptCounter) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Del", varargs...)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockKeyAttemptCounterMockRecorder) Del(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockKeyAttemptCounter)(nil).Del), varargs...)
+}
+
+// Expire mocks base method.
+func (m *MockKeyAttemptCounter) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, key, expiration)
+	ret0, _ := ret[0].(*redis.BoolCmd)
+	return ret0
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockKeyAttemptCounterMockRecorder) Expire(ctx, key, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockKeyAttemptCounter)(nil).Expire), ctx, key, expiration)
+}
+
+// Incr mocks base method.
+func (m *MockKeyAttemptCounter) Incr(ctx context.Context, key string) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, key)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockKeyAttemptCounterMockRecorder) Incr(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockKeySetIncrementer)(nil).Set), ctx, key, value, expiration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockKeyAttemptCounter)(nil).Incr), ctx, key)
 }
 
 // MockKeyGetter is a mock of KeyGetter interface.
@@ -658,6 +2058,80 @@ func (mr *MockKeyDeleterMockRecorder) Del(ctx interface{}, keys ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockKeyDeleter)(nil).Del), varargs...)
 }
 
+// MockKeyExpirer is a mock of KeyExpirer interface.
+type MockKeyExpirer struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyExpirerMockRecorder
+}
+
+// MockKeyExpirerMockRecorder is the mock recorder for MockKeyExpirer.
+type MockKeyExpirerMockRecorder struct {
+	mock *MockKeyExpirer
+}
+
+// NewMockKeyExpirer creates a new mock instance.
+func NewMockKeyExpirer(ctrl *gomock.Controller) *MockKeyExpirer {
+	mock := &MockKeyExpirer{ctrl: ctrl}
+	mock.recorder = &MockKeyExpirerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyExpirer) EXPECT() *MockKeyExpirerMockRecorder {
+	return m.recorder
+}
+
+// Expire mocks base method.
+func (m *MockKeyExpirer) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, key, expiration)
+	ret0, _ := ret[0].(*redis.BoolCmd)
+	return ret0
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockKeyExpirerMockRecorder) Expire(ctx, key, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockKeyExpirer)(nil).Expire), ctx, key, expiration)
+}
+
+// MockKeyPublisher is a mock of KeyPublisher interface.
+type MockKeyPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyPublisherMockRecorder
+}
+
+// MockKeyPublisherMockRecorder is the mock recorder for MockKeyPublisher.
+type MockKeyPublisherMockRecorder struct {
+	mock *MockKeyPublisher
+}
+
+// NewMockKeyPublisher creates a new mock instance.
+func NewMockKeyPublisher(ctrl *gomock.Controller) *MockKeyPublisher {
+	mock := &MockKeyPublisher{ctrl: ctrl}
+	mock.recorder = &MockKeyPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyPublisher) EXPECT() *MockKeyPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockKeyPublisher) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, channel, message)
+	ret0, _ := ret[0].(*redis.IntCmd)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockKeyPublisherMockRecorder) Publish(ctx, channel, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockKeyPublisher)(nil).Publish), ctx, channel, message)
+}
+
 // MockKeySetter is a mock of KeySetter interface.
 type MockKeySetter struct {
 	ctrl     *gomock.Controller
@@ -694,3 +2168,40 @@ func (mr *MockKeySetterMockRecorder) Set(ctx, key, value, expiration interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockKeySetter)(nil).Set), ctx, key, value, expiration)
 }
+
+// MockKeyNxSetter is a mock of KeyNxSetter interface.
+type MockKeyNxSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyNxSetterMockRecorder
+}
+
+// MockKeyNxSetterMockRecorder is the mock recorder for MockKeyNxSetter.
+type MockKeyNxSetterMockRecorder struct {
+	mock *MockKeyNxSetter
+}
+
+// NewMockKeyNxSetter creates a new mock instance.
+func NewMockKeyNxSetter(ctrl *gomock.Controller) *MockKeyNxSetter {
+	mock := &MockKeyNxSetter{ctrl: ctrl}
+	mock.recorder = &MockKeyNxSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyNxSetter) EXPECT() *MockKeyNxSetterMockRecorder {
+	return m.recorder
+}
+
+// SetNX mocks base method.
+func (m *MockKeyNxSetter) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, expiration)
+	ret0, _ := ret[0].(*redis.BoolCmd)
+	return ret0
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockKeyNxSetterMockRecorder) SetNX(ctx, key, value, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockKeyNxSetter)(nil).SetNX), ctx, key, value, expiration)
+}
@@ -3,8 +3,7 @@ package domain
 
 import (
 	"context"
-	"fmt"
-	"net/url"
+	"time"
 )
 
 // UrlDeleter defines the interface for deleting URL mappings.
@@ -12,19 +11,209 @@ type UrlDeleter interface {
 	DeleteUrl(ctx context.Context, urlToken string) error
 }
 
+// UrlRestorer defines the interface for restoring deleted URL mappings.
+type UrlRestorer interface {
+	RestoreUrl(ctx context.Context, urlToken string) error
+}
+
 // UrlGetter defines the interface for retrieving original URLs from shortened tokens.
 type UrlGetter interface {
-	GetOriginalUrl(ctx context.Context, urlToken string) (string, error)
+	GetOriginalUrl(ctx context.Context, urlToken string) (ResolvedUrl, error)
+}
+
+// FlaggedUrlGetter defines the interface for retrieving original URLs of flagged tokens
+// after their warning was acknowledged.
+type FlaggedUrlGetter interface {
+	GetFlaggedOriginalUrl(ctx context.Context, urlToken string) (ResolvedUrl, error)
+}
+
+// UrlUnlocker defines the interface for retrieving original URLs of password-protected tokens.
+type UrlUnlocker interface {
+	UnlockOriginalUrl(ctx context.Context, urlToken string, password string) (ResolvedUrl, error)
+}
+
+// TokenValidator defines the interface for recognizing mistyped tokens without a storage lookup.
+type TokenValidator interface {
+	// ValidateToken returns *MalformedTokenError if the token cannot have been generated.
+	ValidateToken(token string) error
+	// SuggestTokens returns valid tokens the mistyped token was likely meant to be.
+	SuggestTokens(token string) []string
+}
+
+// ShortenOptions contains optional parameters for creating a shortened URL.
+type ShortenOptions struct {
+	// Alias is a custom human-readable token to use instead of a generated one.
+	// An empty Alias means the token is generated from the next mapping ID.
+	Alias string
+	// ExpiresAt is the moment after which the mapping stops redirecting.
+	// A nil ExpiresAt means the mapping never expires.
+	ExpiresAt *time.Time
+	// MaxClicks is the number of redirects after which the mapping stops redirecting.
+	// A nil MaxClicks means the number of redirects is unlimited.
+	MaxClicks *int64
+	// Password protects the mapping, so it redirects only after the password is submitted.
+	// An empty Password means the mapping is not protected.
+	Password string
+	// ReuseExisting returns an existing mapping of the same normalized URL instead of creating a new one.
+	// It only applies to mappings without alias, expiration time, click limit and password.
+	ReuseExisting bool
+	// OwnerId is the owner of the created mapping, usually the owner of the authenticating API key.
+	// Only mappings of the same owner are reused. An empty OwnerId creates a mapping without owner.
+	OwnerId string
+	// WorkspaceId is the workspace of the created mapping, usually the workspace of the authenticating API key.
+	// The mapping counts against the quotas and is subject to the destination policy of the workspace,
+	// and only mappings of the same workspace are reused. Zero creates a mapping outside of workspaces.
+	WorkspaceId int64
+	// Domain is the custom short domain the mapping is served at, which must be registered for WorkspaceId.
+	// Only mappings of the same domain are reused. An empty Domain creates a mapping of the default domain.
+	Domain string
+}
+
+// Reusable reports whether an existing mapping may be returned instead of creating a new one.
+func (o ShortenOptions) Reusable() bool {
+	return o.ReuseExisting && o.Alias == "" && o.ExpiresAt == nil && o.MaxClicks == nil && o.Password == ""
+}
+
+// UpdateOptions contains optional parameters for updating an existing URL mapping.
+type UpdateOptions struct {
+	// ExpiresAt replaces the expiration time of the mapping.
+	// A nil ExpiresAt keeps the current expiration time unchanged.
+	ExpiresAt *time.Time
+	// ClearExpiration removes the expiration time of the mapping, so it never expires.
+	// It cannot be combined with ExpiresAt.
+	ClearExpiration bool
 }
 
 // UrlShortener defines the interface for shortening URLs.
 type UrlShortener interface {
-	ShortenUrl(ctx context.Context, originalUrl string) (MappingInfo, error)
+	// ShortenUrl returns the created mapping, or an existing one and true if opts allowed reusing it.
+	ShortenUrl(ctx context.Context, originalUrl string, opts ShortenOptions) (MappingInfo, bool, error)
+}
+
+// UrlValidator defines the interface for validating the destination URLs of mappings.
+type UrlValidator interface {
+	// ValidateURL returns the URL with its host in ASCII form, as it is stored,
+	// or *InvalidUrlError with the reason the URL is rejected.
+	ValidateURL(URL string) (string, error)
+}
+
+// WorkspaceUrlValidator defines the interface for validating the destination URLs of the mappings of a workspace.
+type WorkspaceUrlValidator interface {
+	// ValidateWorkspaceURL returns the URL with its host in ASCII form, as it is stored,
+	// or *InvalidUrlError or *DestinationBlockedError with the reason the URL is rejected.
+	// A workspaceId of 0 validates the URL for mappings outside of workspaces.
+	ValidateWorkspaceURL(workspaceId int64, URL string) (string, error)
+}
+
+// LinkQuotaChecker defines the interface for enforcing the quota of short URLs created by a workspace.
+type LinkQuotaChecker interface {
+	// CheckLinkQuota returns *QuotaExceededError if the workspace may not create another short URL.
+	// Mappings outside of workspaces (workspaceId 0) are not limited.
+	CheckLinkQuota(ctx context.Context, workspaceId int64) error
+}
+
+// RedirectQuotaChecker defines the interface for enforcing the quota of redirects of a workspace.
+type RedirectQuotaChecker interface {
+	// CheckRedirectQuota registers a redirect of a short URL of the workspace and returns
+	// *QuotaExceededError if it exceeds the quota of the workspace.
+	// Mappings outside of workspaces (workspaceId 0) are not limited.
+	CheckRedirectQuota(ctx context.Context, workspaceId int64) error
+}
+
+// WorkspaceManager defines the interface for administering workspaces.
+type WorkspaceManager interface {
+	ListWorkspaces(ctx context.Context) ([]Workspace, error)
+	CreateWorkspace(ctx context.Context, workspace Workspace) (Workspace, error)
+	// UpdateWorkspace replaces the name and quotas of the workspace with the ID of the given workspace.
+	UpdateWorkspace(ctx context.Context, workspace Workspace) (Workspace, error)
+}
+
+// ShortDomainResolver defines the interface for resolving the custom short domain requests are made to.
+type ShortDomainResolver interface {
+	// ResolveShortDomain returns the registered custom short domain matching the host of a request
+	// and false if the host is not a custom short domain and is served as the default domain.
+	ResolveShortDomain(host string) (string, bool)
+}
+
+// ShortUrlFormatter defines the interface for building the complete short URLs returned to API clients.
+type ShortUrlFormatter interface {
+	// ShortUrl returns the complete short URL of the mapping
+	// and false if the public base URL of its short domain is not configured.
+	ShortUrl(mapping MappingInfo) (string, bool)
+}
+
+// ShortDomainAuthorizer defines the interface for checking that a workspace may create short URLs on a custom short domain.
+type ShortDomainAuthorizer interface {
+	// AuthorizeShortDomain returns the host name of the custom short domain in the form it is stored in,
+	// *ShortDomainNonExistingError if it is not registered and *ForbiddenError if it belongs to another workspace.
+	AuthorizeShortDomain(host string, workspaceId int64) (string, error)
+}
+
+// ShortDomainManager defines the interface for administering custom short domains.
+type ShortDomainManager interface {
+	ListShortDomains(ctx context.Context) ([]ShortDomain, error)
+	AddShortDomain(ctx context.Context, shortDomain ShortDomain) (ShortDomain, error)
+	DeleteShortDomain(ctx context.Context, host string) error
+}
+
+// URLScanner defines the interface for checking destination URLs against a reputation service.
+type URLScanner interface {
+	// ScanURL returns the verdict of the reputation service on the URL.
+	ScanURL(ctx context.Context, URL string) (ScanVerdict, error)
+}
+
+// DestinationRuleManager defines the interface for administering the rules of the destination policy.
+type DestinationRuleManager interface {
+	ListRules(ctx context.Context) ([]DestinationRule, error)
+	AddRule(ctx context.Context, rule DestinationRule) (DestinationRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+}
+
+// AbuseReporter defines the interface for reporting abuse of short URLs.
+type AbuseReporter interface {
+	ReportAbuse(ctx context.Context, report AbuseReport) (AbuseReport, error)
+}
+
+// AbuseModerator defines the interface for reviewing abuse reports and taking down short URLs.
+type AbuseModerator interface {
+	ListReports(ctx context.Context, status AbuseReportStatus) ([]AbuseReport, error)
+	DismissReport(ctx context.Context, id int64) error
+	TakeDown(ctx context.Context, urlToken string, reason string) error
+	Reinstate(ctx context.Context, urlToken string) error
+}
+
+// ApiKeyAuthenticator defines the interface for authenticating requests by their API key.
+type ApiKeyAuthenticator interface {
+	// Authenticate returns the active API key matching the presented key.
+	// Returns *UnauthorizedError if the key is unknown or revoked.
+	Authenticate(ctx context.Context, key string) (ApiKey, error)
+}
+
+// ApiKeyManager defines the interface for administering API keys.
+type ApiKeyManager interface {
+	// CreateKey creates an API key with the name, owner, workspace and scopes of the given key.
+	// Returns the created key together with the key itself, which is not retrievable afterwards.
+	CreateKey(ctx context.Context, key ApiKey) (ApiKey, string, error)
+	ListKeys(ctx context.Context) ([]ApiKey, error)
+	RevokeKey(ctx context.Context, id int64) error
+}
+
+// MappingOwnershipAuthorizer defines the interface for checking that the caller may manage a short URL.
+type MappingOwnershipAuthorizer interface {
+	// AuthorizeMappingOwner returns the owner of the short URL if the API key in ctx may manage it,
+	// *ForbiddenError if it may not and *TokenNonExistingError if the token does not exist.
+	AuthorizeMappingOwner(ctx context.Context, urlToken string) (MappingOwner, error)
 }
 
 // UrlUpdater defines the interface for updating existing URL mappings.
 type UrlUpdater interface {
-	UpdateUrlMapping(ctx context.Context, urlToken string, newOriginalUrl string) (MappingInfo, error)
+	UpdateUrlMapping(ctx context.Context, urlToken string, newOriginalUrl string, opts UpdateOptions) (MappingInfo, error)
+}
+
+// UrlReader defines the interface for reading the details of URL mappings.
+type UrlReader interface {
+	GetUrlMapping(ctx context.Context, urlToken string) (MappingInfo, error)
+	ListUrlMappings(ctx context.Context, afterId int64, limit int) ([]MappingInfo, error)
 }
 
 const (
@@ -35,34 +224,60 @@ const (
 	RedirectAddress = "GET /{" + UrlTokenStr + "}"
 	// UpdateUrlAddress is the route pattern for updating existing URL mappings.
 	UpdateUrlAddress = "PUT /{" + UrlTokenStr + "}"
+	// UnlockAddress is the route pattern for submitting the password of protected URL mappings.
+	UnlockAddress = "POST /{" + UrlTokenStr + "}"
 	// DeleteUrlAddress is the route pattern for deleting URL mappings.
 	DeleteUrlAddress = "DELETE /{" + UrlTokenStr + "}"
+	// RestoreUrlAddress is the route pattern for restoring deleted URL mappings.
+	RestoreUrlAddress = "POST /shorten/{" + UrlTokenStr + "}/restore"
+	// GetUrlAddress is the route pattern for reading the details of a URL mapping.
+	GetUrlAddress = "GET /shorten/{" + UrlTokenStr + "}"
+	// ListUrlsAddress is the route pattern for listing the URL mappings of the caller.
+	ListUrlsAddress = "GET /shorten"
 	// StatsUrlAddress is the route pattern for retrieving URL statistics.
 	StatsUrlAddress = "GET /shorten/{" + UrlTokenStr + "}/stats"
+	// RuleIdStr is the path parameter name for destination rule IDs.
+	RuleIdStr = "ruleId"
+	// ListDestinationRulesAddress is the route pattern for listing the destination policy rules.
+	ListDestinationRulesAddress = "GET /admin/destination-rules"
+	// AddDestinationRuleAddress is the route pattern for adding a destination policy rule.
+	AddDestinationRuleAddress = "POST /admin/destination-rules"
+	// DeleteDestinationRuleAddress is the route pattern for deleting a destination policy rule.
+	DeleteDestinationRuleAddress = "DELETE /admin/destination-rules/{" + RuleIdStr + "}"
+	// ReportAbuseAddress is the route pattern for reporting abuse of a short URL.
+	ReportAbuseAddress = "POST /shorten/{" + UrlTokenStr + "}/report"
+	// ReportIdStr is the path parameter name for abuse report IDs.
+	ReportIdStr = "reportId"
+	// ListAbuseReportsAddress is the route pattern for listing abuse reports.
+	ListAbuseReportsAddress = "GET /admin/abuse-reports"
+	// DismissAbuseReportAddress is the route pattern for dismissing an open abuse report.
+	DismissAbuseReportAddress = "POST /admin/abuse-reports/{" + ReportIdStr + "}/dismiss"
+	// TakeDownUrlAddress is the route pattern for taking down a short URL.
+	TakeDownUrlAddress = "PUT /admin/links/{" + UrlTokenStr + "}/takedown"
+	// ReinstateUrlAddress is the route pattern for reinstating a taken down short URL.
+	ReinstateUrlAddress = "DELETE /admin/links/{" + UrlTokenStr + "}/takedown"
+	// ApiKeyIdStr is the path parameter name for API key IDs.
+	ApiKeyIdStr = "keyId"
+	// ListApiKeysAddress is the route pattern for listing API keys.
+	ListApiKeysAddress = "GET /admin/api-keys"
+	// CreateApiKeyAddress is the route pattern for creating an API key.
+	CreateApiKeyAddress = "POST /admin/api-keys"
+	// RevokeApiKeyAddress is the route pattern for revoking an API key.
+	RevokeApiKeyAddress = "DELETE /admin/api-keys/{" + ApiKeyIdStr + "}"
+	// WorkspaceIdStr is the path parameter name for workspace IDs.
+	WorkspaceIdStr = "workspaceId"
+	// ListWorkspacesAddress is the route pattern for listing workspaces.
+	ListWorkspacesAddress = "GET /admin/workspaces"
+	// CreateWorkspaceAddress is the route pattern for creating a workspace.
+	CreateWorkspaceAddress = "POST /admin/workspaces"
+	// UpdateWorkspaceAddress is the route pattern for updating the name and quotas of a workspace.
+	UpdateWorkspaceAddress = "PUT /admin/workspaces/{" + WorkspaceIdStr + "}"
+	// ShortDomainHostStr is the path parameter name for the host names of custom short domains.
+	ShortDomainHostStr = "host"
+	// ListShortDomainsAddress is the route pattern for listing custom short domains.
+	ListShortDomainsAddress = "GET /admin/domains"
+	// AddShortDomainAddress is the route pattern for registering a custom short domain.
+	AddShortDomainAddress = "POST /admin/domains"
+	// DeleteShortDomainAddress is the route pattern for removing a custom short domain.
+	DeleteShortDomainAddress = "DELETE /admin/domains/{" + ShortDomainHostStr + "}"
 )
-
-var validSchemes = map[string]bool{
-	"http":  true,
-	"https": true,
-}
-
-// ValidateURL checks if the provided URL string is valid and has a supported scheme.
-// It validates the URL format and ensures only HTTP or HTTPS schemes are accepted.
-//
-// Returns *InvalidUrlError if:
-//   - The URL cannot be parsed
-//   - The URL has an empty host
-//   - The URL scheme is not http or https
-//
-// Returns nil if the URL is valid.
-func ValidateURL(URL string) error {
-	parsedUrl, err := url.ParseRequestURI(URL)
-
-	if err != nil || parsedUrl.Host == "" {
-		return &InvalidUrlError{Msg: fmt.Sprintf("Invalid url provided: %s", URL)}
-	} else if _, valid := validSchemes[parsedUrl.Scheme]; !valid {
-		return &InvalidUrlError{Msg: fmt.Sprintf("Unsupported URL scheme: %s", parsedUrl.Scheme)}
-	}
-
-	return nil
-}
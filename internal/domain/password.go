@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// MinPasswordLength is the minimum length of a link password in bytes.
+	MinPasswordLength = 4
+	// MaxPasswordLength is the maximum length of a link password in bytes, limited by bcrypt.
+	MaxPasswordLength = 72
+)
+
+// ValidatePassword checks that the optional link password has a supported length.
+// An empty password is always valid and means the link is not protected.
+//
+// Returns *InvalidPasswordError if the password is shorter than MinPasswordLength
+// or longer than MaxPasswordLength bytes.
+func ValidatePassword(password string) error {
+	if password == "" {
+		return nil
+	}
+
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return &InvalidPasswordError{
+			Msg: fmt.Sprintf("Password must be between %d and %d bytes long", MinPasswordLength, MaxPasswordLength),
+		}
+	}
+
+	return nil
+}
+
+// HashPassword returns a salted bcrypt hash of the password.
+//
+// Returns an error if hashing fails.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hashing password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// CheckPassword reports whether the password matches the bcrypt hash.
+//
+// Returns an error if the hash is malformed.
+func CheckPassword(hash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("comparing password hash: %w", err)
+	}
+
+	return true, nil
+}
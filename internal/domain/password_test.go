@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePassword(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		password    string
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name:        "no password",
+			password:    "",
+			expectedErr: nil,
+		},
+		{
+			name:        "valid password",
+			password:    "s3cret",
+			expectedErr: nil,
+		},
+		{
+			name:        "password of maximum length",
+			password:    strings.Repeat("a", MaxPasswordLength),
+			expectedErr: nil,
+		},
+		{
+			name:        "too short password",
+			password:    "abc",
+			expectedErr: &InvalidPasswordError{},
+		},
+		{
+			name:        "too long password",
+			password:    strings.Repeat("a", MaxPasswordLength+1),
+			expectedErr: &InvalidPasswordError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidatePassword(tt.password)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHashPassword_CheckPassword(t *testing.T) {
+	t.Parallel()
+
+	hash, err := HashPassword("s3cret")
+	require.NoError(t, err)
+	assert.NotEqual(t, "s3cret", hash)
+
+	otherHash, err := HashPassword("s3cret")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash, "hashes must be salted")
+
+	ok, err := CheckPassword(hash, "s3cret")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = CheckPassword(hash, "wrong")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = CheckPassword("not-a-hash", "s3cret")
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// linkKeySeparator separates the custom short domain from the token in link keys.
+// Tokens never contain it, so link keys are unambiguous.
+const linkKeySeparator = "/"
+
+// ShortDomain is a custom branded domain short URLs are served at, e.g. go.example.com.
+// Every custom domain is a namespace of its own: the same token may identify different short URLs
+// on different domains and on the default domain, which covers all hosts that are not custom domains.
+type ShortDomain struct {
+	// Host is the host name of the domain in lowercase ASCII form.
+	Host string `json:"host"`
+	// WorkspaceId is the workspace whose API keys may create short URLs on the domain.
+	// Zero means the domain is used by API keys outside of workspaces.
+	WorkspaceId int64 `json:"workspace_id,omitempty"`
+	// CreatedAt is the timestamp when the domain was registered.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NormalizeShortDomain validates the host name of a custom short domain and returns the domain
+// with its host name in lowercase ASCII form, as requests are matched against it.
+//
+// Returns *InvalidShortDomainError if:
+//   - The host name is empty, an IP address or contains a scheme, port or path
+//   - The host name is a single label, contains an empty label or is too long
+func NormalizeShortDomain(shortDomain ShortDomain) (ShortDomain, error) {
+	host, err := NormalizeShortDomainHost(shortDomain.Host)
+	if err != nil {
+		return ShortDomain{}, err
+	}
+
+	shortDomain.Host = host
+	return shortDomain, nil
+}
+
+// NormalizeShortDomainHost returns the host name of a custom short domain in lowercase ASCII form.
+//
+// Returns *InvalidShortDomainError if the host name is not a valid host name of a custom short domain,
+// see NormalizeShortDomain.
+func NormalizeShortDomainHost(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" || strings.ContainsAny(host, ":/?#@[] ") {
+		return "", &InvalidShortDomainError{Msg: fmt.Sprintf("Invalid short domain host name: %q", host)}
+	}
+
+	if _, err := netip.ParseAddr(host); err == nil {
+		return "", &InvalidShortDomainError{Msg: fmt.Sprintf("Short domain must be a host name, not an IP address: %s", host)}
+	}
+
+	asciiHost, err := ToASCIIHost(host)
+	if err != nil {
+		return "", &InvalidShortDomainError{Msg: fmt.Sprintf("Invalid short domain host name: %v", err)}
+	} else if !strings.Contains(asciiHost, ".") {
+		return "", &InvalidShortDomainError{Msg: fmt.Sprintf("Short domain must have at least two labels: %s", asciiHost)}
+	}
+
+	return asciiHost, nil
+}
+
+// LinkKey returns the key a short URL is identified by across all short domains.
+// Short URLs of the default domain (an empty shortDomain) are identified by their token alone,
+// so their keys never change; short URLs of custom domains by the domain, a slash and the token.
+// Link keys are what the storage, the caches, the click counters and the statistics key short URLs by.
+func LinkKey(shortDomain, token string) string {
+	if shortDomain == "" {
+		return token
+	}
+
+	return shortDomain + linkKeySeparator + token
+}
+
+// SplitLinkKey splits a link key into the custom short domain, empty for the default domain, and the token.
+func SplitLinkKey(key string) (shortDomain, token string) {
+	shortDomain, token, found := strings.Cut(key, linkKeySeparator)
+	if !found {
+		return "", key
+	}
+
+	return shortDomain, token
+}
+
+// shortDomainContextKey is the context key the custom short domain of a request is stored under.
+type shortDomainContextKey struct{}
+
+// WithShortDomain returns a copy of ctx carrying the custom short domain the request was made to.
+func WithShortDomain(ctx context.Context, shortDomain string) context.Context {
+	return context.WithValue(ctx, shortDomainContextKey{}, shortDomain)
+}
+
+// ShortDomainFromContext returns the custom short domain the request was made to,
+// or an empty string for requests to the default domain.
+func ShortDomainFromContext(ctx context.Context) string {
+	shortDomain, _ := ctx.Value(shortDomainContextKey{}).(string)
+	return shortDomain
+}
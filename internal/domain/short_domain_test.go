@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeShortDomainHost(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		host          string
+		expectedHost  string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{name: "lowercases host", host: " Go.Acme.COM ", expectedHost: "go.acme.com"},
+		{name: "converts IDN", host: "kurz.bücher.de", expectedHost: "kurz.xn--bcher-kva.de"},
+		{name: "empty host", host: " ", expectedError: true},
+		{name: "single label", host: "localhost", expectedError: true},
+		{name: "with scheme", host: "https://go.acme.com", expectedError: true},
+		{name: "with port", host: "go.acme.com:8080", expectedError: true},
+		{name: "with path", host: "go.acme.com/x", expectedError: true},
+		{name: "IP address", host: "192.0.2.1", expectedError: true},
+		{name: "empty label", host: "go..acme.com", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			host, err := NormalizeShortDomainHost(tt.host)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, &InvalidShortDomainError{})
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedHost, host)
+			}
+		})
+	}
+}
+
+func TestLinkKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "abc123", LinkKey("", "abc123"))
+	assert.Equal(t, "go.acme.com/abc123", LinkKey("go.acme.com", "abc123"))
+
+	shortDomain, token := SplitLinkKey("go.acme.com/abc123")
+	assert.Equal(t, "go.acme.com", shortDomain)
+	assert.Equal(t, "abc123", token)
+
+	shortDomain, token = SplitLinkKey("abc123")
+	assert.Empty(t, shortDomain)
+	assert.Equal(t, "abc123", token)
+
+	assert.Equal(t, "go.acme.com/abc123", MappingInfo{Token: "abc123", Domain: "go.acme.com"}.Key())
+}
+
+func TestShortDomainFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, ShortDomainFromContext(context.Background()))
+	assert.Equal(t, "go.acme.com", ShortDomainFromContext(WithShortDomain(context.Background(), "go.acme.com")))
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PublicBaseUrls builds the complete short URLs of mappings from the public base URLs their short domains
+// are served at, e.g. https://sho.rt or https://example.com/s for a service behind a reverse proxy
+// that routes the path prefix /s to it.
+type PublicBaseUrls struct {
+	defaultBaseUrl *url.URL
+	domainBaseUrls map[string]*url.URL
+}
+
+// NewPublicBaseUrls creates a new PublicBaseUrls instance.
+// Parameters:
+//   - defaultBaseUrl: base URL of the default domain; empty if unknown
+//   - domainBaseUrls: base URLs of custom short domains, each applying to the custom short domain of its host name
+//
+// Returns an error if a base URL is not an absolute http or https URL without credentials, query and fragment,
+// or if two base URLs of custom short domains have the same host name.
+func NewPublicBaseUrls(defaultBaseUrl string, domainBaseUrls []string) (*PublicBaseUrls, error) {
+	baseUrls := &PublicBaseUrls{domainBaseUrls: make(map[string]*url.URL, len(domainBaseUrls))}
+
+	if strings.TrimSpace(defaultBaseUrl) != "" {
+		baseUrl, err := parseBaseUrl(defaultBaseUrl)
+		if err != nil {
+			return nil, err
+		}
+		baseUrls.defaultBaseUrl = baseUrl
+	}
+
+	for _, rawBaseUrl := range domainBaseUrls {
+		baseUrl, err := parseBaseUrl(rawBaseUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		host := baseUrl.Hostname()
+		if _, found := baseUrls.domainBaseUrls[host]; found {
+			return nil, fmt.Errorf("duplicate base URL of short domain %s", host)
+		}
+		baseUrls.domainBaseUrls[host] = baseUrl
+	}
+
+	return baseUrls, nil
+}
+
+// parseBaseUrl parses a public base URL and returns it with its host in ASCII form and without a trailing slash.
+func parseBaseUrl(rawBaseUrl string) (*url.URL, error) {
+	rawBaseUrl = strings.TrimSpace(rawBaseUrl)
+	baseUrl, err := url.Parse(rawBaseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", rawBaseUrl, err)
+	}
+
+	if !validSchemes[baseUrl.Scheme] || baseUrl.Host == "" {
+		return nil, fmt.Errorf("base URL must be an absolute http or https URL: %q", rawBaseUrl)
+	} else if baseUrl.User != nil || baseUrl.RawQuery != "" || baseUrl.Fragment != "" {
+		return nil, fmt.Errorf("base URL must not contain credentials, a query or a fragment: %q", rawBaseUrl)
+	}
+
+	asciiHost, err := ToASCIIHost(baseUrl.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("invalid host of base URL %q: %w", rawBaseUrl, err)
+	}
+	if strings.Contains(asciiHost, ":") {
+		asciiHost = "[" + asciiHost + "]"
+	}
+	if port := baseUrl.Port(); port != "" {
+		asciiHost += ":" + port
+	}
+
+	baseUrl.Host = asciiHost
+	baseUrl.Path = strings.TrimSuffix(baseUrl.Path, "/")
+	baseUrl.RawPath = ""
+	return baseUrl, nil
+}
+
+// ShortUrl returns the complete short URL of the mapping: the base URL of its short domain followed by its token.
+// It reports false if the base URL of the short domain is not configured.
+func (b *PublicBaseUrls) ShortUrl(mapping MappingInfo) (string, bool) {
+	baseUrl := b.defaultBaseUrl
+	if mapping.Domain != "" {
+		baseUrl = b.domainBaseUrls[mapping.Domain]
+	}
+	if baseUrl == nil {
+		return "", false
+	}
+
+	return baseUrl.JoinPath(mapping.Token).String(), true
+}
+
+// Hosts returns the host names of all base URLs, which short URLs are served at.
+func (b *PublicBaseUrls) Hosts() []string {
+	hosts := make([]string, 0, len(b.domainBaseUrls)+1)
+	if b.defaultBaseUrl != nil {
+		hosts = append(hosts, b.defaultBaseUrl.Hostname())
+	}
+	for host := range b.domainBaseUrls {
+		hosts = append(hosts, host)
+	}
+
+	return hosts
+}
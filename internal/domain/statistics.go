@@ -11,22 +11,24 @@ import (
 // RawStatsEvent represents an unprocessed statistics event captured during URL redirection.
 // It contains raw data that needs to be processed before storage.
 type RawStatsEvent struct {
-	UrlToken  string    `json:"url_token"`
-	Timestamp time.Time `json:"timestamp"`
-	IP        string    `json:"ip"`
-	UserAgent string    `json:"user_agent"`
-	Referrer  string    `json:"referrer"`
+	UrlToken    string    `json:"url_token"`
+	Timestamp   time.Time `json:"timestamp"`
+	IP          string    `json:"ip"`
+	UserAgent   string    `json:"user_agent"`
+	Referrer    string    `json:"referrer"`
+	WorkspaceId int64     `json:"workspace_id,omitempty"`
 }
 
 // ProcessedStatsEvent represents a statistics event after processing.
 // IP addresses are resolved to geographic locations and user agents are parsed.
 type ProcessedStatsEvent struct {
-	UrlToken   string
-	Timestamp  time.Time
-	Country    string
-	City       string
-	DeviceType string
-	Referrer   string
+	UrlToken    string
+	Timestamp   time.Time
+	Country     string
+	City        string
+	DeviceType  string
+	Referrer    string
+	WorkspaceId int64
 }
 
 // CalculatedStatistics represents aggregated statistics for a shortened URL.
@@ -66,6 +68,15 @@ type StatisticsCalculator interface {
 	CalculateStatistics(ctx context.Context, urlToken string) (CalculatedStatistics, error)
 }
 
+// WorkspaceStatisticsCalculator defines the interface for calculating aggregated statistics within a workspace.
+type WorkspaceStatisticsCalculator interface {
+	// CalculateWorkspaceStatistics computes aggregated statistics for a given URL token,
+	// counting only the events recorded for the workspace. A workspaceId of 0 counts
+	// the events of short URLs outside of workspaces.
+	// Returns CalculatedStatistics and an error if calculation fails.
+	CalculateWorkspaceStatistics(ctx context.Context, workspaceId int64, urlToken string) (CalculatedStatistics, error)
+}
+
 // StatisticsSender defines the interface for sending raw statistics events to a message bus.
 type StatisticsSender interface {
 	// SendEvent publishes a raw statistics event for asynchronous processing.
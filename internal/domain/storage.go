@@ -8,11 +8,19 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// MappedGetSetter combines URL retrieval and mapping creation capabilities.
+// MappedGetSetter combines cached mapping retrieval, mapping creation and negative caching capabilities.
 // Used for cache implementations that need both read and write access.
 type MappedGetSetter interface {
-	OriginalUrlGetter
+	CachedMappingGetter
 	UrlTokenSetter
+	MissingMappingSetter
+}
+
+// MappingCache combines cached mapping retrieval, creation and deletion capabilities.
+// Used for cache tiers that are stacked in front of other caches.
+type MappingCache interface {
+	MappedGetSetter
+	UrlTokenDeleter
 }
 
 // MappingInfoGetAdder combines mapping info retrieval and creation capabilities.
@@ -22,18 +30,51 @@ type MappingInfoGetAdder interface {
 	MappingInfoAdder
 }
 
-// OriginalUrlGetter defines the interface for retrieving original URLs by their short token.
-type OriginalUrlGetter interface {
-	// GetOriginalUrl retrieves the original URL for a given short URL token.
-	// Returns the original URL and true if found, or empty string and false if not found.
-	GetOriginalUrl(ctx context.Context, shortUrl string) (string, bool)
+// MappingInfoGetLister combines mapping info retrieval and listing capabilities.
+// Used for storage implementations the details of mappings are read from.
+type MappingInfoGetLister interface {
+	MappingInfoGetter
+	OwnedMappingLister
+}
+
+// CacheLookup is the outcome of looking up a short URL token in a cache.
+type CacheLookup int
+
+const (
+	// CacheMiss means nothing is cached for the token.
+	CacheMiss CacheLookup = iota
+	// CacheHit means the mapping of the token is cached.
+	CacheHit
+	// CacheKnownMissing means the token is cached as not existing.
+	CacheKnownMissing
+)
+
+// CachedMappingGetter defines the interface for retrieving cached mappings by their short token.
+type CachedMappingGetter interface {
+	// GetMapping retrieves the cached mapping for a given short URL token.
+	// The mapping contains at least the original URL and the redirect restrictions
+	// (expiration time and click limit) of the token.
+	// Returns the mapping and CacheHit if found, empty MappingInfo and CacheKnownMissing
+	// if the token is cached as not existing, or empty MappingInfo and CacheMiss otherwise.
+	// Returns an error if the cache could not be read; the lookup is then CacheMiss.
+	GetMapping(ctx context.Context, urlToken string) (MappingInfo, CacheLookup, error)
+}
+
+// MissingMappingSetter defines the interface for caching that a token does not exist (negative caching).
+type MissingMappingSetter interface {
+	// SetMissing caches for a short time that no mapping exists for the token.
+	// Implementations must never replace a cached mapping, and SetMapping must always replace
+	// a negative entry, so a concurrently created mapping is never masked.
+	// Returns an error if the negative entry could not be stored.
+	SetMissing(ctx context.Context, urlToken string) error
 }
 
 // UrlTokenSetter defines the interface for creating URL mappings.
 type UrlTokenSetter interface {
 	// SetMapping creates a new mapping between an original URL and its token.
+	// Implementations must not keep the mapping after its ExpiresAt moment.
 	// Returns an error if the mapping could not be created.
-	SetMapping(ctx context.Context, originalUrl, urlToken string) error
+	SetMapping(ctx context.Context, mapping MappingInfo) error
 }
 
 // UrlTokenDeleter defines the interface for deleting URL mappings from cache.
@@ -46,8 +87,9 @@ type UrlTokenDeleter interface {
 // MappingInfoGetter defines the interface for retrieving full mapping information.
 type MappingInfoGetter interface {
 	// GetMappingByToken retrieves complete mapping information for a given token.
-	// Returns MappingInfo and true if found, or empty MappingInfo and false if not found.
-	GetMappingByToken(ctx context.Context, urlToken string) (MappingInfo, bool)
+	// Returns *TokenNonExistingError if the token does not exist,
+	// or another error if the storage could not be queried.
+	GetMappingByToken(ctx context.Context, urlToken string) (MappingInfo, error)
 }
 
 // MappingInfoLastIdGetter defines the interface for retrieving the last used mapping ID.
@@ -59,28 +101,228 @@ type MappingInfoLastIdGetter interface {
 
 // MappingInfoAdder defines the interface for adding new URL mappings with full details.
 type MappingInfoAdder interface {
-	// AddNewMapping creates a new URL mapping with the specified ID, original URL, and token.
+	// AddNewMapping creates a new URL mapping with the ID, original URL, token
+	// and expiration time taken from the given MappingInfo.
 	// Returns the created MappingInfo and an error if the operation fails.
 	// May return *UrlExistingError if a mapping for this URL already exists.
-	AddNewMapping(ctx context.Context, id int64, originalUrl string, shortUrl string) (MappingInfo, error)
+	// May return *TokenExistingError if a mapping with this token already exists or the token was retired.
+	AddNewMapping(ctx context.Context, mapping MappingInfo) (MappingInfo, error)
+}
+
+// ReusableMappingFinder defines the interface for looking up existing mappings by their destination.
+type ReusableMappingFinder interface {
+	// FindReusableMapping retrieves the oldest mapping of the owner on the short domain whose original URL normalizes
+	// to the same URL as originalUrl and that has no expiration time, click limit or password and is not flagged as a threat.
+	// An empty OwnerId only finds mappings without owner, a zero WorkspaceId only mappings outside of workspaces
+	// and an empty shortDomain only mappings of the default domain.
+	// Returns the MappingInfo and true if found, or empty MappingInfo and false if not found,
+	// and an error if the operation fails.
+	FindReusableMapping(ctx context.Context, originalUrl string, owner MappingOwner, shortDomain string) (MappingInfo, bool, error)
+}
+
+// MappingOwnerGetter defines the interface for looking up the owners of URL mappings.
+type MappingOwnerGetter interface {
+	// GetMappingOwner retrieves the workspace and owner ID of a URL mapping by its token, including soft-deleted mappings.
+	// Returns an empty owner ID for mappings without owner, a zero workspace ID for mappings outside of workspaces,
+	// and an error if the operation fails.
+	// May return *TokenNonExistingError if the token does not exist.
+	GetMappingOwner(ctx context.Context, urlToken string) (MappingOwner, error)
+}
+
+// MappingCreationCounter defines the interface for counting the mappings created by a workspace.
+type MappingCreationCounter interface {
+	// CountMappingsCreatedSince counts the mappings of the workspace created at or after since,
+	// including soft-deleted ones, so deleting short URLs does not return quota.
+	// Returns the number of mappings and an error if the operation fails.
+	CountMappingsCreatedSince(ctx context.Context, workspaceId int64, since time.Time) (int64, error)
 }
 
 // MappingInfoUpdater defines the interface for updating existing URL mappings.
 type MappingInfoUpdater interface {
 	// UpdateOriginalUrl updates the original URL for an existing token.
+	// A non-nil opts.ExpiresAt replaces the expiration time and opts.ClearExpiration removes it,
+	// otherwise the current one is kept.
 	// Returns the updated MappingInfo and an error if the operation fails.
 	// May return *TokenNonExistingError if the token does not exist.
-	UpdateOriginalUrl(ctx context.Context, urlToken string, newOriginalUrl string) (MappingInfo, error)
+	UpdateOriginalUrl(ctx context.Context, urlToken string, newOriginalUrl string, opts UpdateOptions) (MappingInfo, error)
 }
 
 // MappingInfoDeleter defines the interface for deleting URL mappings from persistent storage.
 type MappingInfoDeleter interface {
-	// DeleteMappingInfo removes a URL mapping by its token from persistent storage.
+	// DeleteMappingInfo soft-deletes a URL mapping by its token, so it is no longer resolved
+	// but can be restored until it is purged.
 	// Returns an error if the deletion fails.
-	// May return *TokenNonExistingError if the token does not exist.
+	// May return *TokenNonExistingError if the token does not exist or is already deleted.
 	DeleteMappingInfo(ctx context.Context, urlToken string) error
 }
 
+// MappingInfoRestorer defines the interface for restoring soft-deleted URL mappings.
+type MappingInfoRestorer interface {
+	// RestoreMappingInfo undoes the soft deletion of a URL mapping by its token.
+	// Returns an error if the restoration fails.
+	// May return *TokenNonExistingError if no soft-deleted mapping with the token exists.
+	RestoreMappingInfo(ctx context.Context, urlToken string) error
+}
+
+// DeletedMappingPurger defines the interface for permanently removing soft-deleted URL mappings.
+type DeletedMappingPurger interface {
+	// PurgeDeletedMappings permanently removes up to limit mappings soft-deleted before deletedBefore.
+	// The tokens of purged mappings are retired, so they are never issued again.
+	// Returns the number of purged mappings and an error if the operation fails.
+	PurgeDeletedMappings(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+}
+
+// OwnedMappingLister defines the interface for paging through the mappings of an owner.
+type OwnedMappingLister interface {
+	// ListOwnedMappings returns up to limit undeleted mappings of the owner with an ID greater than afterId, ordered by ID.
+	// An empty OwnerId only lists mappings without owner and a zero WorkspaceId only mappings outside of workspaces.
+	ListOwnedMappings(ctx context.Context, owner MappingOwner, afterId int64, limit int) ([]MappingInfo, error)
+}
+
+// MappingScanStore defines the interface for rescanning the destinations of stored mappings.
+type MappingScanStore interface {
+	// ListMappingsAfter returns up to limit mappings with an ID greater than afterId, ordered by ID.
+	// The mappings contain their ID, token, domain, original URL and threat.
+	ListMappingsAfter(ctx context.Context, afterId int64, limit int) ([]MappingInfo, error)
+	// FlagMapping sets the threat of the mapping; an empty threat clears the flag.
+	// Returns *TokenNonExistingError if the token does not exist.
+	FlagMapping(ctx context.Context, urlToken string, threat string) error
+}
+
+// MappingDisabler defines the interface for taking down URL mappings without deleting them.
+type MappingDisabler interface {
+	// DisableMapping sets the reason the mapping is taken down for; an empty reason reinstates the mapping.
+	// Returns *TokenNonExistingError if the token does not exist.
+	DisableMapping(ctx context.Context, urlToken string, reason string) error
+}
+
+// AbuseReportStore defines the interface for persisting abuse reports of short URLs.
+type AbuseReportStore interface {
+	// AddAbuseReport creates a new open abuse report with the token, reason, contact and reporter IP of the given report.
+	// Returns the created report and an error if the operation fails.
+	// May return *TokenNonExistingError if the token does not exist.
+	AddAbuseReport(ctx context.Context, report AbuseReport) (AbuseReport, error)
+	// ListAbuseReports retrieves all abuse reports with the given status, ordered by ID.
+	// Returns the reports and an error if the operation fails.
+	ListAbuseReports(ctx context.Context, status AbuseReportStatus) ([]AbuseReport, error)
+	// ResolveAbuseReport moves an open abuse report to the given status.
+	// Returns an error if the operation fails.
+	// May return *AbuseReportNonExistingError if no open report with the ID exists.
+	ResolveAbuseReport(ctx context.Context, id int64, status AbuseReportStatus) error
+	// ResolveTokenAbuseReports moves all open abuse reports of the token to the given status.
+	// Returns an error if the operation fails.
+	ResolveTokenAbuseReports(ctx context.Context, urlToken string, status AbuseReportStatus) error
+}
+
+// ClickCounter defines the interface for atomically counting consumed clicks of click-limited mappings.
+type ClickCounter interface {
+	// IncrementClicks atomically increments the consumed clicks counter of the token and returns the new value.
+	// A missing counter is initialized with seed first, so counting continues from the persisted value.
+	// A non-nil expiresAt bounds the lifetime of the counter.
+	// Returns the new counter value and an error if the operation fails.
+	IncrementClicks(ctx context.Context, urlToken string, seed int64, expiresAt *time.Time) (int64, error)
+}
+
+// ClickCountSaver defines the interface for persisting consumed clicks of click-limited mappings.
+type ClickCountSaver interface {
+	// SaveClickCount raises the persisted consumed clicks counter of the token to count.
+	// Lower values are ignored, so out-of-order saves never move the counter backwards.
+	// Returns an error if the operation fails.
+	// May return *TokenNonExistingError if the token does not exist.
+	SaveClickCount(ctx context.Context, urlToken string, count int64) error
+}
+
+// DestinationRuleStore defines the interface for persisting the rules of the destination policy.
+type DestinationRuleStore interface {
+	// ListDestinationRules retrieves all destination rules, ordered by ID.
+	// Returns the rules and an error if the operation fails.
+	ListDestinationRules(ctx context.Context) ([]DestinationRule, error)
+	// AddDestinationRule creates a new destination rule with the workspace, action, match type and pattern of the given rule.
+	// Returns the created rule and an error if the operation fails.
+	// May return *DestinationRuleExistingError if an identical rule already exists in the workspace.
+	// May return *WorkspaceNonExistingError if the workspace of the rule does not exist.
+	AddDestinationRule(ctx context.Context, rule DestinationRule) (DestinationRule, error)
+	// DeleteDestinationRule removes a destination rule by its ID.
+	// Returns an error if the deletion fails.
+	// May return *DestinationRuleNonExistingError if the rule does not exist.
+	DeleteDestinationRule(ctx context.Context, id int64) error
+}
+
+// ApiKeyStore defines the interface for persisting API keys.
+// Keys are stored and looked up by their hash only.
+type ApiKeyStore interface {
+	// AddApiKey creates a new API key with the name, owner, workspace, prefix and scopes of the given key and the key hash.
+	// Returns the created key and an error if the operation fails.
+	// May return *WorkspaceNonExistingError if the workspace of the key does not exist.
+	AddApiKey(ctx context.Context, key ApiKey, keyHash string) (ApiKey, error)
+	// GetApiKeyByHash retrieves an active API key by its hash.
+	// Returns the key and an error if the operation fails.
+	// May return *ApiKeyNonExistingError if no active key has the hash.
+	GetApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	// ListApiKeys retrieves all API keys, including revoked ones, ordered by ID.
+	// Returns the keys and an error if the operation fails.
+	ListApiKeys(ctx context.Context) ([]ApiKey, error)
+	// RevokeApiKey revokes an active API key by its ID.
+	// Returns an error if the operation fails.
+	// May return *ApiKeyNonExistingError if no active key with the ID exists.
+	RevokeApiKey(ctx context.Context, id int64) error
+}
+
+// WorkspaceStore defines the interface for persisting workspaces.
+type WorkspaceStore interface {
+	// ListWorkspaces retrieves all workspaces, ordered by ID.
+	// Returns the workspaces and an error if the operation fails.
+	ListWorkspaces(ctx context.Context) ([]Workspace, error)
+	// AddWorkspace creates a new workspace with the name and quotas of the given workspace.
+	// Returns the created workspace and an error if the operation fails.
+	AddWorkspace(ctx context.Context, workspace Workspace) (Workspace, error)
+	// UpdateWorkspace replaces the name and quotas of the workspace with the ID of the given workspace.
+	// Returns the updated workspace and an error if the operation fails.
+	// May return *WorkspaceNonExistingError if no workspace with the ID exists.
+	UpdateWorkspace(ctx context.Context, workspace Workspace) (Workspace, error)
+}
+
+// ShortDomainStore defines the interface for persisting custom short domains.
+type ShortDomainStore interface {
+	// ListShortDomains retrieves all custom short domains, ordered by host name.
+	// Returns the domains and an error if the operation fails.
+	ListShortDomains(ctx context.Context) ([]ShortDomain, error)
+	// AddShortDomain registers a new custom short domain with the host name and workspace of the given domain.
+	// Returns the registered domain and an error if the operation fails.
+	// May return *ShortDomainExistingError if the domain is already registered.
+	// May return *WorkspaceNonExistingError if the workspace of the domain does not exist.
+	AddShortDomain(ctx context.Context, shortDomain ShortDomain) (ShortDomain, error)
+	// DeleteShortDomain removes a custom short domain by its host name.
+	// Returns an error if the deletion fails.
+	// May return *ShortDomainNonExistingError if the domain is not registered.
+	// May return *ShortDomainInUseError if short URLs of the domain still exist.
+	DeleteShortDomain(ctx context.Context, host string) error
+}
+
+// RateLimiter defines the interface for limiting the rate of events with limits that differ per key.
+type RateLimiter interface {
+	// Allow registers an event for the key and reports whether it is within limit events per window.
+	// Returns an error if the event could not be registered.
+	Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error)
+}
+
+// AttemptLimiter defines the interface for throttling repeated attempts, e.g. password guesses.
+type AttemptLimiter interface {
+	// TryAttempt registers an attempt for the key and reports whether it is within the allowed limit.
+	// Returns an error if the attempt could not be registered.
+	TryAttempt(ctx context.Context, key string) (bool, error)
+	// ResetAttempts forgets all registered attempts for the key.
+	// Returns an error if the operation fails.
+	ResetAttempts(ctx context.Context, key string) error
+}
+
+// InvalidationPublisher defines the interface for propagating cache invalidations to all service replicas.
+type InvalidationPublisher interface {
+	// PublishInvalidation announces that the cached mapping of a token is stale.
+	// Returns an error if the invalidation could not be published.
+	PublishInvalidation(ctx context.Context, urlToken string) error
+}
+
 // IdGenerator defines the interface for generating unique mapping IDs.
 type IdGenerator interface {
 	// GetNextId generates and returns the next unique ID for URL mappings.
@@ -88,16 +330,66 @@ type IdGenerator interface {
 	GetNextId(ctx context.Context) (int64, error)
 }
 
+// IdRangeReserver defines the interface for reserving ranges of unique mapping IDs.
+type IdRangeReserver interface {
+	// ReserveIds reserves count consecutive unique IDs.
+	// Returns the last ID of the reserved range and an error if reservation fails.
+	ReserveIds(ctx context.Context, count int64) (int64, error)
+}
+
+// WorkerIdProvider defines the interface for providing the Snowflake worker ID of the instance.
+type WorkerIdProvider interface {
+	// WorkerId returns the worker ID of the instance.
+	// Returns an error if the instance does not hold a valid worker ID.
+	WorkerId() (int64, error)
+}
+
+// WorkerLeaseStore defines the interface for leasing Snowflake worker IDs from persistent storage,
+// so concurrently running instances never share a worker ID.
+type WorkerLeaseStore interface {
+	// AcquireWorkerId leases the lowest worker ID up to maxWorkerId that is not leased by another owner.
+	// Returns the worker ID and an error if every worker ID is leased or the operation fails.
+	AcquireWorkerId(ctx context.Context, owner string, maxWorkerId int64, ttl time.Duration) (int64, error)
+	// RenewWorkerId extends the lease of a worker ID held by owner.
+	// Returns *WorkerLeaseLostError if the lease expired and was taken over, and an error if the operation fails.
+	RenewWorkerId(ctx context.Context, workerId int64, owner string, ttl time.Duration) error
+	// ReleaseWorkerId gives up the lease of a worker ID held by owner.
+	// Returns an error if the operation fails.
+	ReleaseWorkerId(ctx context.Context, workerId int64, owner string) error
+}
+
+// TokenGenerator defines the interface for deriving the token of a new mapping from its unique ID.
+type TokenGenerator interface {
+	// GenerateToken returns the token for the mapping with the given ID.
+	// Returns the token and an error if the ID cannot be turned into a token.
+	GenerateToken(id int64) (string, error)
+}
+
 // KeyStorage defines the interface for basic key-value operations in Redis.
 type KeyStorage interface {
 	KeySetter
+	KeyNxSetter
 	KeyGetter
 	KeyDeleter
+	KeyExpirer
 }
 
-// KeySetIncrementer defines the interface for setting and incrementing keys in Redis.
-type KeySetIncrementer interface {
-	KeySetter
+// KeyCounter defines the interface for reading and incrementing counters in Redis.
+type KeyCounter interface {
+	KeyGetter
+	IncrBy(ctx context.Context, key string, value int64) *redis.IntCmd
+}
+
+// KeySetNxIncrementer defines the interface for initializing and incrementing counters in Redis.
+type KeySetNxIncrementer interface {
+	KeyNxSetter
+	Incr(ctx context.Context, key string) *redis.IntCmd
+}
+
+// KeyAttemptCounter defines the interface for counting attempts within a time window in Redis.
+type KeyAttemptCounter interface {
+	KeyDeleter
+	KeyExpirer
 	Incr(ctx context.Context, key string) *redis.IntCmd
 }
 
@@ -111,7 +403,22 @@ type KeyDeleter interface {
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 }
 
+// KeyExpirer defines the interface for setting the TTL of keys in Redis.
+type KeyExpirer interface {
+	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
+}
+
+// KeyPublisher defines the interface for publishing messages to channels in Redis.
+type KeyPublisher interface {
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+}
+
 // KeySetter defines the interface for setting keys in Redis.
 type KeySetter interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
 }
+
+// KeyNxSetter defines the interface for setting keys in Redis only if they do not exist yet.
+type KeyNxSetter interface {
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+}
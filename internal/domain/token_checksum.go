@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// MaxTokenSuggestions is the maximum number of corrections suggested for a mistyped token.
+const MaxTokenSuggestions = 3
+
+// confusableCharacters groups characters that are easily mistaken for each other
+// when a printed token is typed in.
+var confusableCharacters = []string{"0Oo", "1lIi", "2Zz", "5Ss", "6Gb", "8B", "9gq", "uvV"}
+
+// ValidateToken rejects a token of the checked length whose check character does not match,
+// without a storage lookup. Tokens of any other length, like custom aliases and tokens issued
+// before check digits were enabled, are always accepted, as they cannot be verified.
+// Aliases of the checked length created before check digits were enabled may fail validation,
+// so a rejected token is only mistyped if no link with it exists.
+// Every token is accepted if check digits are disabled.
+//
+// Returns *MalformedTokenError if the token has the checked length and an invalid check character.
+func (e *AlphabetEncoder) ValidateToken(token string) error {
+	if !e.checkDigit || len(token) != e.minLength+1 {
+		return nil
+	}
+
+	if !e.hasValidCheckDigit(token) {
+		return &MalformedTokenError{Msg: fmt.Sprintf("token check digit does not match: %s", token)}
+	}
+
+	return nil
+}
+
+// SuggestTokens returns up to MaxTokenSuggestions tokens with a valid check character
+// that differ from the mistyped token by one swap of adjacent characters or by one
+// confusable character, in that order. Tokens that are valid yield no suggestions.
+func (e *AlphabetEncoder) SuggestTokens(token string) []string {
+	if e.ValidateToken(token) == nil {
+		return nil
+	}
+
+	suggestions := make([]string, 0, MaxTokenSuggestions)
+	seen := make(map[string]bool)
+	suggest := func(candidate []byte) bool {
+		key := string(candidate)
+		if !seen[key] && e.hasValidCheckDigit(key) {
+			seen[key] = true
+			suggestions = append(suggestions, key)
+		}
+		return len(suggestions) == MaxTokenSuggestions
+	}
+
+	candidate := []byte(token)
+	for i := 0; i+1 < len(candidate); i++ {
+		candidate[i], candidate[i+1] = candidate[i+1], candidate[i]
+		full := suggest(candidate)
+		candidate[i], candidate[i+1] = candidate[i+1], candidate[i]
+		if full {
+			return suggestions
+		}
+	}
+
+	for i, original := range []byte(token) {
+		for _, replacement := range e.confusablesOf(rune(original)) {
+			candidate[i] = byte(replacement)
+			full := suggest(candidate)
+			candidate[i] = original
+			if full {
+				return suggestions
+			}
+		}
+	}
+
+	return suggestions
+}
+
+// confusablesOf returns the characters of the alphabet that are easily mistaken for char,
+// including char in the other case.
+func (e *AlphabetEncoder) confusablesOf(char rune) []rune {
+	var confusables []rune
+	add := func(r rune) {
+		if _, ok := e.digits[r]; ok && r != char {
+			confusables = append(confusables, r)
+		}
+	}
+
+	add(unicode.ToUpper(char))
+	add(unicode.ToLower(char))
+	for _, group := range confusableCharacters {
+		for _, member := range group {
+			if member != char {
+				continue
+			}
+			for _, other := range group {
+				add(other)
+			}
+		}
+	}
+
+	return confusables
+}
+
+// checkValue computes the Luhn mod N check value of a token without its check character.
+// Appending the character of the check value makes the weighted digit sum of the token divisible by N,
+// which detects every single mistyped character and most swaps of adjacent characters.
+func (e *AlphabetEncoder) checkValue(token string) int64 {
+	size := int64(len(e.alphabet))
+	return (size - e.weightedSum(token, 2)%size) % size
+}
+
+// hasValidCheckDigit reports whether the last character of the token is its valid check character.
+func (e *AlphabetEncoder) hasValidCheckDigit(token string) bool {
+	if len(token) < 2 {
+		return false
+	}
+
+	for _, char := range token {
+		if _, ok := e.digits[char]; !ok {
+			return false
+		}
+	}
+
+	return e.weightedSum(token, 1)%int64(len(e.alphabet)) == 0
+}
+
+// weightedSum sums the digits of the token from right to left, doubling every other digit
+// starting with the given factor and folding doubled digits back into the alphabet range.
+// All characters of the token must be part of the alphabet.
+func (e *AlphabetEncoder) weightedSum(token string, factor int64) int64 {
+	size := int64(len(e.alphabet))
+
+	var sum int64
+	for i := len(token) - 1; i >= 0; i-- {
+		addend := factor * e.digits[rune(token[i])]
+		sum += addend/size + addend%size
+		factor = 3 - factor
+	}
+
+	return sum
+}
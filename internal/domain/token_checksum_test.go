@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlphabetEncoder_CheckDigit(t *testing.T) {
+	t.Parallel()
+
+	encoder, err := NewTokenEncoder(TokenEncodingBase58, 6, true)
+	require.NoError(t, err)
+
+	for id := int64(0); id < 2000; id += 7 {
+		token := encoder.Encode(id)
+		assert.Len(t, token, 7)
+		assert.NoError(t, encoder.ValidateToken(token))
+
+		decoded, err := encoder.Decode(token)
+		assert.NoError(t, err)
+		assert.Equal(t, id, decoded)
+
+		for i := 0; i < len(token); i++ {
+			for _, char := range Base58Alphabet {
+				if byte(char) == token[i] {
+					continue
+				}
+				mistyped := token[:i] + string(char) + token[i+1:]
+				assert.ErrorIs(t, encoder.ValidateToken(mistyped), &MalformedTokenError{}, "mistyped token %s of %s", mistyped, token)
+			}
+		}
+	}
+}
+
+func TestAlphabetEncoder_ValidateToken(t *testing.T) {
+	t.Parallel()
+
+	checked, err := NewTokenEncoder(TokenEncodingBase62, 6, true)
+	require.NoError(t, err)
+	unchecked, err := NewTokenEncoder(TokenEncodingBase62, 6, false)
+	require.NoError(t, err)
+
+	token := checked.Encode(543643)
+	mistyped := token[:len(token)-1] + string(Base62Alphabet[(checked.digits[rune(token[len(token)-1])]+1)%62])
+
+	type testCase struct {
+		name    string
+		encoder *AlphabetEncoder
+		token   string
+		wantErr bool
+	}
+
+	testCases := []testCase{
+		{
+			name:    "valid token",
+			encoder: checked,
+			token:   token,
+			wantErr: false,
+		},
+		{
+			name:    "mistyped token",
+			encoder: checked,
+			token:   mistyped,
+			wantErr: true,
+		},
+		{
+			name:    "token of the checked length with an invalid character",
+			encoder: checked,
+			token:   "crAB-x1",
+			wantErr: true,
+		},
+		{
+			name:    "legacy token",
+			encoder: checked,
+			token:   "crAB",
+			wantErr: false,
+		},
+		{
+			name:    "custom alias",
+			encoder: checked,
+			token:   "spring-sale",
+			wantErr: false,
+		},
+		{
+			name:    "check digits disabled",
+			encoder: unchecked,
+			token:   mistyped,
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.encoder.ValidateToken(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, &MalformedTokenError{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAlphabetEncoder_SuggestTokens(t *testing.T) {
+	t.Parallel()
+
+	encoder, err := NewTokenEncoder(TokenEncodingBase62, 6, true)
+	require.NoError(t, err)
+
+	token := encoder.Encode(12345)
+	swapped := token[:2] + token[3:4] + token[2:3] + token[4:]
+	require.Error(t, encoder.ValidateToken(swapped))
+
+	suggestions := encoder.SuggestTokens(swapped)
+
+	assert.Contains(t, suggestions, token)
+	assert.LessOrEqual(t, len(suggestions), MaxTokenSuggestions)
+	for _, suggestion := range suggestions {
+		assert.NoError(t, encoder.ValidateToken(suggestion))
+	}
+	assert.Empty(t, encoder.SuggestTokens(token))
+}
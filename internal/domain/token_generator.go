@@ -1,14 +1,174 @@
 package domain
 
-import "strings"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// TokenEncoding names an alphabet tokens are encoded with.
+type TokenEncoding string
 
 const (
-	// alphabet contains all valid characters for token generation (base62).
-	alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	// base is the number of characters in the alphabet (62).
-	base = int64(len(alphabet))
+	// TokenEncodingBase62 encodes tokens with lowercase letters, uppercase letters and digits.
+	TokenEncodingBase62 TokenEncoding = "base62"
+	// TokenEncodingBase58 encodes tokens without the easily confused characters 0, O, l and I.
+	TokenEncodingBase58 TokenEncoding = "base58"
+	// TokenEncodingBase36 encodes tokens with lowercase letters and digits only,
+	// for channels that do not preserve case, like SMS.
+	TokenEncodingBase36 TokenEncoding = "base36"
 )
 
+const (
+	// Base62Alphabet contains all characters of base62 tokens, in digit order.
+	Base62Alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// Base58Alphabet contains all characters of base58 tokens, in digit order.
+	Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// Base36Alphabet contains all characters of base36 tokens, in digit order.
+	Base36Alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	// MaxTokenMinLength is the largest minimum length tokens can be padded to.
+	MaxTokenMinLength = 32
+)
+
+// base62Encoder encodes the tokens of GenerateToken.
+var base62Encoder = newAlphabetEncoder(Base62Alphabet, 0)
+
+// TokenEncoder converts mapping IDs to tokens and back.
+type TokenEncoder interface {
+	// Encode returns the token of a non-negative ID.
+	Encode(id int64) string
+	// Decode returns the ID the token was encoded from, without a storage lookup.
+	// Returns *MalformedTokenError if the token cannot have been produced by Encode.
+	Decode(token string) (int64, error)
+}
+
+// AlphabetEncoder encodes IDs as numbers written in the digits of an alphabet,
+// left-padded with the zero digit to a minimum length.
+// With check digits enabled, a Luhn mod N check character is appended to every token,
+// so mistyped tokens of the checked length are detected without a storage lookup.
+type AlphabetEncoder struct {
+	alphabet   string
+	digits     map[rune]int64
+	minLength  int
+	checkDigit bool
+}
+
+// NewTokenEncoder creates the AlphabetEncoder of the given encoding.
+// Parameters:
+//   - encoding: the alphabet to encode tokens with
+//   - minLength: the length shorter tokens are padded to, excluding the check digit; zero disables padding
+//   - checkDigit: whether a check character is appended to every token
+//
+// Returns an error if:
+//   - The encoding is unknown
+//   - minLength is negative or greater than MaxTokenMinLength
+//   - Check digits are enabled without a positive minLength
+func NewTokenEncoder(encoding TokenEncoding, minLength int, checkDigit bool) (*AlphabetEncoder, error) {
+	var encodingAlphabet string
+	switch encoding {
+	case TokenEncodingBase62:
+		encodingAlphabet = Base62Alphabet
+	case TokenEncodingBase58:
+		encodingAlphabet = Base58Alphabet
+	case TokenEncodingBase36:
+		encodingAlphabet = Base36Alphabet
+	default:
+		return nil, fmt.Errorf("unknown token encoding: %q", encoding)
+	}
+
+	if minLength < 0 || minLength > MaxTokenMinLength {
+		return nil, fmt.Errorf("token minimum length must be between 0 and %d: %d", MaxTokenMinLength, minLength)
+	}
+	if checkDigit && minLength == 0 {
+		return nil, fmt.Errorf("token check digits require a token minimum length")
+	}
+
+	encoder := newAlphabetEncoder(encodingAlphabet, minLength)
+	encoder.checkDigit = checkDigit
+	return encoder, nil
+}
+
+// newAlphabetEncoder creates an AlphabetEncoder of an alphabet of unique characters.
+func newAlphabetEncoder(encodingAlphabet string, minLength int) *AlphabetEncoder {
+	digits := make(map[rune]int64, len(encodingAlphabet))
+	for i, char := range encodingAlphabet {
+		digits[char] = int64(i)
+	}
+
+	return &AlphabetEncoder{
+		alphabet:  encodingAlphabet,
+		digits:    digits,
+		minLength: minLength,
+	}
+}
+
+// Encode returns the token of a non-negative ID, padded to the minimum length
+// and followed by the check character if check digits are enabled.
+//
+// For ID = 0, it returns the zero digit of the alphabet.
+func (e *AlphabetEncoder) Encode(id int64) string {
+	size := int64(len(e.alphabet))
+
+	var sb strings.Builder
+	sb.WriteByte(e.alphabet[id%size])
+	for id /= size; id > 0; id /= size {
+		sb.WriteByte(e.alphabet[id%size])
+	}
+	for sb.Len() < e.minLength {
+		sb.WriteByte(e.alphabet[0])
+	}
+
+	token := reverse(sb.String())
+	if e.checkDigit {
+		token += string(e.alphabet[e.checkValue(token)])
+	}
+
+	return token
+}
+
+// Decode returns the ID the token was encoded from.
+// Only the exact token Encode produces for an ID is accepted, so every ID has a single valid token.
+//
+// Returns *MalformedTokenError if:
+//   - The token is empty or contains characters outside the alphabet
+//   - The check character does not match the rest of the token
+//   - The token has redundant padding or is shorter than the minimum length
+//   - The decoded ID does not fit into int64
+func (e *AlphabetEncoder) Decode(token string) (int64, error) {
+	if e.checkDigit {
+		if !e.hasValidCheckDigit(token) {
+			return 0, &MalformedTokenError{Msg: fmt.Sprintf("token check digit does not match: %s", token)}
+		}
+		token = token[:len(token)-1]
+	}
+
+	if token == "" {
+		return 0, &MalformedTokenError{Msg: "token is empty"}
+	}
+	if len(token) < e.minLength {
+		return 0, &MalformedTokenError{Msg: fmt.Sprintf("token is shorter than %d characters: %s", e.minLength, token)}
+	}
+	if len(token) > e.minLength && len(token) > 1 && token[0] == e.alphabet[0] {
+		return 0, &MalformedTokenError{Msg: fmt.Sprintf("token has redundant padding: %s", token)}
+	}
+
+	size := int64(len(e.alphabet))
+	var id int64
+	for _, char := range token {
+		digit, ok := e.digits[char]
+		if !ok {
+			return 0, &MalformedTokenError{Msg: fmt.Sprintf("token contains invalid character %q: %s", char, token)}
+		}
+		if id > (math.MaxInt64-digit)/size {
+			return 0, &MalformedTokenError{Msg: fmt.Sprintf("token is out of range: %s", token)}
+		}
+		id = id*size + digit
+	}
+
+	return id, nil
+}
+
 // GenerateToken generates a short alphanumeric token from a given int64 ID.
 // The token is a base62 encoded representation of the ID, using lowercase letters,
 // uppercase letters, and digits.
@@ -22,18 +182,7 @@ const (
 //	GenerateToken(1)  -> "b"
 //	GenerateToken(62) -> "ba"
 func GenerateToken(id int64) string {
-	if id == 0 {
-		return string(alphabet[0])
-	}
-
-	var sb strings.Builder
-	for id > 0 {
-		remainder := id % base
-		sb.WriteByte(alphabet[remainder])
-		id = id / base
-	}
-
-	return reverse(sb.String())
+	return base62Encoder.Encode(id)
 }
 
 // reverse reverses a string and returns the result.
@@ -89,3 +89,233 @@ func TestReverse(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTokenEncoder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name       string
+		encoding   TokenEncoding
+		minLength  int
+		checkDigit bool
+		wantErr    bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "base62",
+			encoding: TokenEncodingBase62,
+			wantErr:  false,
+		},
+		{
+			name:      "base58 with minimum length",
+			encoding:  TokenEncodingBase58,
+			minLength: 6,
+			wantErr:   false,
+		},
+		{
+			name:      "base36 with maximum minimum length",
+			encoding:  TokenEncodingBase36,
+			minLength: MaxTokenMinLength,
+			wantErr:   false,
+		},
+		{
+			name:       "check digit with minimum length",
+			encoding:   TokenEncodingBase62,
+			minLength:  7,
+			checkDigit: true,
+			wantErr:    false,
+		},
+		{
+			name:       "check digit without minimum length",
+			encoding:   TokenEncodingBase62,
+			checkDigit: true,
+			wantErr:    true,
+		},
+		{
+			name:     "unknown encoding",
+			encoding: "base64",
+			wantErr:  true,
+		},
+		{
+			name:      "negative minimum length",
+			encoding:  TokenEncodingBase62,
+			minLength: -1,
+			wantErr:   true,
+		},
+		{
+			name:      "too long minimum length",
+			encoding:  TokenEncodingBase62,
+			minLength: MaxTokenMinLength + 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			encoder, err := NewTokenEncoder(tt.encoding, tt.minLength, tt.checkDigit)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, encoder)
+		})
+	}
+}
+
+func TestAlphabetEncoder_Encode(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		alphabet    string
+		minLength   int
+		id          int64
+		expectedRes string
+	}
+
+	testCases := []testCase{
+		{
+			name:        "base62 without padding",
+			alphabet:    Base62Alphabet,
+			id:          62,
+			expectedRes: "ba",
+		},
+		{
+			name:        "base62 padded",
+			alphabet:    Base62Alphabet,
+			minLength:   6,
+			id:          1,
+			expectedRes: "aaaaab",
+		},
+		{
+			name:        "padding does not truncate",
+			alphabet:    Base62Alphabet,
+			minLength:   2,
+			id:          543643,
+			expectedRes: "crAB",
+		},
+		{
+			name:        "base58 zero",
+			alphabet:    Base58Alphabet,
+			id:          0,
+			expectedRes: "1",
+		},
+		{
+			name:        "base58",
+			alphabet:    Base58Alphabet,
+			id:          58,
+			expectedRes: "21",
+		},
+		{
+			name:        "base36",
+			alphabet:    Base36Alphabet,
+			id:          543643,
+			expectedRes: "lxrh",
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res := newAlphabetEncoder(tt.alphabet, tt.minLength).Encode(tt.id)
+			assert.Equal(t, tt.expectedRes, res)
+		})
+	}
+}
+
+func TestAlphabetEncoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		alphabet    string
+		minLength   int
+		token       string
+		expectedRes int64
+		wantErr     bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "base62",
+			alphabet:    Base62Alphabet,
+			token:       "crAB",
+			expectedRes: 543643,
+		},
+		{
+			name:        "base62 zero",
+			alphabet:    Base62Alphabet,
+			token:       "a",
+			expectedRes: 0,
+		},
+		{
+			name:        "padded token",
+			alphabet:    Base62Alphabet,
+			minLength:   6,
+			token:       "aaaaab",
+			expectedRes: 1,
+		},
+		{
+			name:        "base58",
+			alphabet:    Base58Alphabet,
+			token:       "21",
+			expectedRes: 58,
+		},
+		{
+			name:     "empty token",
+			alphabet: Base62Alphabet,
+			token:    "",
+			wantErr:  true,
+		},
+		{
+			name:     "character outside of the alphabet",
+			alphabet: Base58Alphabet,
+			token:    "0O",
+			wantErr:  true,
+		},
+		{
+			name:     "uppercase base36",
+			alphabet: Base36Alphabet,
+			token:    "LXRH",
+			wantErr:  true,
+		},
+		{
+			name:     "redundant padding",
+			alphabet: Base62Alphabet,
+			token:    "ab",
+			wantErr:  true,
+		},
+		{
+			name:      "shorter than minimum length",
+			alphabet:  Base62Alphabet,
+			minLength: 6,
+			token:     "b",
+			wantErr:   true,
+		},
+		{
+			name:     "out of range",
+			alphabet: Base62Alphabet,
+			token:    "9999999999999",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := newAlphabetEncoder(tt.alphabet, tt.minLength).Decode(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, &MalformedTokenError{})
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRes, res)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// TokenStrategy selects how the tokens of new mappings are derived from their IDs.
+type TokenStrategy string
+
+const (
+	// TokenStrategySequential encodes the ID itself, so consecutive mappings get consecutive tokens.
+	TokenStrategySequential TokenStrategy = "sequential"
+	// TokenStrategyFeistel encodes a keyed permutation of the ID, so tokens cannot be enumerated
+	// without the secret while staying unique and short.
+	TokenStrategyFeistel TokenStrategy = "feistel"
+	// TokenStrategyRandom generates random tokens independent of the ID. Collisions with existing
+	// tokens are retried by the caller.
+	TokenStrategyRandom TokenStrategy = "random"
+)
+
+const (
+	// MinTokenSecretLength is the minimum length of the secret keying the Feistel permutation in bytes.
+	MinTokenSecretLength = 16
+
+	// feistelHalfBits is the width of each half of the permuted value.
+	feistelHalfBits = 24
+	// feistelHalfMask masks a value to the width of one half.
+	feistelHalfMask = 1<<feistelHalfBits - 1
+	// feistelRounds is the number of Feistel rounds applied to an ID.
+	feistelRounds = 4
+	// MaxFeistelId is the largest ID the Feistel permutation can obfuscate.
+	MaxFeistelId = 1<<(2*feistelHalfBits) - 1
+
+	// randomTokenBits is the number of random bits encoded into a random token.
+	randomTokenBits = 48
+)
+
+// TokenDecoder is implemented by token generators whose tokens can be mapped back to the mapping ID
+// without a storage lookup.
+type TokenDecoder interface {
+	// DecodeToken returns the ID the token was generated from.
+	// Returns *MalformedTokenError if the token cannot have been generated.
+	DecodeToken(token string) (int64, error)
+}
+
+// NewTokenGenerator creates the TokenGenerator of the given strategy.
+// Parameters:
+//   - strategy: the token strategy to use
+//   - secret: the secret keying the Feistel permutation; ignored by the other strategies
+//   - encoder: the encoder turning IDs into tokens
+//
+// Returns an error if:
+//   - The strategy is unknown
+//   - The Feistel strategy is selected with a secret shorter than MinTokenSecretLength
+func NewTokenGenerator(strategy TokenStrategy, secret string, encoder TokenEncoder) (TokenGenerator, error) {
+	switch strategy {
+	case TokenStrategySequential:
+		return NewSequentialTokenGenerator(encoder), nil
+	case TokenStrategyFeistel:
+		return NewFeistelTokenGenerator(secret, encoder)
+	case TokenStrategyRandom:
+		return NewRandomTokenGenerator(encoder), nil
+	default:
+		return nil, fmt.Errorf("unknown token strategy: %q", strategy)
+	}
+}
+
+// SequentialTokenGenerator generates tokens that are the encoding of the mapping ID.
+// The zero value generates unpadded base62 tokens, like GenerateToken.
+type SequentialTokenGenerator struct {
+	encoder TokenEncoder
+}
+
+// NewSequentialTokenGenerator creates a new SequentialTokenGenerator instance.
+// Parameters:
+//   - encoder: the encoder turning IDs into tokens
+func NewSequentialTokenGenerator(encoder TokenEncoder) SequentialTokenGenerator {
+	return SequentialTokenGenerator{encoder: encoder}
+}
+
+// GenerateToken returns the encoding of the ID. It never fails.
+func (g SequentialTokenGenerator) GenerateToken(id int64) (string, error) {
+	return g.tokenEncoder().Encode(id), nil
+}
+
+// DecodeToken returns the ID the token encodes.
+//
+// Returns *MalformedTokenError if the token cannot have been produced by the encoder.
+func (g SequentialTokenGenerator) DecodeToken(token string) (int64, error) {
+	return g.tokenEncoder().Decode(token)
+}
+
+// tokenEncoder returns the configured encoder, or the base62 encoder of the zero value.
+func (g SequentialTokenGenerator) tokenEncoder() TokenEncoder {
+	if g.encoder == nil {
+		return base62Encoder
+	}
+
+	return g.encoder
+}
+
+// FeistelTokenGenerator generates tokens that are the encoding of a keyed Feistel
+// permutation of the mapping ID. Distinct IDs always map to distinct tokens,
+// but consecutive IDs map to unrelated tokens.
+type FeistelTokenGenerator struct {
+	key     []byte
+	encoder TokenEncoder
+}
+
+// NewFeistelTokenGenerator creates a new FeistelTokenGenerator instance.
+// Parameters:
+//   - secret: the secret keying the permutation; changing it changes the tokens of all future mappings
+//   - encoder: the encoder turning permuted IDs into tokens
+//
+// Returns an error if the secret is shorter than MinTokenSecretLength bytes.
+func NewFeistelTokenGenerator(secret string, encoder TokenEncoder) (*FeistelTokenGenerator, error) {
+	if len(secret) < MinTokenSecretLength {
+		return nil, fmt.Errorf("token secret must be at least %d bytes long", MinTokenSecretLength)
+	}
+
+	return &FeistelTokenGenerator{key: []byte(secret), encoder: encoder}, nil
+}
+
+// GenerateToken returns the encoding of the permuted ID.
+//
+// Returns an error if the ID is negative or greater than MaxFeistelId.
+func (g *FeistelTokenGenerator) GenerateToken(id int64) (string, error) {
+	if id < 0 || id > MaxFeistelId {
+		return "", fmt.Errorf("id %d is out of the range of the token permutation", id)
+	}
+
+	return g.encoder.Encode(g.permute(id)), nil
+}
+
+// DecodeToken returns the ID the token was generated from by reverting the permutation.
+//
+// Returns *MalformedTokenError if the token cannot have been produced by the encoder
+// or encodes a value outside of the permutation.
+func (g *FeistelTokenGenerator) DecodeToken(token string) (int64, error) {
+	value, err := g.encoder.Decode(token)
+	if err != nil {
+		return 0, err
+	}
+	if value > MaxFeistelId {
+		return 0, &MalformedTokenError{Msg: fmt.Sprintf("token is out of the range of the token permutation: %s", token)}
+	}
+
+	return g.restore(value), nil
+}
+
+// permute applies the Feistel rounds to the ID.
+func (g *FeistelTokenGenerator) permute(id int64) int64 {
+	left, right := id>>feistelHalfBits, id&feistelHalfMask
+	for round := 0; round < feistelRounds; round++ {
+		left, right = right, left^g.roundFunction(round, right)
+	}
+
+	return left<<feistelHalfBits | right
+}
+
+// restore reverts permute, returning the ID the permuted value was derived from.
+func (g *FeistelTokenGenerator) restore(value int64) int64 {
+	left, right := value>>feistelHalfBits, value&feistelHalfMask
+	for round := feistelRounds - 1; round >= 0; round-- {
+		left, right = right^g.roundFunction(round, left), left
+	}
+
+	return left<<feistelHalfBits | right
+}
+
+// roundFunction derives the keyed value one half is mixed with in the given round.
+func (g *FeistelTokenGenerator) roundFunction(round int, half int64) int64 {
+	var input [9]byte
+	input[0] = byte(round)
+	binary.BigEndian.PutUint64(input[1:], uint64(half))
+
+	mac := hmac.New(sha256.New, g.key)
+	mac.Write(input[:])
+	sum := mac.Sum(nil)
+
+	return int64(binary.BigEndian.Uint32(sum[:4])) & feistelHalfMask
+}
+
+// RandomTokenGenerator generates tokens that are the encoding of a random number
+// of randomTokenBits bits, independent of the mapping ID.
+type RandomTokenGenerator struct {
+	encoder TokenEncoder
+}
+
+// NewRandomTokenGenerator creates a new RandomTokenGenerator instance.
+// Parameters:
+//   - encoder: the encoder turning random numbers into tokens
+func NewRandomTokenGenerator(encoder TokenEncoder) RandomTokenGenerator {
+	return RandomTokenGenerator{encoder: encoder}
+}
+
+// GenerateToken returns a random token. The ID is ignored.
+//
+// Returns an error if reading random bytes fails.
+func (g RandomTokenGenerator) GenerateToken(_ int64) (string, error) {
+	var buf [8]byte
+	_, err := rand.Read(buf[:])
+	if err != nil {
+		return "", fmt.Errorf("reading random bytes: %w", err)
+	}
+
+	return g.encoder.Encode(int64(binary.BigEndian.Uint64(buf[:]) >> (64 - randomTokenBits))), nil
+}
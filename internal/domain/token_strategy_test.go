@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenSecret = "0123456789abcdef"
+
+func TestNewTokenGenerator(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		strategy TokenStrategy
+		secret   string
+		wantErr  bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "sequential",
+			strategy: TokenStrategySequential,
+			wantErr:  false,
+		},
+		{
+			name:     "feistel",
+			strategy: TokenStrategyFeistel,
+			secret:   testTokenSecret,
+			wantErr:  false,
+		},
+		{
+			name:     "feistel with short secret",
+			strategy: TokenStrategyFeistel,
+			secret:   "short",
+			wantErr:  true,
+		},
+		{
+			name:     "random",
+			strategy: TokenStrategyRandom,
+			wantErr:  false,
+		},
+		{
+			name:     "unknown strategy",
+			strategy: "hashed",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			generator, err := NewTokenGenerator(tt.strategy, tt.secret, base62Encoder)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, generator)
+		})
+	}
+}
+
+func TestSequentialTokenGenerator_KeepsExistingTokens(t *testing.T) {
+	t.Parallel()
+
+	token, err := SequentialTokenGenerator{}.GenerateToken(543643)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "crAB", token)
+}
+
+func TestSequentialTokenGenerator_DecodeToken(t *testing.T) {
+	t.Parallel()
+
+	generator := NewSequentialTokenGenerator(newAlphabetEncoder(Base36Alphabet, 6))
+
+	token, err := generator.GenerateToken(543643)
+	require.NoError(t, err)
+	id, err := generator.DecodeToken(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "aalxrh", token)
+	assert.Equal(t, int64(543643), id)
+}
+
+func TestFeistelTokenGenerator_GenerateToken(t *testing.T) {
+	t.Parallel()
+
+	generator, err := NewFeistelTokenGenerator(testTokenSecret, base62Encoder)
+	require.NoError(t, err)
+
+	type testCase struct {
+		name    string
+		id      int64
+		wantErr bool
+	}
+
+	testCases := []testCase{
+		{
+			name:    "first id",
+			id:      0,
+			wantErr: false,
+		},
+		{
+			name:    "max id",
+			id:      MaxFeistelId,
+			wantErr: false,
+		},
+		{
+			name:    "negative id",
+			id:      -1,
+			wantErr: true,
+		},
+		{
+			name:    "id out of range",
+			id:      MaxFeistelId + 1,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			token, err := generator.GenerateToken(tt.id)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, token)
+		})
+	}
+}
+
+func TestFeistelTokenGenerator_IsReversiblePermutation(t *testing.T) {
+	t.Parallel()
+
+	generator, err := NewFeistelTokenGenerator(testTokenSecret, base62Encoder)
+	require.NoError(t, err)
+
+	seen := make(map[int64]int64)
+	for id := int64(1); id <= 1000; id++ {
+		permuted := generator.permute(id)
+		assert.Equal(t, id, generator.restore(permuted))
+		assert.LessOrEqual(t, permuted, int64(MaxFeistelId))
+
+		token, err := generator.GenerateToken(id)
+		require.NoError(t, err)
+		decoded, err := generator.DecodeToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, id, decoded)
+
+		previous, duplicate := seen[permuted]
+		assert.False(t, duplicate, "ids %d and %d permute to the same value", previous, id)
+		seen[permuted] = id
+	}
+
+	assert.NotEqual(t, generator.permute(1)+1, generator.permute(2))
+}
+
+func TestFeistelTokenGenerator_DecodeTokenOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	generator, err := NewFeistelTokenGenerator(testTokenSecret, base62Encoder)
+	require.NoError(t, err)
+
+	_, err = generator.DecodeToken(base62Encoder.Encode(MaxFeistelId + 1))
+
+	assert.ErrorIs(t, err, &MalformedTokenError{})
+}
+
+func TestFeistelTokenGenerator_DependsOnSecret(t *testing.T) {
+	t.Parallel()
+
+	first, err := NewFeistelTokenGenerator(testTokenSecret, base62Encoder)
+	require.NoError(t, err)
+	second, err := NewFeistelTokenGenerator("fedcba9876543210", base62Encoder)
+	require.NoError(t, err)
+
+	firstToken, err := first.GenerateToken(42)
+	require.NoError(t, err)
+	sameToken, err := first.GenerateToken(42)
+	require.NoError(t, err)
+	secondToken, err := second.GenerateToken(42)
+	require.NoError(t, err)
+
+	assert.Equal(t, firstToken, sameToken)
+	assert.NotEqual(t, firstToken, secondToken)
+}
+
+func TestRandomTokenGenerator_GenerateToken(t *testing.T) {
+	t.Parallel()
+
+	generator := NewRandomTokenGenerator(newAlphabetEncoder(Base58Alphabet, 10))
+
+	first, err := generator.GenerateToken(1)
+	require.NoError(t, err)
+	second, err := generator.GenerateToken(1)
+	require.NoError(t, err)
+
+	assert.Len(t, first, 10)
+	for _, char := range first {
+		assert.Contains(t, Base58Alphabet, string(char))
+	}
+	assert.NotEqual(t, first, second)
+}
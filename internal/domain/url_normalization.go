@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// defaultPorts maps URL schemes to the port that is implied when a URL has none.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// NormalizeURL returns the canonical form of a URL, so URLs that lead to the same destination
+// compare equal. It lowercases the scheme and host, drops the default port of the scheme,
+// strips trailing slashes from the path and sorts the query parameters by name.
+// The fragment and the case of the path are kept, as they may change the destination.
+//
+// Returns *InvalidUrlError if the URL cannot be parsed.
+func NormalizeURL(rawUrl string) (string, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", &InvalidUrlError{Msg: fmt.Sprintf("Invalid url provided: %s", rawUrl)}
+	}
+
+	parsedUrl.Scheme = strings.ToLower(parsedUrl.Scheme)
+	host := strings.ToLower(parsedUrl.Hostname())
+	port := parsedUrl.Port()
+	if port == "" || port == defaultPorts[parsedUrl.Scheme] {
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		parsedUrl.Host = host
+	} else {
+		parsedUrl.Host = net.JoinHostPort(host, port)
+	}
+
+	parsedUrl.Path = strings.TrimRight(parsedUrl.Path, "/")
+	parsedUrl.RawPath = strings.TrimRight(parsedUrl.RawPath, "/")
+	parsedUrl.RawQuery = parsedUrl.Query().Encode()
+	parsedUrl.ForceQuery = false
+
+	return parsedUrl.String(), nil
+}
+
+// HashURL returns the SHA-256 hash of the normalized URL, used to look up mappings by destination.
+//
+// Returns *InvalidUrlError if the URL cannot be parsed.
+func HashURL(rawUrl string) ([]byte, error) {
+	normalizedUrl, err := NormalizeURL(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256([]byte(normalizedUrl))
+	return hash[:], nil
+}
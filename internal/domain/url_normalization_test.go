@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		url      string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "already normalized",
+			url:      "https://example.com/path",
+			expected: "https://example.com/path",
+		},
+		{
+			name:     "scheme and host case",
+			url:      "HTTPS://Example.COM/Path",
+			expected: "https://example.com/Path",
+		},
+		{
+			name:     "default https port",
+			url:      "https://example.com:443/path",
+			expected: "https://example.com/path",
+		},
+		{
+			name:     "default http port",
+			url:      "http://example.com:80/path",
+			expected: "http://example.com/path",
+		},
+		{
+			name:     "non-default port kept",
+			url:      "https://example.com:8443/path",
+			expected: "https://example.com:8443/path",
+		},
+		{
+			name:     "trailing slash",
+			url:      "https://example.com/path/",
+			expected: "https://example.com/path",
+		},
+		{
+			name:     "root path",
+			url:      "https://example.com/",
+			expected: "https://example.com",
+		},
+		{
+			name:     "sorted query",
+			url:      "https://example.com/search?q=go&lang=en",
+			expected: "https://example.com/search?lang=en&q=go",
+		},
+		{
+			name:     "empty query",
+			url:      "https://example.com/path?",
+			expected: "https://example.com/path",
+		},
+		{
+			name:     "fragment kept",
+			url:      "https://example.com/docs#Intro",
+			expected: "https://example.com/docs#Intro",
+		},
+		{
+			name:     "IPv6 host with default port",
+			url:      "http://[::1]:80/path",
+			expected: "http://[::1]/path",
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			normalized, err := NormalizeURL(tt.url)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestHashURL(t *testing.T) {
+	t.Parallel()
+
+	first, err := HashURL("https://Example.com:443/path/?b=2&a=1")
+	require.NoError(t, err)
+	second, err := HashURL("https://example.com/path?a=1&b=2")
+	require.NoError(t, err)
+	other, err := HashURL("https://example.com/other")
+	require.NoError(t, err)
+
+	assert.Len(t, first, 32)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+
+	_, err = HashURL("https://example.com/%zz")
+	assert.ErrorIs(t, err, &InvalidUrlError{})
+}
@@ -0,0 +1,24 @@
+package domain
+
+// ScanVerdict is the verdict of a reputation service on a destination URL.
+type ScanVerdict struct {
+	// Malicious reports whether the URL is known to be malicious.
+	Malicious bool
+	// Threat is the kind of threat the URL is known for, e.g. "malware" or "phishing".
+	// It is set only for malicious URLs.
+	Threat string
+}
+
+// UnspecifiedThreat is the threat of malicious URLs for which the reputation service reports no threat.
+const UnspecifiedThreat = "malicious"
+
+// FlaggedThreat returns the threat a mapping of the URL is flagged with,
+// or an empty string if the URL is not malicious.
+func (v ScanVerdict) FlaggedThreat() string {
+	if !v.Malicious {
+		return ""
+	} else if v.Threat == "" {
+		return UnspecifiedThreat
+	}
+	return v.Threat
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxUrlLength is the maximum number of bytes of a destination URL.
+const MaxUrlLength = 2048
+
+var validSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+}
+
+// internalHostSuffixes contains host names, and domains of host names, that only resolve inside private networks.
+var internalHostSuffixes = []string{"localhost", "localdomain", "local", "internal", "intranet", "lan", "home.arpa"}
+
+// internalPrefixes contains the address ranges that are not covered by the netip.Addr predicates
+// but must not be reachable through a short URL either.
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// numericLabel matches the last label of hosts that browsers parse as IPv4 addresses in non-standard notation,
+// e.g. 2130706433, 0x7f000001 or 127.1.
+var numericLabel = regexp.MustCompile(`^(0x[0-9a-f]*|[0-9]+)$`)
+
+// ValidateURL checks if the provided URL string can be used as the destination of a mapping.
+// Internationalized host names are checked in their ASCII form, so look-alike characters
+// cannot smuggle in hosts that are rejected otherwise.
+//
+// Returns *InvalidUrlError if:
+//   - The URL is longer than MaxUrlLength
+//   - The URL cannot be parsed or has an empty host
+//   - The URL scheme is not http or https
+//   - The URL contains credentials
+//   - The host name or port is invalid
+//   - The host is a private, loopback, link-local or otherwise internal address or host name
+//   - The host is an IP address in non-standard notation
+//
+// Returns nil if the URL is valid.
+func ValidateURL(URL string) error {
+	_, _, err := parseDestination(URL)
+	return err
+}
+
+// DestinationValidator validates the destination URLs of mappings with ValidateURL,
+// and additionally rejects URLs that point back to the service itself, so short URLs cannot redirect in loops.
+type DestinationValidator struct {
+	ownHosts map[string]bool
+}
+
+// NewDestinationValidator creates a new DestinationValidator instance.
+// Parameters:
+//   - ownHosts: host names the short URLs of the service are served at
+//
+// Returns an error if one of the host names is invalid.
+func NewDestinationValidator(ownHosts []string) (*DestinationValidator, error) {
+	validator := &DestinationValidator{ownHosts: make(map[string]bool, len(ownHosts))}
+	for _, host := range ownHosts {
+		asciiHost, err := ToASCIIHost(strings.TrimSpace(host))
+		if err != nil {
+			return nil, fmt.Errorf("invalid own host %q: %w", host, err)
+		}
+		validator.ownHosts[asciiHost] = true
+	}
+
+	return validator, nil
+}
+
+// ValidateURL checks if the provided URL string can be used as the destination of a mapping
+// and returns it with its host in ASCII form, as browsers would request it.
+//
+// Returns *InvalidUrlError if:
+//   - The URL is rejected by ValidateURL
+//   - The host is one of the hosts the service itself is served at
+func (v *DestinationValidator) ValidateURL(URL string) (string, error) {
+	parsedUrl, asciiHost, err := parseDestination(URL)
+	if err != nil {
+		return "", err
+	}
+
+	if v.ownHosts[asciiHost] {
+		return "", &InvalidUrlError{Msg: fmt.Sprintf("URL must not point to this URL shortener: %s", asciiHost)}
+	}
+
+	host := asciiHost
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port := parsedUrl.Port(); port != "" {
+		host += ":" + port
+	}
+	parsedUrl.Host = host
+
+	return parsedUrl.String(), nil
+}
+
+// parseDestination parses and validates a destination URL and returns it with the ASCII form of its host name.
+func parseDestination(URL string) (*url.URL, string, error) {
+	if len(URL) > MaxUrlLength {
+		return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("URL must not be longer than %d characters", MaxUrlLength)}
+	}
+
+	parsedUrl, err := url.ParseRequestURI(URL)
+	if err != nil || parsedUrl.Host == "" {
+		return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("Invalid url provided: %s", URL)}
+	} else if _, valid := validSchemes[parsedUrl.Scheme]; !valid {
+		return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("Unsupported URL scheme: %s", parsedUrl.Scheme)}
+	} else if parsedUrl.User != nil {
+		return nil, "", &InvalidUrlError{Msg: "URL must not contain credentials"}
+	}
+
+	if port := parsedUrl.Port(); port != "" {
+		number, err := strconv.Atoi(port)
+		if err != nil || number < 1 || number > 65535 {
+			return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("Invalid port: %s", port)}
+		}
+	}
+
+	if addr, err := netip.ParseAddr(parsedUrl.Hostname()); err == nil {
+		if isInternalAddr(addr) {
+			return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("URL must not point to a private or internal address: %s", addr)}
+		}
+		return parsedUrl, addr.String(), nil
+	}
+
+	asciiHost, err := ToASCIIHost(parsedUrl.Hostname())
+	if err != nil {
+		return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("Invalid host name: %v", err)}
+	}
+
+	labels := strings.Split(asciiHost, ".")
+	if numericLabel.MatchString(labels[len(labels)-1]) {
+		return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("URL must not use an IP address in non-standard notation: %s", asciiHost)}
+	} else if isInternalHost(asciiHost) {
+		return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("URL must not point to an internal host: %s", asciiHost)}
+	} else if len(labels) == 1 {
+		return nil, "", &InvalidUrlError{Msg: fmt.Sprintf("URL host must be a fully qualified domain name: %s", asciiHost)}
+	}
+
+	return parsedUrl, asciiHost, nil
+}
+
+// isInternalAddr reports whether the address is not publicly routable.
+// IPv4 addresses embedded in IPv6 addresses are checked as IPv4 addresses.
+func isInternalAddr(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return true
+	}
+
+	for _, prefix := range internalPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isInternalHost reports whether the host name only resolves inside private networks.
+func isInternalHost(host string) bool {
+	for _, suffix := range internalHostSuffixes {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+
+	return false
+}
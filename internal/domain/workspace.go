@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxWorkspaceNameLength is the maximum length of the name of a workspace.
+const MaxWorkspaceNameLength = 100
+
+// Workspace is a tenant of the service. It owns the short URLs created with its API keys
+// and its own destination policy rules, and has its own quotas, so tenants cannot exhaust
+// each other's capacity.
+type Workspace struct {
+	// Id is the unique identifier of the workspace.
+	Id int64 `json:"id"`
+	// Name describes the tenant the workspace belongs to.
+	Name string `json:"name"`
+	// LinksPerMonth is the number of short URLs the workspace may create per calendar month in UTC.
+	// Zero means the number of created short URLs is unlimited.
+	LinksPerMonth int64 `json:"links_per_month"`
+	// RedirectsPerSecond is the number of redirects of all short URLs of the workspace allowed per second.
+	// Zero means the number of redirects is unlimited.
+	RedirectsPerSecond int64 `json:"redirects_per_second"`
+	// CreatedAt is the timestamp when the workspace was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NormalizeWorkspace validates the name and quotas of a workspace and returns it with its name trimmed.
+//
+// Returns *InvalidWorkspaceError if:
+//   - The name is empty or longer than MaxWorkspaceNameLength
+//   - A quota is negative
+func NormalizeWorkspace(workspace Workspace) (Workspace, error) {
+	workspace.Name = strings.TrimSpace(workspace.Name)
+	if workspace.Name == "" || len(workspace.Name) > MaxWorkspaceNameLength {
+		return Workspace{}, &InvalidWorkspaceError{Msg: fmt.Sprintf("Workspace name must be between 1 and %d bytes long", MaxWorkspaceNameLength)}
+	}
+
+	if workspace.LinksPerMonth < 0 || workspace.RedirectsPerSecond < 0 {
+		return Workspace{}, &InvalidWorkspaceError{Msg: "Workspace quotas must not be negative"}
+	}
+
+	return workspace, nil
+}
+
+// LinkQuotaPeriodStart returns the start of the calendar month in UTC the short URLs created at now
+// are counted against the LinksPerMonth quota in.
+func LinkQuotaPeriodStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
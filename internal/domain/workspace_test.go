@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeWorkspace(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		workspace         Workspace
+		expectedWorkspace Workspace
+		expectedError     bool
+	}
+
+	testCases := []testCase{
+		{
+			name:              "trims name",
+			workspace:         Workspace{Name: " acme ", LinksPerMonth: 1000, RedirectsPerSecond: 50},
+			expectedWorkspace: Workspace{Name: "acme", LinksPerMonth: 1000, RedirectsPerSecond: 50},
+		},
+		{
+			name:              "unlimited quotas",
+			workspace:         Workspace{Name: "acme"},
+			expectedWorkspace: Workspace{Name: "acme"},
+		},
+		{
+			name:          "blank name",
+			workspace:     Workspace{Name: "  "},
+			expectedError: true,
+		},
+		{
+			name:          "name too long",
+			workspace:     Workspace{Name: strings.Repeat("a", MaxWorkspaceNameLength+1)},
+			expectedError: true,
+		},
+		{
+			name:          "negative link quota",
+			workspace:     Workspace{Name: "acme", LinksPerMonth: -1},
+			expectedError: true,
+		},
+		{
+			name:          "negative redirect quota",
+			workspace:     Workspace{Name: "acme", RedirectsPerSecond: -1},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			workspace, err := NormalizeWorkspace(tt.workspace)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, &InvalidWorkspaceError{})
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWorkspace, workspace)
+			}
+		})
+	}
+}
+
+func TestLinkQuotaPeriodStart(t *testing.T) {
+	t.Parallel()
+
+	berlin := time.FixedZone("CET", 3600)
+
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), LinkQuotaPeriodStart(time.Date(2026, 3, 17, 15, 4, 5, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), LinkQuotaPeriodStart(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), LinkQuotaPeriodStart(time.Date(2026, 3, 1, 0, 30, 0, 0, berlin)))
+}
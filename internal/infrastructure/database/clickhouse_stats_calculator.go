@@ -18,7 +18,9 @@ func NewClickhouseStatsCalculator(sqlQuerier driver.Conn) *ClickhouseStatsCalcul
 	}
 }
 
-func (s *ClickhouseStatsCalculator) CalculateStatistics(ctx context.Context, urlToken string) (domain.CalculatedStatistics, error) {
+// CalculateWorkspaceStatistics only counts the events of urlToken recorded for workspaceId,
+// so a token of another workspace never leaks its statistics.
+func (s *ClickhouseStatsCalculator) CalculateWorkspaceStatistics(ctx context.Context, workspaceId int64, urlToken string) (domain.CalculatedStatistics, error) {
 	stats := domain.CalculatedStatistics{
 		UrlToken:        urlToken,
 		UniqueCountries: make(map[string]int),
@@ -28,27 +30,27 @@ func (s *ClickhouseStatsCalculator) CalculateStatistics(ctx context.Context, url
 	}
 	var err error
 
-	stats.TotalClicks, err = s.getTotalClicks(ctx, urlToken)
+	stats.TotalClicks, err = s.getTotalClicks(ctx, workspaceId, urlToken)
 	if err != nil {
 		return domain.CalculatedStatistics{}, err
 	}
 
-	stats.UniqueCountries, err = s.getGroupCount(ctx, urlToken, "country")
+	stats.UniqueCountries, err = s.getGroupCount(ctx, workspaceId, urlToken, "country")
 	if err != nil {
 		return domain.CalculatedStatistics{}, err
 	}
 
-	stats.UniqueCities, err = s.getGroupCount(ctx, urlToken, "city")
+	stats.UniqueCities, err = s.getGroupCount(ctx, workspaceId, urlToken, "city")
 	if err != nil {
 		return domain.CalculatedStatistics{}, err
 	}
 
-	stats.DeviceTypeStats, err = s.getGroupCount(ctx, urlToken, "device_type")
+	stats.DeviceTypeStats, err = s.getGroupCount(ctx, workspaceId, urlToken, "device_type")
 	if err != nil {
 		return domain.CalculatedStatistics{}, err
 	}
 
-	stats.ReferrerStats, err = s.getGroupCount(ctx, urlToken, "referrer")
+	stats.ReferrerStats, err = s.getGroupCount(ctx, workspaceId, urlToken, "referrer")
 	if err != nil {
 		return domain.CalculatedStatistics{}, err
 	}
@@ -56,16 +58,17 @@ func (s *ClickhouseStatsCalculator) CalculateStatistics(ctx context.Context, url
 	return stats, nil
 }
 
-func (s *ClickhouseStatsCalculator) getGroupCount(ctx context.Context, urlToken, groupBy string) (map[string]int, error) {
+func (s *ClickhouseStatsCalculator) getGroupCount(ctx context.Context, workspaceId int64, urlToken, groupBy string) (map[string]int, error) {
 	result := make(map[string]int)
 	query := fmt.Sprintf(`
 		SELECT %s, COUNT() 
 		FROM stats_events 
-		WHERE url_token = $1 
+		WHERE url_token = $1 AND domain = $2 AND workspace_id = $3
 		GROUP BY %s
 	`, groupBy, groupBy)
 
-	rows, err := s.sqlQuerier.Query(ctx, query, urlToken)
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	rows, err := s.sqlQuerier.Query(ctx, query, token, shortDomain, uint64(workspaceId))
 	if err != nil {
 		return nil, err
 	}
@@ -84,12 +87,13 @@ func (s *ClickhouseStatsCalculator) getGroupCount(ctx context.Context, urlToken,
 	return result, nil
 }
 
-func (s *ClickhouseStatsCalculator) getTotalClicks(ctx context.Context, urlToken string) (int, error) {
+func (s *ClickhouseStatsCalculator) getTotalClicks(ctx context.Context, workspaceId int64, urlToken string) (int, error) {
 	var totalClicks uint64
 
+	shortDomain, token := domain.SplitLinkKey(urlToken)
 	err := s.sqlQuerier.QueryRow(ctx, `
-		SELECT COUNT() FROM stats_events WHERE url_token = $1
-	`, urlToken).Scan(&totalClicks)
+		SELECT COUNT() FROM stats_events WHERE url_token = $1 AND domain = $2 AND workspace_id = $3
+	`, token, shortDomain, uint64(workspaceId)).Scan(&totalClicks)
 	if err != nil {
 		return 0, err
 	}
@@ -18,7 +18,7 @@ func NewClickhouseStatsStorage(conn driver.Conn) *ClickhouseStatsStorage {
 }
 
 func (s *ClickhouseStatsStorage) AddStatsEvent(ctx context.Context, event domain.ProcessedStatsEvent) error {
-	req := `INSERT INTO stats_events (url_token, timestamp, country, city, device_type, referrer)`
+	req := `INSERT INTO stats_events (url_token, domain, workspace_id, timestamp, country, city, device_type, referrer)`
 
 	batch, err := s.conn.PrepareBatch(ctx, req)
 	if err != nil {
@@ -26,8 +26,11 @@ func (s *ClickhouseStatsStorage) AddStatsEvent(ctx context.Context, event domain
 	}
 	defer batch.Close()
 
+	shortDomain, token := domain.SplitLinkKey(event.UrlToken)
 	err = batch.Append(
-		event.UrlToken,
+		token,
+		shortDomain,
+		uint64(event.WorkspaceId),
 		event.Timestamp,
 		event.Country,
 		event.City,
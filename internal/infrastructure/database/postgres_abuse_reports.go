@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// abuseReportColumns are the columns an AbuseReport is read from, in the order scanAbuseReport expects them.
+const abuseReportColumns = `id, url_token, COALESCE(domain, ''), reason, COALESCE(contact, ''), COALESCE(reporter_ip, ''), status, created_at, resolved_at`
+
+// PostgresAbuseReportStore implements storage of abuse reports of short URLs using PostgreSQL.
+type PostgresAbuseReportStore struct {
+	queryExecutor domain.QueryExecutor
+}
+
+// NewPostgresAbuseReportStore creates a new PostgresAbuseReportStore instance.
+// Parameters:
+//   - queryExecutor: PostgreSQL connection pool
+func NewPostgresAbuseReportStore(queryExecutor domain.QueryExecutor) *PostgresAbuseReportStore {
+	return &PostgresAbuseReportStore{queryExecutor: queryExecutor}
+}
+
+// AddAbuseReport creates a new open abuse report in PostgreSQL.
+// The report is only created if an undeleted mapping with the reported token exists.
+// Returns the created report with its ID, status and creation timestamp.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: no undeleted mapping with the reported token exists
+//   - Database operation fails
+func (s *PostgresAbuseReportStore) AddAbuseReport(ctx context.Context, report domain.AbuseReport) (domain.AbuseReport, error) {
+	sql := `INSERT INTO abuse_reports (url_token, domain, reason, contact, reporter_ip)
+		SELECT url_token, domain, $2, NULLIF($3, ''), NULLIF($4, '') FROM mappings
+		WHERE url_token = $1 AND domain IS NOT DISTINCT FROM NULLIF($5, '') AND deleted_at IS NULL
+		RETURNING ` + abuseReportColumns
+
+	shortDomain, token := domain.SplitLinkKey(report.Token)
+	created, err := scanAbuseReport(s.queryExecutor.QueryRow(ctx, sql, token, report.Reason, report.Contact, report.ReporterIP, shortDomain))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AbuseReport{}, &domain.TokenNonExistingError{Msg: fmt.Sprintf("Token %s does not exist", report.Token)}
+	} else if err != nil {
+		return domain.AbuseReport{}, fmt.Errorf("failed to add abuse report to db: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListAbuseReports retrieves all abuse reports with the given status from PostgreSQL, ordered by ID.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresAbuseReportStore) ListAbuseReports(ctx context.Context, status domain.AbuseReportStatus) ([]domain.AbuseReport, error) {
+	sql := `SELECT ` + abuseReportColumns + ` FROM abuse_reports WHERE status = $1 ORDER BY id`
+
+	rows, err := s.queryExecutor.Query(ctx, sql, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list abuse reports from db: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]domain.AbuseReport, 0)
+	for rows.Next() {
+		report, err := scanAbuseReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read abuse report from db: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list abuse reports from db: %w", err)
+	}
+
+	return reports, nil
+}
+
+// ResolveAbuseReport moves an open abuse report to the given status and records the resolution time.
+//
+// Returns an error if:
+//   - *domain.AbuseReportNonExistingError: no open report with the given ID exists
+//   - Database operation fails
+func (s *PostgresAbuseReportStore) ResolveAbuseReport(ctx context.Context, id int64, status domain.AbuseReportStatus) error {
+	sql := `UPDATE abuse_reports SET status = $1, resolved_at = NOW() WHERE id = $2 AND status = 'open'`
+
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, status, id)
+	if err != nil {
+		return fmt.Errorf("failed to resolve abuse report in db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.AbuseReportNonExistingError{Msg: fmt.Sprintf("No open abuse report with id %d found", id)}
+	}
+
+	return nil
+}
+
+// ResolveTokenAbuseReports moves all open abuse reports of the token to the given status
+// and records the resolution time. Tokens without open reports are left unchanged.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresAbuseReportStore) ResolveTokenAbuseReports(ctx context.Context, urlToken string, status domain.AbuseReportStatus) error {
+	sql := `UPDATE abuse_reports SET status = $1, resolved_at = NOW()
+		WHERE url_token = $2 AND domain IS NOT DISTINCT FROM NULLIF($3, '') AND status = 'open'`
+
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	_, err := s.queryExecutor.Exec(ctx, sql, status, token, shortDomain)
+	if err != nil {
+		return fmt.Errorf("failed to resolve abuse reports in db: %w", err)
+	}
+
+	return nil
+}
+
+// scanAbuseReport reads an AbuseReport selected with abuseReportColumns,
+// joining the reported token and its short domain into the link key of the report.
+func scanAbuseReport(row pgx.Row) (domain.AbuseReport, error) {
+	var report domain.AbuseReport
+	var shortDomain string
+	err := row.Scan(&report.Id, &report.Token, &shortDomain, &report.Reason, &report.Contact, &report.ReporterIP,
+		&report.Status, &report.CreatedAt, &report.ResolvedAt)
+	report.Token = domain.LinkKey(shortDomain, report.Token)
+
+	return report, err
+}
@@ -0,0 +1,292 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	exampleReportCreatedAt  = time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	exampleReportResolvedAt = time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC)
+	abuseReportRowColumns   = []string{"id", "url_token", "domain", "reason", "contact", "reporter_ip", "status", "created_at", "resolved_at"}
+)
+
+func TestPostgresAbuseReportStore_AddAbuseReport(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		expectedReport domain.AbuseReport
+		expectedError  error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	report := domain.AbuseReport{Token: "abc123", Reason: "phishing", ReporterIP: "203.0.113.7"}
+
+	testCases := []testCase{
+		{
+			name: "Success - report added",
+			expectedReport: domain.AbuseReport{
+				Id: 1, Token: "abc123", Reason: "phishing", ReporterIP: "203.0.113.7",
+				Status: domain.AbuseReportOpen, CreatedAt: exampleReportCreatedAt,
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO abuse_reports \(url_token, domain, reason, contact, reporter_ip\)\s+SELECT url_token, domain, \$2, NULLIF\(\$3, ''\), NULLIF\(\$4, ''\) FROM mappings\s+WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$5, ''\) AND deleted_at IS NULL`).
+					WithArgs("abc123", "phishing", "", "203.0.113.7", "").
+					WillReturnRows(pgxmock.NewRows(abuseReportRowColumns).
+						AddRow(int64(1), "abc123", "", "phishing", "", "203.0.113.7", domain.AbuseReportOpen, exampleReportCreatedAt, nil))
+			},
+		},
+		{
+			name:          "Error - token not found",
+			expectedError: &domain.TokenNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO abuse_reports`).
+					WithArgs("abc123", "phishing", "", "203.0.113.7", "").
+					WillReturnRows(pgxmock.NewRows(abuseReportRowColumns))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO abuse_reports`).
+					WithArgs("abc123", "phishing", "", "203.0.113.7", "").
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresAbuseReportStore(mockPool)
+			created, err := store.AddAbuseReport(context.Background(), report)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReport, created)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresAbuseReportStore_ListAbuseReports(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name            string
+		status          domain.AbuseReportStatus
+		expectedReports []domain.AbuseReport
+		expectedError   bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name:   "Success - reports listed",
+			status: domain.AbuseReportDismissed,
+			expectedReports: []domain.AbuseReport{
+				{
+					Id: 2, Token: "abc123", Reason: "spam", Contact: "me@example.com", Status: domain.AbuseReportDismissed,
+					CreatedAt: exampleReportCreatedAt, ResolvedAt: &exampleReportResolvedAt,
+				},
+				{
+					Id: 3, Token: "go.example.com/sale", Reason: "phishing", Status: domain.AbuseReportDismissed,
+					CreatedAt: exampleReportCreatedAt, ResolvedAt: &exampleReportResolvedAt,
+				},
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, url_token, COALESCE\(domain, ''\), reason, COALESCE\(contact, ''\), COALESCE\(reporter_ip, ''\), status, created_at, resolved_at FROM abuse_reports WHERE status = \$1 ORDER BY id`).
+					WithArgs(domain.AbuseReportDismissed).
+					WillReturnRows(pgxmock.NewRows(abuseReportRowColumns).
+						AddRow(int64(2), "abc123", "", "spam", "me@example.com", "", domain.AbuseReportDismissed, exampleReportCreatedAt, &exampleReportResolvedAt).
+						AddRow(int64(3), "sale", "go.example.com", "phishing", "", "", domain.AbuseReportDismissed, exampleReportCreatedAt, &exampleReportResolvedAt))
+			},
+		},
+		{
+			name:            "Success - no reports",
+			status:          domain.AbuseReportOpen,
+			expectedReports: []domain.AbuseReport{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM abuse_reports WHERE status = \$1`).
+					WithArgs(domain.AbuseReportOpen).
+					WillReturnRows(pgxmock.NewRows(abuseReportRowColumns))
+			},
+		},
+		{
+			name:          "Error - database error",
+			status:        domain.AbuseReportOpen,
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM abuse_reports WHERE status = \$1`).
+					WithArgs(domain.AbuseReportOpen).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresAbuseReportStore(mockPool)
+			reports, err := store.ListAbuseReports(context.Background(), tt.status)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReports, reports)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresAbuseReportStore_ResolveAbuseReport(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - report resolved",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE abuse_reports SET status = \$1, resolved_at = NOW\(\) WHERE id = \$2 AND status = 'open'`).
+					WithArgs(domain.AbuseReportDismissed, int64(7)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+		},
+		{
+			name:          "Error - no open report",
+			expectedError: &domain.AbuseReportNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE abuse_reports`).
+					WithArgs(domain.AbuseReportDismissed, int64(7)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE abuse_reports`).
+					WithArgs(domain.AbuseReportDismissed, int64(7)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresAbuseReportStore(mockPool)
+			err = store.ResolveAbuseReport(context.Background(), 7, domain.AbuseReportDismissed)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresAbuseReportStore_ResolveTokenAbuseReports(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - reports resolved",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE abuse_reports SET status = \$1, resolved_at = NOW\(\)\s+WHERE url_token = \$2 AND domain IS NOT DISTINCT FROM NULLIF\(\$3, ''\) AND status = 'open'`).
+					WithArgs(domain.AbuseReportActioned, "abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+			},
+		},
+		{
+			name: "Success - no open reports",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE abuse_reports`).
+					WithArgs(domain.AbuseReportActioned, "abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE abuse_reports`).
+					WithArgs(domain.AbuseReportActioned, "abc123", "").
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresAbuseReportStore(mockPool)
+			err = store.ResolveTokenAbuseReports(context.Background(), "abc123", domain.AbuseReportActioned)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
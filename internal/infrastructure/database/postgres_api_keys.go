@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// apiKeyColumns are the columns an ApiKey is read from, in the order scanApiKey expects them.
+const apiKeyColumns = `id, name, owner_id, COALESCE(workspace_id, 0), key_prefix, scopes, created_at, revoked_at`
+
+// apiKeyWorkspaceConstraint is the name of the foreign key constraint on api_keys.workspace_id.
+const apiKeyWorkspaceConstraint = "api_keys_workspace_id_fkey"
+
+// PostgresApiKeyStore implements storage of API keys using PostgreSQL.
+// Only the hashes of the keys are stored.
+type PostgresApiKeyStore struct {
+	queryExecutor domain.QueryExecutor
+}
+
+// NewPostgresApiKeyStore creates a new PostgresApiKeyStore instance.
+// Parameters:
+//   - queryExecutor: PostgreSQL connection pool
+func NewPostgresApiKeyStore(queryExecutor domain.QueryExecutor) *PostgresApiKeyStore {
+	return &PostgresApiKeyStore{queryExecutor: queryExecutor}
+}
+
+// AddApiKey creates a new API key in PostgreSQL with the name, owner, workspace, prefix and scopes of the given key
+// and the key hash. A zero WorkspaceId stores the key outside of workspaces.
+// Returns the created key with its ID and creation timestamp.
+//
+// Returns an error if:
+//   - *domain.WorkspaceNonExistingError: the workspace of the key does not exist
+//   - Database operation fails
+func (s *PostgresApiKeyStore) AddApiKey(ctx context.Context, key domain.ApiKey, keyHash string) (domain.ApiKey, error) {
+	sql := `INSERT INTO api_keys (name, owner_id, workspace_id, key_prefix, key_hash, scopes) VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6) RETURNING ` + apiKeyColumns
+
+	created, err := scanApiKey(s.queryExecutor.QueryRow(ctx, sql, key.Name, key.Owner, key.WorkspaceId, key.Prefix, keyHash, scopeNames(key.Scopes)))
+	if isForeignKeyViolation(err, apiKeyWorkspaceConstraint) {
+		return domain.ApiKey{}, &domain.WorkspaceNonExistingError{Msg: fmt.Sprintf("Workspace %d does not exist", key.WorkspaceId)}
+	} else if err != nil {
+		return domain.ApiKey{}, fmt.Errorf("failed to add API key to db: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetApiKeyByHash retrieves an active API key from PostgreSQL by its hash.
+//
+// Returns an error if:
+//   - *domain.ApiKeyNonExistingError: no active key has the hash
+//   - Database operation fails
+func (s *PostgresApiKeyStore) GetApiKeyByHash(ctx context.Context, keyHash string) (domain.ApiKey, error) {
+	sql := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	key, err := scanApiKey(s.queryExecutor.QueryRow(ctx, sql, keyHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ApiKey{}, &domain.ApiKeyNonExistingError{Msg: "API key does not exist"}
+	} else if err != nil {
+		return domain.ApiKey{}, fmt.Errorf("failed to get API key from db: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListApiKeys retrieves all API keys from PostgreSQL, including revoked ones, ordered by ID.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresApiKeyStore) ListApiKeys(ctx context.Context) ([]domain.ApiKey, error) {
+	sql := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY id`
+
+	rows, err := s.queryExecutor.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys from db: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]domain.ApiKey, 0)
+	for rows.Next() {
+		key, err := scanApiKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read API key from db: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list API keys from db: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeApiKey revokes an active API key in PostgreSQL by its ID and records the revocation time.
+//
+// Returns an error if:
+//   - *domain.ApiKeyNonExistingError: no active key with the ID exists
+//   - Database operation fails
+func (s *PostgresApiKeyStore) RevokeApiKey(ctx context.Context, id int64) error {
+	sql := `UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key in db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.ApiKeyNonExistingError{Msg: fmt.Sprintf("No active API key with id %d found", id)}
+	}
+
+	return nil
+}
+
+// scanApiKey reads an ApiKey selected with apiKeyColumns.
+func scanApiKey(row pgx.Row) (domain.ApiKey, error) {
+	var key domain.ApiKey
+	var scopes []string
+	err := row.Scan(&key.Id, &key.Name, &key.Owner, &key.WorkspaceId, &key.Prefix, &scopes, &key.CreatedAt, &key.RevokedAt)
+	if err != nil {
+		return domain.ApiKey{}, err
+	}
+
+	key.Scopes = make([]domain.ApiKeyScope, len(scopes))
+	for i, scope := range scopes {
+		key.Scopes[i] = domain.ApiKeyScope(scope)
+	}
+
+	return key, nil
+}
+
+// scopeNames returns the scopes as the text array they are stored in.
+func scopeNames(scopes []domain.ApiKeyScope) []string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+
+	return names
+}
@@ -0,0 +1,311 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	exampleKeyCreatedAt = time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+	exampleKeyRevokedAt = time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	apiKeyRowColumns    = []string{"id", "name", "owner_id", "workspace_id", "key_prefix", "scopes", "created_at", "revoked_at"}
+)
+
+func TestPostgresApiKeyStore_AddApiKey(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		key           domain.ApiKey
+		expectedKey   domain.ApiKey
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	key := domain.ApiKey{Name: "ci", Owner: "team-a", Prefix: "usk_0123abcd", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite, domain.ScopeStatsRead}}
+	workspaceKey := domain.ApiKey{Name: "ci", Owner: "team-a", WorkspaceId: 7, Prefix: "usk_0123abcd", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}
+
+	testCases := []testCase{
+		{
+			name: "Success - key added",
+			key:  key,
+			expectedKey: domain.ApiKey{
+				Id: 1, Name: "ci", Owner: "team-a", Prefix: "usk_0123abcd",
+				Scopes:    []domain.ApiKeyScope{domain.ScopeLinksWrite, domain.ScopeStatsRead},
+				CreatedAt: exampleKeyCreatedAt,
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO api_keys \(name, owner_id, workspace_id, key_prefix, key_hash, scopes\) VALUES \(\$1, \$2, NULLIF\(\$3, 0\), \$4, \$5, \$6\) RETURNING id, name, owner_id, COALESCE\(workspace_id, 0\), key_prefix, scopes, created_at, revoked_at`).
+					WithArgs("ci", "team-a", int64(0), "usk_0123abcd", "hash", []string{"links:write", "stats:read"}).
+					WillReturnRows(pgxmock.NewRows(apiKeyRowColumns).
+						AddRow(int64(1), "ci", "team-a", int64(0), "usk_0123abcd", []string{"links:write", "stats:read"}, exampleKeyCreatedAt, nil))
+			},
+		},
+		{
+			name: "Success - workspace key added",
+			key:  workspaceKey,
+			expectedKey: domain.ApiKey{
+				Id: 2, Name: "ci", Owner: "team-a", WorkspaceId: 7, Prefix: "usk_0123abcd",
+				Scopes:    []domain.ApiKeyScope{domain.ScopeLinksWrite},
+				CreatedAt: exampleKeyCreatedAt,
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO api_keys`).
+					WithArgs("ci", "team-a", int64(7), "usk_0123abcd", "hash", []string{"links:write"}).
+					WillReturnRows(pgxmock.NewRows(apiKeyRowColumns).
+						AddRow(int64(2), "ci", "team-a", int64(7), "usk_0123abcd", []string{"links:write"}, exampleKeyCreatedAt, nil))
+			},
+		},
+		{
+			name:          "Error - unknown workspace",
+			key:           workspaceKey,
+			expectedError: &domain.WorkspaceNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO api_keys`).
+					WithArgs("ci", "team-a", int64(7), "usk_0123abcd", "hash", []string{"links:write"}).
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: apiKeyWorkspaceConstraint})
+			},
+		},
+		{
+			name:          "Error - database error",
+			key:           key,
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO api_keys`).
+					WithArgs("ci", "team-a", int64(0), "usk_0123abcd", "hash", []string{"links:write", "stats:read"}).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresApiKeyStore(mockPool)
+			created, err := store.AddApiKey(context.Background(), tt.key, "hash")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKey, created)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresApiKeyStore_GetApiKeyByHash(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedKey   domain.ApiKey
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - active key found",
+			expectedKey: domain.ApiKey{
+				Id: 1, Name: "ci", Owner: "team-a", Prefix: "usk_0123abcd", Scopes: []domain.ApiKeyScope{domain.ScopeAdmin}, CreatedAt: exampleKeyCreatedAt,
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, name, owner_id, COALESCE\(workspace_id, 0\), key_prefix, scopes, created_at, revoked_at FROM api_keys WHERE key_hash = \$1 AND revoked_at IS NULL`).
+					WithArgs("hash").
+					WillReturnRows(pgxmock.NewRows(apiKeyRowColumns).
+						AddRow(int64(1), "ci", "team-a", int64(0), "usk_0123abcd", []string{"admin"}, exampleKeyCreatedAt, nil))
+			},
+		},
+		{
+			name:          "Error - unknown or revoked key",
+			expectedError: &domain.ApiKeyNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM api_keys WHERE key_hash = \$1`).
+					WithArgs("hash").
+					WillReturnRows(pgxmock.NewRows(apiKeyRowColumns))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM api_keys WHERE key_hash = \$1`).
+					WithArgs("hash").
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresApiKeyStore(mockPool)
+			key, err := store.GetApiKeyByHash(context.Background(), "hash")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKey, key)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresApiKeyStore_ListApiKeys(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedKeys  []domain.ApiKey
+		expectedError bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - keys listed",
+			expectedKeys: []domain.ApiKey{
+				{Id: 1, Name: "ci", Owner: "team-a", Prefix: "usk_0123abcd", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}, CreatedAt: exampleKeyCreatedAt},
+				{
+					Id: 2, Name: "old", Owner: "old", Prefix: "usk_4567ef01", Scopes: []domain.ApiKeyScope{domain.ScopeStatsRead},
+					CreatedAt: exampleKeyCreatedAt, RevokedAt: &exampleKeyRevokedAt,
+				},
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, name, owner_id, COALESCE\(workspace_id, 0\), key_prefix, scopes, created_at, revoked_at FROM api_keys ORDER BY id`).
+					WillReturnRows(pgxmock.NewRows(apiKeyRowColumns).
+						AddRow(int64(1), "ci", "team-a", int64(0), "usk_0123abcd", []string{"links:write"}, exampleKeyCreatedAt, nil).
+						AddRow(int64(2), "old", "old", int64(0), "usk_4567ef01", []string{"stats:read"}, exampleKeyCreatedAt, &exampleKeyRevokedAt))
+			},
+		},
+		{
+			name:         "Success - no keys",
+			expectedKeys: []domain.ApiKey{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM api_keys ORDER BY id`).
+					WillReturnRows(pgxmock.NewRows(apiKeyRowColumns))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM api_keys ORDER BY id`).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresApiKeyStore(mockPool)
+			keys, err := store.ListApiKeys(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKeys, keys)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresApiKeyStore_RevokeApiKey(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - key revoked",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE api_keys SET revoked_at = NOW\(\) WHERE id = \$1 AND revoked_at IS NULL`).
+					WithArgs(int64(3)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+		},
+		{
+			name:          "Error - no active key",
+			expectedError: &domain.ApiKeyNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE api_keys`).
+					WithArgs(int64(3)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE api_keys`).
+					WithArgs(int64(3)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresApiKeyStore(mockPool)
+			err = store.RevokeApiKey(context.Background(), 3)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
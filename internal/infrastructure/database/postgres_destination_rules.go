@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"url-shortening-service/internal/domain"
+)
+
+const (
+	// destinationRuleConstraint is the name of the unique constraint on the workspace, action, match type and pattern of destination_rules.
+	destinationRuleConstraint = "destination_rules_rule_key"
+	// destinationRuleWorkspaceConstraint is the name of the foreign key constraint on destination_rules.workspace_id.
+	destinationRuleWorkspaceConstraint = "destination_rules_workspace_id_fkey"
+)
+
+// PostgresDestinationRuleStore implements storage of the destination policy rules using PostgreSQL.
+type PostgresDestinationRuleStore struct {
+	queryExecutor domain.QueryExecutor
+}
+
+// NewPostgresDestinationRuleStore creates a new PostgresDestinationRuleStore instance.
+// Parameters:
+//   - queryExecutor: PostgreSQL connection pool
+func NewPostgresDestinationRuleStore(queryExecutor domain.QueryExecutor) *PostgresDestinationRuleStore {
+	return &PostgresDestinationRuleStore{queryExecutor: queryExecutor}
+}
+
+// ListDestinationRules retrieves all destination rules from PostgreSQL, ordered by ID.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresDestinationRuleStore) ListDestinationRules(ctx context.Context) ([]domain.DestinationRule, error) {
+	sql := `SELECT id, COALESCE(workspace_id, 0), action, match_type, pattern, created_at FROM destination_rules ORDER BY id`
+
+	rows, err := s.queryExecutor.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list destination rules from db: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]domain.DestinationRule, 0)
+	for rows.Next() {
+		var rule domain.DestinationRule
+		err = rows.Scan(&rule.Id, &rule.WorkspaceId, &rule.Action, &rule.Match, &rule.Pattern, &rule.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read destination rule from db: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list destination rules from db: %w", err)
+	}
+
+	return rules, nil
+}
+
+// AddDestinationRule creates a new destination rule in PostgreSQL.
+// A zero WorkspaceId stores a global rule.
+// Returns the created rule with its ID and creation timestamp.
+//
+// Returns an error if:
+//   - *domain.DestinationRuleExistingError: an identical rule already exists
+//   - *domain.WorkspaceNonExistingError: the workspace of the rule does not exist
+//   - Database operation fails
+func (s *PostgresDestinationRuleStore) AddDestinationRule(ctx context.Context, rule domain.DestinationRule) (domain.DestinationRule, error) {
+	sql := `INSERT INTO destination_rules (workspace_id, action, match_type, pattern) VALUES (NULLIF($1, 0), $2, $3, $4)
+		RETURNING id, COALESCE(workspace_id, 0), action, match_type, pattern, created_at`
+	var created domain.DestinationRule
+
+	err := s.queryExecutor.QueryRow(ctx, sql, rule.WorkspaceId, rule.Action, rule.Match, rule.Pattern).
+		Scan(&created.Id, &created.WorkspaceId, &created.Action, &created.Match, &created.Pattern, &created.CreatedAt)
+	if isUniqueViolation(err, destinationRuleConstraint) {
+		return domain.DestinationRule{}, &domain.DestinationRuleExistingError{
+			Msg: fmt.Sprintf("Destination rule %s %s %s already exists", rule.Action, rule.Match, rule.Pattern),
+		}
+	} else if isForeignKeyViolation(err, destinationRuleWorkspaceConstraint) {
+		return domain.DestinationRule{}, &domain.WorkspaceNonExistingError{Msg: fmt.Sprintf("Workspace %d does not exist", rule.WorkspaceId)}
+	} else if err != nil {
+		return domain.DestinationRule{}, fmt.Errorf("failed to add destination rule to db: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteDestinationRule removes a destination rule from PostgreSQL by its ID.
+//
+// Returns an error if:
+//   - *domain.DestinationRuleNonExistingError: no rule with the given ID exists
+//   - Database operation fails
+func (s *PostgresDestinationRuleStore) DeleteDestinationRule(ctx context.Context, id int64) error {
+	sql := `DELETE FROM destination_rules WHERE id = $1`
+
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete destination rule from db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.DestinationRuleNonExistingError{Msg: fmt.Sprintf("No destination rule with id %d found", id)}
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleRuleCreatedAt = time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC)
+
+func TestPostgresDestinationRuleStore_ListDestinationRules(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedRules []domain.DestinationRule
+		expectedError bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - rules listed",
+			expectedRules: []domain.DestinationRule{
+				{Id: 1, Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com", CreatedAt: exampleRuleCreatedAt},
+				{Id: 2, WorkspaceId: 7, Action: domain.DestinationAllow, Match: domain.DestinationMatchWildcard, Pattern: "*.example.com", CreatedAt: exampleRuleCreatedAt},
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, COALESCE\(workspace_id, 0\), action, match_type, pattern, created_at FROM destination_rules ORDER BY id`).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "workspace_id", "action", "match_type", "pattern", "created_at"}).
+						AddRow(int64(1), int64(0), domain.DestinationBlock, domain.DestinationMatchExact, "evil.com", exampleRuleCreatedAt).
+						AddRow(int64(2), int64(7), domain.DestinationAllow, domain.DestinationMatchWildcard, "*.example.com", exampleRuleCreatedAt))
+			},
+		},
+		{
+			name:          "Success - no rules",
+			expectedRules: []domain.DestinationRule{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, COALESCE\(workspace_id, 0\), action, match_type, pattern, created_at FROM destination_rules`).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "workspace_id", "action", "match_type", "pattern", "created_at"}))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, COALESCE\(workspace_id, 0\), action, match_type, pattern, created_at FROM destination_rules`).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresDestinationRuleStore(mockPool)
+			rules, err := store.ListDestinationRules(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRules, rules)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresDestinationRuleStore_AddDestinationRule(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	rule := domain.DestinationRule{WorkspaceId: 7, Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com"}
+
+	testCases := []testCase{
+		{
+			name: "Success - rule added",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO destination_rules \(workspace_id, action, match_type, pattern\) VALUES \(NULLIF\(\$1, 0\), \$2, \$3, \$4\)`).
+					WithArgs(int64(7), domain.DestinationBlock, domain.DestinationMatchExact, "evil.com").
+					WillReturnRows(pgxmock.NewRows([]string{"id", "workspace_id", "action", "match_type", "pattern", "created_at"}).
+						AddRow(int64(7), int64(7), domain.DestinationBlock, domain.DestinationMatchExact, "evil.com", exampleRuleCreatedAt))
+			},
+		},
+		{
+			name:          "Error - rule already exists",
+			expectedError: &domain.DestinationRuleExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO destination_rules`).
+					WithArgs(int64(7), domain.DestinationBlock, domain.DestinationMatchExact, "evil.com").
+					WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: destinationRuleConstraint})
+			},
+		},
+		{
+			name:          "Error - unknown workspace",
+			expectedError: &domain.WorkspaceNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO destination_rules`).
+					WithArgs(int64(7), domain.DestinationBlock, domain.DestinationMatchExact, "evil.com").
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: destinationRuleWorkspaceConstraint})
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO destination_rules`).
+					WithArgs(int64(7), domain.DestinationBlock, domain.DestinationMatchExact, "evil.com").
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresDestinationRuleStore(mockPool)
+			created, err := store.AddDestinationRule(context.Background(), rule)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(7), created.Id)
+				assert.Equal(t, rule.WorkspaceId, created.WorkspaceId)
+				assert.Equal(t, rule.Pattern, created.Pattern)
+				assert.Equal(t, exampleRuleCreatedAt, created.CreatedAt)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresDestinationRuleStore_DeleteDestinationRule(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - rule deleted",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`DELETE FROM destination_rules WHERE id = \$1`).
+					WithArgs(int64(7)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			},
+		},
+		{
+			name:          "Error - rule not found",
+			expectedError: &domain.DestinationRuleNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`DELETE FROM destination_rules`).
+					WithArgs(int64(7)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`DELETE FROM destination_rules`).
+					WithArgs(int64(7)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresDestinationRuleStore(mockPool)
+			err = store.DeleteDestinationRule(context.Background(), 7)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
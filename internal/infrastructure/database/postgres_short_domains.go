@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"url-shortening-service/internal/domain"
+)
+
+const (
+	// shortDomainConstraint is the name of the primary key constraint on short_domains.host.
+	shortDomainConstraint = "short_domains_pkey"
+	// shortDomainWorkspaceConstraint is the name of the foreign key constraint on short_domains.workspace_id.
+	shortDomainWorkspaceConstraint = "short_domains_workspace_id_fkey"
+)
+
+// PostgresShortDomainStore implements storage of custom short domains using PostgreSQL.
+type PostgresShortDomainStore struct {
+	queryExecutor domain.QueryExecutor
+}
+
+// NewPostgresShortDomainStore creates a new PostgresShortDomainStore instance.
+// Parameters:
+//   - queryExecutor: PostgreSQL connection pool
+func NewPostgresShortDomainStore(queryExecutor domain.QueryExecutor) *PostgresShortDomainStore {
+	return &PostgresShortDomainStore{queryExecutor: queryExecutor}
+}
+
+// ListShortDomains retrieves all custom short domains from PostgreSQL, ordered by host name.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresShortDomainStore) ListShortDomains(ctx context.Context) ([]domain.ShortDomain, error) {
+	sql := `SELECT host, COALESCE(workspace_id, 0), created_at FROM short_domains ORDER BY host`
+
+	rows, err := s.queryExecutor.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list short domains from db: %w", err)
+	}
+	defer rows.Close()
+
+	shortDomains := make([]domain.ShortDomain, 0)
+	for rows.Next() {
+		var shortDomain domain.ShortDomain
+		err = rows.Scan(&shortDomain.Host, &shortDomain.WorkspaceId, &shortDomain.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read short domain from db: %w", err)
+		}
+		shortDomains = append(shortDomains, shortDomain)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list short domains from db: %w", err)
+	}
+
+	return shortDomains, nil
+}
+
+// AddShortDomain registers a custom short domain in PostgreSQL with the host name and workspace of the given domain.
+// A zero WorkspaceId registers the domain outside of workspaces.
+// Returns the registered domain with its creation timestamp.
+//
+// Returns an error if:
+//   - *domain.ShortDomainExistingError: the domain is already registered
+//   - *domain.WorkspaceNonExistingError: the workspace of the domain does not exist
+//   - Database operation fails
+func (s *PostgresShortDomainStore) AddShortDomain(ctx context.Context, shortDomain domain.ShortDomain) (domain.ShortDomain, error) {
+	sql := `INSERT INTO short_domains (host, workspace_id) VALUES ($1, NULLIF($2, 0)) RETURNING host, COALESCE(workspace_id, 0), created_at`
+	var created domain.ShortDomain
+
+	err := s.queryExecutor.QueryRow(ctx, sql, shortDomain.Host, shortDomain.WorkspaceId).
+		Scan(&created.Host, &created.WorkspaceId, &created.CreatedAt)
+	if isUniqueViolation(err, shortDomainConstraint) {
+		return domain.ShortDomain{}, &domain.ShortDomainExistingError{Msg: fmt.Sprintf("Short domain %s is already registered", shortDomain.Host)}
+	} else if isForeignKeyViolation(err, shortDomainWorkspaceConstraint) {
+		return domain.ShortDomain{}, &domain.WorkspaceNonExistingError{Msg: fmt.Sprintf("Workspace %d does not exist", shortDomain.WorkspaceId)}
+	} else if err != nil {
+		return domain.ShortDomain{}, fmt.Errorf("failed to add short domain to db: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteShortDomain removes a custom short domain from PostgreSQL by its host name.
+// Domains are only removed once all of their short URLs are purged, so their tokens are never reissued.
+//
+// Returns an error if:
+//   - *domain.ShortDomainNonExistingError: the domain is not registered
+//   - *domain.ShortDomainInUseError: short URLs of the domain still exist
+//   - Database operation fails
+func (s *PostgresShortDomainStore) DeleteShortDomain(ctx context.Context, host string) error {
+	sql := `DELETE FROM short_domains WHERE host = $1`
+
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, host)
+	if isForeignKeyViolation(err, mappingDomainConstraint) {
+		return &domain.ShortDomainInUseError{Msg: fmt.Sprintf("Short domain %s still has short URLs", host)}
+	} else if err != nil {
+		return fmt.Errorf("failed to delete short domain from db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.ShortDomainNonExistingError{Msg: fmt.Sprintf("Short domain %s is not registered", host)}
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	exampleShortDomainCreatedAt = time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC)
+	shortDomainRowColumns       = []string{"host", "workspace_id", "created_at"}
+)
+
+func TestPostgresShortDomainStore_ListShortDomains(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                 string
+		expectedShortDomains []domain.ShortDomain
+		expectedError        bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - domains listed",
+			expectedShortDomains: []domain.ShortDomain{
+				{Host: "go.acme.com", WorkspaceId: 7, CreatedAt: exampleShortDomainCreatedAt},
+				{Host: "s.example.io", CreatedAt: exampleShortDomainCreatedAt},
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT host, COALESCE\(workspace_id, 0\), created_at FROM short_domains ORDER BY host`).
+					WillReturnRows(pgxmock.NewRows(shortDomainRowColumns).
+						AddRow("go.acme.com", int64(7), exampleShortDomainCreatedAt).
+						AddRow("s.example.io", int64(0), exampleShortDomainCreatedAt))
+			},
+		},
+		{
+			name:                 "Success - no domains",
+			expectedShortDomains: []domain.ShortDomain{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM short_domains ORDER BY host`).
+					WillReturnRows(pgxmock.NewRows(shortDomainRowColumns))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM short_domains ORDER BY host`).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresShortDomainStore(mockPool)
+			shortDomains, err := store.ListShortDomains(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedShortDomains, shortDomains)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresShortDomainStore_AddShortDomain(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                string
+		expectedShortDomain domain.ShortDomain
+		expectedError       error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	shortDomain := domain.ShortDomain{Host: "go.acme.com", WorkspaceId: 7}
+
+	testCases := []testCase{
+		{
+			name:                "Success - domain added",
+			expectedShortDomain: domain.ShortDomain{Host: "go.acme.com", WorkspaceId: 7, CreatedAt: exampleShortDomainCreatedAt},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO short_domains \(host, workspace_id\) VALUES \(\$1, NULLIF\(\$2, 0\)\) RETURNING host, COALESCE\(workspace_id, 0\), created_at`).
+					WithArgs("go.acme.com", int64(7)).
+					WillReturnRows(pgxmock.NewRows(shortDomainRowColumns).
+						AddRow("go.acme.com", int64(7), exampleShortDomainCreatedAt))
+			},
+		},
+		{
+			name:          "Error - domain already registered",
+			expectedError: &domain.ShortDomainExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO short_domains`).
+					WithArgs("go.acme.com", int64(7)).
+					WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: shortDomainConstraint})
+			},
+		},
+		{
+			name:          "Error - unknown workspace",
+			expectedError: &domain.WorkspaceNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO short_domains`).
+					WithArgs("go.acme.com", int64(7)).
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: shortDomainWorkspaceConstraint})
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO short_domains`).
+					WithArgs("go.acme.com", int64(7)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresShortDomainStore(mockPool)
+			created, err := store.AddShortDomain(context.Background(), shortDomain)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedShortDomain, created)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresShortDomainStore_DeleteShortDomain(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - domain deleted",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`DELETE FROM short_domains WHERE host = \$1`).
+					WithArgs("go.acme.com").
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			},
+		},
+		{
+			name:          "Error - domain not registered",
+			expectedError: &domain.ShortDomainNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`DELETE FROM short_domains`).
+					WithArgs("go.acme.com").
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+			},
+		},
+		{
+			name:          "Error - domain still has short URLs",
+			expectedError: &domain.ShortDomainInUseError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`DELETE FROM short_domains`).
+					WithArgs("go.acme.com").
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: mappingDomainConstraint})
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`DELETE FROM short_domains`).
+					WithArgs("go.acme.com").
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresShortDomainStore(mockPool)
+			err = store.DeleteShortDomain(context.Background(), "go.acme.com")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
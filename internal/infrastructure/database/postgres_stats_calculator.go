@@ -72,11 +72,12 @@ func (s *PostgresStatsCalculator) getGroupCount(ctx context.Context, urlToken, g
 	query := fmt.Sprintf(`
 		SELECT %s, COUNT(*) 
 		FROM stats_events 
-		WHERE url_token = $1 
+		WHERE url_token = $1 AND domain IS NOT DISTINCT FROM NULLIF($2, '')
 		GROUP BY %s
 	`, groupBy, groupBy)
 
-	rows, err := s.sqlQuerier.Query(ctx, query, urlToken)
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	rows, err := s.sqlQuerier.Query(ctx, query, token, shortDomain)
 	if err != nil {
 		return nil, err
 	}
@@ -98,9 +99,10 @@ func (s *PostgresStatsCalculator) getGroupCount(ctx context.Context, urlToken, g
 func (s *PostgresStatsCalculator) getTotalClicks(ctx context.Context, urlToken string) (int, error) {
 	var totalClicks int
 
+	shortDomain, token := domain.SplitLinkKey(urlToken)
 	err := s.sqlQuerier.QueryRow(ctx, `
-		SELECT COUNT(*) FROM stats_events WHERE url_token = $1
-	`, urlToken).Scan(&totalClicks)
+		SELECT COUNT(*) FROM stats_events WHERE url_token = $1 AND domain IS NOT DISTINCT FROM NULLIF($2, '')
+	`, token, shortDomain).Scan(&totalClicks)
 	if err != nil {
 		return 0, err
 	}
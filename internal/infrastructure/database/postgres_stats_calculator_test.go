@@ -43,8 +43,8 @@ func TestGetGroupCount(t *testing.T) {
 					AddRow("USA", 10).
 					AddRow("Germany", 5).
 					AddRow("France", 3)
-				mock.ExpectQuery(`SELECT country, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 GROUP BY country`).
-					WithArgs("abc123").
+				mock.ExpectQuery(`SELECT country, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) GROUP BY country`).
+					WithArgs("abc123", "").
 					WillReturnRows(rows)
 			},
 		},
@@ -56,8 +56,8 @@ func TestGetGroupCount(t *testing.T) {
 			expectedError:  nil,
 			prepareMocks: func(t *testing.T, mock pgxmock.PgxConnIface) {
 				rows := pgxmock.NewRows([]string{"city", "count"})
-				mock.ExpectQuery(`SELECT city, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 GROUP BY city`).
-					WithArgs("abc123").
+				mock.ExpectQuery(`SELECT city, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) GROUP BY city`).
+					WithArgs("abc123", "").
 					WillReturnRows(rows)
 			},
 		},
@@ -68,8 +68,8 @@ func TestGetGroupCount(t *testing.T) {
 			expectedResult: nil,
 			expectedError:  assert.AnError,
 			prepareMocks: func(t *testing.T, mock pgxmock.PgxConnIface) {
-				mock.ExpectQuery(`SELECT device_type, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 GROUP BY device_type`).
-					WithArgs("abc123").
+				mock.ExpectQuery(`SELECT device_type, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) GROUP BY device_type`).
+					WithArgs("abc123", "").
 					WillReturnError(assert.AnError)
 			},
 		},
@@ -83,8 +83,8 @@ func TestGetGroupCount(t *testing.T) {
 				rows := pgxmock.NewRows([]string{"referrer", "count"}).
 					AddRow("google.com", 10).
 					RowError(0, assert.AnError)
-				mock.ExpectQuery(`SELECT referrer, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 GROUP BY referrer`).
-					WithArgs("abc123").
+				mock.ExpectQuery(`SELECT referrer, COUNT\(\*\) FROM stats_events WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) GROUP BY referrer`).
+					WithArgs("abc123", "").
 					WillReturnRows(rows)
 			},
 		},
@@ -135,8 +135,8 @@ func TestGetTotalClicks(t *testing.T) {
 			expectedError:  nil,
 			prepareMocks: func(t *testing.T, mock pgxmock.PgxConnIface) {
 				rows := pgxmock.NewRows([]string{"count"}).AddRow(42)
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM stats_events WHERE url_token = \$1`).
-					WithArgs("abc123").
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM stats_events WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("abc123", "").
 					WillReturnRows(rows)
 			},
 		},
@@ -147,8 +147,8 @@ func TestGetTotalClicks(t *testing.T) {
 			expectedError:  &domain.TokenNonExistingError{},
 			prepareMocks: func(t *testing.T, mock pgxmock.PgxConnIface) {
 				rows := pgxmock.NewRows([]string{"count"}).AddRow(0)
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM stats_events WHERE url_token = \$1`).
-					WithArgs("nonexistent").
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM stats_events WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("nonexistent", "").
 					WillReturnRows(rows)
 			},
 		},
@@ -158,8 +158,8 @@ func TestGetTotalClicks(t *testing.T) {
 			expectedClicks: 0,
 			expectedError:  assert.AnError,
 			prepareMocks: func(t *testing.T, mock pgxmock.PgxConnIface) {
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM stats_events WHERE url_token = \$1`).
-					WithArgs("abc123").
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM stats_events WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("abc123", "").
 					WillReturnError(assert.AnError)
 			},
 		},
@@ -315,6 +315,7 @@ func TestCalculateStatistics_Integration(t *testing.T) {
 			CREATE TABLE stats_events (
 				id              BIGSERIAL PRIMARY KEY,
 				url_token       TEXT NOT NULL,
+				domain          TEXT,
 				timestamp       TIMESTAMP WITH TIME ZONE,
 				country         TEXT,
 				city            TEXT,
@@ -21,13 +21,15 @@ func NewPostgresStatsStorage(sqlQuerier domain.Executor) *PostgresStatsStorage {
 }
 
 // AddStatsEvent persists a processed statistics event to PostgreSQL.
-// It stores URL token, timestamp, country, city, device type, and referrer.
+// It stores URL token, short domain, timestamp, country, city, device type, and referrer.
 //
 // Returns an error if the database operation fails.
 func (s *PostgresStatsStorage) AddStatsEvent(ctx context.Context, event domain.ProcessedStatsEvent) error {
-	sql := `INSERT INTO stats_events (url_token, timestamp, country, city, device_type, referrer) VALUES ($1, $2, $3, $4, $5, $6)`
+	sql := `INSERT INTO stats_events (url_token, domain, timestamp, country, city, device_type, referrer)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7)`
 
-	_, err := s.sqlExecutor.Exec(ctx, sql, event.UrlToken, event.Timestamp, event.Country, event.City, event.DeviceType, event.Referrer)
+	shortDomain, token := domain.SplitLinkKey(event.UrlToken)
+	_, err := s.sqlExecutor.Exec(ctx, sql, token, shortDomain, event.Timestamp, event.Country, event.City, event.DeviceType, event.Referrer)
 	if err != nil {
 		return err
 	}
@@ -38,14 +38,14 @@ func TestPostgersStatsStorage_AddStatsEvent(t *testing.T) {
 			expectedError: nil,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) {
 				mockPool.ExpectExec(`INSERT INTO stats_events`).
-					WithArgs("abc123", testTime, "USA", "New York", "desktop", "google.com").
+					WithArgs("abc123", "", testTime, "USA", "New York", "desktop", "google.com").
 					WillReturnResult(pgxmock.NewResult("INSERT", 1))
 			},
 		},
 		{
 			name: "Database error - returns error",
 			event: domain.ProcessedStatsEvent{
-				UrlToken:   "abc123",
+				UrlToken:   "go.example.com/abc123",
 				Timestamp:  testTime,
 				Country:    "Germany",
 				City:       "Berlin",
@@ -55,7 +55,7 @@ func TestPostgersStatsStorage_AddStatsEvent(t *testing.T) {
 			expectedError: assert.AnError,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) {
 				mockPool.ExpectExec(`INSERT INTO stats_events`).
-					WithArgs("abc123", testTime, "Germany", "Berlin", "mobile", "facebook.com").
+					WithArgs("abc123", "go.example.com", testTime, "Germany", "Berlin", "mobile", "facebook.com").
 					WillReturnError(assert.AnError)
 			},
 		},
@@ -2,15 +2,34 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 	"url-shortening-service/internal/domain"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// uniqueViolationCode is the PostgreSQL error code for unique constraint violations.
+	uniqueViolationCode = "23505"
+	// urlTokenConstraint is the name of the unique constraint on the short domain and token of mappings.
+	urlTokenConstraint = "mappings_domain_url_token_key"
+	// idConstraint is the name of the primary key constraint on mappings.id.
+	idConstraint = "mappings_pkey"
+	// foreignKeyViolationCode is the PostgreSQL error code for foreign key violations.
+	foreignKeyViolationCode = "23503"
+	// mappingWorkspaceConstraint is the name of the foreign key constraint on mappings.workspace_id.
+	mappingWorkspaceConstraint = "mappings_workspace_id_fkey"
+	// mappingDomainConstraint is the name of the foreign key constraint on mappings.domain.
+	mappingDomainConstraint = "mappings_domain_fkey"
 )
 
 // PostgresStorage implements URL mapping storage operations using PostgreSQL.
 // It provides CRUD operations for URL mappings with PostgreSQL as the backend.
+// Mappings are looked up by their link key (see domain.LinkKey), which is split into the token stored in
+// the url_token column and the custom short domain stored in the domain column, NULL for the default domain.
 type PostgresStorage struct {
 	queryExecutor domain.QueryExecutor
 	logger        domain.Logger
@@ -27,72 +46,165 @@ func NewPostgresStorage(queryExecutor domain.QueryExecutor, logger domain.Logger
 	}
 }
 
-// GetMappingByToken retrieves a URL mapping by its token from PostgreSQL.
-// Returns the MappingInfo and true if found, or empty MappingInfo and false if not found.
-// Database errors are logged and result in returning false.
-func (s *PostgresStorage) GetMappingByToken(ctx context.Context, urlToken string) (domain.MappingInfo, bool) {
-	sql := `SELECT id, original_url, url_token FROM mappings WHERE url_token = $1`
+// GetMappingByToken retrieves a URL mapping by its link key from PostgreSQL.
+// The returned mapping includes the password hash of protected mappings, the threat of flagged mappings,
+// the takedown reason of taken down mappings and the owner and workspace of the mapping. Soft-deleted mappings are not found.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist or its mapping is deleted
+//   - Database operation fails
+func (s *PostgresStorage) GetMappingByToken(ctx context.Context, urlToken string) (domain.MappingInfo, error) {
+	sql := `SELECT id, original_url, url_token, COALESCE(domain, ''), created_at, updated_at, expires_at, max_clicks, click_count,
+		COALESCE(password_hash, ''), COALESCE(flagged_threat, ''), COALESCE(disabled_reason, ''), COALESCE(owner_id, ''),
+		COALESCE(workspace_id, 0) FROM mappings WHERE url_token = $1 AND domain IS NOT DISTINCT FROM NULLIF($2, '') AND deleted_at IS NULL`
 	var mapping domain.MappingInfo
 
-	err := s.queryExecutor.QueryRow(ctx, sql, urlToken).Scan(&mapping.Id, &mapping.OriginalURL, &mapping.Token)
-	if err == pgx.ErrNoRows {
-		return domain.MappingInfo{}, false
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	err := s.queryExecutor.QueryRow(ctx, sql, token, shortDomain).
+		Scan(&mapping.Id, &mapping.OriginalURL, &mapping.Token, &mapping.Domain, &mapping.CreatedAt, &mapping.UpdatedAt, &mapping.ExpiresAt,
+			&mapping.MaxClicks, &mapping.ClickCount, &mapping.PasswordHash, &mapping.Threat, &mapping.DisabledReason, &mapping.OwnerId,
+			&mapping.WorkspaceId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: fmt.Sprintf("Token %s does not exist", urlToken)}
 	} else if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to get mapping from db: %v", err))
-		return domain.MappingInfo{}, false
+		return domain.MappingInfo{}, fmt.Errorf("failed to get mapping from db: %w", err)
 	}
 
-	return mapping, true
+	mapping.Protected = mapping.PasswordHash != ""
+
+	return mapping, nil
 }
 
 // AddNewMapping creates a new URL mapping in PostgreSQL.
-// Returns the created MappingInfo with ID, URL, token, and creation timestamp.
+// An empty PasswordHash stores the mapping without password protection, an empty OwnerId without owner,
+// a zero WorkspaceId outside of workspaces and an empty Domain on the default domain.
+// The hash of the normalized original URL is stored alongside, so the mapping can be found by FindReusableMapping.
+// Tokens of purged mappings are retired and never stored again.
+// Returns the created MappingInfo with ID, URL, token, creation timestamp, expiration time,
+// click limit, protection flag, owner, workspace and domain. The password hash itself is never returned.
 //
-// Returns an error if the database operation fails.
-func (s *PostgresStorage) AddNewMapping(ctx context.Context, id int64, originalUrl string, urlToken string) (domain.MappingInfo, error) {
-	sql := `INSERT INTO mappings (id, original_url, url_token) VALUES ($1, $2, $3) RETURNING id, original_url, url_token, created_at`
+// Returns an error if:
+//   - *domain.TokenExistingError: a mapping with the given token already exists on the domain or the token is retired
+//   - *domain.IdExistingError: a mapping with the given ID already exists
+//   - *domain.WorkspaceNonExistingError: the workspace of the mapping does not exist
+//   - *domain.ShortDomainNonExistingError: the custom short domain of the mapping is not registered
+//   - Database operation fails
+func (s *PostgresStorage) AddNewMapping(ctx context.Context, mapping domain.MappingInfo) (domain.MappingInfo, error) {
+	sql := `INSERT INTO mappings (id, original_url, url_token, expires_at, max_clicks, password_hash, url_hash, owner_id, workspace_id, domain)
+		SELECT $1, $2, $3, $4, $5, NULLIF($6, ''), $7, NULLIF($8, ''), NULLIF($9, 0), NULLIF($10, '')
+		WHERE NOT EXISTS (SELECT 1 FROM retired_tokens WHERE url_token = $3 AND domain IS NOT DISTINCT FROM NULLIF($10, ''))
+		RETURNING id, original_url, url_token, COALESCE(domain, ''), created_at, expires_at, max_clicks, password_hash IS NOT NULL,
+		COALESCE(owner_id, ''), COALESCE(workspace_id, 0)`
 	var result domain.MappingInfo
 
-	err := s.queryExecutor.QueryRow(ctx, sql, id, originalUrl, urlToken).Scan(&result.Id, &result.OriginalURL, &result.Token, &result.CreatedAt)
+	urlHash, err := domain.HashURL(mapping.OriginalURL)
 	if err != nil {
+		return domain.MappingInfo{}, err
+	}
+
+	err = s.queryExecutor.QueryRow(ctx, sql, mapping.Id, mapping.OriginalURL, mapping.Token, mapping.ExpiresAt, mapping.MaxClicks, mapping.PasswordHash, urlHash,
+		mapping.OwnerId, mapping.WorkspaceId, mapping.Domain).
+		Scan(&result.Id, &result.OriginalURL, &result.Token, &result.Domain, &result.CreatedAt, &result.ExpiresAt, &result.MaxClicks, &result.Protected,
+			&result.OwnerId, &result.WorkspaceId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.MappingInfo{}, &domain.TokenExistingError{Msg: fmt.Sprintf("Token %s is retired", mapping.Key())}
+	} else if isUniqueViolation(err, urlTokenConstraint) {
+		return domain.MappingInfo{}, &domain.TokenExistingError{Msg: fmt.Sprintf("Token %s is already taken", mapping.Key())}
+	} else if isUniqueViolation(err, idConstraint) {
+		return domain.MappingInfo{}, &domain.IdExistingError{Msg: fmt.Sprintf("Mapping id %d is already taken", mapping.Id)}
+	} else if isForeignKeyViolation(err, mappingWorkspaceConstraint) {
+		return domain.MappingInfo{}, &domain.WorkspaceNonExistingError{Msg: fmt.Sprintf("Workspace %d does not exist", mapping.WorkspaceId)}
+	} else if isForeignKeyViolation(err, mappingDomainConstraint) {
+		return domain.MappingInfo{}, &domain.ShortDomainNonExistingError{Msg: fmt.Sprintf("Short domain %s is not registered", mapping.Domain)}
+	} else if err != nil {
 		return domain.MappingInfo{}, fmt.Errorf("failed to add new mapping to db: %w", err)
 	}
 
 	return result, nil
 }
 
-// GetLastId retrieves the highest ID from the mappings table.
+// FindReusableMapping retrieves the oldest mapping of owner on the short domain whose original URL normalizes to the same URL
+// as originalUrl and that has no expiration time, click limit or password and is neither flagged as a threat, taken down nor deleted.
+// An empty OwnerId only finds mappings without owner, a zero WorkspaceId only mappings outside of workspaces
+// and an empty shortDomain only mappings of the default domain.
+// Mappings created before URL hashes were stored are not found.
+// Returns the MappingInfo and true if found, or empty MappingInfo and false if not found.
+//
+// Returns an error if:
+//   - *domain.InvalidUrlError: the URL cannot be parsed
+//   - Database operation fails
+func (s *PostgresStorage) FindReusableMapping(ctx context.Context, originalUrl string, owner domain.MappingOwner,
+	shortDomain string) (domain.MappingInfo, bool, error) {
+	sql := `SELECT id, original_url, url_token, COALESCE(domain, ''), created_at, updated_at, COALESCE(owner_id, ''), COALESCE(workspace_id, 0) FROM mappings
+		WHERE url_hash = $1 AND expires_at IS NULL AND max_clicks IS NULL AND password_hash IS NULL AND flagged_threat IS NULL
+		AND disabled_reason IS NULL AND deleted_at IS NULL
+		AND owner_id IS NOT DISTINCT FROM NULLIF($2, '') AND workspace_id IS NOT DISTINCT FROM NULLIF($3, 0)
+		AND domain IS NOT DISTINCT FROM NULLIF($4, '') ORDER BY id LIMIT 1`
+	var mapping domain.MappingInfo
+
+	urlHash, err := domain.HashURL(originalUrl)
+	if err != nil {
+		return domain.MappingInfo{}, false, err
+	}
+
+	err = s.queryExecutor.QueryRow(ctx, sql, urlHash, owner.OwnerId, owner.WorkspaceId, shortDomain).
+		Scan(&mapping.Id, &mapping.OriginalURL, &mapping.Token, &mapping.Domain, &mapping.CreatedAt, &mapping.UpdatedAt, &mapping.OwnerId, &mapping.WorkspaceId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.MappingInfo{}, false, nil
+	} else if err != nil {
+		return domain.MappingInfo{}, false, fmt.Errorf("failed to find mapping by url in db: %w", err)
+	}
+
+	return mapping, true, nil
+}
+
+// GetLastId retrieves the highest ID ever used by a mapping, including mappings that were purged.
 // Returns 0 if no mappings exist.
 //
 // Returns an error if the database query fails.
 func (s *PostgresStorage) GetLastId(ctx context.Context) (int64, error) {
-	sql := `SELECT id FROM mappings ORDER BY id DESC LIMIT 1`
-	var lastId int64
+	sql := `SELECT GREATEST((SELECT id FROM mappings ORDER BY id DESC LIMIT 1),
+		(SELECT mapping_id FROM retired_tokens ORDER BY mapping_id DESC LIMIT 1))`
+	var lastId *int64
 
 	err := s.queryExecutor.QueryRow(ctx, sql).Scan(&lastId)
-	if err == pgx.ErrNoRows {
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last mapping id from db: %w", err)
+	} else if lastId == nil {
 		s.logger.Info("No existing mappings found in database.")
 		return 0, nil
-	} else if err != nil {
-		return 0, fmt.Errorf("failed to get last mapping id from db: %w", err)
 	}
 
-	s.logger.Info(fmt.Sprintf("Retrieved last mapping id is: %d", lastId))
+	s.logger.Info(fmt.Sprintf("Retrieved last mapping id is: %d", *lastId))
 
-	return lastId, nil
+	return *lastId, nil
 }
 
-// UpdateOriginalUrl updates the original URL for an existing token.
+// UpdateOriginalUrl updates the original URL for an existing token, together with the hash of the normalized URL.
+// A flag of the previous original URL is cleared.
+// A non-nil opts.ExpiresAt replaces the expiration time and opts.ClearExpiration removes it,
+// otherwise the current one is kept.
 // Returns the updated MappingInfo with new timestamps.
 //
 // Returns an error if:
-//   - *domain.TokenNonExistingError: no mapping with the given token exists
+//   - *domain.TokenNonExistingError: no mapping with the given token exists or its mapping is deleted
+//   - *domain.InvalidUrlError: the new URL cannot be parsed
 //   - Database operation fails
-func (s *PostgresStorage) UpdateOriginalUrl(ctx context.Context, urlToken string, newOriginalUrl string) (domain.MappingInfo, error) {
-	sql := `UPDATE mappings SET original_url = $1, updated_at = $2 WHERE url_token = $3 RETURNING id, original_url, url_token, created_at, updated_at`
+func (s *PostgresStorage) UpdateOriginalUrl(ctx context.Context, urlToken string, newOriginalUrl string, opts domain.UpdateOptions) (domain.MappingInfo, error) {
+	sql := `UPDATE mappings SET original_url = $1, updated_at = $2, expires_at = CASE WHEN $6 THEN NULL ELSE COALESCE($3, expires_at) END,
+		url_hash = $5, flagged_threat = NULL WHERE url_token = $4 AND domain IS NOT DISTINCT FROM NULLIF($7, '') AND deleted_at IS NULL
+		RETURNING id, original_url, url_token, COALESCE(domain, ''), created_at, updated_at, expires_at, max_clicks, click_count, password_hash IS NOT NULL`
 	var updatedMapping domain.MappingInfo
 
-	err := s.queryExecutor.QueryRow(ctx, sql, newOriginalUrl, time.Now(), urlToken).Scan(&updatedMapping.Id, &updatedMapping.OriginalURL, &updatedMapping.Token, &updatedMapping.CreatedAt, &updatedMapping.UpdatedAt)
+	urlHash, err := domain.HashURL(newOriginalUrl)
+	if err != nil {
+		return domain.MappingInfo{}, err
+	}
+
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	err = s.queryExecutor.QueryRow(ctx, sql, newOriginalUrl, time.Now(), opts.ExpiresAt, token, urlHash, opts.ClearExpiration, shortDomain).
+		Scan(&updatedMapping.Id, &updatedMapping.OriginalURL, &updatedMapping.Token, &updatedMapping.Domain, &updatedMapping.CreatedAt,
+			&updatedMapping.UpdatedAt, &updatedMapping.ExpiresAt, &updatedMapping.MaxClicks, &updatedMapping.ClickCount, &updatedMapping.Protected)
 	if err == pgx.ErrNoRows {
 		return domain.MappingInfo{}, &domain.TokenNonExistingError{Msg: fmt.Sprintf("No mapping with token %s found", urlToken)}
 	} else if err != nil {
@@ -102,15 +214,183 @@ func (s *PostgresStorage) UpdateOriginalUrl(ctx context.Context, urlToken string
 	return updatedMapping, nil
 }
 
-// DeleteMappingInfo removes a URL mapping from PostgreSQL by its token.
+// SaveClickCount raises the consumed clicks counter of the mapping to count.
+// Lower values are ignored, so out-of-order saves never move the counter backwards.
 //
 // Returns an error if:
 //   - *domain.TokenNonExistingError: no mapping with the given token exists
 //   - Database operation fails
+func (s *PostgresStorage) SaveClickCount(ctx context.Context, urlToken string, count int64) error {
+	sql := `UPDATE mappings SET click_count = GREATEST(click_count, $1) WHERE url_token = $2 AND domain IS NOT DISTINCT FROM NULLIF($3, '')`
+
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, count, token, shortDomain)
+	if err != nil {
+		return fmt.Errorf("failed to save click count in db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.TokenNonExistingError{Msg: fmt.Sprintf("No mapping with token %s found", urlToken)}
+	}
+
+	return nil
+}
+
+// ListMappingsAfter retrieves up to limit mappings with an ID greater than afterId, ordered by ID,
+// so all mappings can be walked in batches. The mappings contain their ID, token, domain, original URL and threat.
+// Soft-deleted mappings are skipped.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresStorage) ListMappingsAfter(ctx context.Context, afterId int64, limit int) ([]domain.MappingInfo, error) {
+	sql := `SELECT id, original_url, url_token, COALESCE(domain, ''), COALESCE(flagged_threat, '') FROM mappings
+		WHERE id > $1 AND deleted_at IS NULL ORDER BY id LIMIT $2`
+
+	rows, err := s.queryExecutor.Query(ctx, sql, afterId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mappings from db: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := make([]domain.MappingInfo, 0, limit)
+	for rows.Next() {
+		var mapping domain.MappingInfo
+		err = rows.Scan(&mapping.Id, &mapping.OriginalURL, &mapping.Token, &mapping.Domain, &mapping.Threat)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mapping from db: %w", err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list mappings from db: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// ListOwnedMappings retrieves up to limit mappings of the owner with an ID greater than afterId, ordered by ID,
+// so the mappings of an owner can be paged through. An empty OwnerId only lists mappings without owner
+// and a zero WorkspaceId only mappings outside of workspaces. Soft-deleted mappings are skipped.
+// The password hashes of protected mappings are not returned.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresStorage) ListOwnedMappings(ctx context.Context, owner domain.MappingOwner, afterId int64, limit int) ([]domain.MappingInfo, error) {
+	sql := `SELECT id, original_url, url_token, COALESCE(domain, ''), created_at, updated_at, expires_at, max_clicks, click_count,
+		password_hash IS NOT NULL, COALESCE(flagged_threat, ''), COALESCE(disabled_reason, ''), COALESCE(owner_id, ''),
+		COALESCE(workspace_id, 0) FROM mappings
+		WHERE owner_id IS NOT DISTINCT FROM NULLIF($1, '') AND workspace_id IS NOT DISTINCT FROM NULLIF($2, 0)
+		AND id > $3 AND deleted_at IS NULL ORDER BY id LIMIT $4`
+
+	rows, err := s.queryExecutor.Query(ctx, sql, owner.OwnerId, owner.WorkspaceId, afterId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned mappings from db: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := make([]domain.MappingInfo, 0, limit)
+	for rows.Next() {
+		var mapping domain.MappingInfo
+		err = rows.Scan(&mapping.Id, &mapping.OriginalURL, &mapping.Token, &mapping.Domain, &mapping.CreatedAt, &mapping.UpdatedAt,
+			&mapping.ExpiresAt, &mapping.MaxClicks, &mapping.ClickCount, &mapping.Protected, &mapping.Threat, &mapping.DisabledReason,
+			&mapping.OwnerId, &mapping.WorkspaceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mapping from db: %w", err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list owned mappings from db: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// FlagMapping sets the threat the original URL of the mapping is known for.
+// An empty threat clears the flag.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: no mapping with the given token exists
+//   - Database operation fails
+func (s *PostgresStorage) FlagMapping(ctx context.Context, urlToken string, threat string) error {
+	sql := `UPDATE mappings SET flagged_threat = NULLIF($1, '') WHERE url_token = $2 AND domain IS NOT DISTINCT FROM NULLIF($3, '')`
+
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, threat, token, shortDomain)
+	if err != nil {
+		return fmt.Errorf("failed to flag mapping in db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.TokenNonExistingError{Msg: fmt.Sprintf("No mapping with token %s found", urlToken)}
+	}
+
+	return nil
+}
+
+// DisableMapping sets the reason the mapping is taken down for, so it no longer redirects.
+// An empty reason reinstates the mapping.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: no mapping with the given token exists
+//   - Database operation fails
+func (s *PostgresStorage) DisableMapping(ctx context.Context, urlToken string, reason string) error {
+	sql := `UPDATE mappings SET disabled_reason = NULLIF($1, '') WHERE url_token = $2 AND domain IS NOT DISTINCT FROM NULLIF($3, '')`
+
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, reason, token, shortDomain)
+	if err != nil {
+		return fmt.Errorf("failed to disable mapping in db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.TokenNonExistingError{Msg: fmt.Sprintf("No mapping with token %s found", urlToken)}
+	}
+
+	return nil
+}
+
+// GetMappingOwner retrieves the workspace and owner ID of a URL mapping in PostgreSQL by its token.
+// Soft-deleted mappings are found as well, so their owners can restore them.
+// Returns an empty owner ID for mappings without owner and a zero workspace ID for mappings outside of workspaces.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist
+//   - Database operation fails
+func (s *PostgresStorage) GetMappingOwner(ctx context.Context, urlToken string) (domain.MappingOwner, error) {
+	sql := `SELECT COALESCE(workspace_id, 0), COALESCE(owner_id, '') FROM mappings WHERE url_token = $1 AND domain IS NOT DISTINCT FROM NULLIF($2, '')`
+	var owner domain.MappingOwner
+
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	err := s.queryExecutor.QueryRow(ctx, sql, token, shortDomain).Scan(&owner.WorkspaceId, &owner.OwnerId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.MappingOwner{}, &domain.TokenNonExistingError{Msg: fmt.Sprintf("Token %s does not exist", urlToken)}
+	} else if err != nil {
+		return domain.MappingOwner{}, fmt.Errorf("failed to get mapping owner from db: %w", err)
+	}
+
+	return owner, nil
+}
+
+// CountMappingsCreatedSince counts the mappings of a workspace created at or after since.
+// Soft-deleted mappings are counted as well, so deleting short URLs does not free up quota.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresStorage) CountMappingsCreatedSince(ctx context.Context, workspaceId int64, since time.Time) (int64, error) {
+	sql := `SELECT COUNT(*) FROM mappings WHERE workspace_id = $1 AND created_at >= $2`
+	var count int64
+
+	err := s.queryExecutor.QueryRow(ctx, sql, workspaceId, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count mappings of workspace in db: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteMappingInfo soft-deletes a URL mapping in PostgreSQL by its token.
+// The mapping keeps its row until it is purged, so it can be restored with RestoreMappingInfo.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: no undeleted mapping with the given token exists
+//   - Database operation fails
 func (s *PostgresStorage) DeleteMappingInfo(ctx context.Context, urlToken string) error {
-	sql := `DELETE FROM mappings WHERE url_token = $1`
+	sql := `UPDATE mappings SET deleted_at = NOW() WHERE url_token = $1 AND domain IS NOT DISTINCT FROM NULLIF($2, '') AND deleted_at IS NULL`
 
-	cmdTag, err := s.queryExecutor.Exec(ctx, sql, urlToken)
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, token, shortDomain)
 	if err != nil {
 		return fmt.Errorf("failed to delete mapping from db: %w", err)
 	} else if cmdTag.RowsAffected() == 0 {
@@ -119,3 +399,58 @@ func (s *PostgresStorage) DeleteMappingInfo(ctx context.Context, urlToken string
 
 	return nil
 }
+
+// RestoreMappingInfo undoes the soft deletion of a URL mapping in PostgreSQL by its token.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: no soft-deleted mapping with the given token exists
+//   - Database operation fails
+func (s *PostgresStorage) RestoreMappingInfo(ctx context.Context, urlToken string) error {
+	sql := `UPDATE mappings SET deleted_at = NULL WHERE url_token = $1 AND domain IS NOT DISTINCT FROM NULLIF($2, '') AND deleted_at IS NOT NULL`
+
+	shortDomain, token := domain.SplitLinkKey(urlToken)
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, token, shortDomain)
+	if err != nil {
+		return fmt.Errorf("failed to restore mapping in db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.TokenNonExistingError{Msg: fmt.Sprintf("No deleted mapping with token %s found", urlToken)}
+	}
+
+	return nil
+}
+
+// PurgeDeletedMappings permanently removes up to limit mappings soft-deleted before deletedBefore,
+// together with their abuse reports. The tokens, short domains and IDs of purged mappings are
+// retired in the same statement, so they are never issued again.
+// Returns the number of purged mappings.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresStorage) PurgeDeletedMappings(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	sql := `WITH purged AS (
+			DELETE FROM mappings WHERE id IN (SELECT id FROM mappings WHERE deleted_at < $1 ORDER BY id LIMIT $2)
+			RETURNING id, url_token, domain
+		), purged_reports AS (
+			DELETE FROM abuse_reports USING purged
+			WHERE abuse_reports.url_token = purged.url_token AND abuse_reports.domain IS NOT DISTINCT FROM purged.domain
+		)
+		INSERT INTO retired_tokens (url_token, domain, mapping_id) SELECT url_token, domain, id FROM purged`
+
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, deletedBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted mappings from db: %w", err)
+	}
+
+	return cmdTag.RowsAffected(), nil
+}
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign key violation of the given constraint.
+func isForeignKeyViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode && pgErr.ConstraintName == constraint
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique violation of the given constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == constraint
+}
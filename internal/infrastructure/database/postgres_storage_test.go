@@ -7,71 +7,1206 @@ import (
 	"testing"
 	"time"
 	"url-shortening-service/internal/domain"
-	"url-shortening-service/internal/domain/mocks"
 
-	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// exampleUrlHash and newExampleUrlHash are the stored hashes of the normalized test URLs.
+var (
+	exampleUrlHash, _    = domain.HashURL("https://example.com")
+	newExampleUrlHash, _ = domain.HashURL("https://newexample.com")
+)
+
 func TestPostgresStorage_GetMappingByToken(t *testing.T) {
 	t.Parallel()
 
+	testExpiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testMaxClicks := int64(3)
+	testCreatedAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name           string
+		urlToken       string
+		expectedResult domain.MappingInfo
+		expectedError  error
+
+		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Success - mapping found",
+			urlToken: "abc123",
+			expectedResult: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com",
+				Token:       "abc123",
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(1), "https://example.com", "abc123", "", testCreatedAt, testCreatedAt, nil, nil, int64(0), "", "", "", "", int64(0))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at, expires_at, max_clicks, click_count,\s+COALESCE\(password_hash, ''\), COALESCE\(flagged_threat, ''\), COALESCE\(disabled_reason, ''\), COALESCE\(owner_id, ''\),\s+COALESCE\(workspace_id, 0\) FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND deleted_at IS NULL`).
+					WithArgs("abc123", "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:     "Success - mapping with expiration found",
+			urlToken: "abc123",
+			expectedResult: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com",
+				Token:       "abc123",
+				ExpiresAt:   &testExpiresAt,
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(1), "https://example.com", "abc123", "", testCreatedAt, testCreatedAt, &testExpiresAt, nil, int64(0), "", "", "", "", int64(0))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at, expires_at, max_clicks, click_count,\s+COALESCE\(password_hash, ''\), COALESCE\(flagged_threat, ''\), COALESCE\(disabled_reason, ''\), COALESCE\(owner_id, ''\),\s+COALESCE\(workspace_id, 0\) FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND deleted_at IS NULL`).
+					WithArgs("abc123", "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:     "Success - click-limited mapping found",
+			urlToken: "once",
+			expectedResult: domain.MappingInfo{
+				Id:          2,
+				OriginalURL: "https://example.com/download",
+				Token:       "once",
+				MaxClicks:   &testMaxClicks,
+				ClickCount:  1,
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(2), "https://example.com/download", "once", "", testCreatedAt, testCreatedAt, nil, &testMaxClicks, int64(1), "", "", "", "", int64(0))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at, expires_at, max_clicks, click_count,\s+COALESCE\(password_hash, ''\), COALESCE\(flagged_threat, ''\), COALESCE\(disabled_reason, ''\), COALESCE\(owner_id, ''\),\s+COALESCE\(workspace_id, 0\) FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND deleted_at IS NULL`).
+					WithArgs("once", "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:     "Success - protected mapping found with password hash",
+			urlToken: "secret",
+			expectedResult: domain.MappingInfo{
+				Id:           3,
+				OriginalURL:  "https://example.com/internal",
+				Token:        "secret",
+				Protected:    true,
+				PasswordHash: "$2a$10$hash",
+				CreatedAt:    testCreatedAt,
+				UpdatedAt:    testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(3), "https://example.com/internal", "secret", "", testCreatedAt, testCreatedAt, nil, nil, int64(0), "$2a$10$hash", "", "", "", int64(0))
+				mockPool.ExpectQuery(`FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("secret", "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:     "Success - taken down mapping found",
+			urlToken: "down",
+			expectedResult: domain.MappingInfo{
+				Id:             5,
+				OriginalURL:    "https://example.com/spam",
+				Token:          "down",
+				DisabledReason: "spam",
+				CreatedAt:      testCreatedAt,
+				UpdatedAt:      testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(5), "https://example.com/spam", "down", "", testCreatedAt, testCreatedAt, nil, nil, int64(0), "", "", "spam", "", int64(0))
+				mockPool.ExpectQuery(`FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("down", "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:     "Success - flagged mapping found",
+			urlToken: "flagged",
+			expectedResult: domain.MappingInfo{
+				Id:          4,
+				OriginalURL: "https://example.com/login",
+				Token:       "flagged",
+				Threat:      "phishing",
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(4), "https://example.com/login", "flagged", "", testCreatedAt, testCreatedAt, nil, nil, int64(0), "", "phishing", "", "", int64(0))
+				mockPool.ExpectQuery(`FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("flagged", "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:     "Success - mapping of workspace found",
+			urlToken: "ws1234",
+			expectedResult: domain.MappingInfo{
+				Id:          6,
+				OriginalURL: "https://example.com/campaign",
+				Token:       "ws1234",
+				OwnerId:     "team-a",
+				WorkspaceId: 7,
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(6), "https://example.com/campaign", "ws1234", "", testCreatedAt, testCreatedAt, nil, nil, int64(0), "", "", "", "team-a", int64(7))
+				mockPool.ExpectQuery(`FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("ws1234", "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:     "Success - mapping on custom short domain",
+			urlToken: "go.example.com/sale",
+			expectedResult: domain.MappingInfo{
+				Id:          7,
+				OriginalURL: "https://example.com/sale",
+				Token:       "sale",
+				Domain:      "go.example.com",
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "password_hash", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}).
+					AddRow(int64(7), "https://example.com/sale", "sale", "go.example.com", testCreatedAt, testCreatedAt, nil, nil, int64(0), "", "", "", "", int64(0))
+				mockPool.ExpectQuery(`FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("sale", "go.example.com").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Not found - returns token non existing error",
+			urlToken:       "nonexistent",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  &domain.TokenNonExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at, expires_at, max_clicks, click_count,\s+COALESCE\(password_hash, ''\), COALESCE\(flagged_threat, ''\), COALESCE\(disabled_reason, ''\), COALESCE\(owner_id, ''\),\s+COALESCE\(workspace_id, 0\) FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND deleted_at IS NULL`).
+					WithArgs("nonexistent", "").
+					WillReturnError(pgx.ErrNoRows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Database error - returns error",
+			urlToken:       "abc123",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  assert.AnError,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at, expires_at, max_clicks, click_count,\s+COALESCE\(password_hash, ''\), COALESCE\(flagged_threat, ''\), COALESCE\(disabled_reason, ''\), COALESCE\(owner_id, ''\),\s+COALESCE\(workspace_id, 0\) FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND deleted_at IS NULL`).
+					WithArgs("abc123", "").
+					WillReturnError(assert.AnError)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			logger := tt.prepareMocks(t, mockPool)
+
+			storage := NewPostgresStorage(mockPool, logger)
+			result, err := storage.GetMappingByToken(context.Background(), tt.urlToken)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestPostgresStorage_AddNewMapping(t *testing.T) {
+	t.Parallel()
+
+	testTime := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+	testExpiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testMaxClicks := int64(1)
+
+	type testCase struct {
+		name           string
+		id             int64
+		originalUrl    string
+		urlToken       string
+		expiresAt      *time.Time
+		maxClicks      *int64
+		passwordHash   string
+		ownerId        string
+		workspaceId    int64
+		shortDomain    string
+		expectedResult domain.MappingInfo
+		expectedError  error
+
+		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Success - mapping created",
+			id:          1,
+			originalUrl: "https://example.com",
+			urlToken:    "abc123",
+			expectedResult: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com",
+				Token:       "abc123",
+				CreatedAt:   testTime,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "expires_at", "max_clicks", "protected", "owner_id", "workspace_id"}).
+					AddRow(int64(1), "https://example.com", "abc123", "", testTime, nil, nil, false, "", int64(0))
+				mockPool.ExpectQuery(`INSERT INTO mappings \(id, original_url, url_token, expires_at, max_clicks, password_hash, url_hash, owner_id, workspace_id, domain\)\s+SELECT \$1, \$2, \$3, \$4, \$5, NULLIF\(\$6, ''\), \$7, NULLIF\(\$8, ''\), NULLIF\(\$9, 0\), NULLIF\(\$10, ''\)\s+WHERE NOT EXISTS \(SELECT 1 FROM retired_tokens WHERE url_token = \$3 AND domain IS NOT DISTINCT FROM NULLIF\(\$10, ''\)\)`).
+					WithArgs(int64(1), "https://example.com", "abc123", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(0), "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:        "Success - mapping with expiration created",
+			id:          4,
+			originalUrl: "https://example.com",
+			urlToken:    "e",
+			expiresAt:   &testExpiresAt,
+			expectedResult: domain.MappingInfo{
+				Id:          4,
+				OriginalURL: "https://example.com",
+				Token:       "e",
+				CreatedAt:   testTime,
+				ExpiresAt:   &testExpiresAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "expires_at", "max_clicks", "protected", "owner_id", "workspace_id"}).
+					AddRow(int64(4), "https://example.com", "e", "", testTime, &testExpiresAt, nil, false, "", int64(0))
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(4), "https://example.com", "e", &testExpiresAt, (*int64)(nil), "", exampleUrlHash, "", int64(0), "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:        "Success - click-limited mapping created",
+			id:          5,
+			originalUrl: "https://example.com",
+			urlToken:    "f",
+			maxClicks:   &testMaxClicks,
+			expectedResult: domain.MappingInfo{
+				Id:          5,
+				OriginalURL: "https://example.com",
+				Token:       "f",
+				CreatedAt:   testTime,
+				MaxClicks:   &testMaxClicks,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "expires_at", "max_clicks", "protected", "owner_id", "workspace_id"}).
+					AddRow(int64(5), "https://example.com", "f", "", testTime, nil, &testMaxClicks, false, "", int64(0))
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(5), "https://example.com", "f", (*time.Time)(nil), &testMaxClicks, "", exampleUrlHash, "", int64(0), "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:         "Success - protected mapping created",
+			id:           6,
+			originalUrl:  "https://example.com",
+			urlToken:     "g",
+			passwordHash: "$2a$10$hash",
+			expectedResult: domain.MappingInfo{
+				Id:          6,
+				OriginalURL: "https://example.com",
+				Token:       "g",
+				CreatedAt:   testTime,
+				Protected:   true,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "expires_at", "max_clicks", "protected", "owner_id", "workspace_id"}).
+					AddRow(int64(6), "https://example.com", "g", "", testTime, nil, nil, true, "", int64(0))
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(6), "https://example.com", "g", (*time.Time)(nil), (*int64)(nil), "$2a$10$hash", exampleUrlHash, "", int64(0), "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:        "Success - owned mapping created",
+			id:          8,
+			originalUrl: "https://example.com",
+			urlToken:    "i",
+			ownerId:     "team-a",
+			expectedResult: domain.MappingInfo{
+				Id:          8,
+				OriginalURL: "https://example.com",
+				Token:       "i",
+				CreatedAt:   testTime,
+				OwnerId:     "team-a",
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "expires_at", "max_clicks", "protected", "owner_id", "workspace_id"}).
+					AddRow(int64(8), "https://example.com", "i", "", testTime, nil, nil, false, "team-a", int64(0))
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(8), "https://example.com", "i", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "team-a", int64(0), "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:        "Success - mapping of workspace created",
+			id:          9,
+			originalUrl: "https://example.com",
+			urlToken:    "j",
+			workspaceId: 7,
+			expectedResult: domain.MappingInfo{
+				Id:          9,
+				OriginalURL: "https://example.com",
+				Token:       "j",
+				CreatedAt:   testTime,
+				WorkspaceId: 7,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "expires_at", "max_clicks", "protected", "owner_id", "workspace_id"}).
+					AddRow(int64(9), "https://example.com", "j", "", testTime, nil, nil, false, "", int64(7))
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(9), "https://example.com", "j", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(7), "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:        "Success - mapping created on custom short domain",
+			id:          11,
+			originalUrl: "https://example.com",
+			urlToken:    "sale",
+			shortDomain: "go.example.com",
+			expectedResult: domain.MappingInfo{
+				Id:          11,
+				OriginalURL: "https://example.com",
+				Token:       "sale",
+				Domain:      "go.example.com",
+				CreatedAt:   testTime,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "expires_at", "max_clicks", "protected", "owner_id", "workspace_id"}).
+					AddRow(int64(11), "https://example.com", "sale", "go.example.com", testTime, nil, nil, false, "", int64(0))
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(11), "https://example.com", "sale", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(0), "go.example.com").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Unknown short domain - returns ShortDomainNonExistingError",
+			id:             12,
+			originalUrl:    "https://example.com",
+			urlToken:       "sale",
+			shortDomain:    "go.example.com",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  &domain.ShortDomainNonExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(12), "https://example.com", "sale", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(0), "go.example.com").
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: mappingDomainConstraint})
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Unknown workspace - returns WorkspaceNonExistingError",
+			id:             10,
+			originalUrl:    "https://example.com",
+			urlToken:       "k",
+			workspaceId:    99,
+			expectedResult: domain.MappingInfo{},
+			expectedError:  &domain.WorkspaceNonExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(10), "https://example.com", "k", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(99), "").
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: mappingWorkspaceConstraint})
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Database error - returns error",
+			id:             1,
+			originalUrl:    "https://example.com",
+			urlToken:       "abc123",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  assert.AnError,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(1), "https://example.com", "abc123", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(0), "").
+					WillReturnError(assert.AnError)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Token taken - returns TokenExistingError",
+			id:             2,
+			originalUrl:    "https://example.com",
+			urlToken:       "spring-sale",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  &domain.TokenExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(2), "https://example.com", "spring-sale", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(0), "").
+					WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: urlTokenConstraint})
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Retired token - returns TokenExistingError",
+			id:             7,
+			originalUrl:    "https://example.com",
+			urlToken:       "h",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  &domain.TokenExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(7), "https://example.com", "h", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(0), "").
+					WillReturnError(pgx.ErrNoRows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Duplicate id - returns IdExistingError",
+			id:             3,
+			originalUrl:    "https://example.com",
+			urlToken:       "d",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  &domain.IdExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`INSERT INTO mappings`).
+					WithArgs(int64(3), "https://example.com", "d", (*time.Time)(nil), (*int64)(nil), "", exampleUrlHash, "", int64(0), "").
+					WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: idConstraint})
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			logger := tt.prepareMocks(t, mockPool)
+
+			storage := NewPostgresStorage(mockPool, logger)
+			result, err := storage.AddNewMapping(context.Background(), domain.MappingInfo{
+				Id:           tt.id,
+				OriginalURL:  tt.originalUrl,
+				Token:        tt.urlToken,
+				ExpiresAt:    tt.expiresAt,
+				MaxClicks:    tt.maxClicks,
+				PasswordHash: tt.passwordHash,
+				OwnerId:      tt.ownerId,
+				WorkspaceId:  tt.workspaceId,
+				Domain:       tt.shortDomain,
+			})
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				if _, ok := tt.expectedError.(*domain.TokenExistingError); ok {
+					assert.ErrorIs(t, err, &domain.TokenExistingError{})
+				} else {
+					assert.NotErrorIs(t, err, &domain.TokenExistingError{})
+				}
+				if _, ok := tt.expectedError.(*domain.IdExistingError); ok {
+					assert.ErrorIs(t, err, &domain.IdExistingError{})
+				} else {
+					assert.NotErrorIs(t, err, &domain.IdExistingError{})
+				}
+				if _, ok := tt.expectedError.(*domain.WorkspaceNonExistingError); ok {
+					assert.ErrorIs(t, err, &domain.WorkspaceNonExistingError{})
+				}
+				if _, ok := tt.expectedError.(*domain.ShortDomainNonExistingError); ok {
+					assert.ErrorIs(t, err, &domain.ShortDomainNonExistingError{})
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestPostgresStorage_FindReusableMapping(t *testing.T) {
+	t.Parallel()
+
+	testCreatedAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name           string
+		originalUrl    string
+		owner          domain.MappingOwner
+		shortDomain    string
+		expectedResult domain.MappingInfo
+		expectedFound  bool
+		expectedError  error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Success - mapping found by normalized URL",
+			originalUrl: "HTTPS://Example.com:443/",
+			expectedResult: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com",
+				Token:       "b",
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedFound: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "owner_id", "workspace_id"}).
+					AddRow(int64(1), "https://example.com", "b", "", testCreatedAt, testCreatedAt, "", int64(0))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at, COALESCE\(owner_id, ''\), COALESCE\(workspace_id, 0\) FROM mappings\s+WHERE url_hash = \$1 AND expires_at IS NULL AND max_clicks IS NULL AND password_hash IS NULL AND flagged_threat IS NULL\s+AND disabled_reason IS NULL AND deleted_at IS NULL\s+AND owner_id IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND workspace_id IS NOT DISTINCT FROM NULLIF\(\$3, 0\)\s+AND domain IS NOT DISTINCT FROM NULLIF\(\$4, ''\) ORDER BY id LIMIT 1`).
+					WithArgs(exampleUrlHash, "", int64(0), "").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:        "Success - mapping of owner found",
+			originalUrl: "https://example.com",
+			owner:       domain.MappingOwner{OwnerId: "team-a"},
+			expectedResult: domain.MappingInfo{
+				Id:          2,
+				OriginalURL: "https://example.com",
+				Token:       "c",
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+				OwnerId:     "team-a",
+			},
+			expectedFound: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "owner_id", "workspace_id"}).
+					AddRow(int64(2), "https://example.com", "c", "", testCreatedAt, testCreatedAt, "team-a", int64(0))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at`).
+					WithArgs(exampleUrlHash, "team-a", int64(0), "").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:        "Success - mapping of workspace found",
+			originalUrl: "https://example.com",
+			owner:       domain.MappingOwner{WorkspaceId: 7, OwnerId: "team-a"},
+			expectedResult: domain.MappingInfo{
+				Id:          3,
+				OriginalURL: "https://example.com",
+				Token:       "d",
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+				OwnerId:     "team-a",
+				WorkspaceId: 7,
+			},
+			expectedFound: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "owner_id", "workspace_id"}).
+					AddRow(int64(3), "https://example.com", "d", "", testCreatedAt, testCreatedAt, "team-a", int64(7))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at`).
+					WithArgs(exampleUrlHash, "team-a", int64(7), "").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:        "Success - mapping of custom short domain found",
+			originalUrl: "https://example.com",
+			shortDomain: "go.example.com",
+			expectedResult: domain.MappingInfo{
+				Id:          4,
+				OriginalURL: "https://example.com",
+				Token:       "e",
+				Domain:      "go.example.com",
+				CreatedAt:   testCreatedAt,
+				UpdatedAt:   testCreatedAt,
+			},
+			expectedFound: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "owner_id", "workspace_id"}).
+					AddRow(int64(4), "https://example.com", "e", "go.example.com", testCreatedAt, testCreatedAt, "", int64(0))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at`).
+					WithArgs(exampleUrlHash, "", int64(0), "go.example.com").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:          "Success - mapping flagged as a threat not reused",
+			originalUrl:   "https://example.com",
+			expectedFound: false,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`AND password_hash IS NULL AND flagged_threat IS NULL`).
+					WithArgs(exampleUrlHash, "", int64(0), "").
+					WillReturnError(pgx.ErrNoRows)
+			},
+		},
+		{
+			name:          "Success - no reusable mapping",
+			originalUrl:   "https://example.com",
+			expectedFound: false,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at`).
+					WithArgs(exampleUrlHash, "", int64(0), "").
+					WillReturnError(pgx.ErrNoRows)
+			},
+		},
+		{
+			name:          "Error - invalid URL",
+			originalUrl:   "https://example.com/%zz",
+			expectedError: &domain.InvalidUrlError{},
+			prepareMocks:  func(mockPool pgxmock.PgxConnIface) {},
+		},
+		{
+			name:          "Error - database error",
+			originalUrl:   "https://example.com",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at`).
+					WithArgs(exampleUrlHash, "", int64(0), "").
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			storage := NewPostgresStorage(mockPool, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			result, found, err := storage.FindReusableMapping(context.Background(), tt.originalUrl, tt.owner, tt.shortDomain)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedFound, found)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresStorage_GetLastId(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedId    int64
+		expectedError error
+
+		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Success - returns last id",
+			expectedId:    100,
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				lastId := int64(100)
+				rows := pgxmock.NewRows([]string{"greatest"}).AddRow(&lastId)
+				mockPool.ExpectQuery(`SELECT GREATEST\(\(SELECT id FROM mappings ORDER BY id DESC LIMIT 1\),\s+\(SELECT mapping_id FROM retired_tokens ORDER BY mapping_id DESC LIMIT 1\)\)`).
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:          "No mappings - returns 0",
+			expectedId:    0,
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"greatest"}).AddRow((*int64)(nil))
+				mockPool.ExpectQuery(`SELECT GREATEST`).
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:          "Database error - returns error",
+			expectedId:    0,
+			expectedError: assert.AnError,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`SELECT GREATEST`).
+					WillReturnError(assert.AnError)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			logger := tt.prepareMocks(t, mockPool)
+
+			storage := NewPostgresStorage(mockPool, logger)
+			result, err := storage.GetLastId(context.Background())
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedId, result)
+			}
+		})
+	}
+}
+
+func TestPostgresStorage_UpdateOriginalUrl(t *testing.T) {
+	t.Parallel()
+
+	testCreatedTime := time.Date(2025, 12, 17, 10, 0, 0, 0, time.UTC)
+	testUpdatedTime := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+	testExpiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name           string
+		urlToken       string
+		newOriginalUrl string
+		opts           domain.UpdateOptions
+		expectedResult domain.MappingInfo
+		expectedError  error
+
+		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success - url updated",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://newexample.com",
+			expectedResult: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://newexample.com",
+				Token:       "abc123",
+				CreatedAt:   testCreatedTime,
+				UpdatedAt:   testUpdatedTime,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "protected"}).
+					AddRow(int64(1), "https://newexample.com", "abc123", "", testCreatedTime, testUpdatedTime, nil, nil, int64(0), false)
+				mockPool.ExpectQuery(`UPDATE mappings SET original_url = \$1, updated_at = \$2, expires_at = CASE WHEN \$6 THEN NULL ELSE COALESCE\(\$3, expires_at\) END,\s+url_hash = \$5, flagged_threat = NULL WHERE url_token = \$4 AND domain IS NOT DISTINCT FROM NULLIF\(\$7, ''\) AND deleted_at IS NULL`).
+					WithArgs("https://newexample.com", pgxmock.AnyArg(), (*time.Time)(nil), "abc123", newExampleUrlHash, false, "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Success - url and expiration updated",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://newexample.com",
+			opts:           domain.UpdateOptions{ExpiresAt: &testExpiresAt},
+			expectedResult: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://newexample.com",
+				Token:       "abc123",
+				CreatedAt:   testCreatedTime,
+				UpdatedAt:   testUpdatedTime,
+				ExpiresAt:   &testExpiresAt,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "protected"}).
+					AddRow(int64(1), "https://newexample.com", "abc123", "", testCreatedTime, testUpdatedTime, &testExpiresAt, nil, int64(0), false)
+				mockPool.ExpectQuery(`UPDATE mappings SET original_url`).
+					WithArgs("https://newexample.com", pgxmock.AnyArg(), &testExpiresAt, "abc123", newExampleUrlHash, false, "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Success - url updated and expiration cleared",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://newexample.com",
+			opts:           domain.UpdateOptions{ClearExpiration: true},
+			expectedResult: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://newexample.com",
+				Token:       "abc123",
+				CreatedAt:   testCreatedTime,
+				UpdatedAt:   testUpdatedTime,
+			},
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks", "click_count", "protected"}).
+					AddRow(int64(1), "https://newexample.com", "abc123", "", testCreatedTime, testUpdatedTime, nil, nil, int64(0), false)
+				mockPool.ExpectQuery(`UPDATE mappings SET original_url`).
+					WithArgs("https://newexample.com", pgxmock.AnyArg(), (*time.Time)(nil), "abc123", newExampleUrlHash, true, "").
+					WillReturnRows(rows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Token not found - returns TokenNonExistingError",
+			urlToken:       "nonexistent",
+			newOriginalUrl: "https://newexample.com",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  &domain.TokenNonExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`UPDATE mappings SET original_url`).
+					WithArgs("https://newexample.com", pgxmock.AnyArg(), (*time.Time)(nil), "nonexistent", newExampleUrlHash, false, "").
+					WillReturnError(pgx.ErrNoRows)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Database error - returns error",
+			urlToken:       "abc123",
+			newOriginalUrl: "https://newexample.com",
+			expectedResult: domain.MappingInfo{},
+			expectedError:  assert.AnError,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`UPDATE mappings SET original_url`).
+					WithArgs("https://newexample.com", pgxmock.AnyArg(), (*time.Time)(nil), "abc123", newExampleUrlHash, false, "").
+					WillReturnError(assert.AnError)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			logger := tt.prepareMocks(t, mockPool)
+
+			storage := NewPostgresStorage(mockPool, logger)
+			result, err := storage.UpdateOriginalUrl(context.Background(), tt.urlToken, tt.newOriginalUrl, tt.opts)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				if _, ok := tt.expectedError.(*domain.TokenNonExistingError); ok {
+					assert.ErrorIs(t, err, &domain.TokenNonExistingError{})
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestPostgresStorage_GetMappingOwner(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		urlToken      string
+		expectedOwner domain.MappingOwner
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Success - workspace and owner found",
+			urlToken:      "abc123",
+			expectedOwner: domain.MappingOwner{WorkspaceId: 7, OwnerId: "team-a"},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT COALESCE\(workspace_id, 0\), COALESCE\(owner_id, ''\) FROM mappings WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\)`).
+					WithArgs("abc123", "").
+					WillReturnRows(pgxmock.NewRows([]string{"workspace_id", "owner_id"}).AddRow(int64(7), "team-a"))
+			},
+		},
+		{
+			name:          "Success - mapping without owner",
+			urlToken:      "abc123",
+			expectedOwner: domain.MappingOwner{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT COALESCE\(workspace_id, 0\), COALESCE\(owner_id, ''\) FROM mappings`).
+					WithArgs("abc123", "").
+					WillReturnRows(pgxmock.NewRows([]string{"workspace_id", "owner_id"}).AddRow(int64(0), ""))
+			},
+		},
+		{
+			name:          "Token not found - returns TokenNonExistingError",
+			urlToken:      "nonexistent",
+			expectedError: &domain.TokenNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT COALESCE\(workspace_id, 0\), COALESCE\(owner_id, ''\) FROM mappings`).
+					WithArgs("nonexistent", "").
+					WillReturnError(pgx.ErrNoRows)
+			},
+		},
+		{
+			name:          "Database error - returns error",
+			urlToken:      "abc123",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT COALESCE\(workspace_id, 0\), COALESCE\(owner_id, ''\) FROM mappings`).
+					WithArgs("abc123", "").
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			storage := NewPostgresStorage(mockPool, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			owner, err := storage.GetMappingOwner(context.Background(), tt.urlToken)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOwner, owner)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresStorage_CountMappingsCreatedSince(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		expectedCount int64
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Success - mappings counted",
+			expectedCount: 42,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT COUNT\(\*\) FROM mappings WHERE workspace_id = \$1 AND created_at >= \$2`).
+					WithArgs(int64(7), since).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(42)))
+			},
+		},
+		{
+			name:          "Database error - returns error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT COUNT\(\*\) FROM mappings`).
+					WithArgs(int64(7), since).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			storage := NewPostgresStorage(mockPool, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			count, err := storage.CountMappingsCreatedSince(context.Background(), 7, since)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, count)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresStorage_DeleteMappingInfo(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		urlToken      string
+		expectedError error
+
+		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Success - mapping soft-deleted",
+			urlToken:      "abc123",
+			expectedError: nil,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`UPDATE mappings SET deleted_at = NOW\(\) WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND deleted_at IS NULL`).
+					WithArgs("abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:          "Token not found - returns TokenNonExistingError",
+			urlToken:      "nonexistent",
+			expectedError: &domain.TokenNonExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`UPDATE mappings SET deleted_at = NOW\(\)`).
+					WithArgs("nonexistent", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:          "Database error - returns error",
+			urlToken:      "abc123",
+			expectedError: assert.AnError,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`UPDATE mappings SET deleted_at = NOW\(\)`).
+					WithArgs("abc123", "").
+					WillReturnError(assert.AnError)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			logger := tt.prepareMocks(t, mockPool)
+
+			storage := NewPostgresStorage(mockPool, logger)
+			err = storage.DeleteMappingInfo(context.Background(), tt.urlToken)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				if _, ok := tt.expectedError.(*domain.TokenNonExistingError); ok {
+					assert.ErrorIs(t, err, &domain.TokenNonExistingError{})
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPostgresStorage_SaveClickCount(t *testing.T) {
+	t.Parallel()
+
 	type testCase struct {
-		name           string
-		urlToken       string
-		expectedResult domain.MappingInfo
-		expectedFound  bool
+		name          string
+		urlToken      string
+		count         int64
+		expectedError error
 
 		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
 	}
 
 	testCases := []testCase{
 		{
-			name:     "Success - mapping found",
-			urlToken: "abc123",
-			expectedResult: domain.MappingInfo{
-				Id:          1,
-				OriginalURL: "https://example.com",
-				Token:       "abc123",
-			},
-			expectedFound: true,
+			name:          "Success - click count saved",
+			urlToken:      "abc123",
+			count:         3,
+			expectedError: nil,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token"}).
-					AddRow(int64(1), "https://example.com", "abc123")
-				mockPool.ExpectQuery(`SELECT id, original_url, url_token FROM mappings WHERE url_token = \$1`).
-					WithArgs("abc123").
-					WillReturnRows(rows)
+				mockPool.ExpectExec(`UPDATE mappings SET click_count = GREATEST\(click_count, \$1\) WHERE url_token = \$2 AND domain IS NOT DISTINCT FROM NULLIF\(\$3, ''\)`).
+					WithArgs(int64(3), "abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
-			name:           "Not found - returns empty mapping and false",
-			urlToken:       "nonexistent",
-			expectedResult: domain.MappingInfo{},
-			expectedFound:  false,
+			name:          "Token not found - returns TokenNonExistingError",
+			urlToken:      "nonexistent",
+			count:         1,
+			expectedError: &domain.TokenNonExistingError{},
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectQuery(`SELECT id, original_url, url_token FROM mappings WHERE url_token = \$1`).
-					WithArgs("nonexistent").
-					WillReturnError(pgx.ErrNoRows)
+				mockPool.ExpectExec(`UPDATE mappings SET click_count`).
+					WithArgs(int64(1), "nonexistent", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
-			name:           "Database error - returns empty mapping and false",
-			urlToken:       "abc123",
-			expectedResult: domain.MappingInfo{},
-			expectedFound:  false,
+			name:          "Database error - returns error",
+			urlToken:      "abc123",
+			count:         2,
+			expectedError: assert.AnError,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectQuery(`SELECT id, original_url, url_token FROM mappings WHERE url_token = \$1`).
-					WithArgs("abc123").
+				mockPool.ExpectExec(`UPDATE mappings SET click_count`).
+					WithArgs(int64(2), "abc123", "").
 					WillReturnError(assert.AnError)
-				ctrl := gomock.NewController(t)
-				mockLogger := mocks.NewMockLogger(ctrl)
-				mockLogger.EXPECT().Error(gomock.Any())
-				return mockLogger
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 	}
@@ -87,62 +1222,64 @@ func TestPostgresStorage_GetMappingByToken(t *testing.T) {
 			logger := tt.prepareMocks(t, mockPool)
 
 			storage := NewPostgresStorage(mockPool, logger)
-			result, found := storage.GetMappingByToken(context.Background(), tt.urlToken)
+			err = storage.SaveClickCount(context.Background(), tt.urlToken, tt.count)
 
-			assert.Equal(t, tt.expectedFound, found)
-			assert.Equal(t, tt.expectedResult, result)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestPostgresStorage_AddNewMapping(t *testing.T) {
+func TestPostgresStorage_ListMappingsAfter(t *testing.T) {
 	t.Parallel()
 
-	testTime := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
-
 	type testCase struct {
 		name           string
-		id             int64
-		originalUrl    string
-		urlToken       string
-		expectedResult domain.MappingInfo
-		expectedError  error
+		expectedResult []domain.MappingInfo
+		expectedError  bool
 
 		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
 	}
 
 	testCases := []testCase{
 		{
-			name:        "Success - mapping created",
-			id:          1,
-			originalUrl: "https://example.com",
-			urlToken:    "abc123",
-			expectedResult: domain.MappingInfo{
-				Id:          1,
-				OriginalURL: "https://example.com",
-				Token:       "abc123",
-				CreatedAt:   testTime,
+			name: "Success - batch listed",
+			expectedResult: []domain.MappingInfo{
+				{Id: 11, OriginalURL: "https://example.com", Token: "b"},
+				{Id: 12, OriginalURL: "https://example.com/login", Token: "c", Threat: "phishing"},
+				{Id: 13, OriginalURL: "https://example.com/sale", Token: "sale", Domain: "go.example.com"},
 			},
-			expectedError: nil,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "created_at"}).
-					AddRow(int64(1), "https://example.com", "abc123", testTime)
-				mockPool.ExpectQuery(`INSERT INTO mappings`).
-					WithArgs(int64(1), "https://example.com", "abc123").
+				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "flagged_threat"}).
+					AddRow(int64(11), "https://example.com", "b", "", "").
+					AddRow(int64(12), "https://example.com/login", "c", "", "phishing").
+					AddRow(int64(13), "https://example.com/sale", "sale", "go.example.com", "")
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), COALESCE\(flagged_threat, ''\) FROM mappings\s+WHERE id > \$1 AND deleted_at IS NULL ORDER BY id LIMIT \$2`).
+					WithArgs(int64(10), 2).
 					WillReturnRows(rows)
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
-			name:           "Database error - returns error",
-			id:             1,
-			originalUrl:    "https://example.com",
-			urlToken:       "abc123",
-			expectedResult: domain.MappingInfo{},
-			expectedError:  assert.AnError,
+			name:           "Success - no mappings left",
+			expectedResult: []domain.MappingInfo{},
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectQuery(`INSERT INTO mappings`).
-					WithArgs(int64(1), "https://example.com", "abc123").
+				mockPool.ExpectQuery(`WHERE id > \$1`).
+					WithArgs(int64(10), 2).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "original_url", "url_token", "domain", "flagged_threat"}))
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:          "Database error - returns error",
+			expectedError: true,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectQuery(`WHERE id > \$1`).
+					WithArgs(int64(10), 2).
 					WillReturnError(assert.AnError)
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
@@ -160,24 +1297,106 @@ func TestPostgresStorage_AddNewMapping(t *testing.T) {
 			logger := tt.prepareMocks(t, mockPool)
 
 			storage := NewPostgresStorage(mockPool, logger)
-			result, err := storage.AddNewMapping(context.Background(), tt.id, tt.originalUrl, tt.urlToken)
+			result, err := storage.ListMappingsAfter(context.Background(), 10, 2)
 
-			if tt.expectedError != nil {
+			if tt.expectedError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedResult, result)
 			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestPostgresStorage_GetLastId(t *testing.T) {
+func TestPostgresStorage_ListOwnedMappings(t *testing.T) {
+	t.Parallel()
+
+	testCreatedAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	ownedMappingColumns := []string{"id", "original_url", "url_token", "domain", "created_at", "updated_at", "expires_at", "max_clicks",
+		"click_count", "protected", "flagged_threat", "disabled_reason", "owner_id", "workspace_id"}
+
+	type testCase struct {
+		name           string
+		owner          domain.MappingOwner
+		expectedResult []domain.MappingInfo
+		expectedError  bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name:  "Success - mappings of owner listed",
+			owner: domain.MappingOwner{WorkspaceId: 7, OwnerId: "team-a"},
+			expectedResult: []domain.MappingInfo{
+				{Id: 11, OriginalURL: "https://example.com", Token: "b", CreatedAt: testCreatedAt, UpdatedAt: testCreatedAt, OwnerId: "team-a", WorkspaceId: 7},
+				{
+					Id: 13, OriginalURL: "https://example.com/sale", Token: "sale", Domain: "go.example.com", CreatedAt: testCreatedAt,
+					UpdatedAt: testCreatedAt, Protected: true, OwnerId: "team-a", WorkspaceId: 7,
+				},
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				rows := pgxmock.NewRows(ownedMappingColumns).
+					AddRow(int64(11), "https://example.com", "b", "", testCreatedAt, testCreatedAt, nil, nil, int64(0), false, "", "", "team-a", int64(7)).
+					AddRow(int64(13), "https://example.com/sale", "sale", "go.example.com", testCreatedAt, testCreatedAt, nil, nil, int64(0), true, "", "", "team-a", int64(7))
+				mockPool.ExpectQuery(`SELECT id, original_url, url_token, COALESCE\(domain, ''\), created_at, updated_at, expires_at, max_clicks, click_count,\s+password_hash IS NOT NULL, COALESCE\(flagged_threat, ''\), COALESCE\(disabled_reason, ''\), COALESCE\(owner_id, ''\),\s+COALESCE\(workspace_id, 0\) FROM mappings\s+WHERE owner_id IS NOT DISTINCT FROM NULLIF\(\$1, ''\) AND workspace_id IS NOT DISTINCT FROM NULLIF\(\$2, 0\)\s+AND id > \$3 AND deleted_at IS NULL ORDER BY id LIMIT \$4`).
+					WithArgs("team-a", int64(7), int64(10), 2).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:           "Success - no mappings left",
+			expectedResult: []domain.MappingInfo{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`WHERE owner_id IS NOT DISTINCT FROM`).
+					WithArgs("", int64(0), int64(10), 2).
+					WillReturnRows(pgxmock.NewRows(ownedMappingColumns))
+			},
+		},
+		{
+			name:          "Database error - returns error",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`WHERE owner_id IS NOT DISTINCT FROM`).
+					WithArgs("", int64(0), int64(10), 2).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			storage := NewPostgresStorage(mockPool, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			result, err := storage.ListOwnedMappings(context.Background(), tt.owner, 10, 2)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresStorage_FlagMapping(t *testing.T) {
 	t.Parallel()
 
 	type testCase struct {
 		name          string
-		expectedId    int64
+		urlToken      string
+		threat        string
 		expectedError error
 
 		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
@@ -185,32 +1404,46 @@ func TestPostgresStorage_GetLastId(t *testing.T) {
 
 	testCases := []testCase{
 		{
-			name:          "Success - returns last id",
-			expectedId:    100,
-			expectedError: nil,
+			name:     "Success - mapping flagged",
+			urlToken: "abc123",
+			threat:   "malware",
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				rows := pgxmock.NewRows([]string{"id"}).AddRow(int64(100))
-				mockPool.ExpectQuery(`SELECT id FROM mappings ORDER BY id DESC LIMIT 1`).
-					WillReturnRows(rows)
+				mockPool.ExpectExec(`UPDATE mappings SET flagged_threat = NULLIF\(\$1, ''\) WHERE url_token = \$2 AND domain IS NOT DISTINCT FROM NULLIF\(\$3, ''\)`).
+					WithArgs("malware", "abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
-			name:          "No rows - returns 0",
-			expectedId:    0,
-			expectedError: nil,
+			name:     "Success - flag cleared",
+			urlToken: "abc123",
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectQuery(`SELECT id FROM mappings ORDER BY id DESC LIMIT 1`).
-					WillReturnError(pgx.ErrNoRows)
+				mockPool.ExpectExec(`UPDATE mappings SET flagged_threat`).
+					WithArgs("", "abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:          "Token not found - returns TokenNonExistingError",
+			urlToken:      "nonexistent",
+			threat:        "malware",
+			expectedError: &domain.TokenNonExistingError{},
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`UPDATE mappings SET flagged_threat`).
+					WithArgs("malware", "nonexistent", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
 			name:          "Database error - returns error",
-			expectedId:    0,
+			urlToken:      "abc123",
+			threat:        "malware",
 			expectedError: assert.AnError,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectQuery(`SELECT id FROM mappings ORDER BY id DESC LIMIT 1`).
+				mockPool.ExpectExec(`UPDATE mappings SET flagged_threat`).
+					WithArgs("malware", "abc123", "").
 					WillReturnError(assert.AnError)
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
@@ -228,78 +1461,72 @@ func TestPostgresStorage_GetLastId(t *testing.T) {
 			logger := tt.prepareMocks(t, mockPool)
 
 			storage := NewPostgresStorage(mockPool, logger)
-			result, err := storage.GetLastId(context.Background())
+			err = storage.FlagMapping(context.Background(), tt.urlToken, tt.threat)
 
 			if tt.expectedError != nil {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, tt.expectedError)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedId, result)
 			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestPostgresStorage_UpdateOriginalUrl(t *testing.T) {
+func TestPostgresStorage_DisableMapping(t *testing.T) {
 	t.Parallel()
 
-	testCreatedTime := time.Date(2025, 12, 17, 10, 0, 0, 0, time.UTC)
-	testUpdatedTime := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
-
 	type testCase struct {
-		name           string
-		urlToken       string
-		newOriginalUrl string
-		expectedResult domain.MappingInfo
-		expectedError  error
+		name          string
+		urlToken      string
+		reason        string
+		expectedError error
 
 		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
 	}
 
 	testCases := []testCase{
 		{
-			name:           "Success - url updated",
-			urlToken:       "abc123",
-			newOriginalUrl: "https://newexample.com",
-			expectedResult: domain.MappingInfo{
-				Id:          1,
-				OriginalURL: "https://newexample.com",
-				Token:       "abc123",
-				CreatedAt:   testCreatedTime,
-				UpdatedAt:   testUpdatedTime,
+			name:     "Success - mapping taken down",
+			urlToken: "abc123",
+			reason:   "spam",
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`UPDATE mappings SET disabled_reason = NULLIF\(\$1, ''\) WHERE url_token = \$2 AND domain IS NOT DISTINCT FROM NULLIF\(\$3, ''\)`).
+					WithArgs("spam", "abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
-			expectedError: nil,
+		},
+		{
+			name:     "Success - mapping reinstated",
+			urlToken: "abc123",
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				rows := pgxmock.NewRows([]string{"id", "original_url", "url_token", "created_at", "updated_at"}).
-					AddRow(int64(1), "https://newexample.com", "abc123", testCreatedTime, testUpdatedTime)
-				mockPool.ExpectQuery(`UPDATE mappings SET original_url`).
-					WithArgs("https://newexample.com", pgxmock.AnyArg(), "abc123").
-					WillReturnRows(rows)
+				mockPool.ExpectExec(`UPDATE mappings SET disabled_reason`).
+					WithArgs("", "abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
-			name:           "Token not found - returns TokenNonExistingError",
-			urlToken:       "nonexistent",
-			newOriginalUrl: "https://newexample.com",
-			expectedResult: domain.MappingInfo{},
-			expectedError:  &domain.TokenNonExistingError{},
+			name:          "Token not found - returns TokenNonExistingError",
+			urlToken:      "nonexistent",
+			reason:        "spam",
+			expectedError: &domain.TokenNonExistingError{},
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectQuery(`UPDATE mappings SET original_url`).
-					WithArgs("https://newexample.com", pgxmock.AnyArg(), "nonexistent").
-					WillReturnError(pgx.ErrNoRows)
+				mockPool.ExpectExec(`UPDATE mappings SET disabled_reason`).
+					WithArgs("spam", "nonexistent", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
-			name:           "Database error - returns error",
-			urlToken:       "abc123",
-			newOriginalUrl: "https://newexample.com",
-			expectedResult: domain.MappingInfo{},
-			expectedError:  assert.AnError,
+			name:          "Database error - returns error",
+			urlToken:      "abc123",
+			reason:        "spam",
+			expectedError: assert.AnError,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectQuery(`UPDATE mappings SET original_url`).
-					WithArgs("https://newexample.com", pgxmock.AnyArg(), "abc123").
+				mockPool.ExpectExec(`UPDATE mappings SET disabled_reason`).
+					WithArgs("spam", "abc123", "").
 					WillReturnError(assert.AnError)
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
@@ -317,22 +1544,19 @@ func TestPostgresStorage_UpdateOriginalUrl(t *testing.T) {
 			logger := tt.prepareMocks(t, mockPool)
 
 			storage := NewPostgresStorage(mockPool, logger)
-			result, err := storage.UpdateOriginalUrl(context.Background(), tt.urlToken, tt.newOriginalUrl)
+			err = storage.DisableMapping(context.Background(), tt.urlToken, tt.reason)
 
 			if tt.expectedError != nil {
-				assert.Error(t, err)
-				if _, ok := tt.expectedError.(*domain.TokenNonExistingError); ok {
-					assert.ErrorIs(t, err, &domain.TokenNonExistingError{})
-				}
+				assert.ErrorIs(t, err, tt.expectedError)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedResult, result)
 			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestPostgresStorage_DeleteMappingInfo(t *testing.T) {
+func TestPostgresStorage_RestoreMappingInfo(t *testing.T) {
 	t.Parallel()
 
 	type testCase struct {
@@ -345,24 +1569,23 @@ func TestPostgresStorage_DeleteMappingInfo(t *testing.T) {
 
 	testCases := []testCase{
 		{
-			name:          "Success - mapping deleted",
-			urlToken:      "abc123",
-			expectedError: nil,
+			name:     "Success - mapping restored",
+			urlToken: "abc123",
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectExec(`DELETE FROM mappings WHERE url_token = \$1`).
-					WithArgs("abc123").
-					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mockPool.ExpectExec(`UPDATE mappings SET deleted_at = NULL WHERE url_token = \$1 AND domain IS NOT DISTINCT FROM NULLIF\(\$2, ''\) AND deleted_at IS NOT NULL`).
+					WithArgs("abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
 		{
-			name:          "Token not found - returns TokenNonExistingError",
-			urlToken:      "nonexistent",
+			name:          "Not deleted - returns TokenNonExistingError",
+			urlToken:      "abc123",
 			expectedError: &domain.TokenNonExistingError{},
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectExec(`DELETE FROM mappings WHERE url_token = \$1`).
-					WithArgs("nonexistent").
-					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mockPool.ExpectExec(`UPDATE mappings SET deleted_at = NULL`).
+					WithArgs("abc123", "").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
 		},
@@ -371,8 +1594,8 @@ func TestPostgresStorage_DeleteMappingInfo(t *testing.T) {
 			urlToken:      "abc123",
 			expectedError: assert.AnError,
 			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
-				mockPool.ExpectExec(`DELETE FROM mappings WHERE url_token = \$1`).
-					WithArgs("abc123").
+				mockPool.ExpectExec(`UPDATE mappings SET deleted_at = NULL`).
+					WithArgs("abc123", "").
 					WillReturnError(assert.AnError)
 				return slog.New(slog.NewTextHandler(io.Discard, nil))
 			},
@@ -390,16 +1613,84 @@ func TestPostgresStorage_DeleteMappingInfo(t *testing.T) {
 			logger := tt.prepareMocks(t, mockPool)
 
 			storage := NewPostgresStorage(mockPool, logger)
-			err = storage.DeleteMappingInfo(context.Background(), tt.urlToken)
+			err = storage.RestoreMappingInfo(context.Background(), tt.urlToken)
 
 			if tt.expectedError != nil {
-				assert.Error(t, err)
-				if _, ok := tt.expectedError.(*domain.TokenNonExistingError); ok {
-					assert.ErrorIs(t, err, &domain.TokenNonExistingError{})
-				}
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresStorage_PurgeDeletedMappings(t *testing.T) {
+	t.Parallel()
+
+	deletedBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name           string
+		expectedPurged int64
+		expectedError  error
+
+		prepareMocks func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success - mappings purged and tokens retired",
+			expectedPurged: 2,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`WITH purged AS \(\s+DELETE FROM mappings WHERE id IN \(SELECT id FROM mappings WHERE deleted_at < \$1 ORDER BY id LIMIT \$2\)\s+RETURNING id, url_token, domain\s+\), purged_reports AS \(\s+DELETE FROM abuse_reports USING purged\s+WHERE abuse_reports.url_token = purged.url_token AND abuse_reports.domain IS NOT DISTINCT FROM purged.domain\s+\)\s+INSERT INTO retired_tokens \(url_token, domain, mapping_id\) SELECT url_token, domain, id FROM purged`).
+					WithArgs(deletedBefore, 100).
+					WillReturnResult(pgxmock.NewResult("INSERT", 2))
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "Success - nothing to purge",
+			expectedPurged: 0,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`WITH purged AS`).
+					WithArgs(deletedBefore, 100).
+					WillReturnResult(pgxmock.NewResult("INSERT", 0))
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:          "Database error - returns error",
+			expectedError: assert.AnError,
+			prepareMocks: func(t *testing.T, mockPool pgxmock.PgxConnIface) domain.Logger {
+				mockPool.ExpectExec(`WITH purged AS`).
+					WithArgs(deletedBefore, 100).
+					WillReturnError(assert.AnError)
+				return slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			logger := tt.prepareMocks(t, mockPool)
+
+			storage := NewPostgresStorage(mockPool, logger)
+			purged, err := storage.PurgeDeletedMappings(context.Background(), deletedBefore, 100)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPurged, purged)
 			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
 		})
 	}
 }
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresWorkerLeaseStore leases Snowflake worker IDs using the worker_leases table in PostgreSQL.
+// A worker ID is free if it has no lease or its lease has expired.
+type PostgresWorkerLeaseStore struct {
+	queryExecutor domain.QueryExecutor
+}
+
+// NewPostgresWorkerLeaseStore creates a new PostgresWorkerLeaseStore instance.
+// Parameters:
+//   - queryExecutor: PostgreSQL connection pool
+func NewPostgresWorkerLeaseStore(queryExecutor domain.QueryExecutor) *PostgresWorkerLeaseStore {
+	return &PostgresWorkerLeaseStore{queryExecutor: queryExecutor}
+}
+
+// AcquireWorkerId leases the lowest free worker ID up to maxWorkerId for owner.
+// An expired lease is only taken over if it is still expired when the row is locked,
+// so two instances never acquire the same worker ID.
+//
+// Returns an error if:
+//   - Every worker ID is leased, or the free worker ID was taken concurrently
+//   - Database operation fails
+func (s *PostgresWorkerLeaseStore) AcquireWorkerId(ctx context.Context, owner string, maxWorkerId int64, ttl time.Duration) (int64, error) {
+	sql := `INSERT INTO worker_leases (worker_id, owner, expires_at)
+		SELECT candidate, $1, NOW() + make_interval(secs => $2)
+		FROM generate_series(0, $3) AS candidate
+		WHERE NOT EXISTS (SELECT 1 FROM worker_leases WHERE worker_id = candidate AND expires_at > NOW())
+		ORDER BY candidate
+		LIMIT 1
+		ON CONFLICT (worker_id) DO UPDATE SET owner = EXCLUDED.owner, expires_at = EXCLUDED.expires_at
+		WHERE worker_leases.expires_at <= NOW()
+		RETURNING worker_id`
+	var workerId int64
+
+	err := s.queryExecutor.QueryRow(ctx, sql, owner, ttl.Seconds(), maxWorkerId).Scan(&workerId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("no free worker id up to %d", maxWorkerId)
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to acquire worker id in db: %w", err)
+	}
+
+	return workerId, nil
+}
+
+// RenewWorkerId extends the lease of a worker ID held by owner by ttl from now.
+//
+// Returns an error if:
+//   - *domain.WorkerLeaseLostError: the worker ID is no longer leased by owner
+//   - Database operation fails
+func (s *PostgresWorkerLeaseStore) RenewWorkerId(ctx context.Context, workerId int64, owner string, ttl time.Duration) error {
+	sql := `UPDATE worker_leases SET expires_at = NOW() + make_interval(secs => $3) WHERE worker_id = $1 AND owner = $2`
+
+	cmdTag, err := s.queryExecutor.Exec(ctx, sql, workerId, owner, ttl.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to renew worker lease in db: %w", err)
+	} else if cmdTag.RowsAffected() == 0 {
+		return &domain.WorkerLeaseLostError{Msg: fmt.Sprintf("Worker id %d is no longer leased by %s", workerId, owner)}
+	}
+
+	return nil
+}
+
+// ReleaseWorkerId deletes the lease of a worker ID held by owner. Leases held by other owners are kept.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresWorkerLeaseStore) ReleaseWorkerId(ctx context.Context, workerId int64, owner string) error {
+	sql := `DELETE FROM worker_leases WHERE worker_id = $1 AND owner = $2`
+
+	_, err := s.queryExecutor.Exec(ctx, sql, workerId, owner)
+	if err != nil {
+		return fmt.Errorf("failed to release worker lease in db: %w", err)
+	}
+
+	return nil
+}
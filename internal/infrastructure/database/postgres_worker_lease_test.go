@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresWorkerLeaseStore_AcquireWorkerId(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name             string
+		expectedWorkerId int64
+		expectedError    bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name:             "Success - free worker id leased",
+			expectedWorkerId: 3,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO worker_leases \(worker_id, owner, expires_at\)`).
+					WithArgs("host-1", float64(30), int64(1023)).
+					WillReturnRows(pgxmock.NewRows([]string{"worker_id"}).AddRow(int64(3)))
+			},
+		},
+		{
+			name:          "Error - every worker id leased",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO worker_leases`).
+					WithArgs("host-1", float64(30), int64(1023)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO worker_leases`).
+					WithArgs("host-1", float64(30), int64(1023)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresWorkerLeaseStore(mockPool)
+			workerId, err := store.AcquireWorkerId(context.Background(), "host-1", 1023, 30*time.Second)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWorkerId, workerId)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresWorkerLeaseStore_RenewWorkerId(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - lease renewed",
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE worker_leases SET expires_at = NOW\(\) \+ make_interval\(secs => \$3\) WHERE worker_id = \$1 AND owner = \$2`).
+					WithArgs(int64(3), "host-1", float64(30)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+		},
+		{
+			name:          "Error - lease lost",
+			expectedError: &domain.WorkerLeaseLostError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE worker_leases`).
+					WithArgs(int64(3), "host-1", float64(30)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectExec(`UPDATE worker_leases`).
+					WithArgs(int64(3), "host-1", float64(30)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresWorkerLeaseStore(mockPool)
+			err = store.RenewWorkerId(context.Background(), 3, "host-1", 30*time.Second)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresWorkerLeaseStore_ReleaseWorkerId(t *testing.T) {
+	t.Parallel()
+
+	mockPool, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	defer mockPool.Close(context.Background())
+
+	mockPool.ExpectExec(`DELETE FROM worker_leases WHERE worker_id = \$1 AND owner = \$2`).
+		WithArgs(int64(3), "host-1").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	store := NewPostgresWorkerLeaseStore(mockPool)
+	err = store.ReleaseWorkerId(context.Background(), 3, "host-1")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"url-shortening-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// workspaceColumns are the columns a Workspace is read from, in the order scanWorkspace expects them.
+const workspaceColumns = `id, name, links_per_month, redirects_per_second, created_at`
+
+// PostgresWorkspaceStore implements storage of workspaces using PostgreSQL.
+type PostgresWorkspaceStore struct {
+	queryExecutor domain.QueryExecutor
+}
+
+// NewPostgresWorkspaceStore creates a new PostgresWorkspaceStore instance.
+// Parameters:
+//   - queryExecutor: PostgreSQL connection pool
+func NewPostgresWorkspaceStore(queryExecutor domain.QueryExecutor) *PostgresWorkspaceStore {
+	return &PostgresWorkspaceStore{queryExecutor: queryExecutor}
+}
+
+// ListWorkspaces retrieves all workspaces from PostgreSQL, ordered by ID.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresWorkspaceStore) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
+	sql := `SELECT ` + workspaceColumns + ` FROM workspaces ORDER BY id`
+
+	rows, err := s.queryExecutor.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces from db: %w", err)
+	}
+	defer rows.Close()
+
+	workspaces := make([]domain.Workspace, 0)
+	for rows.Next() {
+		workspace, err := scanWorkspace(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workspace from db: %w", err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list workspaces from db: %w", err)
+	}
+
+	return workspaces, nil
+}
+
+// AddWorkspace creates a new workspace in PostgreSQL with the name and quotas of the given workspace.
+// Returns the created workspace with its ID and creation timestamp.
+//
+// Returns an error if the database operation fails.
+func (s *PostgresWorkspaceStore) AddWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	sql := `INSERT INTO workspaces (name, links_per_month, redirects_per_second) VALUES ($1, $2, $3) RETURNING ` + workspaceColumns
+
+	created, err := scanWorkspace(s.queryExecutor.QueryRow(ctx, sql, workspace.Name, workspace.LinksPerMonth, workspace.RedirectsPerSecond))
+	if err != nil {
+		return domain.Workspace{}, fmt.Errorf("failed to add workspace to db: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateWorkspace replaces the name and quotas of a workspace in PostgreSQL by its ID.
+// Returns the updated workspace.
+//
+// Returns an error if:
+//   - *domain.WorkspaceNonExistingError: no workspace with the ID exists
+//   - Database operation fails
+func (s *PostgresWorkspaceStore) UpdateWorkspace(ctx context.Context, workspace domain.Workspace) (domain.Workspace, error) {
+	sql := `UPDATE workspaces SET name = $1, links_per_month = $2, redirects_per_second = $3 WHERE id = $4 RETURNING ` + workspaceColumns
+
+	updated, err := scanWorkspace(s.queryExecutor.QueryRow(ctx, sql, workspace.Name, workspace.LinksPerMonth, workspace.RedirectsPerSecond, workspace.Id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Workspace{}, &domain.WorkspaceNonExistingError{Msg: fmt.Sprintf("No workspace with id %d found", workspace.Id)}
+	} else if err != nil {
+		return domain.Workspace{}, fmt.Errorf("failed to update workspace in db: %w", err)
+	}
+
+	return updated, nil
+}
+
+// scanWorkspace reads a Workspace selected with workspaceColumns.
+func scanWorkspace(row pgx.Row) (domain.Workspace, error) {
+	var workspace domain.Workspace
+	err := row.Scan(&workspace.Id, &workspace.Name, &workspace.LinksPerMonth, &workspace.RedirectsPerSecond, &workspace.CreatedAt)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+
+	return workspace, nil
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	exampleWorkspaceCreatedAt = time.Date(2026, 1, 6, 12, 0, 0, 0, time.UTC)
+	workspaceRowColumns       = []string{"id", "name", "links_per_month", "redirects_per_second", "created_at"}
+)
+
+func TestPostgresWorkspaceStore_ListWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		expectedWorkspaces []domain.Workspace
+		expectedError      bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	testCases := []testCase{
+		{
+			name: "Success - workspaces listed",
+			expectedWorkspaces: []domain.Workspace{
+				{Id: 1, Name: "acme", LinksPerMonth: 1000, RedirectsPerSecond: 50, CreatedAt: exampleWorkspaceCreatedAt},
+				{Id: 2, Name: "globex", CreatedAt: exampleWorkspaceCreatedAt},
+			},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`SELECT id, name, links_per_month, redirects_per_second, created_at FROM workspaces ORDER BY id`).
+					WillReturnRows(pgxmock.NewRows(workspaceRowColumns).
+						AddRow(int64(1), "acme", int64(1000), int64(50), exampleWorkspaceCreatedAt).
+						AddRow(int64(2), "globex", int64(0), int64(0), exampleWorkspaceCreatedAt))
+			},
+		},
+		{
+			name:               "Success - no workspaces",
+			expectedWorkspaces: []domain.Workspace{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM workspaces ORDER BY id`).
+					WillReturnRows(pgxmock.NewRows(workspaceRowColumns))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`FROM workspaces ORDER BY id`).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresWorkspaceStore(mockPool)
+			workspaces, err := store.ListWorkspaces(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWorkspaces, workspaces)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresWorkspaceStore_AddWorkspace(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		expectedWorkspace domain.Workspace
+		expectedError     bool
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	workspace := domain.Workspace{Name: "acme", LinksPerMonth: 1000, RedirectsPerSecond: 50}
+
+	testCases := []testCase{
+		{
+			name:              "Success - workspace added",
+			expectedWorkspace: domain.Workspace{Id: 1, Name: "acme", LinksPerMonth: 1000, RedirectsPerSecond: 50, CreatedAt: exampleWorkspaceCreatedAt},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO workspaces \(name, links_per_month, redirects_per_second\) VALUES \(\$1, \$2, \$3\) RETURNING id, name, links_per_month, redirects_per_second, created_at`).
+					WithArgs("acme", int64(1000), int64(50)).
+					WillReturnRows(pgxmock.NewRows(workspaceRowColumns).
+						AddRow(int64(1), "acme", int64(1000), int64(50), exampleWorkspaceCreatedAt))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: true,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`INSERT INTO workspaces`).
+					WithArgs("acme", int64(1000), int64(50)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresWorkspaceStore(mockPool)
+			created, err := store.AddWorkspace(context.Background(), workspace)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWorkspace, created)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresWorkspaceStore_UpdateWorkspace(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		expectedWorkspace domain.Workspace
+		expectedError     error
+
+		prepareMocks func(mockPool pgxmock.PgxConnIface)
+	}
+
+	workspace := domain.Workspace{Id: 1, Name: "acme", LinksPerMonth: 5000}
+
+	testCases := []testCase{
+		{
+			name:              "Success - workspace updated",
+			expectedWorkspace: domain.Workspace{Id: 1, Name: "acme", LinksPerMonth: 5000, CreatedAt: exampleWorkspaceCreatedAt},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`UPDATE workspaces SET name = \$1, links_per_month = \$2, redirects_per_second = \$3 WHERE id = \$4 RETURNING id, name`).
+					WithArgs("acme", int64(5000), int64(0), int64(1)).
+					WillReturnRows(pgxmock.NewRows(workspaceRowColumns).
+						AddRow(int64(1), "acme", int64(5000), int64(0), exampleWorkspaceCreatedAt))
+			},
+		},
+		{
+			name:          "Error - workspace not found",
+			expectedError: &domain.WorkspaceNonExistingError{},
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`UPDATE workspaces`).
+					WithArgs("acme", int64(5000), int64(0), int64(1)).
+					WillReturnRows(pgxmock.NewRows(workspaceRowColumns))
+			},
+		},
+		{
+			name:          "Error - database error",
+			expectedError: assert.AnError,
+			prepareMocks: func(mockPool pgxmock.PgxConnIface) {
+				mockPool.ExpectQuery(`UPDATE workspaces`).
+					WithArgs("acme", int64(5000), int64(0), int64(1)).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockPool, err := pgxmock.NewConn()
+			require.NoError(t, err)
+			defer mockPool.Close(context.Background())
+
+			tt.prepareMocks(mockPool)
+
+			store := NewPostgresWorkspaceStore(mockPool)
+			updated, err := store.UpdateWorkspace(context.Background(), workspace)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWorkspace, updated)
+			}
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
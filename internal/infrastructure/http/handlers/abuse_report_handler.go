@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"url-shortening-service/internal/domain"
+)
+
+// AbuseReportHandler handles HTTP requests for reporting abuse of short URLs.
+type AbuseReportHandler struct {
+	reporter domain.AbuseReporter
+	logger   domain.Logger
+}
+
+type ReportAbuseRequest struct {
+	Reason  string `json:"reason"`
+	Contact string `json:"contact,omitempty"`
+}
+
+// NewAbuseReportHandler creates a new AbuseReportHandler instance.
+// Parameters:
+//   - reporter: service for filing abuse reports
+//   - logger: logger for recording errors
+func NewAbuseReportHandler(reporter domain.AbuseReporter, logger domain.Logger) *AbuseReportHandler {
+	return &AbuseReportHandler{
+		reporter: reporter,
+		logger:   logger,
+	}
+}
+
+// Report handles POST requests to report abuse of a short URL.
+// It expects a JSON body with the reason of the report and an optional contact of the reporter.
+// The report is filed for review by an administrator; the link keeps redirecting until it is taken down.
+//
+// HTTP Responses:
+//   - 202 Accepted: report filed for review
+//   - 400 Bad Request: invalid request payload, missing reason or too long reason or contact
+//   - 404 Not Found: the URL token does not exist
+//   - 429 Too Many Requests: too many reports from the client
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *AbuseReportHandler) Report(w http.ResponseWriter, r *http.Request) {
+	var req ReportAbuseRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.reporter.ReportAbuse(r.Context(), domain.AbuseReport{
+		Token:      linkKey(r),
+		Reason:     req.Reason,
+		Contact:    req.Contact,
+		ReporterIP: retrieveIP(r),
+	})
+	if errors.Is(err, &domain.InvalidAbuseReportError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, &domain.TokenNonExistingError{}) {
+		http.Error(w, "URL not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, &domain.TooManyAttemptsError{}) {
+		http.Error(w, "Too many abuse reports, please try again later", http.StatusTooManyRequests)
+		return
+	} else if err != nil {
+		h.logger.Error("Failed to file abuse report: " + err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
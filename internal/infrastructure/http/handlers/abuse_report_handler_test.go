@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbuseReportHandler_Report(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseReporter, domain.Logger)
+	}
+
+	report := domain.AbuseReport{Token: "abc123", Reason: "phishing", Contact: "me@example.com", ReporterIP: "203.0.113.7"}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			requestBody:    ReportAbuseRequest{Reason: "phishing", Contact: "me@example.com"},
+			expectedStatus: http.StatusAccepted,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseReporter, domain.Logger) {
+				reporter := mocks.NewMockAbuseReporter(ctrl)
+				reporter.EXPECT().ReportAbuse(gomock.Any(), report).Return(domain.AbuseReport{Id: 1}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return reporter, logger
+			},
+		},
+		{
+			name:           "InvalidJSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseReporter, domain.Logger) {
+				reporter := mocks.NewMockAbuseReporter(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return reporter, logger
+			},
+		},
+		{
+			name:           "MissingReason",
+			requestBody:    ReportAbuseRequest{},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseReporter, domain.Logger) {
+				reporter := mocks.NewMockAbuseReporter(ctrl)
+				reporter.EXPECT().ReportAbuse(gomock.Any(), gomock.Any()).Return(domain.AbuseReport{}, &domain.InvalidAbuseReportError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return reporter, logger
+			},
+		},
+		{
+			name:           "TokenNotFound",
+			requestBody:    ReportAbuseRequest{Reason: "phishing", Contact: "me@example.com"},
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseReporter, domain.Logger) {
+				reporter := mocks.NewMockAbuseReporter(ctrl)
+				reporter.EXPECT().ReportAbuse(gomock.Any(), report).Return(domain.AbuseReport{}, &domain.TokenNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return reporter, logger
+			},
+		},
+		{
+			name:           "TooManyReports",
+			requestBody:    ReportAbuseRequest{Reason: "phishing", Contact: "me@example.com"},
+			expectedStatus: http.StatusTooManyRequests,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseReporter, domain.Logger) {
+				reporter := mocks.NewMockAbuseReporter(ctrl)
+				reporter.EXPECT().ReportAbuse(gomock.Any(), report).Return(domain.AbuseReport{}, &domain.TooManyAttemptsError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return reporter, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			requestBody:    ReportAbuseRequest{Reason: "phishing", Contact: "me@example.com"},
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseReporter, domain.Logger) {
+				reporter := mocks.NewMockAbuseReporter(ctrl)
+				reporter.EXPECT().ReportAbuse(gomock.Any(), report).Return(domain.AbuseReport{}, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return reporter, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			reporterMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewAbuseReportHandler(reporterMock, loggerMock)
+
+			var body []byte
+			switch v := tt.requestBody.(type) {
+			case string:
+				body = []byte(v)
+			default:
+				body, _ = json.Marshal(v)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/shorten/abc123/report", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			req.SetPathValue(domain.UrlTokenStr, "abc123")
+			w := httptest.NewRecorder()
+
+			handler.Report(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
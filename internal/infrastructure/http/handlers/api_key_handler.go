@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"url-shortening-service/internal/domain"
+)
+
+// ApiKeyHandler handles HTTP requests for administering API keys.
+type ApiKeyHandler struct {
+	keyManager domain.ApiKeyManager
+	logger     domain.Logger
+}
+
+type CreateApiKeyRequest struct {
+	Name string `json:"name"`
+	// Owner is the owner of the short URLs created with the key. An empty Owner defaults to the name.
+	Owner string `json:"owner,omitempty"`
+	// WorkspaceId is the workspace the short URLs created with the key belong to. Zero creates a key outside of workspaces.
+	WorkspaceId int64                `json:"workspace_id,omitempty"`
+	Scopes      []domain.ApiKeyScope `json:"scopes"`
+}
+
+// CreateApiKeyResponse is the created API key together with the key itself, which is only shown once.
+type CreateApiKeyResponse struct {
+	domain.ApiKey
+	Key string `json:"key"`
+}
+
+// NewApiKeyHandler creates a new ApiKeyHandler instance.
+// Parameters:
+//   - keyManager: service for administering API keys
+//   - logger: logger for recording errors
+func NewApiKeyHandler(keyManager domain.ApiKeyManager, logger domain.Logger) *ApiKeyHandler {
+	return &ApiKeyHandler{
+		keyManager: keyManager,
+		logger:     logger,
+	}
+}
+
+// List handles GET requests to list all API keys, including revoked ones.
+// The keys themselves are never listed, only their prefixes.
+//
+// HTTP Responses:
+//   - 200 OK: returns a JSON array of ApiKey
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ApiKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.keyManager.ListKeys(r.Context())
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to list API keys: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, keys)
+}
+
+// Create handles POST requests to create an API key.
+// It expects a JSON body with the name, the optional owner, the optional workspace and the scopes of the key.
+//
+// HTTP Responses:
+//   - 201 Created: key successfully created, returns ApiKey JSON with the key itself in "key"
+//   - 400 Bad Request: invalid request payload, missing name, too long owner, unknown scope,
+//     admin scope for a workspace key or unknown workspace
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ApiKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateApiKeyRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	key, rawKey, err := h.keyManager.CreateKey(r.Context(), domain.ApiKey{Name: req.Name, Owner: req.Owner, WorkspaceId: req.WorkspaceId, Scopes: req.Scopes})
+	if errors.Is(err, &domain.InvalidApiKeyError{}) || errors.Is(err, &domain.WorkspaceNonExistingError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to create API key: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeJSON(w, http.StatusCreated, CreateApiKeyResponse{ApiKey: key, Key: rawKey})
+}
+
+// Revoke handles DELETE requests to revoke an API key by its ID.
+//
+// HTTP Responses:
+//   - 204 No Content: key successfully revoked
+//   - 400 Bad Request: the key ID is not a number
+//   - 404 Not Found: no active key with the ID exists
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ApiKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue(domain.ApiKeyIdStr), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid API key id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.keyManager.RevokeKey(r.Context(), id)
+	if errors.Is(err, &domain.ApiKeyNonExistingError{}) {
+		http.Error(w, "API key not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to revoke API key: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes the value as a JSON response with the given status code.
+func (h *ApiKeyHandler) writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
+	}
+}
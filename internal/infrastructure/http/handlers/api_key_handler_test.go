@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleApiKey = domain.ApiKey{
+	Id:     1,
+	Name:   "ci",
+	Prefix: "usk_01234567",
+	Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite},
+}
+
+func TestApiKeyHandler_List(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		expectedStatus int
+		expectedKeys   []domain.ApiKey
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []domain.ApiKey{exampleApiKey},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().ListKeys(gomock.Any()).Return([]domain.ApiKey{exampleApiKey}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().ListKeys(gomock.Any()).Return(nil, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewApiKeyHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+			w := httptest.NewRecorder()
+
+			handler.List(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedKeys != nil {
+				var keys []domain.ApiKey
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&keys))
+				assert.Equal(t, tt.expectedKeys, keys)
+			}
+		})
+	}
+}
+
+func TestApiKeyHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			requestBody:    `{"name":"ci","scopes":["links:write"]}`,
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().CreateKey(gomock.Any(), domain.ApiKey{Name: "ci", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}).
+					Return(exampleApiKey, "usk_0123456789", nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "SuccessWithOwner",
+			requestBody:    `{"name":"ci","owner":"team-a","scopes":["links:write"]}`,
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().CreateKey(gomock.Any(), domain.ApiKey{Name: "ci", Owner: "team-a", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}).
+					Return(exampleApiKey, "usk_0123456789", nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "SuccessInWorkspace",
+			requestBody:    `{"name":"ci","workspace_id":7,"scopes":["links:write"]}`,
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().CreateKey(gomock.Any(), domain.ApiKey{Name: "ci", WorkspaceId: 7, Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}).
+					Return(exampleApiKey, "usk_0123456789", nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "UnknownWorkspace",
+			requestBody:    `{"name":"ci","workspace_id":99,"scopes":["links:write"]}`,
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(domain.ApiKey{}, "", &domain.WorkspaceNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidJSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "UnknownScope",
+			requestBody:    `{"name":"ci","scopes":["everything"]}`,
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(domain.ApiKey{}, "", &domain.InvalidApiKeyError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			requestBody:    `{"name":"ci","scopes":["links:write"]}`,
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().CreateKey(gomock.Any(), gomock.Any()).Return(domain.ApiKey{}, "", assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewApiKeyHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/api-keys", bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.Create(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response CreateApiKeyResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				assert.Equal(t, exampleApiKey, response.ApiKey)
+				assert.Equal(t, "usk_0123456789", response.Key)
+				assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestApiKeyHandler_Revoke(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		keyId          string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			keyId:          "1",
+			expectedStatus: http.StatusNoContent,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().RevokeKey(gomock.Any(), int64(1)).Return(nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidId",
+			keyId:          "abc",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "KeyNotFound",
+			keyId:          "2",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().RevokeKey(gomock.Any(), int64(2)).Return(&domain.ApiKeyNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			keyId:          "3",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyManager, domain.Logger) {
+				manager := mocks.NewMockApiKeyManager(ctrl)
+				manager.EXPECT().RevokeKey(gomock.Any(), int64(3)).Return(assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewApiKeyHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/api-keys/"+tt.keyId, nil)
+			req.SetPathValue(domain.ApiKeyIdStr, tt.keyId)
+			w := httptest.NewRecorder()
+
+			handler.Revoke(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"url-shortening-service/internal/domain"
+)
+
+// AuthMiddleware requires an API key with the matching scope for the protected routes of a ServeMux.
+// Routes without a required scope, such as redirects, stay public.
+type AuthMiddleware struct {
+	authenticator domain.ApiKeyAuthenticator
+	routeScopes   map[string]domain.ApiKeyScope
+	logger        domain.Logger
+}
+
+// NewAuthMiddleware creates a new AuthMiddleware instance.
+// Parameters:
+//   - authenticator: service for authenticating API keys
+//   - routeScopes: scope required per route pattern, as registered on the ServeMux
+//   - logger: logger for recording errors
+func NewAuthMiddleware(authenticator domain.ApiKeyAuthenticator, routeScopes map[string]domain.ApiKeyScope, logger domain.Logger) *AuthMiddleware {
+	return &AuthMiddleware{
+		authenticator: authenticator,
+		routeScopes:   routeScopes,
+		logger:        logger,
+	}
+}
+
+// Wrap returns a handler that authenticates requests to protected routes of mux before serving them.
+// The API key is expected as a bearer token in the Authorization header. The authenticated key
+// is passed on in the request context, see domain.ApiKeyFromContext.
+//
+// HTTP Responses of protected routes, besides those of the route itself:
+//   - 401 Unauthorized: the API key is missing, unknown or revoked
+//   - 403 Forbidden: the API key lacks the scope of the route
+//   - 500 Internal Server Error: unexpected error occurred
+func (m *AuthMiddleware) Wrap(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		scope, protected := m.routeScopes[pattern]
+		if !protected {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || key == "" {
+			writeUnauthorized(w, "API key required")
+			return
+		}
+
+		apiKey, err := m.authenticator.Authenticate(r.Context(), key)
+		if errors.Is(err, &domain.UnauthorizedError{}) {
+			writeUnauthorized(w, "Invalid API key")
+			return
+		} else if err != nil {
+			m.logger.Error(fmt.Sprintf("Failed to authenticate API key: %v", err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if !apiKey.HasScope(scope) {
+			http.Error(w, fmt.Sprintf("API key lacks the %s scope", scope), http.StatusForbidden)
+			return
+		}
+
+		mux.ServeHTTP(w, r.WithContext(domain.WithApiKey(r.Context(), apiKey)))
+	})
+}
+
+// writeUnauthorized writes a 401 response asking for a bearer token.
+func writeUnauthorized(w http.ResponseWriter, msg string) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	http.Error(w, msg, http.StatusUnauthorized)
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddleware_Wrap(t *testing.T) {
+	t.Parallel()
+
+	writerKey := domain.ApiKey{Id: 1, Name: "ci", Scopes: []domain.ApiKeyScope{domain.ScopeLinksWrite}}
+
+	type testCase struct {
+		name           string
+		method         string
+		path           string
+		authorization  string
+		expectedStatus int
+		expectedKeyId  string
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "PublicRouteWithoutKey",
+			method:         http.MethodGet,
+			path:           "/abc123",
+			expectedStatus: http.StatusOK,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				return mocks.NewMockApiKeyAuthenticator(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "UnknownRoutePassesThrough",
+			method:         http.MethodGet,
+			path:           "/a/b/c",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				return mocks.NewMockApiKeyAuthenticator(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "ProtectedRouteWithScope",
+			method:         http.MethodPut,
+			path:           "/abc123",
+			authorization:  "Bearer usk_key",
+			expectedStatus: http.StatusOK,
+			expectedKeyId:  "1",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				authenticator := mocks.NewMockApiKeyAuthenticator(ctrl)
+				authenticator.EXPECT().Authenticate(gomock.Any(), "usk_key").Return(writerKey, nil)
+				return authenticator, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "MissingKey",
+			method:         http.MethodPut,
+			path:           "/abc123",
+			expectedStatus: http.StatusUnauthorized,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				return mocks.NewMockApiKeyAuthenticator(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "NonBearerAuthorization",
+			method:         http.MethodPut,
+			path:           "/abc123",
+			authorization:  "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				return mocks.NewMockApiKeyAuthenticator(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "InvalidKey",
+			method:         http.MethodPut,
+			path:           "/abc123",
+			authorization:  "Bearer usk_revoked",
+			expectedStatus: http.StatusUnauthorized,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				authenticator := mocks.NewMockApiKeyAuthenticator(ctrl)
+				authenticator.EXPECT().Authenticate(gomock.Any(), "usk_revoked").Return(domain.ApiKey{}, &domain.UnauthorizedError{})
+				return authenticator, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "MissingScope",
+			method:         http.MethodGet,
+			path:           "/admin/things",
+			authorization:  "Bearer usk_key",
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				authenticator := mocks.NewMockApiKeyAuthenticator(ctrl)
+				authenticator.EXPECT().Authenticate(gomock.Any(), "usk_key").Return(writerKey, nil)
+				return authenticator, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "AuthenticationFailure",
+			method:         http.MethodPut,
+			path:           "/abc123",
+			authorization:  "Bearer usk_key",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ApiKeyAuthenticator, domain.Logger) {
+				authenticator := mocks.NewMockApiKeyAuthenticator(ctrl)
+				authenticator.EXPECT().Authenticate(gomock.Any(), "usk_key").Return(domain.ApiKey{}, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return authenticator, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			writeKeyId := func(w http.ResponseWriter, r *http.Request) {
+				if key, ok := domain.ApiKeyFromContext(r.Context()); ok {
+					_, _ = io.WriteString(w, "1")
+					assert.Equal(t, writerKey, key)
+				}
+			}
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /{"+domain.UrlTokenStr+"}", writeKeyId)
+			mux.HandleFunc("PUT /{"+domain.UrlTokenStr+"}", writeKeyId)
+			mux.HandleFunc("GET /admin/things", writeKeyId)
+
+			authenticator, logger := tt.prepareMocks(t, ctrl)
+			middleware := NewAuthMiddleware(authenticator, map[string]domain.ApiKeyScope{
+				"PUT /{" + domain.UrlTokenStr + "}": domain.ScopeLinksWrite,
+				"GET /admin/things":                 domain.ScopeAdmin,
+			}, logger)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			middleware.Wrap(mux).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedKeyId, w.Body.String())
+			}
+		})
+	}
+}
@@ -24,19 +24,23 @@ func NewDeleteUrlHandler(urlDeleter domain.UrlDeleter, logger domain.Logger) *De
 }
 
 // Delete handles DELETE requests to remove a URL mapping.
-// It extracts the URL token from the path and deletes the corresponding mapping.
+// It extracts the URL token from the path and deletes the corresponding mapping of the short domain of the request.
 //
 // HTTP Responses:
 //   - 204 No Content: mapping successfully deleted
+//   - 403 Forbidden: the URL token is owned by another client
 //   - 404 Not Found: URL token does not exist
 //   - 500 Internal Server Error: unexpected error occurred
 func (h *DeleteUrlHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	urlToken := r.PathValue(domain.UrlTokenStr)
+	urlToken := linkKey(r)
 
 	err := h.urlDeleter.DeleteUrl(r.Context(), urlToken)
 	if errors.Is(err, &domain.TokenNonExistingError{}) {
 		http.Error(w, "URL token not found", http.StatusNotFound)
 		return
+	} else if errors.Is(err, &domain.ForbiddenError{}) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
 	} else if err != nil {
 		h.logger.Error("Failed to delete URL: " + err.Error())
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -49,6 +49,18 @@ func TestDeleteUrlHandler_Delete(t *testing.T) {
 				return deleter, logger
 			},
 		},
+		{
+			name:           "NotOwner",
+			urlToken:       "foreignToken",
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlDeleter, domain.Logger) {
+				deleter := mocks.NewMockUrlDeleter(ctrl)
+				deleter.EXPECT().DeleteUrl(gomock.Any(), "foreignToken").Return(&domain.ForbiddenError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return deleter, logger
+			},
+		},
 		{
 			name:           "InternalError",
 			urlToken:       "errorToken",
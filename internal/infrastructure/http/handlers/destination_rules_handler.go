@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"url-shortening-service/internal/domain"
+)
+
+// DestinationRulesHandler handles HTTP requests for administering the destination policy rules.
+type DestinationRulesHandler struct {
+	ruleManager domain.DestinationRuleManager
+	logger      domain.Logger
+}
+
+type AddDestinationRuleRequest struct {
+	Action  domain.DestinationAction `json:"action"`
+	Match   domain.DestinationMatch  `json:"match"`
+	Pattern string                   `json:"pattern"`
+	// WorkspaceId limits the rule to the destinations of a workspace. Zero adds a global rule.
+	WorkspaceId int64 `json:"workspace_id,omitempty"`
+}
+
+// NewDestinationRulesHandler creates a new DestinationRulesHandler instance.
+// Parameters:
+//   - ruleManager: service for administering destination policy rules
+//   - logger: logger for recording errors
+func NewDestinationRulesHandler(ruleManager domain.DestinationRuleManager, logger domain.Logger) *DestinationRulesHandler {
+	return &DestinationRulesHandler{
+		ruleManager: ruleManager,
+		logger:      logger,
+	}
+}
+
+// List handles GET requests to list all destination policy rules.
+//
+// HTTP Responses:
+//   - 200 OK: returns a JSON array of DestinationRule
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *DestinationRulesHandler) List(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.ruleManager.ListRules(r.Context())
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to list destination rules: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, rules)
+}
+
+// Create handles POST requests to add a destination policy rule.
+// It expects a JSON body with the action (allow or block), the match type (exact, wildcard or regex)
+// and the pattern of the rule, and optionally the workspace the rule applies to.
+//
+// HTTP Responses:
+//   - 201 Created: rule successfully added, returns DestinationRule JSON
+//   - 400 Bad Request: invalid request payload, invalid rule or unknown workspace
+//   - 409 Conflict: an identical rule already exists
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *DestinationRulesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req AddDestinationRuleRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := h.ruleManager.AddRule(r.Context(), domain.DestinationRule{
+		Action:      req.Action,
+		Match:       req.Match,
+		Pattern:     req.Pattern,
+		WorkspaceId: req.WorkspaceId,
+	})
+	if errors.Is(err, &domain.InvalidDestinationRuleError{}) || errors.Is(err, &domain.WorkspaceNonExistingError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, &domain.DestinationRuleExistingError{}) {
+		http.Error(w, "Destination rule already exists", http.StatusConflict)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to add destination rule: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, rule)
+}
+
+// Delete handles DELETE requests to remove a destination policy rule by its ID.
+//
+// HTTP Responses:
+//   - 204 No Content: rule successfully deleted
+//   - 400 Bad Request: the rule ID is not a number
+//   - 404 Not Found: the rule does not exist
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *DestinationRulesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue(domain.RuleIdStr), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid destination rule id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.ruleManager.DeleteRule(r.Context(), id)
+	if errors.Is(err, &domain.DestinationRuleNonExistingError{}) {
+		http.Error(w, "Destination rule not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to delete destination rule: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes the value as a JSON response with the given status code.
+func (h *DestinationRulesHandler) writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
+	}
+}
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleDestinationRule = domain.DestinationRule{
+	Id:      1,
+	Action:  domain.DestinationBlock,
+	Match:   domain.DestinationMatchExact,
+	Pattern: "evil.com",
+}
+
+func TestDestinationRulesHandler_List(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		expectedStatus int
+		expectedRules  []domain.DestinationRule
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusOK,
+			expectedRules:  []domain.DestinationRule{exampleDestinationRule},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().ListRules(gomock.Any()).Return([]domain.DestinationRule{exampleDestinationRule}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().ListRules(gomock.Any()).Return(nil, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return ruleManager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			ruleManagerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewDestinationRulesHandler(ruleManagerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/destination-rules", nil)
+			w := httptest.NewRecorder()
+
+			handler.List(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedRules != nil {
+				var rules []domain.DestinationRule
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&rules))
+				assert.Equal(t, tt.expectedRules, rules)
+			}
+		})
+	}
+}
+
+func TestDestinationRulesHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger)
+	}
+
+	newRule := domain.DestinationRule{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com"}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			requestBody:    AddDestinationRuleRequest{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com"},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().AddRule(gomock.Any(), newRule).Return(exampleDestinationRule, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "SuccessInWorkspace",
+			requestBody:    AddDestinationRuleRequest{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com", WorkspaceId: 7},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				workspaceRule := newRule
+				workspaceRule.WorkspaceId = 7
+				ruleManager.EXPECT().AddRule(gomock.Any(), workspaceRule).Return(exampleDestinationRule, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "UnknownWorkspace",
+			requestBody:    AddDestinationRuleRequest{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com", WorkspaceId: 99},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().AddRule(gomock.Any(), gomock.Any()).Return(domain.DestinationRule{}, &domain.WorkspaceNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "InvalidJSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "InvalidRule",
+			requestBody:    AddDestinationRuleRequest{Action: domain.DestinationBlock, Match: domain.DestinationMatchRegex, Pattern: "("},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().AddRule(gomock.Any(), gomock.Any()).Return(domain.DestinationRule{}, &domain.InvalidDestinationRuleError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "RuleExists",
+			requestBody:    AddDestinationRuleRequest{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com"},
+			expectedStatus: http.StatusConflict,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().AddRule(gomock.Any(), newRule).Return(domain.DestinationRule{}, &domain.DestinationRuleExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			requestBody:    AddDestinationRuleRequest{Action: domain.DestinationBlock, Match: domain.DestinationMatchExact, Pattern: "evil.com"},
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().AddRule(gomock.Any(), newRule).Return(domain.DestinationRule{}, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return ruleManager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			ruleManagerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewDestinationRulesHandler(ruleManagerMock, loggerMock)
+
+			var body []byte
+			switch v := tt.requestBody.(type) {
+			case string:
+				body = []byte(v)
+			default:
+				body, _ = json.Marshal(v)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/destination-rules", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.Create(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestDestinationRulesHandler_Delete(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		ruleId         string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			ruleId:         "1",
+			expectedStatus: http.StatusNoContent,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().DeleteRule(gomock.Any(), int64(1)).Return(nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "InvalidId",
+			ruleId:         "abc",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "RuleNotFound",
+			ruleId:         "2",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().DeleteRule(gomock.Any(), int64(2)).Return(&domain.DestinationRuleNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return ruleManager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			ruleId:         "3",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.DestinationRuleManager, domain.Logger) {
+				ruleManager := mocks.NewMockDestinationRuleManager(ctrl)
+				ruleManager.EXPECT().DeleteRule(gomock.Any(), int64(3)).Return(assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return ruleManager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			ruleManagerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewDestinationRulesHandler(ruleManagerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/destination-rules/"+tt.ruleId, nil)
+			req.SetPathValue(domain.RuleIdStr, tt.ruleId)
+			w := httptest.NewRecorder()
+
+			handler.Delete(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
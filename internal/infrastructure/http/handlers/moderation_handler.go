@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"url-shortening-service/internal/domain"
+)
+
+// statusParamName is the name of the query parameter filtering abuse reports by status.
+const statusParamName = "status"
+
+// ModerationHandler handles HTTP requests for reviewing abuse reports and taking down short URLs.
+type ModerationHandler struct {
+	moderator domain.AbuseModerator
+	logger    domain.Logger
+}
+
+type TakeDownRequest struct {
+	Reason string `json:"reason"`
+}
+
+// NewModerationHandler creates a new ModerationHandler instance.
+// Parameters:
+//   - moderator: service for reviewing abuse reports and taking down short URLs
+//   - logger: logger for recording errors
+func NewModerationHandler(moderator domain.AbuseModerator, logger domain.Logger) *ModerationHandler {
+	return &ModerationHandler{
+		moderator: moderator,
+		logger:    logger,
+	}
+}
+
+// ListReports handles GET requests to list abuse reports, oldest first.
+// The optional status query parameter selects open (the default), dismissed or actioned reports.
+//
+// HTTP Responses:
+//   - 200 OK: returns a JSON array of AbuseReport
+//   - 400 Bad Request: unknown status
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ModerationHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	status := domain.AbuseReportOpen
+	if r.URL.Query().Has(statusParamName) {
+		status = domain.AbuseReportStatus(r.URL.Query().Get(statusParamName))
+	}
+
+	reports, err := h.moderator.ListReports(r.Context(), status)
+	if errors.Is(err, &domain.InvalidAbuseReportError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to list abuse reports: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(reports)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
+	}
+}
+
+// DismissReport handles POST requests to dismiss an open abuse report by its ID,
+// keeping the reported link redirecting.
+//
+// HTTP Responses:
+//   - 204 No Content: report dismissed
+//   - 400 Bad Request: the report ID is not a number
+//   - 404 Not Found: no open report with the ID exists
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ModerationHandler) DismissReport(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue(domain.ReportIdStr), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid abuse report id", http.StatusBadRequest)
+		return
+	}
+
+	err = h.moderator.DismissReport(r.Context(), id)
+	if errors.Is(err, &domain.AbuseReportNonExistingError{}) {
+		http.Error(w, "Open abuse report not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to dismiss abuse report: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TakeDown handles PUT requests to take down a short URL.
+// It expects a JSON body with the reason of the takedown. Open abuse reports of the link are resolved as actioned.
+//
+// HTTP Responses:
+//   - 204 No Content: link taken down
+//   - 400 Bad Request: invalid request payload, missing or too long reason
+//   - 404 Not Found: the URL token does not exist
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ModerationHandler) TakeDown(w http.ResponseWriter, r *http.Request) {
+	var req TakeDownRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	err := h.moderator.TakeDown(r.Context(), linkKey(r), req.Reason)
+	if errors.Is(err, &domain.InvalidAbuseReportError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.writeLinkResult(w, "take down", err)
+}
+
+// Reinstate handles DELETE requests to lift the takedown of a short URL, so it redirects again.
+//
+// HTTP Responses:
+//   - 204 No Content: link reinstated
+//   - 404 Not Found: the URL token does not exist
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ModerationHandler) Reinstate(w http.ResponseWriter, r *http.Request) {
+	err := h.moderator.Reinstate(r.Context(), linkKey(r))
+	h.writeLinkResult(w, "reinstate", err)
+}
+
+// writeLinkResult maps the result of taking down or reinstating a short URL to an HTTP response.
+func (h *ModerationHandler) writeLinkResult(w http.ResponseWriter, action string, err error) {
+	if errors.Is(err, &domain.TokenNonExistingError{}) {
+		http.Error(w, "URL not found", http.StatusNotFound)
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to %s short URL: %v", action, err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
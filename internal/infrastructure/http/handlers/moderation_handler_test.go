@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleAbuseReport = domain.AbuseReport{
+	Id:     1,
+	Token:  "abc123",
+	Reason: "phishing",
+	Status: domain.AbuseReportOpen,
+}
+
+func TestModerationHandler_ListReports(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedReports []domain.AbuseReport
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:            "SuccessOpenByDefault",
+			expectedStatus:  http.StatusOK,
+			expectedReports: []domain.AbuseReport{exampleAbuseReport},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().ListReports(gomock.Any(), domain.AbuseReportOpen).Return([]domain.AbuseReport{exampleAbuseReport}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:            "SuccessByStatus",
+			query:           "?status=dismissed",
+			expectedStatus:  http.StatusOK,
+			expectedReports: []domain.AbuseReport{},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().ListReports(gomock.Any(), domain.AbuseReportDismissed).Return([]domain.AbuseReport{}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "UnknownStatus",
+			query:          "?status=closed",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().ListReports(gomock.Any(), domain.AbuseReportStatus("closed")).Return(nil, &domain.InvalidAbuseReportError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().ListReports(gomock.Any(), domain.AbuseReportOpen).Return(nil, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return moderator, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			moderatorMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewModerationHandler(moderatorMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/abuse-reports"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListReports(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedReports != nil {
+				var reports []domain.AbuseReport
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&reports))
+				assert.Equal(t, tt.expectedReports, reports)
+			}
+		})
+	}
+}
+
+func TestModerationHandler_DismissReport(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		reportId       string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			reportId:       "1",
+			expectedStatus: http.StatusNoContent,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().DismissReport(gomock.Any(), int64(1)).Return(nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "InvalidId",
+			reportId:       "abc",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "ReportNotFound",
+			reportId:       "2",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().DismissReport(gomock.Any(), int64(2)).Return(&domain.AbuseReportNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			reportId:       "3",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().DismissReport(gomock.Any(), int64(3)).Return(assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return moderator, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			moderatorMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewModerationHandler(moderatorMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/abuse-reports/"+tt.reportId+"/dismiss", nil)
+			req.SetPathValue(domain.ReportIdStr, tt.reportId)
+			w := httptest.NewRecorder()
+
+			handler.DismissReport(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestModerationHandler_TakeDown(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			requestBody:    `{"reason":"confirmed phishing"}`,
+			expectedStatus: http.StatusNoContent,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().TakeDown(gomock.Any(), "abc123", "confirmed phishing").Return(nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "InvalidJSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "MissingReason",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().TakeDown(gomock.Any(), "abc123", "").Return(&domain.InvalidAbuseReportError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "TokenNotFound",
+			requestBody:    `{"reason":"spam"}`,
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().TakeDown(gomock.Any(), "abc123", "spam").Return(&domain.TokenNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return moderator, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			requestBody:    `{"reason":"spam"}`,
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.AbuseModerator, domain.Logger) {
+				moderator := mocks.NewMockAbuseModerator(ctrl)
+				moderator.EXPECT().TakeDown(gomock.Any(), "abc123", "spam").Return(assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return moderator, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			moderatorMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewModerationHandler(moderatorMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/links/abc123/takedown", bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req.SetPathValue(domain.UrlTokenStr, "abc123")
+			w := httptest.NewRecorder()
+
+			handler.TakeDown(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestModerationHandler_Reinstate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		urlToken       string
+		expectedStatus int
+		moderatorError error
+	}
+
+	testCases := []testCase{
+		{name: "Success", urlToken: "abc123", expectedStatus: http.StatusNoContent},
+		{name: "TokenNotFound", urlToken: "missing", expectedStatus: http.StatusNotFound, moderatorError: &domain.TokenNonExistingError{}},
+		{name: "InternalError", urlToken: "abc123", expectedStatus: http.StatusInternalServerError, moderatorError: assert.AnError},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			moderator := mocks.NewMockAbuseModerator(ctrl)
+			moderator.EXPECT().Reinstate(gomock.Any(), tt.urlToken).Return(tt.moderatorError)
+			handler := NewModerationHandler(moderator, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/links/"+tt.urlToken+"/takedown", strings.NewReader(""))
+			req.SetPathValue(domain.UrlTokenStr, tt.urlToken)
+			w := httptest.NewRecorder()
+
+			handler.Reinstate(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// passwordFieldName is the name of the form field carrying the submitted link password.
+const passwordFieldName = "password"
+
+// passwordFormTemplate is the page served instead of redirecting for password-protected links.
+// The form is submitted to the same short URL with the POST method; the action is relative to the page,
+// as the service may be served behind a path prefix.
+var passwordFormTemplate = template.Must(template.New("password_form").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+</head>
+<body>
+<h1>This link is password-protected</h1>
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+<form method="post" action="./{{.Token}}">
+<label for="password">Password</label>
+<input type="password" id="password" name="password" autocomplete="current-password" required autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// passwordFormData contains the values rendered into passwordFormTemplate.
+type passwordFormData struct {
+	Token string
+	Error string
+}
+
+// writePasswordForm renders the password form for the token with the given HTTP status.
+// A non-empty errMsg is shown above the form.
+func writePasswordForm(w http.ResponseWriter, status int, token, errMsg string) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	return passwordFormTemplate.Execute(w, passwordFormData{Token: token, Error: errMsg})
+}
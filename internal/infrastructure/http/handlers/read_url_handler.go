@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"url-shortening-service/internal/domain"
+)
+
+const (
+	// afterParamName is the name of the query parameter with the ID after which listed mappings start.
+	afterParamName = "after"
+	// limitParamName is the name of the query parameter with the maximum number of listed mappings.
+	limitParamName = "limit"
+	// defaultListLimit is the number of mappings listed without limit query parameter.
+	defaultListLimit = 100
+	// maxListLimit is the maximum number of mappings listed at once.
+	maxListLimit = 1000
+)
+
+// ReadUrlHandler handles HTTP requests for reading the details of URL mappings.
+type ReadUrlHandler struct {
+	urlReader domain.UrlReader
+	shortUrls domain.ShortUrlFormatter
+	logger    domain.Logger
+}
+
+// NewReadUrlHandler creates a new ReadUrlHandler instance.
+// Parameters:
+//   - urlReader: service for reading URL mappings
+//   - shortUrls: builds the complete short URLs returned to clients
+//   - logger: logger for recording errors
+func NewReadUrlHandler(urlReader domain.UrlReader, shortUrls domain.ShortUrlFormatter, logger domain.Logger) *ReadUrlHandler {
+	return &ReadUrlHandler{
+		urlReader: urlReader,
+		shortUrls: shortUrls,
+		logger:    logger,
+	}
+}
+
+// Get handles GET requests to read the details of the URL mapping of a token on the short domain of the request.
+//
+// HTTP Responses:
+//   - 200 OK: returns the mapping as MappingResponse JSON
+//   - 403 Forbidden: the URL token is owned by another client
+//   - 404 Not Found: URL token does not exist or its mapping is deleted
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ReadUrlHandler) Get(w http.ResponseWriter, r *http.Request) {
+	mappingInfo, err := h.urlReader.GetUrlMapping(r.Context(), linkKey(r))
+	if errors.Is(err, &domain.ForbiddenError{}) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	} else if errors.Is(err, &domain.TokenNonExistingError{}) {
+		http.Error(w, "Short URL not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to read URL mapping: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, newMappingResponse(h.shortUrls, mappingInfo))
+}
+
+// List handles GET requests to list the URL mappings owned by the owner of the API key, across all short domains,
+// ordered by ID. The optional after query parameter continues the listing after the mapping with the given ID,
+// so clients page through their mappings by passing the ID of the last mapping of the previous page,
+// and the optional limit query parameter caps the number of listed mappings (100 by default, at most 1000).
+//
+// HTTP Responses:
+//   - 200 OK: returns a JSON array of MappingResponse
+//   - 400 Bad Request: invalid after or limit query parameter
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ReadUrlHandler) List(w http.ResponseWriter, r *http.Request) {
+	afterId, limit, err := parsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mappings, err := h.urlReader.ListUrlMappings(r.Context(), afterId, limit)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to list URL mappings: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]MappingResponse, 0, len(mappings))
+	for _, mapping := range mappings {
+		responses = append(responses, newMappingResponse(h.shortUrls, mapping))
+	}
+
+	h.writeJSON(w, responses)
+}
+
+// parsePage parses the after and limit query parameters of a listing request.
+func parsePage(r *http.Request) (int64, int, error) {
+	afterId := int64(0)
+	if r.URL.Query().Has(afterParamName) {
+		var err error
+		afterId, err = strconv.ParseInt(r.URL.Query().Get(afterParamName), 10, 64)
+		if err != nil || afterId < 0 {
+			return 0, 0, fmt.Errorf("after must be a non-negative mapping ID")
+		}
+	}
+
+	limit := defaultListLimit
+	if r.URL.Query().Has(limitParamName) {
+		var err error
+		limit, err = strconv.Atoi(r.URL.Query().Get(limitParamName))
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxListLimit)
+		}
+	}
+
+	return afterId, limit, nil
+}
+
+// writeJSON writes the value as a JSON response with status 200 OK.
+func (h *ReadUrlHandler) writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
+	}
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUrlHandler_Get(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name             string
+		shortDomain      string
+		expectedStatus   int
+		expectedShortUrl string
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger)
+	}
+
+	baseUrls, err := domain.NewPublicBaseUrls("https://example.com/s", []string{"https://go.acme.com/l"})
+	require.NoError(t, err)
+
+	testCases := []testCase{
+		{
+			name:             "mapping with short URL",
+			expectedStatus:   http.StatusOK,
+			expectedShortUrl: "https://example.com/s/abc123",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().GetUrlMapping(gomock.Any(), "abc123").Return(domain.MappingInfo{Id: 1, OriginalURL: "https://example.org", Token: "abc123"}, nil)
+
+				return urlReader, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:             "mapping on custom short domain",
+			shortDomain:      "go.acme.com",
+			expectedStatus:   http.StatusOK,
+			expectedShortUrl: "https://go.acme.com/l/abc123",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().GetUrlMapping(gomock.Any(), "go.acme.com/abc123").Return(domain.MappingInfo{Id: 1, OriginalURL: "https://example.org", Token: "abc123", Domain: "go.acme.com"}, nil)
+
+				return urlReader, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "not found",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().GetUrlMapping(gomock.Any(), "abc123").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{})
+
+				return urlReader, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "forbidden",
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().GetUrlMapping(gomock.Any(), "abc123").Return(domain.MappingInfo{}, &domain.ForbiddenError{})
+
+				return urlReader, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "internal error",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().GetUrlMapping(gomock.Any(), "abc123").Return(domain.MappingInfo{}, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return urlReader, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			urlReaderMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewReadUrlHandler(urlReaderMock, baseUrls, loggerMock)
+
+			req := httptest.NewRequest(http.MethodGet, "/shorten/abc123", nil)
+			req.SetPathValue(domain.UrlTokenStr, "abc123")
+			req = req.WithContext(domain.WithShortDomain(req.Context(), tt.shortDomain))
+			w := httptest.NewRecorder()
+
+			handler.Get(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response MappingResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				assert.Equal(t, tt.expectedShortUrl, response.ShortURL)
+				assert.Equal(t, "abc123", response.Token)
+			}
+		})
+	}
+}
+
+func TestReadUrlHandler_List(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		query             string
+		expectedStatus    int
+		expectedShortUrls []string
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger)
+	}
+
+	baseUrls, err := domain.NewPublicBaseUrls("https://example.com/s", []string{"https://go.acme.com/l"})
+	require.NoError(t, err)
+	mappings := []domain.MappingInfo{
+		{Id: 11, OriginalURL: "https://example.org", Token: "abc123"},
+		{Id: 12, OriginalURL: "https://example.org", Token: "def456", Domain: "go.acme.com"},
+	}
+
+	testCases := []testCase{
+		{
+			name:              "default page",
+			expectedStatus:    http.StatusOK,
+			expectedShortUrls: []string{"https://example.com/s/abc123", "https://go.acme.com/l/def456"},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().ListUrlMappings(gomock.Any(), int64(0), defaultListLimit).Return(mappings, nil)
+
+				return urlReader, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:              "page after ID with limit",
+			query:             "?after=10&limit=2",
+			expectedStatus:    http.StatusOK,
+			expectedShortUrls: []string{"https://example.com/s/abc123", "https://go.acme.com/l/def456"},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().ListUrlMappings(gomock.Any(), int64(10), 2).Return(mappings, nil)
+
+				return urlReader, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:              "empty page",
+			query:             "?after=12",
+			expectedStatus:    http.StatusOK,
+			expectedShortUrls: []string{},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().ListUrlMappings(gomock.Any(), int64(12), defaultListLimit).Return(nil, nil)
+
+				return urlReader, slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "invalid after",
+			query:          "?after=abc",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				return mocks.NewMockUrlReader(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "limit too large",
+			query:          "?limit=1001",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				return mocks.NewMockUrlReader(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil))
+			},
+		},
+		{
+			name:           "internal error",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlReader, domain.Logger) {
+				urlReader := mocks.NewMockUrlReader(ctrl)
+				urlReader.EXPECT().ListUrlMappings(gomock.Any(), int64(0), defaultListLimit).Return(nil, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return urlReader, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			urlReaderMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewReadUrlHandler(urlReaderMock, baseUrls, loggerMock)
+
+			req := httptest.NewRequest(http.MethodGet, "/shorten"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.List(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var responses []MappingResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&responses))
+				shortUrls := make([]string, 0, len(responses))
+				for _, response := range responses {
+					shortUrls = append(shortUrls, response.ShortURL)
+				}
+				assert.Equal(t, tt.expectedShortUrls, shortUrls)
+			}
+		})
+	}
+}
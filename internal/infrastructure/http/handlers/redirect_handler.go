@@ -9,13 +9,24 @@ import (
 	"url-shortening-service/internal/domain"
 )
 
+const (
+	// storageRetryAfterSeconds is the Retry-After hint sent when the storage is unavailable.
+	storageRetryAfterSeconds = "5"
+	// redirectQuotaRetryAfterSeconds is the Retry-After hint sent when a workspace exceeds its redirects per second.
+	redirectQuotaRetryAfterSeconds = "1"
+)
+
 // RedirectHandler handles HTTP requests for URL redirection.
 // It retrieves the original URL and redirects the client, while also
 // sending statistics events for analytics.
 type RedirectHandler struct {
-	urlGetter   domain.UrlGetter
-	statsSender domain.StatisticsSender
-	logger      domain.Logger
+	urlGetter        domain.UrlGetter
+	flaggedUrlGetter domain.FlaggedUrlGetter
+	urlUnlocker      domain.UrlUnlocker
+	tokenValidator   domain.TokenValidator
+	suggestTokens    bool
+	statsSender      domain.StatisticsSender
+	logger           domain.Logger
 }
 
 type RedirectRequest struct {
@@ -25,49 +36,193 @@ type RedirectRequest struct {
 // NewRedirectHandler creates a new RedirectHandler instance.
 // Parameters:
 //   - urlGetter: service for retrieving original URLs
+//   - flaggedUrlGetter: service for retrieving original URLs of flagged links after their warning
+//   - urlUnlocker: service for retrieving original URLs of password-protected links
+//   - tokenValidator: recognizes mistyped tokens of links that do not exist
+//   - suggestTokens: whether mistyped tokens get a "did you mean" page instead of a plain 404
 //   - statsSender: sender for statistics events
 //   - logger: logger for recording warnings and errors
-func NewRedirectHandler(urlGetter domain.UrlGetter, statsSender domain.StatisticsSender, logger domain.Logger) *RedirectHandler {
+func NewRedirectHandler(urlGetter domain.UrlGetter, flaggedUrlGetter domain.FlaggedUrlGetter, urlUnlocker domain.UrlUnlocker,
+	tokenValidator domain.TokenValidator, suggestTokens bool, statsSender domain.StatisticsSender, logger domain.Logger) *RedirectHandler {
 	return &RedirectHandler{
-		urlGetter:   urlGetter,
-		logger:      logger,
-		statsSender: statsSender,
+		urlGetter:        urlGetter,
+		flaggedUrlGetter: flaggedUrlGetter,
+		urlUnlocker:      urlUnlocker,
+		tokenValidator:   tokenValidator,
+		suggestTokens:    suggestTokens,
+		logger:           logger,
+		statsSender:      statsSender,
 	}
 }
 
 // Redirect handles GET requests to redirect from short URL to original URL.
 // It retrieves the original URL, sends a statistics event asynchronously,
 // and redirects the client with HTTP 307 Temporary Redirect.
+// Password-protected links are not redirected; a password form is served instead.
+// Links flagged as malicious are not redirected either; an interstitial warning page is served instead,
+// which continues to the same URL with the proceed query parameter acknowledging the warning.
+// Links that were taken down are not redirected; a takedown notice is served instead.
+// Tokens that do not exist and fail token validation are answered as mistyped. Validation only runs
+// after the lookup, so links whose token fails it, like aliases created before check digits were enabled,
+// are still redirected.
+// The token is looked up on the custom short domain of the request, or on the default domain.
 //
 // HTTP Responses:
+//   - 200 OK: the link is password-protected, returns an HTML password form
+//   - 200 OK: the link is flagged and the warning was not acknowledged, returns an HTML warning page
 //   - 307 Temporary Redirect: successful redirect to original URL
-//   - 404 Not Found: URL token does not exist
+//   - 404 Not Found: URL token is mistyped or does not exist; mistyped tokens may get a "did you mean" page
+//   - 410 Gone: URL mapping has expired or has reached its click limit
+//   - 429 Too Many Requests: the workspace of the link has exceeded its redirects per second
+//   - 451 Unavailable For Legal Reasons: the link was taken down, returns an HTML takedown notice
 //   - 500 Internal Server Error: unexpected error occurred
+//   - 503 Service Unavailable: the storage failed, the client may retry later
 func (h *RedirectHandler) Redirect(w http.ResponseWriter, r *http.Request) {
 	token := r.PathValue(domain.UrlTokenStr)
+	key := linkKey(r)
+	var resolved domain.ResolvedUrl
+	var err error
+	if r.URL.Query().Has(proceedParamName) {
+		resolved, err = h.flaggedUrlGetter.GetFlaggedOriginalUrl(r.Context(), key)
+	} else {
+		resolved, err = h.urlGetter.GetOriginalUrl(r.Context(), key)
+	}
+	if errors.Is(err, &domain.UrlNonExistingError{}) && h.rejectMistypedToken(w, token) {
+		return
+	} else if errors.Is(err, &domain.UrlFlaggedError{}) {
+		h.serveWarningPage(w, token)
+		return
+	} else if errors.Is(err, &domain.PasswordRequiredError{}) {
+		h.servePasswordForm(w, http.StatusOK, token, "")
+		return
+	} else if err != nil {
+		h.writeRedirectError(w, token, err)
+		return
+	}
 
-	originalUrl, err := h.urlGetter.GetOriginalUrl(r.Context(), token)
-	if errors.Is(err, &domain.UrlNonExistingError{}) {
-		http.Error(w, "URL not found", http.StatusNotFound)
+	h.sendStatsEvent(r, key, resolved.WorkspaceId)
+	http.Redirect(w, r, resolved.OriginalURL, http.StatusTemporaryRedirect)
+}
+
+// Unlock handles POST requests submitting the password form of a protected short URL.
+// It verifies the password, sends a statistics event asynchronously,
+// and redirects the client with HTTP 303 See Other.
+//
+// HTTP Responses:
+//   - 303 See Other: password accepted, redirect to original URL
+//   - 400 Bad Request: invalid form payload, or the URL is not password-protected
+//   - 401 Unauthorized: wrong password, returns the HTML password form again
+//   - 404 Not Found: URL token is mistyped or does not exist
+//   - 410 Gone: URL mapping has expired or has reached its click limit
+//   - 429 Too Many Requests: too many password attempts for the token, or the workspace of the link
+//     has exceeded its redirects per second
+//   - 451 Unavailable For Legal Reasons: the link was taken down, returns an HTML takedown notice
+//   - 500 Internal Server Error: unexpected error occurred
+//   - 503 Service Unavailable: the storage failed, the client may retry later
+func (h *RedirectHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue(domain.UrlTokenStr)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form payload", http.StatusBadRequest)
+		return
+	}
+
+	key := linkKey(r)
+	resolved, err := h.urlUnlocker.UnlockOriginalUrl(r.Context(), key, r.PostForm.Get(passwordFieldName))
+	if errors.Is(err, &domain.UrlNonExistingError{}) && h.rejectMistypedToken(w, token) {
+		return
+	} else if errors.Is(err, &domain.WrongPasswordError{}) {
+		h.servePasswordForm(w, http.StatusUnauthorized, token, "Wrong password, please try again.")
+		return
+	} else if errors.Is(err, &domain.TooManyAttemptsError{}) {
+		http.Error(w, "Too many password attempts, please try again later", http.StatusTooManyRequests)
+		return
+	} else if errors.Is(err, &domain.UrlNotProtectedError{}) {
+		http.Error(w, "URL is not password-protected", http.StatusBadRequest)
 		return
 	} else if err != nil {
+		h.writeRedirectError(w, token, err)
+		return
+	}
+
+	h.sendStatsEvent(r, key, resolved.WorkspaceId)
+	http.Redirect(w, r, resolved.OriginalURL, http.StatusSeeOther)
+}
+
+// rejectMistypedToken responds with 404 Not Found if the token of a link that does not exist fails validation,
+// serving the "did you mean" page if suggestions are enabled. It reports whether the token was rejected.
+func (h *RedirectHandler) rejectMistypedToken(w http.ResponseWriter, token string) bool {
+	if h.tokenValidator.ValidateToken(token) == nil {
+		return false
+	}
+
+	if !h.suggestTokens {
+		http.Error(w, "URL not found", http.StatusNotFound)
+		return true
+	}
+
+	err := writeSuggestionPage(w, token, h.tokenValidator.SuggestTokens(token))
+	if err != nil {
+		h.logger.Error("Failed to render suggestion page: " + err.Error())
+	}
+	return true
+}
+
+// writeRedirectError maps errors of resolving a short URL to HTTP responses.
+func (h *RedirectHandler) writeRedirectError(w http.ResponseWriter, token string, err error) {
+	if errors.Is(err, &domain.UrlDisabledError{}) {
+		h.serveTakedownPage(w, token)
+	} else if errors.Is(err, &domain.UrlNonExistingError{}) {
+		http.Error(w, "URL not found", http.StatusNotFound)
+	} else if errors.Is(err, &domain.UrlExpiredError{}) {
+		http.Error(w, "URL has expired", http.StatusGone)
+	} else if errors.Is(err, &domain.ClickLimitReachedError{}) {
+		http.Error(w, "URL has reached its click limit", http.StatusGone)
+	} else if errors.Is(err, &domain.QuotaExceededError{}) {
+		w.Header().Set("Retry-After", redirectQuotaRetryAfterSeconds)
+		http.Error(w, "Too many redirects, please try again later", http.StatusTooManyRequests)
+	} else if errors.Is(err, &domain.StorageUnavailableError{}) {
+		h.logger.Error("Storage unavailable while resolving short URL: " + err.Error())
+		w.Header().Set("Retry-After", storageRetryAfterSeconds)
+		http.Error(w, "Service temporarily unavailable", http.StatusServiceUnavailable)
+	} else {
 		h.logger.Error("Failed to get original URL: " + err.Error())
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
 	}
+}
+
+func (h *RedirectHandler) servePasswordForm(w http.ResponseWriter, status int, token, errMsg string) {
+	err := writePasswordForm(w, status, token, errMsg)
+	if err != nil {
+		h.logger.Error("Failed to render password form: " + err.Error())
+	}
+}
 
-	err = h.statsSender.SendEvent(r.Context(), domain.RawStatsEvent{
-		UrlToken:  token,
-		Timestamp: time.Now(),
-		IP:        retrieveIP(r),
-		UserAgent: r.UserAgent(),
-		Referrer:  r.Referer(),
+func (h *RedirectHandler) serveWarningPage(w http.ResponseWriter, token string) {
+	err := writeWarningPage(w, token)
+	if err != nil {
+		h.logger.Error("Failed to render warning page: " + err.Error())
+	}
+}
+
+func (h *RedirectHandler) serveTakedownPage(w http.ResponseWriter, token string) {
+	err := writeTakedownPage(w, token)
+	if err != nil {
+		h.logger.Error("Failed to render takedown page: " + err.Error())
+	}
+}
+
+func (h *RedirectHandler) sendStatsEvent(r *http.Request, key string, workspaceId int64) {
+	err := h.statsSender.SendEvent(r.Context(), domain.RawStatsEvent{
+		UrlToken:    key,
+		WorkspaceId: workspaceId,
+		Timestamp:   time.Now(),
+		IP:          retrieveIP(r),
+		UserAgent:   r.UserAgent(),
+		Referrer:    r.Referer(),
 	})
 	if err != nil {
 		h.logger.Warn("Failed to send statistics event: " + err.Error())
 	}
-
-	http.Redirect(w, r, originalUrl, http.StatusTemporaryRedirect)
 }
 
 func retrieveIP(r *http.Request) string {
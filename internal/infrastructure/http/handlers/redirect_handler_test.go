@@ -1,16 +1,19 @@
 package handlers
 
 import (
+	"context"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"url-shortening-service/internal/domain"
 	"url-shortening-service/internal/domain/mocks"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRedirectHandler_Redirect(t *testing.T) {
@@ -21,6 +24,7 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 		urlToken       string
 		expectedStatus int
 		expectedHeader string
+		expectedBody   string
 
 		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger)
 	}
@@ -33,7 +37,7 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 			expectedHeader: "https://example.com",
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
 				urlGetter := mocks.NewMockUrlGetter(ctrl)
-				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "validToken").Return("https://example.com", nil)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "validToken").Return(domain.ResolvedUrl{OriginalURL: "https://example.com"}, nil)
 
 				statsSender := mocks.NewMockStatisticsSender(ctrl)
 				statsSender.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Return(nil)
@@ -42,6 +46,26 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 				return urlGetter, statsSender, logger
 			},
 		},
+		{
+			name:           "SuccessInWorkspace",
+			urlToken:       "workspaceToken",
+			expectedStatus: http.StatusTemporaryRedirect,
+			expectedHeader: "https://example.com/campaign",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "workspaceToken").Return(domain.ResolvedUrl{OriginalURL: "https://example.com/campaign", WorkspaceId: 7}, nil)
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				statsSender.EXPECT().SendEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event domain.RawStatsEvent) error {
+					assert.Equal(t, "workspaceToken", event.UrlToken)
+					assert.Equal(t, int64(7), event.WorkspaceId)
+					return nil
+				})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlGetter, statsSender, logger
+			},
+		},
 		{
 			name:           "SuccessWithStatsSendError",
 			urlToken:       "validToken",
@@ -49,7 +73,7 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 			expectedHeader: "https://example.com",
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
 				urlGetter := mocks.NewMockUrlGetter(ctrl)
-				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "validToken").Return("https://example.com", nil)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "validToken").Return(domain.ResolvedUrl{OriginalURL: "https://example.com"}, nil)
 
 				statsSender := mocks.NewMockStatisticsSender(ctrl)
 				statsSender.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Return(assert.AnError)
@@ -65,20 +89,115 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
 				urlGetter := mocks.NewMockUrlGetter(ctrl)
-				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "missingToken").Return("", &domain.UrlNonExistingError{})
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "missingToken").Return(domain.ResolvedUrl{}, &domain.UrlNonExistingError{})
 
 				statsSender := mocks.NewMockStatisticsSender(ctrl)
 				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 				return urlGetter, statsSender, logger
 			},
 		},
+		{
+			name:           "PasswordProtected",
+			urlToken:       "secretToken",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `<form method="post" action="./secretToken">`,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "secretToken").Return(domain.ResolvedUrl{}, &domain.PasswordRequiredError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlGetter, statsSender, logger
+			},
+		},
+		{
+			name:           "UrlExpired",
+			urlToken:       "expiredToken",
+			expectedStatus: http.StatusGone,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "expiredToken").Return(domain.ResolvedUrl{}, &domain.UrlExpiredError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlGetter, statsSender, logger
+			},
+		},
+		{
+			name:           "FlaggedServesWarningPage",
+			urlToken:       "flaggedToken",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `href="./flaggedToken?proceed=1"`,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "flaggedToken").Return(domain.ResolvedUrl{}, &domain.UrlFlaggedError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlGetter, statsSender, logger
+			},
+		},
+		{
+			name:           "TakenDownServesNotice",
+			urlToken:       "downToken",
+			expectedStatus: http.StatusUnavailableForLegalReasons,
+			expectedBody:   "This link has been disabled",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "downToken").Return(domain.ResolvedUrl{}, &domain.UrlDisabledError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlGetter, statsSender, logger
+			},
+		},
+		{
+			name:           "ClickLimitReached",
+			urlToken:       "usedToken",
+			expectedStatus: http.StatusGone,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "usedToken").Return(domain.ResolvedUrl{}, &domain.ClickLimitReachedError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlGetter, statsSender, logger
+			},
+		},
+		{
+			name:           "RedirectQuotaExceeded",
+			urlToken:       "busyToken",
+			expectedStatus: http.StatusTooManyRequests,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "busyToken").Return(domain.ResolvedUrl{}, &domain.QuotaExceededError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlGetter, statsSender, logger
+			},
+		},
+		{
+			name:           "StorageUnavailable",
+			urlToken:       "downToken",
+			expectedStatus: http.StatusServiceUnavailable,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "downToken").Return(domain.ResolvedUrl{}, &domain.StorageUnavailableError{Msg: "database is down"})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return urlGetter, statsSender, logger
+			},
+		},
 		{
 			name:           "InternalError",
 			urlToken:       "errorToken",
 			expectedStatus: http.StatusInternalServerError,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.StatisticsSender, domain.Logger) {
 				urlGetter := mocks.NewMockUrlGetter(ctrl)
-				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "errorToken").Return("", assert.AnError)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "errorToken").Return(domain.ResolvedUrl{}, assert.AnError)
 
 				statsSender := mocks.NewMockStatisticsSender(ctrl)
 				logger := mocks.NewMockLogger(ctrl)
@@ -95,7 +214,7 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			urlGetterMock, statsSenderMock, loggerMock := tt.prepareMocks(t, ctrl)
-			handler := NewRedirectHandler(urlGetterMock, statsSenderMock, loggerMock)
+			handler := NewRedirectHandler(urlGetterMock, mocks.NewMockFlaggedUrlGetter(ctrl), mocks.NewMockUrlUnlocker(ctrl), acceptingTokenValidator(ctrl), false, statsSenderMock, loggerMock)
 
 			req := httptest.NewRequest(http.MethodGet, "/"+tt.urlToken, nil)
 			req.SetPathValue(domain.UrlTokenStr, tt.urlToken)
@@ -107,6 +226,384 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 			if tt.expectedHeader != "" {
 				assert.Equal(t, tt.expectedHeader, w.Header().Get("Location"))
 			}
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.Equal(t, storageRetryAfterSeconds, w.Header().Get("Retry-After"))
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests {
+				assert.Equal(t, redirectQuotaRetryAfterSeconds, w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestRedirectHandler_Redirect_WarningAcknowledged(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		expectedStatus int
+		expectedHeader string
+		expectedBody   string
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.FlaggedUrlGetter, domain.StatisticsSender)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusTemporaryRedirect,
+			expectedHeader: "https://example.com/login",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.FlaggedUrlGetter, domain.StatisticsSender) {
+				flaggedGetter := mocks.NewMockFlaggedUrlGetter(ctrl)
+				flaggedGetter.EXPECT().GetFlaggedOriginalUrl(gomock.Any(), "flaggedToken").Return(domain.ResolvedUrl{OriginalURL: "https://example.com/login"}, nil)
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				statsSender.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Return(nil)
+				return flaggedGetter, statsSender
+			},
+		},
+		{
+			name:           "PasswordRequired",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `action="./flaggedToken"`,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.FlaggedUrlGetter, domain.StatisticsSender) {
+				flaggedGetter := mocks.NewMockFlaggedUrlGetter(ctrl)
+				flaggedGetter.EXPECT().GetFlaggedOriginalUrl(gomock.Any(), "flaggedToken").Return(domain.ResolvedUrl{}, &domain.PasswordRequiredError{})
+
+				return flaggedGetter, mocks.NewMockStatisticsSender(ctrl)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			flaggedGetterMock, statsSenderMock := tt.prepareMocks(t, ctrl)
+			handler := NewRedirectHandler(mocks.NewMockUrlGetter(ctrl), flaggedGetterMock, mocks.NewMockUrlUnlocker(ctrl),
+				acceptingTokenValidator(ctrl), false, statsSenderMock, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			req := httptest.NewRequest(http.MethodGet, "/flaggedToken?proceed=1", nil)
+			req.SetPathValue(domain.UrlTokenStr, "flaggedToken")
+			w := httptest.NewRecorder()
+
+			handler.Redirect(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedHeader != "" {
+				assert.Equal(t, tt.expectedHeader, w.Header().Get("Location"))
+			}
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestRedirectHandler_Unlock(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		urlToken       string
+		body           string
+		expectedStatus int
+		expectedHeader string
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			urlToken:       "secretToken",
+			body:           "password=s3cret",
+			expectedStatus: http.StatusSeeOther,
+			expectedHeader: "https://example.com/internal",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "secretToken", "s3cret").Return(domain.ResolvedUrl{OriginalURL: "https://example.com/internal"}, nil)
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				statsSender.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Return(nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "WrongPassword",
+			urlToken:       "secretToken",
+			body:           "password=guess",
+			expectedStatus: http.StatusUnauthorized,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "secretToken", "guess").Return(domain.ResolvedUrl{}, &domain.WrongPasswordError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "TooManyAttempts",
+			urlToken:       "secretToken",
+			body:           "password=guess",
+			expectedStatus: http.StatusTooManyRequests,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "secretToken", "guess").Return(domain.ResolvedUrl{}, &domain.TooManyAttemptsError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "TokenNotFound",
+			urlToken:       "missingToken",
+			body:           "password=s3cret",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "missingToken", "s3cret").Return(domain.ResolvedUrl{}, &domain.UrlNonExistingError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "NotProtected",
+			urlToken:       "flagToken",
+			body:           "password=",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "flagToken", "").Return(domain.ResolvedUrl{}, &domain.UrlNotProtectedError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "TakenDown",
+			urlToken:       "downToken",
+			body:           "password=s3cret",
+			expectedStatus: http.StatusUnavailableForLegalReasons,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "downToken", "s3cret").Return(domain.ResolvedUrl{}, &domain.UrlDisabledError{})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "InvalidFormPayload",
+			urlToken:       "secretToken",
+			body:           "password=%zz",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "StorageUnavailable",
+			urlToken:       "downToken",
+			body:           "password=s3cret",
+			expectedStatus: http.StatusServiceUnavailable,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "downToken", "s3cret").Return(domain.ResolvedUrl{}, &domain.StorageUnavailableError{Msg: "database is down"})
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return urlUnlocker, statsSender, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			urlToken:       "errorToken",
+			body:           "password=s3cret",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUnlocker, domain.StatisticsSender, domain.Logger) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "errorToken", "s3cret").Return(domain.ResolvedUrl{}, assert.AnError)
+
+				statsSender := mocks.NewMockStatisticsSender(ctrl)
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return urlUnlocker, statsSender, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			urlUnlockerMock, statsSenderMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewRedirectHandler(mocks.NewMockUrlGetter(ctrl), mocks.NewMockFlaggedUrlGetter(ctrl), urlUnlockerMock, acceptingTokenValidator(ctrl), false, statsSenderMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/"+tt.urlToken, strings.NewReader(tt.body))
+			req.SetPathValue(domain.UrlTokenStr, tt.urlToken)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			handler.Unlock(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedHeader != "" {
+				assert.Equal(t, tt.expectedHeader, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestRedirectHandler_MistypedToken(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		method         string
+		suggestTokens  bool
+		expectedStatus int
+		expectedBody   string
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.UrlUnlocker, domain.TokenValidator)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "RedirectRejected",
+			method:         http.MethodGet,
+			suggestTokens:  false,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "URL not found",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.UrlUnlocker, domain.TokenValidator) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "aaaaacrBA").Return(domain.ResolvedUrl{}, &domain.UrlNonExistingError{})
+				tokenValidator := mocks.NewMockTokenValidator(ctrl)
+				tokenValidator.EXPECT().ValidateToken("aaaaacrBA").Return(&domain.MalformedTokenError{})
+				return urlGetter, mocks.NewMockUrlUnlocker(ctrl), tokenValidator
+			},
+		},
+		{
+			name:           "RedirectRejectedWithSuggestions",
+			method:         http.MethodGet,
+			suggestTokens:  true,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `<a href="./aaaaacrAB">aaaaacrAB</a>`,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.UrlUnlocker, domain.TokenValidator) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "aaaaacrBA").Return(domain.ResolvedUrl{}, &domain.UrlNonExistingError{})
+				tokenValidator := mocks.NewMockTokenValidator(ctrl)
+				tokenValidator.EXPECT().ValidateToken("aaaaacrBA").Return(&domain.MalformedTokenError{})
+				tokenValidator.EXPECT().SuggestTokens("aaaaacrBA").Return([]string{"aaaaacrAB"})
+				return urlGetter, mocks.NewMockUrlUnlocker(ctrl), tokenValidator
+			},
+		},
+		{
+			name:           "UnlockRejected",
+			method:         http.MethodPost,
+			suggestTokens:  false,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "URL not found",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.UrlUnlocker, domain.TokenValidator) {
+				urlUnlocker := mocks.NewMockUrlUnlocker(ctrl)
+				urlUnlocker.EXPECT().UnlockOriginalUrl(gomock.Any(), "aaaaacrBA", "secret").Return(domain.ResolvedUrl{}, &domain.UrlNonExistingError{})
+				tokenValidator := mocks.NewMockTokenValidator(ctrl)
+				tokenValidator.EXPECT().ValidateToken("aaaaacrBA").Return(&domain.MalformedTokenError{})
+				return mocks.NewMockUrlGetter(ctrl), urlUnlocker, tokenValidator
+			},
+		},
+		{
+			name:           "MissingValidTokenNotSuggested",
+			method:         http.MethodGet,
+			suggestTokens:  true,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "URL not found",
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlGetter, domain.UrlUnlocker, domain.TokenValidator) {
+				urlGetter := mocks.NewMockUrlGetter(ctrl)
+				urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), "aaaaacrBA").Return(domain.ResolvedUrl{}, &domain.UrlNonExistingError{})
+				tokenValidator := mocks.NewMockTokenValidator(ctrl)
+				tokenValidator.EXPECT().ValidateToken("aaaaacrBA").Return(nil)
+				return urlGetter, mocks.NewMockUrlUnlocker(ctrl), tokenValidator
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			urlGetter, urlUnlocker, tokenValidator := tt.prepareMocks(t, ctrl)
+			handler := NewRedirectHandler(urlGetter, mocks.NewMockFlaggedUrlGetter(ctrl), urlUnlocker, tokenValidator,
+				tt.suggestTokens, mocks.NewMockStatisticsSender(ctrl), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			req := httptest.NewRequest(tt.method, "/aaaaacrBA", strings.NewReader("password=secret"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetPathValue(domain.UrlTokenStr, "aaaaacrBA")
+			w := httptest.NewRecorder()
+
+			if tt.method == http.MethodPost {
+				handler.Unlock(w, req)
+			} else {
+				handler.Redirect(w, req)
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
 		})
 	}
 }
+
+func TestRedirectHandler_AliasFailingCheckDigit(t *testing.T) {
+	t.Parallel()
+
+	encoder, err := domain.NewTokenEncoder(domain.TokenEncodingBase62, 6, true)
+	require.NoError(t, err)
+	alias := "spring7"
+	require.Len(t, alias, 7)
+	require.ErrorIs(t, encoder.ValidateToken(alias), &domain.MalformedTokenError{})
+
+	ctrl := gomock.NewController(t)
+	urlGetter := mocks.NewMockUrlGetter(ctrl)
+	urlGetter.EXPECT().GetOriginalUrl(gomock.Any(), alias).Return(domain.ResolvedUrl{OriginalURL: "https://example.com/spring"}, nil)
+	statsSender := mocks.NewMockStatisticsSender(ctrl)
+	statsSender.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	handler := NewRedirectHandler(urlGetter, mocks.NewMockFlaggedUrlGetter(ctrl), mocks.NewMockUrlUnlocker(ctrl), encoder,
+		true, statsSender, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	req := httptest.NewRequest(http.MethodGet, "/"+alias, nil)
+	req.SetPathValue(domain.UrlTokenStr, alias)
+	w := httptest.NewRecorder()
+
+	handler.Redirect(w, req)
+
+	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	assert.Equal(t, "https://example.com/spring", w.Header().Get("Location"))
+}
+
+// acceptingTokenValidator returns a token validator that accepts every token.
+func acceptingTokenValidator(ctrl *gomock.Controller) domain.TokenValidator {
+	tokenValidator := mocks.NewMockTokenValidator(ctrl)
+	tokenValidator.EXPECT().ValidateToken(gomock.Any()).Return(nil).AnyTimes()
+	return tokenValidator
+}
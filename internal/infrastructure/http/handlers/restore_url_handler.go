@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"url-shortening-service/internal/domain"
+)
+
+// RestoreUrlHandler handles HTTP requests for deleting URL mappings.
+type RestoreUrlHandler struct {
+	urlRestorer domain.UrlRestorer
+	logger      domain.Logger
+}
+
+// NewRestoreUrlHandler creates a new RestoreUrlHandler instance.
+// Parameters:
+//   - urlRestorer: service for deleting URL mappings
+//   - logger: logger for recording errors
+func NewRestoreUrlHandler(urlRestorer domain.UrlRestorer, logger domain.Logger) *RestoreUrlHandler {
+	return &RestoreUrlHandler{
+		urlRestorer: urlRestorer,
+		logger:      logger,
+	}
+}
+
+// Restore handles POST requests to undo the deletion of a URL mapping.
+// It extracts the URL token from the path and restores the corresponding deleted mapping of the short domain of the request.
+//
+// HTTP Responses:
+//   - 204 No Content: mapping successfully restored
+//   - 403 Forbidden: the URL token is owned by another client
+//   - 404 Not Found: no deleted mapping with the URL token exists (never deleted, or already purged)
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *RestoreUrlHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	urlToken := linkKey(r)
+
+	err := h.urlRestorer.RestoreUrl(r.Context(), urlToken)
+	if errors.Is(err, &domain.TokenNonExistingError{}) {
+		http.Error(w, "Deleted URL token not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, &domain.ForbiddenError{}) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	} else if err != nil {
+		h.logger.Error("Failed to restore URL: " + err.Error())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreUrlHandler_Restore(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		urlToken       string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlRestorer, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			urlToken:       "validToken",
+			expectedStatus: http.StatusNoContent,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlRestorer, domain.Logger) {
+				restorer := mocks.NewMockUrlRestorer(ctrl)
+				restorer.EXPECT().RestoreUrl(gomock.Any(), "validToken").Return(nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return restorer, logger
+			},
+		},
+		{
+			name:           "TokenNotFound",
+			urlToken:       "missingToken",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlRestorer, domain.Logger) {
+				restorer := mocks.NewMockUrlRestorer(ctrl)
+				restorer.EXPECT().RestoreUrl(gomock.Any(), "missingToken").Return(&domain.TokenNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return restorer, logger
+			},
+		},
+		{
+			name:           "NotOwner",
+			urlToken:       "foreignToken",
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlRestorer, domain.Logger) {
+				restorer := mocks.NewMockUrlRestorer(ctrl)
+				restorer.EXPECT().RestoreUrl(gomock.Any(), "foreignToken").Return(&domain.ForbiddenError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return restorer, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			urlToken:       "errorToken",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlRestorer, domain.Logger) {
+				restorer := mocks.NewMockUrlRestorer(ctrl)
+				restorer.EXPECT().RestoreUrl(gomock.Any(), "errorToken").Return(assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return restorer, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			urlRestorerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewRestoreUrlHandler(urlRestorerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/shorten/"+tt.urlToken+"/restore", nil)
+			req.SetPathValue(domain.UrlTokenStr, tt.urlToken)
+			w := httptest.NewRecorder()
+
+			handler.Restore(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"url-shortening-service/internal/domain"
+)
+
+// ShortDomainHandler handles HTTP requests for administering custom short domains.
+type ShortDomainHandler struct {
+	shortDomainManager domain.ShortDomainManager
+	logger             domain.Logger
+}
+
+// ShortDomainRequest is a custom short domain to register. Without a workspace,
+// the domain is used by API keys outside of workspaces.
+type ShortDomainRequest struct {
+	Host        string `json:"host"`
+	WorkspaceId int64  `json:"workspace_id,omitempty"`
+}
+
+// NewShortDomainHandler creates a new ShortDomainHandler instance.
+// Parameters:
+//   - shortDomainManager: service for administering custom short domains
+//   - logger: logger for recording errors
+func NewShortDomainHandler(shortDomainManager domain.ShortDomainManager, logger domain.Logger) *ShortDomainHandler {
+	return &ShortDomainHandler{
+		shortDomainManager: shortDomainManager,
+		logger:             logger,
+	}
+}
+
+// List handles GET requests to list all custom short domains.
+//
+// HTTP Responses:
+//   - 200 OK: returns a JSON array of ShortDomain
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ShortDomainHandler) List(w http.ResponseWriter, r *http.Request) {
+	shortDomains, err := h.shortDomainManager.ListShortDomains(r.Context())
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to list short domains: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, shortDomains)
+}
+
+// Create handles POST requests to register a custom short domain.
+// It expects a JSON body with the host name of the domain and the workspace that may use it.
+// The domain must point to the service, e.g. through a DNS record, to serve its short URLs.
+//
+// HTTP Responses:
+//   - 201 Created: domain successfully registered, returns ShortDomain JSON
+//   - 400 Bad Request: invalid request payload, invalid host name or unknown workspace
+//   - 409 Conflict: the domain is already registered
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ShortDomainHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req ShortDomainRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	shortDomain, err := h.shortDomainManager.AddShortDomain(r.Context(), domain.ShortDomain{
+		Host:        req.Host,
+		WorkspaceId: req.WorkspaceId,
+	})
+	if errors.Is(err, &domain.InvalidShortDomainError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, &domain.WorkspaceNonExistingError{}) {
+		http.Error(w, "Workspace not found", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, &domain.ShortDomainExistingError{}) {
+		http.Error(w, "Short domain is already registered", http.StatusConflict)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to register short domain: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, shortDomain)
+}
+
+// Delete handles DELETE requests to remove a custom short domain by its host name.
+// Domains are only removed once all of their short URLs are deleted and purged.
+//
+// HTTP Responses:
+//   - 204 No Content: domain successfully removed
+//   - 400 Bad Request: invalid host name
+//   - 404 Not Found: the domain is not registered
+//   - 409 Conflict: short URLs still exist on the domain
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *ShortDomainHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	err := h.shortDomainManager.DeleteShortDomain(r.Context(), r.PathValue(domain.ShortDomainHostStr))
+	if errors.Is(err, &domain.InvalidShortDomainError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, &domain.ShortDomainNonExistingError{}) {
+		http.Error(w, "Short domain not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, &domain.ShortDomainInUseError{}) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to delete short domain: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes the value as a JSON response with the given status code.
+func (h *ShortDomainHandler) writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
+	}
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleShortDomain = domain.ShortDomain{
+	Host:        "go.acme.com",
+	WorkspaceId: 7,
+	CreatedAt:   time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC),
+}
+
+func TestShortDomainHandler_List(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                 string
+		expectedStatus       int
+		expectedShortDomains []domain.ShortDomain
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:                 "Success",
+			expectedStatus:       http.StatusOK,
+			expectedShortDomains: []domain.ShortDomain{exampleShortDomain},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().ListShortDomains(gomock.Any()).Return([]domain.ShortDomain{exampleShortDomain}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().ListShortDomains(gomock.Any()).Return(nil, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewShortDomainHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/domains", nil)
+			w := httptest.NewRecorder()
+
+			handler.List(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedShortDomains != nil {
+				var shortDomains []domain.ShortDomain
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&shortDomains))
+				assert.Equal(t, tt.expectedShortDomains, shortDomains)
+			}
+		})
+	}
+}
+
+func TestShortDomainHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger)
+	}
+
+	requested := domain.ShortDomain{Host: "go.acme.com", WorkspaceId: 7}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			requestBody:    ShortDomainRequest{Host: "go.acme.com", WorkspaceId: 7},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().AddShortDomain(gomock.Any(), requested).Return(exampleShortDomain, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidRequestPayload",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidHost",
+			requestBody:    ShortDomainRequest{Host: "localhost"},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().AddShortDomain(gomock.Any(), domain.ShortDomain{Host: "localhost"}).Return(domain.ShortDomain{}, &domain.InvalidShortDomainError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "UnknownWorkspace",
+			requestBody:    ShortDomainRequest{Host: "go.acme.com", WorkspaceId: 7},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().AddShortDomain(gomock.Any(), requested).Return(domain.ShortDomain{}, &domain.WorkspaceNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "AlreadyRegistered",
+			requestBody:    ShortDomainRequest{Host: "go.acme.com", WorkspaceId: 7},
+			expectedStatus: http.StatusConflict,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().AddShortDomain(gomock.Any(), requested).Return(domain.ShortDomain{}, &domain.ShortDomainExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			requestBody:    ShortDomainRequest{Host: "go.acme.com", WorkspaceId: 7},
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().AddShortDomain(gomock.Any(), requested).Return(domain.ShortDomain{}, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewShortDomainHandler(managerMock, loggerMock)
+
+			var body []byte
+			switch v := tt.requestBody.(type) {
+			case string:
+				body = []byte(v)
+			default:
+				body, _ = json.Marshal(v)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/domains", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler.Create(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestShortDomainHandler_Delete(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusNoContent,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().DeleteShortDomain(gomock.Any(), "go.acme.com").Return(nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "NotFound",
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().DeleteShortDomain(gomock.Any(), "go.acme.com").Return(&domain.ShortDomainNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InUse",
+			expectedStatus: http.StatusConflict,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().DeleteShortDomain(gomock.Any(), "go.acme.com").Return(&domain.ShortDomainInUseError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.ShortDomainManager, domain.Logger) {
+				manager := mocks.NewMockShortDomainManager(ctrl)
+				manager.EXPECT().DeleteShortDomain(gomock.Any(), "go.acme.com").Return(assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewShortDomainHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/domains/go.acme.com", nil)
+			req.SetPathValue(domain.ShortDomainHostStr, "go.acme.com")
+			w := httptest.NewRecorder()
+
+			handler.Delete(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
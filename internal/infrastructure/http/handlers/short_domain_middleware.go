@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"url-shortening-service/internal/domain"
+)
+
+// ShortDomainMiddleware resolves the custom short domain requests are made to by their Host header.
+// Every custom short domain is a token namespace of its own, so all routes addressing a short URL
+// by its token look it up on the domain of the request, see linkKey.
+type ShortDomainMiddleware struct {
+	resolver domain.ShortDomainResolver
+}
+
+// NewShortDomainMiddleware creates a new ShortDomainMiddleware instance.
+// Parameters:
+//   - resolver: service for resolving the custom short domain of a host
+func NewShortDomainMiddleware(resolver domain.ShortDomainResolver) *ShortDomainMiddleware {
+	return &ShortDomainMiddleware{resolver: resolver}
+}
+
+// Wrap returns a handler that passes the custom short domain of requests to next in the request context,
+// see domain.ShortDomainFromContext. Requests to hosts that are not custom short domains are passed on
+// unchanged and served by the default domain.
+func (m *ShortDomainMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+
+		shortDomain, found := m.resolver.ResolveShortDomain(host)
+		if !found {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(domain.WithShortDomain(r.Context(), shortDomain)))
+	})
+}
+
+// linkKey returns the link key of the short URL addressed by the token in the path of the request
+// on the custom short domain of the request, see domain.LinkKey.
+func linkKey(r *http.Request) string {
+	return domain.LinkKey(domain.ShortDomainFromContext(r.Context()), r.PathValue(domain.UrlTokenStr))
+}
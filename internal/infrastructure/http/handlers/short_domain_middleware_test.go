@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortDomainMiddleware_Wrap(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		host        string
+		expectedKey string
+
+		setupMocks func(resolver *mocks.MockShortDomainResolver)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "custom domain",
+			host:        "go.acme.com",
+			expectedKey: "go.acme.com/abc123",
+			setupMocks: func(resolver *mocks.MockShortDomainResolver) {
+				resolver.EXPECT().ResolveShortDomain("go.acme.com").Return("go.acme.com", true)
+			},
+		},
+		{
+			name:        "custom domain with port",
+			host:        "GO.acme.com:8080",
+			expectedKey: "go.acme.com/abc123",
+			setupMocks: func(resolver *mocks.MockShortDomainResolver) {
+				resolver.EXPECT().ResolveShortDomain("GO.acme.com").Return("go.acme.com", true)
+			},
+		},
+		{
+			name:        "default domain",
+			host:        "localhost:8080",
+			expectedKey: "abc123",
+			setupMocks: func(resolver *mocks.MockShortDomainResolver) {
+				resolver.EXPECT().ResolveShortDomain("localhost").Return("localhost", false)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			resolver := mocks.NewMockShortDomainResolver(ctrl)
+			tt.setupMocks(resolver)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc(domain.RedirectAddress, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, linkKey(r))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			req.Host = tt.host
+			w := httptest.NewRecorder()
+
+			NewShortDomainMiddleware(resolver).Wrap(mux).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedKey, w.Body.String())
+		})
+	}
+}
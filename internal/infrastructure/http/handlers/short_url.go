@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"url-shortening-service/internal/domain"
+)
+
+// MappingResponse is a mapping along with its complete short URL, so clients do not have to know
+// the base URLs the short URLs of the service are served at.
+type MappingResponse struct {
+	domain.MappingInfo
+	ShortURL string `json:"short_url,omitempty"`
+}
+
+// newMappingResponse returns the mapping along with its complete short URL, built from the configured
+// public base URL of its short domain. Without one, the short URL is omitted: it is never derived from
+// the Host or X-Forwarded-Proto headers of the request, as clients could choose the returned host with them.
+func newMappingResponse(shortUrls domain.ShortUrlFormatter, mapping domain.MappingInfo) MappingResponse {
+	shortUrl, _ := shortUrls.ShortUrl(mapping)
+	return MappingResponse{MappingInfo: mapping, ShortURL: shortUrl}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"testing"
+	"url-shortening-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noPublicBaseUrls returns a short URL formatter without configured base URLs,
+// so responses carry no short URLs.
+func noPublicBaseUrls() domain.ShortUrlFormatter {
+	baseUrls, _ := domain.NewPublicBaseUrls("", nil)
+	return baseUrls
+}
+
+func TestNewMappingResponse(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name             string
+		shortUrls        domain.ShortUrlFormatter
+		mapping          domain.MappingInfo
+		expectedShortURL string
+	}
+
+	baseUrls, err := domain.NewPublicBaseUrls("https://example.com/s", []string{"https://go.acme.com"})
+	require.NoError(t, err)
+
+	testCases := []testCase{
+		{
+			name:             "configured default domain behind path prefix",
+			shortUrls:        baseUrls,
+			mapping:          domain.MappingInfo{Token: "abc123"},
+			expectedShortURL: "https://example.com/s/abc123",
+		},
+		{
+			name:             "configured custom domain",
+			shortUrls:        baseUrls,
+			mapping:          domain.MappingInfo{Token: "abc123", Domain: "go.acme.com"},
+			expectedShortURL: "https://go.acme.com/abc123",
+		},
+		{
+			name:             "custom domain without base URL",
+			shortUrls:        baseUrls,
+			mapping:          domain.MappingInfo{Token: "abc123", Domain: "s.example.io"},
+			expectedShortURL: "",
+		},
+		{
+			name:             "default domain without base URL",
+			shortUrls:        noPublicBaseUrls(),
+			mapping:          domain.MappingInfo{Token: "abc123"},
+			expectedShortURL: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			response := newMappingResponse(tt.shortUrls, tt.mapping)
+
+			assert.Equal(t, tt.expectedShortURL, response.ShortURL)
+			assert.Equal(t, tt.mapping, response.MappingInfo)
+		})
+	}
+}
@@ -5,36 +5,62 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 	"url-shortening-service/internal/domain"
 )
 
 // ShortenUrlHandler handles HTTP requests for creating shortened URLs.
 type ShortenUrlHandler struct {
 	urlShortener domain.UrlShortener
+	shortUrls    domain.ShortUrlFormatter
 	logger       domain.Logger
 }
 
 type ShortenUrlRequest struct {
-	URL string `json:"url"`
+	URL        string     `json:"url"`
+	Alias      string     `json:"alias,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	TTLSeconds int64      `json:"ttl_seconds,omitempty"`
+	MaxClicks  *int64     `json:"max_clicks,omitempty"`
+	Password   string     `json:"password,omitempty"`
+	// ReuseExisting returns an existing short URL of the same URL instead of creating a new one.
+	ReuseExisting bool `json:"reuse_existing,omitempty"`
+	// Domain is the custom short domain the short URL is served at; the default domain if empty.
+	Domain string `json:"domain,omitempty"`
 }
 
 // NewAddUrlHandler creates a new ShortenUrlHandler instance.
 // Parameters:
 //   - urlShortener: service for creating shortened URLs
+//   - shortUrls: builds the complete short URLs returned to clients
 //   - logger: logger for recording errors
-func NewAddUrlHandler(urlShortener domain.UrlShortener, logger domain.Logger) *ShortenUrlHandler {
+func NewAddUrlHandler(urlShortener domain.UrlShortener, shortUrls domain.ShortUrlFormatter, logger domain.Logger) *ShortenUrlHandler {
 	return &ShortenUrlHandler{
 		urlShortener: urlShortener,
+		shortUrls:    shortUrls,
 		logger:       logger,
 	}
 }
 
 // Create handles POST requests to create a new shortened URL.
-// It expects a JSON body with the original URL and returns the created mapping.
+// It expects a JSON body with the original URL, an optional custom alias,
+// an optional lifetime (either expires_at or ttl_seconds), an optional click limit
+// and an optional password and returns the created mapping along with its complete short URL.
+// With reuse_existing set, an existing mapping of the same URL is returned instead, if there is one.
+// The created mapping is owned by the owner and the workspace of the API key the request was authenticated with
+// and counts against the quota of the workspace.
+// With domain set, the short URL is served at that custom short domain, which must be registered
+// for the workspace of the API key; aliases and reuse are scoped to the domain.
 //
 // HTTP Responses:
-//   - 201 Created: URL successfully shortened, returns MappingInfo JSON
-//   - 400 Bad Request: invalid request payload or invalid URL format
+//   - 200 OK: an existing mapping of the URL was reused, returns MappingResponse JSON
+//   - 201 Created: URL successfully shortened, returns MappingResponse JSON
+//   - 400 Bad Request: invalid request payload, rejected URL with the reason, invalid alias, invalid expiration, invalid click limit,
+//     invalid password, or unknown or invalid custom short domain
+//   - 403 Forbidden: the destination is not allowed by the destination policy or is reported as malicious,
+//     or the custom short domain belongs to another workspace
+//   - 409 Conflict: the requested alias is already taken
+//   - 429 Too Many Requests: the workspace has reached its quota of short URLs for the month
 //   - 500 Internal Server Error: unexpected error occurred
 func (h *ShortenUrlHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req ShortenUrlRequest
@@ -44,9 +70,43 @@ func (h *ShortenUrlHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mappingInfo, err := h.urlShortener.ShortenUrl(r.Context(), req.URL)
-	if errors.Is(err, &domain.InvalidUrlError{}) {
-		http.Error(w, "Invalid URL", http.StatusBadRequest)
+	expiresAt, err := domain.ResolveExpiration(req.ExpiresAt, req.TTLSeconds, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var ownerId string
+	var workspaceId int64
+	if key, ok := domain.ApiKeyFromContext(r.Context()); ok {
+		ownerId = key.Owner
+		workspaceId = key.WorkspaceId
+	}
+
+	mappingInfo, reused, err := h.urlShortener.ShortenUrl(r.Context(), req.URL, domain.ShortenOptions{
+		Alias:         req.Alias,
+		ExpiresAt:     expiresAt,
+		MaxClicks:     req.MaxClicks,
+		Password:      req.Password,
+		ReuseExisting: req.ReuseExisting,
+		OwnerId:       ownerId,
+		WorkspaceId:   workspaceId,
+		Domain:        req.Domain,
+	})
+	if errors.Is(err, &domain.InvalidUrlError{}) || errors.Is(err, &domain.InvalidAliasError{}) || errors.Is(err, &domain.InvalidExpirationError{}) ||
+		errors.Is(err, &domain.InvalidClickLimitError{}) || errors.Is(err, &domain.InvalidPasswordError{}) ||
+		errors.Is(err, &domain.InvalidShortDomainError{}) || errors.Is(err, &domain.ShortDomainNonExistingError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, &domain.DestinationBlockedError{}) || errors.Is(err, &domain.MaliciousUrlError{}) ||
+		errors.Is(err, &domain.ForbiddenError{}) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	} else if errors.Is(err, &domain.TokenExistingError{}) {
+		http.Error(w, "Alias is already taken", http.StatusConflict)
+		return
+	} else if errors.Is(err, &domain.QuotaExceededError{}) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
 		return
 	} else if err != nil {
 		h.logger.Error(fmt.Sprintf("Failed to shorten URL: %v", err))
@@ -54,10 +114,15 @@ func (h *ShortenUrlHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	status := http.StatusCreated
+	if reused {
+		status = http.StatusOK
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(status)
 
-	err = json.NewEncoder(w).Encode(mappingInfo)
+	err = json.NewEncoder(w).Encode(newMappingResponse(h.shortUrls, mappingInfo))
 	if err != nil {
 		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
 	}
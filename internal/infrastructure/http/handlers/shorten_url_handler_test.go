@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestShortenUrlHandler_Create(t *testing.T) {
@@ -27,6 +29,11 @@ func TestShortenUrlHandler_Create(t *testing.T) {
 		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger)
 	}
 
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	pastExpiresAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	maxClicks := int64(1)
+	zeroClicks := int64(0)
+
 	testCases := []testCase{
 		{
 			name:           "Success",
@@ -34,13 +41,29 @@ func TestShortenUrlHandler_Create(t *testing.T) {
 			expectedStatus: http.StatusCreated,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
 				urlShortener := mocks.NewMockUrlShortener(ctrl)
-				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com").Return(domain.MappingInfo{
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{}).Return(domain.MappingInfo{
 					Id:          1,
 					OriginalURL: "https://example.com",
 					Token:       "abc123",
 					CreatedAt:   time.Now(),
 					UpdatedAt:   time.Now(),
-				}, nil)
+				}, false, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "SuccessReusedExisting",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", ReuseExisting: true},
+			expectedStatus: http.StatusOK,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{ReuseExisting: true}).Return(domain.MappingInfo{
+					Id:          1,
+					OriginalURL: "https://example.com/",
+					Token:       "abc123",
+				}, true, nil)
 
 				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 				return urlShortener, logger
@@ -62,7 +85,223 @@ func TestShortenUrlHandler_Create(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
 				urlShortener := mocks.NewMockUrlShortener(ctrl)
-				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "invalid-url").Return(domain.MappingInfo{}, &domain.InvalidUrlError{})
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "invalid-url", domain.ShortenOptions{}).Return(domain.MappingInfo{}, false, &domain.InvalidUrlError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "DestinationBlocked",
+			requestBody:    ShortenUrlRequest{URL: "https://evil.com"},
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://evil.com", domain.ShortenOptions{}).Return(domain.MappingInfo{}, false, &domain.DestinationBlockedError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "MaliciousDestination",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com/login"},
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com/login", domain.ShortenOptions{}).Return(domain.MappingInfo{}, false, &domain.MaliciousUrlError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "SuccessWithAlias",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", Alias: "spring-sale"},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{Alias: "spring-sale"}).Return(domain.MappingInfo{
+					Id:          2,
+					OriginalURL: "https://example.com",
+					Token:       "spring-sale",
+					CreatedAt:   time.Now(),
+					UpdatedAt:   time.Now(),
+				}, false, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "InvalidAlias",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", Alias: "shorten"},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{Alias: "shorten"}).Return(domain.MappingInfo{}, false, &domain.InvalidAliasError{Msg: "Alias is reserved: shorten"})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "AliasTaken",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", Alias: "spring-sale"},
+			expectedStatus: http.StatusConflict,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{Alias: "spring-sale"}).Return(domain.MappingInfo{}, false, &domain.TokenExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "SuccessWithExpiresAt",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", ExpiresAt: &expiresAt},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, opts domain.ShortenOptions) (domain.MappingInfo, bool, error) {
+						require.NotNil(t, opts.ExpiresAt)
+						assert.True(t, expiresAt.Equal(*opts.ExpiresAt))
+						return domain.MappingInfo{Id: 3, OriginalURL: "https://example.com", Token: "d", ExpiresAt: opts.ExpiresAt}, false, nil
+					})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "SuccessWithTTL",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", TTLSeconds: 60},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, opts domain.ShortenOptions) (domain.MappingInfo, bool, error) {
+						require.NotNil(t, opts.ExpiresAt)
+						assert.WithinDuration(t, time.Now().Add(time.Minute), *opts.ExpiresAt, 10*time.Second)
+						return domain.MappingInfo{Id: 4, OriginalURL: "https://example.com", Token: "e", ExpiresAt: opts.ExpiresAt}, false, nil
+					})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "BothExpiresAtAndTTL",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", ExpiresAt: &expiresAt, TTLSeconds: 60},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "ExpirationInPast",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", ExpiresAt: &pastExpiresAt},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", gomock.Any()).Return(domain.MappingInfo{}, false, &domain.InvalidExpirationError{Msg: "expiration time must be in the future"})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "SuccessWithMaxClicks",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", MaxClicks: &maxClicks},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{MaxClicks: &maxClicks}).Return(domain.MappingInfo{
+					Id:          5,
+					OriginalURL: "https://example.com",
+					Token:       "f",
+					MaxClicks:   &maxClicks,
+				}, false, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "InvalidMaxClicks",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", MaxClicks: &zeroClicks},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{MaxClicks: &zeroClicks}).Return(domain.MappingInfo{}, false, &domain.InvalidClickLimitError{Msg: "Max clicks must be positive: 0"})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "SuccessWithPassword",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com/internal", Password: "s3cret"},
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com/internal", domain.ShortenOptions{Password: "s3cret"}).Return(domain.MappingInfo{
+					Id:          6,
+					OriginalURL: "https://example.com/internal",
+					Token:       "g",
+					Protected:   true,
+				}, false, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "InvalidPassword",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com/internal", Password: "abc"},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com/internal", domain.ShortenOptions{Password: "abc"}).Return(domain.MappingInfo{}, false, &domain.InvalidPasswordError{Msg: "Password must be between 4 and 72 bytes long"})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "LinkQuotaExceeded",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com"},
+			expectedStatus: http.StatusTooManyRequests,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{}).Return(domain.MappingInfo{}, false, &domain.QuotaExceededError{Msg: "Workspace 7 has reached its quota of 100 short URLs per month"})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "UnknownShortDomain",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", Domain: "go.globex.com"},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{Domain: "go.globex.com"}).Return(domain.MappingInfo{}, false, &domain.ShortDomainNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlShortener, logger
+			},
+		},
+		{
+			name:           "ShortDomainOfAnotherWorkspace",
+			requestBody:    ShortenUrlRequest{URL: "https://example.com", Domain: "go.acme.com"},
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
+				urlShortener := mocks.NewMockUrlShortener(ctrl)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{Domain: "go.acme.com"}).Return(domain.MappingInfo{}, false, &domain.ForbiddenError{})
 
 				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 				return urlShortener, logger
@@ -74,7 +313,7 @@ func TestShortenUrlHandler_Create(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlShortener, domain.Logger) {
 				urlShortener := mocks.NewMockUrlShortener(ctrl)
-				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com").Return(domain.MappingInfo{}, assert.AnError)
+				urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{}).Return(domain.MappingInfo{}, false, assert.AnError)
 
 				logger := mocks.NewMockLogger(ctrl)
 				logger.EXPECT().Error(gomock.Any())
@@ -90,7 +329,7 @@ func TestShortenUrlHandler_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			urlShortenerMock, loggerMock := tt.prepareMocks(t, ctrl)
-			handler := NewAddUrlHandler(urlShortenerMock, loggerMock)
+			handler := NewAddUrlHandler(urlShortenerMock, noPublicBaseUrls(), loggerMock)
 
 			var body []byte
 			switch v := tt.requestBody.(type) {
@@ -110,3 +349,111 @@ func TestShortenUrlHandler_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestShortenUrlHandler_CreateOwned(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	urlShortener := mocks.NewMockUrlShortener(ctrl)
+	urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{OwnerId: "team-a"}).Return(domain.MappingInfo{
+		Id:          1,
+		OriginalURL: "https://example.com",
+		Token:       "abc123",
+		OwnerId:     "team-a",
+	}, false, nil)
+	handler := NewAddUrlHandler(urlShortener, noPublicBaseUrls(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	body, _ := json.Marshal(ShortenUrlRequest{URL: "https://example.com"})
+	req := httptest.NewRequest(http.MethodPost, "/shorten", bytes.NewReader(body))
+	req = req.WithContext(domain.WithApiKey(req.Context(), domain.ApiKey{Name: "ci", Owner: "team-a"}))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var mappingInfo domain.MappingInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&mappingInfo))
+	assert.Equal(t, "team-a", mappingInfo.OwnerId)
+}
+
+func TestShortenUrlHandler_CreateInWorkspace(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	urlShortener := mocks.NewMockUrlShortener(ctrl)
+	urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{OwnerId: "team-a", WorkspaceId: 7}).Return(domain.MappingInfo{
+		Id:          1,
+		OriginalURL: "https://example.com",
+		Token:       "abc123",
+		OwnerId:     "team-a",
+		WorkspaceId: 7,
+	}, false, nil)
+	handler := NewAddUrlHandler(urlShortener, noPublicBaseUrls(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	body, _ := json.Marshal(ShortenUrlRequest{URL: "https://example.com"})
+	req := httptest.NewRequest(http.MethodPost, "/shorten", bytes.NewReader(body))
+	req = req.WithContext(domain.WithApiKey(req.Context(), domain.ApiKey{Name: "ci", Owner: "team-a", WorkspaceId: 7}))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var mappingInfo domain.MappingInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&mappingInfo))
+	assert.Equal(t, int64(7), mappingInfo.WorkspaceId)
+}
+
+func TestShortenUrlHandler_CreateOnShortDomain(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name             string
+		requestDomain    string
+		mappingDomain    string
+		expectedShortURL string
+	}
+
+	baseUrls, err := domain.NewPublicBaseUrls("https://sho.rt", []string{"https://go.acme.com"})
+	require.NoError(t, err)
+
+	testCases := []testCase{
+		{name: "default domain", expectedShortURL: "https://sho.rt/abc123"},
+		{name: "custom domain", requestDomain: "GO.acme.com", mappingDomain: "go.acme.com", expectedShortURL: "https://go.acme.com/abc123"},
+		{name: "custom domain without base URL", requestDomain: "s.example.io", mappingDomain: "s.example.io", expectedShortURL: ""},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			urlShortener := mocks.NewMockUrlShortener(ctrl)
+			urlShortener.EXPECT().ShortenUrl(gomock.Any(), "https://example.com", domain.ShortenOptions{Domain: tt.requestDomain}).Return(domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "https://example.com",
+				Token:       "abc123",
+				Domain:      tt.mappingDomain,
+			}, false, nil)
+			handler := NewAddUrlHandler(urlShortener, baseUrls, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			// The request headers are chosen by the client and must never end up in the short URL.
+			body, _ := json.Marshal(ShortenUrlRequest{URL: "https://example.com", Domain: tt.requestDomain})
+			req := httptest.NewRequest(http.MethodPost, "http://evil.example:8080/shorten", bytes.NewReader(body))
+			req.Header.Set("X-Forwarded-Proto", "http")
+			w := httptest.NewRecorder()
+
+			handler.Create(w, req)
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.expectedShortURL == "" {
+				assert.NotContains(t, response, "short_url")
+			} else {
+				assert.Equal(t, tt.expectedShortURL, response["short_url"])
+			}
+			assert.Equal(t, "abc123", response["url_token"])
+		})
+	}
+}
@@ -32,15 +32,19 @@ func NewStatsShowHandler(statsCalculator domain.StatisticsCalculator, logger dom
 //
 // HTTP Responses:
 //   - 200 OK: returns CalculatedStatistics JSON
+//   - 403 Forbidden: the URL token is owned by another client
 //   - 404 Not Found: no statistics exist for the given token
 //   - 500 Internal Server Error: unexpected error occurred
 func (h *StatsShowHandler) Show(w http.ResponseWriter, r *http.Request) {
-	token := r.PathValue(domain.UrlTokenStr)
+	token := linkKey(r)
 
 	stats, err := h.statsCalculator.CalculateStatistics(r.Context(), token)
 	if errors.Is(err, &domain.TokenNonExistingError{}) {
 		http.Error(w, "Statistics not found for the given URL token", http.StatusNotFound)
 		return
+	} else if errors.Is(err, &domain.ForbiddenError{}) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
 	} else if err != nil {
 		h.logger.Error("Failed to calculate statistics: " + err.Error())
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
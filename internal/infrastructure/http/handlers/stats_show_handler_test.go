@@ -56,6 +56,18 @@ func TestStatsShowHandler_Show(t *testing.T) {
 				return statsCalculator, logger
 			},
 		},
+		{
+			name:           "NotOwner",
+			urlToken:       "foreignToken",
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.StatisticsCalculator, domain.Logger) {
+				statsCalculator := mocks.NewMockStatisticsCalculator(ctrl)
+				statsCalculator.EXPECT().CalculateStatistics(gomock.Any(), "foreignToken").Return(domain.CalculatedStatistics{}, &domain.ForbiddenError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return statsCalculator, logger
+			},
+		},
 		{
 			name:           "InternalError",
 			urlToken:       "errorToken",
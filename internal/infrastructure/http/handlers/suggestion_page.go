@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// suggestionPageTemplate is the "did you mean" page served instead of a plain 404 for mistyped tokens.
+// Suggested tokens are linked relative to the page, like the links of the other pages.
+var suggestionPageTemplate = template.Must(template.New("suggestion_page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link not found</title>
+</head>
+<body>
+<h1>This link does not exist</h1>
+<p>The short link <code>{{.Token}}</code> looks mistyped.</p>
+{{if .Suggestions}}<p>Did you mean:</p>
+<ul>
+{{range .Suggestions}}<li><a href="./{{.}}">{{.}}</a></li>
+{{end}}</ul>{{end}}
+</body>
+</html>
+`))
+
+// suggestionPageData contains the values rendered into suggestionPageTemplate.
+type suggestionPageData struct {
+	Token       string
+	Suggestions []string
+}
+
+// writeSuggestionPage renders the "did you mean" page for a mistyped token with 404 Not Found.
+func writeSuggestionPage(w http.ResponseWriter, token string, suggestions []string) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusNotFound)
+
+	return suggestionPageTemplate.Execute(w, suggestionPageData{Token: token, Suggestions: suggestions})
+}
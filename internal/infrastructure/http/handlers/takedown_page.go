@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// takedownPageTemplate is the notice served instead of redirecting for links that were taken down.
+var takedownPageTemplate = template.Must(template.New("takedown_page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link unavailable</title>
+</head>
+<body>
+<h1>This link has been disabled</h1>
+<p>The short link <code>{{.Token}}</code> was taken down following a report of abuse or a legal request, and no longer leads to its destination.</p>
+</body>
+</html>
+`))
+
+// takedownPageData contains the values rendered into takedownPageTemplate.
+type takedownPageData struct {
+	Token string
+}
+
+// writeTakedownPage renders the takedown notice for the token with 451 Unavailable For Legal Reasons.
+func writeTakedownPage(w http.ResponseWriter, token string) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusUnavailableForLegalReasons)
+
+	return takedownPageTemplate.Execute(w, takedownPageData{Token: token})
+}
@@ -5,36 +5,47 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 	"url-shortening-service/internal/domain"
 )
 
 // UpdaterUrlHandler handles HTTP requests for updating URL mappings.
 type UpdaterUrlHandler struct {
 	urlUpdater domain.UrlUpdater
+	shortUrls  domain.ShortUrlFormatter
 	logger     domain.Logger
 }
 
 type UpdateUrlRequest struct {
-	NewURL string `json:"url"`
+	NewURL          string     `json:"url"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	TTLSeconds      int64      `json:"ttl_seconds,omitempty"`
+	ClearExpiration bool       `json:"clear_expiration,omitempty"`
 }
 
 // NewUpdateUrlHandler creates a new UpdaterUrlHandler instance.
 // Parameters:
 //   - urlUpdater: service for updating URL mappings
+//   - shortUrls: builds the complete short URLs returned to clients
 //   - logger: logger for recording errors
-func NewUpdateUrlHandler(urlUpdater domain.UrlUpdater, logger domain.Logger) *UpdaterUrlHandler {
+func NewUpdateUrlHandler(urlUpdater domain.UrlUpdater, shortUrls domain.ShortUrlFormatter, logger domain.Logger) *UpdaterUrlHandler {
 	return &UpdaterUrlHandler{
 		urlUpdater: urlUpdater,
+		shortUrls:  shortUrls,
 		logger:     logger,
 	}
 }
 
 // Update handles PUT requests to update an existing URL mapping.
-// It expects a JSON body with the new URL and updates the mapping for the given token.
+// It expects a JSON body with the new URL and updates the mapping for the given token on the short domain of the request.
+// An optional lifetime (either expires_at or ttl_seconds) replaces the current expiration time,
+// and clear_expiration removes it so the mapping never expires; if omitted, the expiration time stays unchanged.
 //
 // HTTP Responses:
-//   - 200 OK: URL successfully updated, returns updated MappingInfo JSON
-//   - 400 Bad Request: invalid request payload or invalid URL format
+//   - 200 OK: URL successfully updated, returns the updated mapping as MappingResponse JSON
+//   - 400 Bad Request: invalid request payload, invalid URL format or invalid expiration
+//   - 403 Forbidden: the URL token is owned by another client, or the new destination is not allowed
+//     by the destination policy or is reported as malicious
 //   - 404 Not Found: URL token does not exist
 //   - 500 Internal Server Error: unexpected error occurred
 func (h *UpdaterUrlHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -45,12 +56,25 @@ func (h *UpdaterUrlHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token := r.PathValue(domain.UrlTokenStr)
+	expiresAt, err := domain.ResolveExpiration(req.ExpiresAt, req.TTLSeconds, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	token := linkKey(r)
 
-	mappingInfo, err := h.urlUpdater.UpdateUrlMapping(r.Context(), token, req.NewURL)
-	if errors.Is(err, &domain.InvalidUrlError{}) {
+	mappingInfo, err := h.urlUpdater.UpdateUrlMapping(r.Context(), token, req.NewURL, domain.UpdateOptions{
+		ExpiresAt:       expiresAt,
+		ClearExpiration: req.ClearExpiration,
+	})
+	if errors.Is(err, &domain.InvalidUrlError{}) || errors.Is(err, &domain.InvalidExpirationError{}) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
+	} else if errors.Is(err, &domain.ForbiddenError{}) || errors.Is(err, &domain.DestinationBlockedError{}) ||
+		errors.Is(err, &domain.MaliciousUrlError{}) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
 	} else if errors.Is(err, &domain.TokenNonExistingError{}) {
 		http.Error(w, "Short URL not found", http.StatusNotFound)
 		return
@@ -63,7 +87,7 @@ func (h *UpdaterUrlHandler) Update(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	err = json.NewEncoder(w).Encode(mappingInfo)
+	err = json.NewEncoder(w).Encode(newMappingResponse(h.shortUrls, mappingInfo))
 	if err != nil {
 		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
 	}
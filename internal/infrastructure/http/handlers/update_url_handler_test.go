@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpdateUrlHandler_Update(t *testing.T) {
@@ -36,7 +38,7 @@ func TestUpdateUrlHandler_Update(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
 				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
-				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "https://newexample.com").Return(domain.MappingInfo{
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "https://newexample.com", domain.UpdateOptions{}).Return(domain.MappingInfo{
 					Id:          1,
 					OriginalURL: "https://newexample.com",
 					Token:       "validToken",
@@ -66,7 +68,87 @@ func TestUpdateUrlHandler_Update(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
 				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
-				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "invalid-url").Return(domain.MappingInfo{}, &domain.InvalidUrlError{})
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "invalid-url", domain.UpdateOptions{}).Return(domain.MappingInfo{}, &domain.InvalidUrlError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUpdater, logger
+			},
+		},
+		{
+			name:           "DestinationBlocked",
+			urlToken:       "validToken",
+			requestBody:    UpdateUrlRequest{NewURL: "https://evil.com"},
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
+				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "https://evil.com", domain.UpdateOptions{}).Return(domain.MappingInfo{}, &domain.DestinationBlockedError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUpdater, logger
+			},
+		},
+		{
+			name:           "SuccessWithTTL",
+			urlToken:       "validToken",
+			requestBody:    UpdateUrlRequest{NewURL: "https://newexample.com", TTLSeconds: 3600},
+			expectedStatus: http.StatusOK,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
+				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "https://newexample.com", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, opts domain.UpdateOptions) (domain.MappingInfo, error) {
+						require.NotNil(t, opts.ExpiresAt)
+						assert.WithinDuration(t, time.Now().Add(time.Hour), *opts.ExpiresAt, time.Minute)
+						return domain.MappingInfo{Token: "validToken", ExpiresAt: opts.ExpiresAt}, nil
+					})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUpdater, logger
+			},
+		},
+		{
+			name:           "SuccessClearingExpiration",
+			urlToken:       "validToken",
+			requestBody:    UpdateUrlRequest{NewURL: "https://newexample.com", ClearExpiration: true},
+			expectedStatus: http.StatusOK,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
+				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "https://newexample.com", domain.UpdateOptions{ClearExpiration: true}).
+					Return(domain.MappingInfo{Token: "validToken"}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUpdater, logger
+			},
+		},
+		{
+			name:           "TTLAboveMaximum",
+			urlToken:       "validToken",
+			requestBody:    UpdateUrlRequest{NewURL: "https://newexample.com", TTLSeconds: domain.MaxTTLSeconds + 1},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
+				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUpdater, logger
+			},
+		},
+		{
+			name:           "NegativeTTL",
+			urlToken:       "validToken",
+			requestBody:    UpdateUrlRequest{NewURL: "https://newexample.com", TTLSeconds: -1},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
+				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUpdater, logger
+			},
+		},
+		{
+			name:           "ExpirationInPast",
+			urlToken:       "validToken",
+			requestBody:    UpdateUrlRequest{NewURL: "https://newexample.com"},
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
+				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "validToken", "https://newexample.com", domain.UpdateOptions{}).Return(domain.MappingInfo{}, &domain.InvalidExpirationError{})
 
 				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 				return urlUpdater, logger
@@ -79,7 +161,20 @@ func TestUpdateUrlHandler_Update(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
 				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
-				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "missingToken", "https://newexample.com").Return(domain.MappingInfo{}, &domain.TokenNonExistingError{})
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "missingToken", "https://newexample.com", domain.UpdateOptions{}).Return(domain.MappingInfo{}, &domain.TokenNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return urlUpdater, logger
+			},
+		},
+		{
+			name:           "NotOwner",
+			urlToken:       "foreignToken",
+			requestBody:    UpdateUrlRequest{NewURL: "https://newexample.com"},
+			expectedStatus: http.StatusForbidden,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
+				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "foreignToken", "https://newexample.com", domain.UpdateOptions{}).Return(domain.MappingInfo{}, &domain.ForbiddenError{})
 
 				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 				return urlUpdater, logger
@@ -92,7 +187,7 @@ func TestUpdateUrlHandler_Update(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.UrlUpdater, domain.Logger) {
 				urlUpdater := mocks.NewMockUrlUpdater(ctrl)
-				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "errorToken", "https://newexample.com").Return(domain.MappingInfo{}, assert.AnError)
+				urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "errorToken", "https://newexample.com", domain.UpdateOptions{}).Return(domain.MappingInfo{}, assert.AnError)
 
 				logger := mocks.NewMockLogger(ctrl)
 				logger.EXPECT().Error(gomock.Any())
@@ -108,7 +203,7 @@ func TestUpdateUrlHandler_Update(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			urlUpdaterMock, loggerMock := tt.prepareMocks(t, ctrl)
-			handler := NewUpdateUrlHandler(urlUpdaterMock, loggerMock)
+			handler := NewUpdateUrlHandler(urlUpdaterMock, noPublicBaseUrls(), loggerMock)
 
 			var body []byte
 			switch v := tt.requestBody.(type) {
@@ -129,3 +224,33 @@ func TestUpdateUrlHandler_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateUrlHandler_UpdateReturnsShortUrl(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	urlUpdater := mocks.NewMockUrlUpdater(ctrl)
+	urlUpdater.EXPECT().UpdateUrlMapping(gomock.Any(), "go.acme.com/abc123", "https://newexample.com", domain.UpdateOptions{}).Return(domain.MappingInfo{
+		Id:          1,
+		OriginalURL: "https://newexample.com",
+		Token:       "abc123",
+		Domain:      "go.acme.com",
+	}, nil)
+	baseUrls, err := domain.NewPublicBaseUrls("https://example.com/s", []string{"https://go.acme.com/l"})
+	require.NoError(t, err)
+	handler := NewUpdateUrlHandler(urlUpdater, baseUrls, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	body, _ := json.Marshal(UpdateUrlRequest{NewURL: "https://newexample.com"})
+	req := httptest.NewRequest(http.MethodPut, "/abc123", bytes.NewReader(body))
+	req.SetPathValue(domain.UrlTokenStr, "abc123")
+	req = req.WithContext(domain.WithShortDomain(req.Context(), "go.acme.com"))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response MappingResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, "https://go.acme.com/l/abc123", response.ShortURL)
+	assert.Equal(t, "abc123", response.Token)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// proceedParamName is the name of the query parameter acknowledging the warning of a flagged link.
+const proceedParamName = "proceed"
+
+// warningPageTemplate is the interstitial page served instead of redirecting for links flagged as malicious.
+// Continuing requests the same short URL with the warning acknowledged.
+var warningPageTemplate = template.Must(template.New("warning_page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Warning: suspicious link</title>
+</head>
+<body>
+<h1>This link may be harmful</h1>
+<p>The destination of this short link has been reported as malicious, for example as a phishing or malware site.
+Visiting it may put your data or your device at risk.</p>
+<p><a href="./{{.Token}}?{{.ProceedParam}}=1" rel="nofollow noreferrer">Continue anyway</a></p>
+</body>
+</html>
+`))
+
+// warningPageData contains the values rendered into warningPageTemplate.
+type warningPageData struct {
+	Token        string
+	ProceedParam string
+}
+
+// writeWarningPage renders the interstitial warning page for the flagged token.
+func writeWarningPage(w http.ResponseWriter, token string) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	return warningPageTemplate.Execute(w, warningPageData{Token: token, ProceedParam: proceedParamName})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"url-shortening-service/internal/domain"
+)
+
+// WorkspaceHandler handles HTTP requests for administering workspaces and their quotas.
+type WorkspaceHandler struct {
+	workspaceManager domain.WorkspaceManager
+	logger           domain.Logger
+}
+
+// WorkspaceRequest is the name and the quotas of a workspace. A zero quota is unlimited.
+type WorkspaceRequest struct {
+	Name               string `json:"name"`
+	LinksPerMonth      int64  `json:"links_per_month"`
+	RedirectsPerSecond int64  `json:"redirects_per_second"`
+}
+
+// NewWorkspaceHandler creates a new WorkspaceHandler instance.
+// Parameters:
+//   - workspaceManager: service for administering workspaces
+//   - logger: logger for recording errors
+func NewWorkspaceHandler(workspaceManager domain.WorkspaceManager, logger domain.Logger) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceManager: workspaceManager,
+		logger:           logger,
+	}
+}
+
+// List handles GET requests to list all workspaces with their quotas.
+//
+// HTTP Responses:
+//   - 200 OK: returns a JSON array of Workspace
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *WorkspaceHandler) List(w http.ResponseWriter, r *http.Request) {
+	workspaces, err := h.workspaceManager.ListWorkspaces(r.Context())
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to list workspaces: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, workspaces)
+}
+
+// Create handles POST requests to create a workspace.
+// It expects a JSON body with the name and the quotas of the workspace.
+//
+// HTTP Responses:
+//   - 201 Created: workspace successfully created, returns Workspace JSON
+//   - 400 Bad Request: invalid request payload, missing or too long name or negative quota
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *WorkspaceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req WorkspaceRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	workspace, err := h.workspaceManager.CreateWorkspace(r.Context(), domain.Workspace{
+		Name:               req.Name,
+		LinksPerMonth:      req.LinksPerMonth,
+		RedirectsPerSecond: req.RedirectsPerSecond,
+	})
+	if errors.Is(err, &domain.InvalidWorkspaceError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to create workspace: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, workspace)
+}
+
+// Update handles PUT requests to replace the name and the quotas of a workspace by its ID.
+// It expects the same JSON body as Create.
+//
+// HTTP Responses:
+//   - 200 OK: workspace successfully updated, returns Workspace JSON
+//   - 400 Bad Request: the workspace ID is not a number, invalid request payload, missing or too long name or negative quota
+//   - 404 Not Found: the workspace does not exist
+//   - 500 Internal Server Error: unexpected error occurred
+func (h *WorkspaceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue(domain.WorkspaceIdStr), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid workspace id", http.StatusBadRequest)
+		return
+	}
+
+	var req WorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	workspace, err := h.workspaceManager.UpdateWorkspace(r.Context(), domain.Workspace{
+		Id:                 id,
+		Name:               req.Name,
+		LinksPerMonth:      req.LinksPerMonth,
+		RedirectsPerSecond: req.RedirectsPerSecond,
+	})
+	if errors.Is(err, &domain.InvalidWorkspaceError{}) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if errors.Is(err, &domain.WorkspaceNonExistingError{}) {
+		http.Error(w, "Workspace not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to update workspace: %v", err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, workspace)
+}
+
+// writeJSON writes the value as a JSON response with the given status code.
+func (h *WorkspaceHandler) writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to encode response: %v", err))
+	}
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleWorkspace = domain.Workspace{
+	Id:                 7,
+	Name:               "acme",
+	LinksPerMonth:      1000,
+	RedirectsPerSecond: 50,
+}
+
+func TestWorkspaceHandler_List(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		expectedStatus     int
+		expectedWorkspaces []domain.Workspace
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger)
+	}
+
+	testCases := []testCase{
+		{
+			name:               "Success",
+			expectedStatus:     http.StatusOK,
+			expectedWorkspaces: []domain.Workspace{exampleWorkspace},
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().ListWorkspaces(gomock.Any()).Return([]domain.Workspace{exampleWorkspace}, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().ListWorkspaces(gomock.Any()).Return(nil, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewWorkspaceHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/workspaces", nil)
+			w := httptest.NewRecorder()
+
+			handler.List(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedWorkspaces != nil {
+				var workspaces []domain.Workspace
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&workspaces))
+				assert.Equal(t, tt.expectedWorkspaces, workspaces)
+			}
+		})
+	}
+}
+
+func TestWorkspaceHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger)
+	}
+
+	newWorkspace := domain.Workspace{Name: "acme", LinksPerMonth: 1000, RedirectsPerSecond: 50}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			requestBody:    `{"name":"acme","links_per_month":1000,"redirects_per_second":50}`,
+			expectedStatus: http.StatusCreated,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().CreateWorkspace(gomock.Any(), newWorkspace).Return(exampleWorkspace, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidJSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidWorkspace",
+			requestBody:    `{"name":"acme","links_per_month":-1}`,
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().CreateWorkspace(gomock.Any(), gomock.Any()).Return(domain.Workspace{}, &domain.InvalidWorkspaceError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			requestBody:    `{"name":"acme","links_per_month":1000,"redirects_per_second":50}`,
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().CreateWorkspace(gomock.Any(), newWorkspace).Return(domain.Workspace{}, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewWorkspaceHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/workspaces", bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.Create(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var workspace domain.Workspace
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&workspace))
+				assert.Equal(t, exampleWorkspace, workspace)
+			}
+		})
+	}
+}
+
+func TestWorkspaceHandler_Update(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		workspaceId    string
+		requestBody    string
+		expectedStatus int
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger)
+	}
+
+	updatedWorkspace := domain.Workspace{Id: 7, Name: "acme", LinksPerMonth: 1000, RedirectsPerSecond: 50}
+
+	testCases := []testCase{
+		{
+			name:           "Success",
+			workspaceId:    "7",
+			requestBody:    `{"name":"acme","links_per_month":1000,"redirects_per_second":50}`,
+			expectedStatus: http.StatusOK,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().UpdateWorkspace(gomock.Any(), updatedWorkspace).Return(exampleWorkspace, nil)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidId",
+			workspaceId:    "abc",
+			requestBody:    `{"name":"acme"}`,
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidJSON",
+			workspaceId:    "7",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InvalidWorkspace",
+			workspaceId:    "7",
+			requestBody:    `{"name":""}`,
+			expectedStatus: http.StatusBadRequest,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().UpdateWorkspace(gomock.Any(), gomock.Any()).Return(domain.Workspace{}, &domain.InvalidWorkspaceError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "NotFound",
+			workspaceId:    "7",
+			requestBody:    `{"name":"acme","links_per_month":1000,"redirects_per_second":50}`,
+			expectedStatus: http.StatusNotFound,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().UpdateWorkspace(gomock.Any(), updatedWorkspace).Return(domain.Workspace{}, &domain.WorkspaceNonExistingError{})
+
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+				return manager, logger
+			},
+		},
+		{
+			name:           "InternalError",
+			workspaceId:    "7",
+			requestBody:    `{"name":"acme","links_per_month":1000,"redirects_per_second":50}`,
+			expectedStatus: http.StatusInternalServerError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.WorkspaceManager, domain.Logger) {
+				manager := mocks.NewMockWorkspaceManager(ctrl)
+				manager.EXPECT().UpdateWorkspace(gomock.Any(), updatedWorkspace).Return(domain.Workspace{}, assert.AnError)
+
+				logger := mocks.NewMockLogger(ctrl)
+				logger.EXPECT().Error(gomock.Any())
+				return manager, logger
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			managerMock, loggerMock := tt.prepareMocks(t, ctrl)
+			handler := NewWorkspaceHandler(managerMock, loggerMock)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/workspaces/"+tt.workspaceId, bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req.SetPathValue(domain.WorkspaceIdStr, tt.workspaceId)
+			w := httptest.NewRecorder()
+
+			handler.Update(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -10,31 +10,92 @@ import (
 )
 
 // HandlersServer is the HTTP server that handles all URL shortening service endpoints.
-// It registers handlers for URL creation, retrieval, update, deletion, and statistics.
+// It registers handlers for URL creation, retrieval, reading, listing, update, deletion, restoration, statistics, abuse reports
+// and the administration of destination policy rules, takedowns, API keys, workspaces and custom short domains.
+// All routes except redirects and abuse reports require an API key, see routeScopes.
+// Routes addressing a short URL by its token look it up on the custom short domain the request is made to.
 type HandlersServer struct {
 	mux    *http.ServeMux
 	server *http.Server
 
 	urlAdder        domain.UrlShortener
 	urlGetter       domain.UrlGetter
+	flaggedGetter   domain.FlaggedUrlGetter
+	urlUnlocker     domain.UrlUnlocker
+	urlReader       domain.UrlReader
+	tokenValidator  domain.TokenValidator
+	suggestTokens   bool
 	urlUpdater      domain.UrlUpdater
 	urlDeleter      domain.UrlDeleter
+	urlRestorer     domain.UrlRestorer
 	statsSender     domain.StatisticsSender
 	statsCalculator domain.StatisticsCalculator
+	ruleManager     domain.DestinationRuleManager
+	abuseReporter   domain.AbuseReporter
+	moderator       domain.AbuseModerator
+	authenticator   domain.ApiKeyAuthenticator
+	keyManager      domain.ApiKeyManager
+	workspaces      domain.WorkspaceManager
+	shortDomains    domain.ShortDomainManager
+	domainResolver  domain.ShortDomainResolver
+	shortUrls       domain.ShortUrlFormatter
 	logger          domain.Logger
 	port            string
 
 	once *sync.Once
 }
 
+// routeScopes are the API key scopes required by the protected routes.
+// Routes missing here, such as redirects, unlocking and abuse reports, are public.
+var routeScopes = map[string]domain.ApiKeyScope{
+	domain.ShortenUrlAddress:            domain.ScopeLinksWrite,
+	domain.GetUrlAddress:                domain.ScopeLinksRead,
+	domain.ListUrlsAddress:              domain.ScopeLinksRead,
+	domain.UpdateUrlAddress:             domain.ScopeLinksWrite,
+	domain.DeleteUrlAddress:             domain.ScopeLinksWrite,
+	domain.RestoreUrlAddress:            domain.ScopeLinksWrite,
+	domain.StatsUrlAddress:              domain.ScopeStatsRead,
+	domain.ListDestinationRulesAddress:  domain.ScopeAdmin,
+	domain.AddDestinationRuleAddress:    domain.ScopeAdmin,
+	domain.DeleteDestinationRuleAddress: domain.ScopeAdmin,
+	domain.ListAbuseReportsAddress:      domain.ScopeAdmin,
+	domain.DismissAbuseReportAddress:    domain.ScopeAdmin,
+	domain.TakeDownUrlAddress:           domain.ScopeAdmin,
+	domain.ReinstateUrlAddress:          domain.ScopeAdmin,
+	domain.ListApiKeysAddress:           domain.ScopeAdmin,
+	domain.CreateApiKeyAddress:          domain.ScopeAdmin,
+	domain.RevokeApiKeyAddress:          domain.ScopeAdmin,
+	domain.ListWorkspacesAddress:        domain.ScopeAdmin,
+	domain.CreateWorkspaceAddress:       domain.ScopeAdmin,
+	domain.UpdateWorkspaceAddress:       domain.ScopeAdmin,
+	domain.ListShortDomainsAddress:      domain.ScopeAdmin,
+	domain.AddShortDomainAddress:        domain.ScopeAdmin,
+	domain.DeleteShortDomainAddress:     domain.ScopeAdmin,
+}
+
 // NewSimpleServer creates a new HandlersServer instance with all required dependencies.
 func NewSimpleServer(
 	urlAdder domain.UrlShortener,
 	urlGetter domain.UrlGetter,
+	flaggedGetter domain.FlaggedUrlGetter,
+	urlUnlocker domain.UrlUnlocker,
+	urlReader domain.UrlReader,
+	tokenValidator domain.TokenValidator,
+	suggestTokens bool,
 	urlUpdater domain.UrlUpdater,
 	urlDeleter domain.UrlDeleter,
+	urlRestorer domain.UrlRestorer,
 	statsSender domain.StatisticsSender,
 	statsCalculator domain.StatisticsCalculator,
+	ruleManager domain.DestinationRuleManager,
+	abuseReporter domain.AbuseReporter,
+	moderator domain.AbuseModerator,
+	authenticator domain.ApiKeyAuthenticator,
+	keyManager domain.ApiKeyManager,
+	workspaces domain.WorkspaceManager,
+	shortDomains domain.ShortDomainManager,
+	domainResolver domain.ShortDomainResolver,
+	shortUrls domain.ShortUrlFormatter,
 	logger domain.Logger,
 	port string,
 ) *HandlersServer {
@@ -42,10 +103,25 @@ func NewSimpleServer(
 		mux:             http.NewServeMux(),
 		urlAdder:        urlAdder,
 		urlGetter:       urlGetter,
+		flaggedGetter:   flaggedGetter,
+		urlUnlocker:     urlUnlocker,
+		urlReader:       urlReader,
+		tokenValidator:  tokenValidator,
+		suggestTokens:   suggestTokens,
 		urlUpdater:      urlUpdater,
 		urlDeleter:      urlDeleter,
+		urlRestorer:     urlRestorer,
 		statsSender:     statsSender,
 		statsCalculator: statsCalculator,
+		ruleManager:     ruleManager,
+		abuseReporter:   abuseReporter,
+		moderator:       moderator,
+		authenticator:   authenticator,
+		keyManager:      keyManager,
+		workspaces:      workspaces,
+		shortDomains:    shortDomains,
+		domainResolver:  domainResolver,
+		shortUrls:       shortUrls,
 		logger:          logger,
 		once:            &sync.Once{},
 		port:            port,
@@ -56,21 +132,52 @@ func NewSimpleServer(
 // The server listens on the configured port and blocks until an error occurs.
 func (s *HandlersServer) Start() {
 	mux := http.NewServeMux()
-	shortenUrlHandler := handlers.NewAddUrlHandler(s.urlAdder, s.logger)
-	redirectHandler := handlers.NewRedirectHandler(s.urlGetter, s.statsSender, s.logger)
-	updateUrlHandler := handlers.NewUpdateUrlHandler(s.urlUpdater, s.logger)
+	shortenUrlHandler := handlers.NewAddUrlHandler(s.urlAdder, s.shortUrls, s.logger)
+	redirectHandler := handlers.NewRedirectHandler(s.urlGetter, s.flaggedGetter, s.urlUnlocker, s.tokenValidator, s.suggestTokens, s.statsSender, s.logger)
+	readUrlHandler := handlers.NewReadUrlHandler(s.urlReader, s.shortUrls, s.logger)
+	updateUrlHandler := handlers.NewUpdateUrlHandler(s.urlUpdater, s.shortUrls, s.logger)
 	deleteUrlHandler := handlers.NewDeleteUrlHandler(s.urlDeleter, s.logger)
+	restoreUrlHandler := handlers.NewRestoreUrlHandler(s.urlRestorer, s.logger)
 	statsHandler := handlers.NewStatsShowHandler(s.statsCalculator, s.logger)
+	destinationRulesHandler := handlers.NewDestinationRulesHandler(s.ruleManager, s.logger)
+	abuseReportHandler := handlers.NewAbuseReportHandler(s.abuseReporter, s.logger)
+	moderationHandler := handlers.NewModerationHandler(s.moderator, s.logger)
+	apiKeyHandler := handlers.NewApiKeyHandler(s.keyManager, s.logger)
+	workspaceHandler := handlers.NewWorkspaceHandler(s.workspaces, s.logger)
+	shortDomainHandler := handlers.NewShortDomainHandler(s.shortDomains, s.logger)
+	authMiddleware := handlers.NewAuthMiddleware(s.authenticator, routeScopes, s.logger)
+	shortDomainMiddleware := handlers.NewShortDomainMiddleware(s.domainResolver)
 
 	mux.HandleFunc(domain.ShortenUrlAddress, shortenUrlHandler.Create)
 	mux.HandleFunc(domain.RedirectAddress, redirectHandler.Redirect)
+	mux.HandleFunc(domain.UnlockAddress, redirectHandler.Unlock)
+	mux.HandleFunc(domain.GetUrlAddress, readUrlHandler.Get)
+	mux.HandleFunc(domain.ListUrlsAddress, readUrlHandler.List)
 	mux.HandleFunc(domain.UpdateUrlAddress, updateUrlHandler.Update)
 	mux.HandleFunc(domain.DeleteUrlAddress, deleteUrlHandler.Delete)
+	mux.HandleFunc(domain.RestoreUrlAddress, restoreUrlHandler.Restore)
 	mux.HandleFunc(domain.StatsUrlAddress, statsHandler.Show)
+	mux.HandleFunc(domain.ListDestinationRulesAddress, destinationRulesHandler.List)
+	mux.HandleFunc(domain.AddDestinationRuleAddress, destinationRulesHandler.Create)
+	mux.HandleFunc(domain.DeleteDestinationRuleAddress, destinationRulesHandler.Delete)
+	mux.HandleFunc(domain.ReportAbuseAddress, abuseReportHandler.Report)
+	mux.HandleFunc(domain.ListAbuseReportsAddress, moderationHandler.ListReports)
+	mux.HandleFunc(domain.DismissAbuseReportAddress, moderationHandler.DismissReport)
+	mux.HandleFunc(domain.TakeDownUrlAddress, moderationHandler.TakeDown)
+	mux.HandleFunc(domain.ReinstateUrlAddress, moderationHandler.Reinstate)
+	mux.HandleFunc(domain.ListApiKeysAddress, apiKeyHandler.List)
+	mux.HandleFunc(domain.CreateApiKeyAddress, apiKeyHandler.Create)
+	mux.HandleFunc(domain.RevokeApiKeyAddress, apiKeyHandler.Revoke)
+	mux.HandleFunc(domain.ListWorkspacesAddress, workspaceHandler.List)
+	mux.HandleFunc(domain.CreateWorkspaceAddress, workspaceHandler.Create)
+	mux.HandleFunc(domain.UpdateWorkspaceAddress, workspaceHandler.Update)
+	mux.HandleFunc(domain.ListShortDomainsAddress, shortDomainHandler.List)
+	mux.HandleFunc(domain.AddShortDomainAddress, shortDomainHandler.Create)
+	mux.HandleFunc(domain.DeleteShortDomainAddress, shortDomainHandler.Delete)
 
 	s.server = &http.Server{
 		Addr:    ":" + s.port,
-		Handler: mux,
+		Handler: shortDomainMiddleware.Wrap(authMiddleware.Wrap(mux)),
 	}
 
 	if err := s.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
@@ -0,0 +1,152 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// leaseRefillTimeout bounds a background reservation of the spare lease.
+	leaseRefillTimeout = 5 * time.Second
+	// leaseReservationKey is the key concurrent synchronous lease reservations are shared under.
+	leaseReservationKey = "lease"
+)
+
+// idLease is a range of reserved IDs from next up to, but excluding, end.
+// The zero value is an exhausted lease.
+type idLease struct {
+	next int64
+	end  int64
+}
+
+// remaining returns the number of IDs left in the lease.
+func (l idLease) remaining() int64 {
+	return l.end - l.next
+}
+
+// IdLeaser hands out mapping IDs from ranges reserved from a shared ID counter (e.g., Redis).
+// Once half of the current lease is used, a spare lease is reserved in the background,
+// so IDs keep being handed out without touching the counter during short counter outages.
+// IDs of leases that are not used up before the process stops are never handed out.
+// Leases are reserved without holding the lock, so IDs left in the current lease are handed out
+// while a reservation waits for the counter.
+type IdLeaser struct {
+	reserver     domain.IdRangeReserver
+	leaseSize    int64
+	logger       domain.Logger
+	reservations singleflight.Group
+
+	mu        sync.Mutex
+	current   idLease
+	spare     idLease
+	refilling bool
+}
+
+// NewIdLeaser creates a new IdLeaser instance.
+// Parameters:
+//   - reserver: shared counter ID ranges are reserved from
+//   - leaseSize: number of IDs reserved at once
+//   - logger: logger for recording warnings
+func NewIdLeaser(reserver domain.IdRangeReserver, leaseSize int64, logger domain.Logger) *IdLeaser {
+	return &IdLeaser{
+		reserver:  reserver,
+		leaseSize: leaseSize,
+		logger:    logger,
+	}
+}
+
+// GetNextId returns the next ID of the current lease. An exhausted lease is replaced by the spare lease,
+// or by a lease reserved synchronously if the spare lease is not available yet.
+// Concurrent callers share a single synchronous reservation, which is detached from the cancellation
+// of the caller that started it.
+//
+// Returns an error if the current lease is exhausted, no spare lease is available
+// and reserving a new lease fails.
+func (l *IdLeaser) GetNextId(ctx context.Context) (int64, error) {
+	l.mu.Lock()
+	for l.current.remaining() <= 0 {
+		if l.spare.remaining() > 0 {
+			l.current, l.spare = l.spare, idLease{}
+			break
+		}
+
+		l.mu.Unlock()
+		_, err, _ := l.reservations.Do(leaseReservationKey, func() (interface{}, error) {
+			return nil, l.reserveAndInstallLease(context.WithoutCancel(ctx))
+		})
+		if err != nil {
+			return 0, err
+		}
+		l.mu.Lock()
+	}
+	defer l.mu.Unlock()
+
+	id := l.current.next
+	l.current.next++
+
+	if l.current.remaining() <= l.leaseSize/2 && l.spare.remaining() <= 0 && !l.refilling {
+		l.refilling = true
+		go l.refillSpare()
+	}
+
+	return id, nil
+}
+
+// reserveAndInstallLease reserves a lease without holding the lock and installs it.
+func (l *IdLeaser) reserveAndInstallLease(ctx context.Context) error {
+	lease, err := l.reserveLease(ctx)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.installLease(lease)
+	return nil
+}
+
+// refillSpare reserves the spare lease in the background.
+func (l *IdLeaser) refillSpare() {
+	ctx, cancel := context.WithTimeout(context.Background(), leaseRefillTimeout)
+	defer cancel()
+
+	lease, err := l.reserveLease(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refilling = false
+	if err != nil {
+		l.logger.Warn(fmt.Sprintf("Failed to reserve spare id lease: %v", err))
+		return
+	}
+	l.installLease(lease)
+}
+
+// installLease installs a reserved lease as the current lease if that is exhausted, or as the spare lease otherwise.
+// As reservations run without the lock, a synchronous and a background reservation may both finish;
+// a lease that finds both slots taken is dropped, and its IDs are never handed out.
+// The caller must hold the lock.
+func (l *IdLeaser) installLease(lease idLease) {
+	if l.current.remaining() <= 0 {
+		l.current = lease
+	} else if l.spare.remaining() <= 0 {
+		l.spare = lease
+	}
+}
+
+// reserveLease reserves a lease of leaseSize IDs from the shared counter.
+func (l *IdLeaser) reserveLease(ctx context.Context) (idLease, error) {
+	last, err := l.reserver.ReserveIds(ctx, l.leaseSize)
+	if err != nil {
+		return idLease{}, fmt.Errorf("reserving id lease: %w", err)
+	}
+
+	return idLease{next: last - l.leaseSize + 1, end: last + 1}, nil
+}
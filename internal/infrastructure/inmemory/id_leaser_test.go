@@ -0,0 +1,144 @@
+package inmemory
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLeaseSize = 4
+
+func newTestIdLeaser(ctrl *gomock.Controller) (*IdLeaser, *mocks.MockIdRangeReserver) {
+	reserver := mocks.NewMockIdRangeReserver(ctrl)
+	return NewIdLeaser(reserver, testLeaseSize, slog.New(slog.NewTextHandler(io.Discard, nil))), reserver
+}
+
+// waitForRefill waits until the background reservation of the spare lease has finished.
+func waitForRefill(t *testing.T, leaser *IdLeaser) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		leaser.mu.Lock()
+		defer leaser.mu.Unlock()
+		return !leaser.refilling
+	}, time.Second, time.Millisecond)
+}
+
+func TestIdLeaser_GetNextId(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	leaser, reserver := newTestIdLeaser(ctrl)
+
+	gomock.InOrder(
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(testLeaseSize)).Return(int64(4), nil),
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(testLeaseSize)).Return(int64(12), nil),
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(testLeaseSize)).Return(int64(16), nil),
+	)
+
+	var ids []int64
+	for i := 0; i < 6; i++ {
+		id, err := leaser.GetNextId(context.Background())
+		require.NoError(t, err)
+		ids = append(ids, id)
+		waitForRefill(t, leaser)
+	}
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 9, 10}, ids)
+}
+
+func TestIdLeaser_SurvivesCounterOutage(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	leaser, reserver := newTestIdLeaser(ctrl)
+
+	gomock.InOrder(
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(testLeaseSize)).Return(int64(4), nil),
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(testLeaseSize)).Return(int64(8), nil),
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(testLeaseSize)).Return(int64(0), assert.AnError).AnyTimes(),
+	)
+
+	var ids []int64
+	for i := 0; i < 8; i++ {
+		id, err := leaser.GetNextId(context.Background())
+		require.NoError(t, err)
+		ids = append(ids, id)
+		waitForRefill(t, leaser)
+	}
+
+	_, err := leaser.GetNextId(context.Background())
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8}, ids)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestIdLeaser_HandsOutIdsWhileReserving(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	reserver := mocks.NewMockIdRangeReserver(ctrl)
+	leaser := NewIdLeaser(reserver, 2, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	refillStarted := make(chan struct{})
+	refillGate := make(chan struct{})
+	reservationStarted := make(chan struct{})
+	reservationGate := make(chan struct{})
+	gomock.InOrder(
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(2)).Return(int64(2), nil),
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(2)).DoAndReturn(func(_ context.Context, _ int64) (int64, error) {
+			close(refillStarted)
+			<-refillGate
+			return 4, nil
+		}),
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(2)).DoAndReturn(func(_ context.Context, _ int64) (int64, error) {
+			close(reservationStarted)
+			<-reservationGate
+			return 6, nil
+		}),
+		reserver.EXPECT().ReserveIds(gomock.Any(), int64(2)).Return(int64(8), nil).AnyTimes(),
+	)
+
+	// IDs 1 and 2 are handed out from the first lease, starting the background refill of the spare lease.
+	for _, expected := range []int64{1, 2} {
+		id, err := leaser.GetNextId(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expected, id)
+	}
+
+	// With the refill still pending, the next caller reserves a lease synchronously.
+	<-refillStarted
+	blockedId := make(chan int64)
+	go func() {
+		id, err := leaser.GetNextId(context.Background())
+		assert.NoError(t, err)
+		blockedId <- id
+	}()
+	<-reservationStarted
+
+	// The refill finishes while the synchronous reservation waits, and its IDs are handed out right away.
+	close(refillGate)
+	waitForRefill(t, leaser)
+	nextId := make(chan int64)
+	go func() {
+		id, err := leaser.GetNextId(context.Background())
+		assert.NoError(t, err)
+		nextId <- id
+	}()
+	select {
+	case id := <-nextId:
+		assert.Equal(t, int64(3), id)
+	case <-time.After(time.Second):
+		t.Error("no ID was handed out while a lease was reserved")
+	}
+
+	close(reservationGate)
+	assert.Equal(t, int64(4), <-blockedId)
+	waitForRefill(t, leaser)
+}
@@ -0,0 +1,207 @@
+package inmemory
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// CacheStats contains the counters of an in-process cache.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+	Size   int
+}
+
+// lruEntry is a mapping kept in the LRU cache together with the moment it has to be dropped.
+type lruEntry struct {
+	mapping   domain.MappingInfo
+	expiresAt time.Time
+}
+
+// LRUCache is a bounded in-process cache of URL mappings stacked in front of another cache tier (e.g., Redis).
+// It evicts the least recently used mapping once it is full and drops mappings after a TTL.
+// Deletions are propagated to the in-process caches of all replicas through an invalidation publisher,
+// while the TTL bounds the staleness of replicas that miss an invalidation.
+type LRUCache struct {
+	next        domain.MappingCache
+	invalidator domain.InvalidationPublisher
+	size        int
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewLRUCache creates a new LRUCache instance.
+// Parameters:
+//   - next: cache tier the LRU cache falls back to and writes through to
+//   - invalidator: publisher propagating deletions to all replicas
+//   - size: maximum number of mappings kept in memory
+//   - ttl: maximum time a mapping is kept in memory
+func NewLRUCache(next domain.MappingCache, invalidator domain.InvalidationPublisher, size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		next:        next,
+		invalidator: invalidator,
+		size:        size,
+		ttl:         ttl,
+		now:         time.Now,
+		order:       list.New(),
+		entries:     make(map[string]*list.Element, size),
+	}
+}
+
+// GetMapping retrieves the mapping for a given short URL token from memory,
+// falling back to the next cache tier and keeping the mapping found there in memory.
+// Negative entries are not kept in memory and are always looked up in the next cache tier.
+// Returns the mapping and the outcome of the lookup.
+//
+// Returns an error if:
+//   - The mapping is not in memory and the next cache tier fails
+func (c *LRUCache) GetMapping(ctx context.Context, urlToken string) (domain.MappingInfo, domain.CacheLookup, error) {
+	mapping, found := c.get(urlToken)
+	if found {
+		c.hits.Add(1)
+		return mapping, domain.CacheHit, nil
+	}
+
+	c.misses.Add(1)
+	mapping, lookup, err := c.next.GetMapping(ctx, urlToken)
+	if err != nil {
+		return domain.MappingInfo{}, domain.CacheMiss, err
+	}
+	if lookup == domain.CacheHit {
+		c.put(mapping)
+	}
+
+	return mapping, lookup, nil
+}
+
+// SetMapping stores the mapping in memory and in the next cache tier.
+//
+// Returns an error if:
+//   - Storing the mapping in the next cache tier fails
+func (c *LRUCache) SetMapping(ctx context.Context, mapping domain.MappingInfo) error {
+	c.put(mapping)
+	return c.next.SetMapping(ctx, mapping)
+}
+
+// SetMissing stores a negative entry for the token in the next cache tier.
+//
+// Returns an error if:
+//   - Storing the negative entry in the next cache tier fails
+func (c *LRUCache) SetMissing(ctx context.Context, urlToken string) error {
+	return c.next.SetMissing(ctx, urlToken)
+}
+
+// DeleteMapping removes the mapping from memory and from the next cache tier,
+// and publishes an invalidation so all replicas drop the mapping from memory.
+// The invalidation is published even if the next cache tier does not hold the mapping,
+// as other replicas may still keep it in memory.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist in the next cache tier
+//   - Deleting the mapping from the next cache tier or publishing the invalidation fails
+func (c *LRUCache) DeleteMapping(ctx context.Context, urlToken string) error {
+	c.Invalidate(urlToken)
+
+	deleteErr := c.next.DeleteMapping(ctx, urlToken)
+	if deleteErr != nil && !errors.Is(deleteErr, &domain.TokenNonExistingError{}) {
+		return deleteErr
+	}
+
+	err := c.invalidator.PublishInvalidation(ctx, urlToken)
+	if err != nil {
+		return fmt.Errorf("propagating deletion of cached mapping: %w", err)
+	}
+
+	return deleteErr
+}
+
+// Invalidate drops the mapping of the token from memory only.
+// It is called for invalidations received from other replicas.
+func (c *LRUCache) Invalidate(urlToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, found := c.entries[urlToken]; found {
+		c.removeElement(elem)
+	}
+}
+
+// Stats returns the hit and miss counters and the current number of mappings in memory.
+func (c *LRUCache) Stats() CacheStats {
+	c.mu.Lock()
+	size := c.order.Len()
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   size,
+	}
+}
+
+// get returns a mapping kept in memory that has not reached its TTL yet and marks it as recently used.
+func (c *LRUCache) get(urlToken string) (domain.MappingInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, found := c.entries[urlToken]
+	if !found {
+		return domain.MappingInfo{}, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.removeElement(elem)
+		return domain.MappingInfo{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.mapping, true
+}
+
+// put keeps a mapping in memory until its TTL or its own expiration time, whichever comes first,
+// evicting the least recently used mapping if the cache is full. Expired mappings are not kept.
+func (c *LRUCache) put(mapping domain.MappingInfo) {
+	now := c.now()
+	expiresAt := now.Add(c.ttl)
+	if mapping.ExpiresAt != nil && mapping.ExpiresAt.Before(expiresAt) {
+		expiresAt = *mapping.ExpiresAt
+	}
+	if !now.Before(expiresAt) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{mapping: mapping, expiresAt: expiresAt}
+	if elem, found := c.entries[mapping.Key()]; found {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[mapping.Key()] = c.order.PushFront(entry)
+	if c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// removeElement drops an element from memory. The caller must hold the lock.
+func (c *LRUCache) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).mapping.Key())
+}
@@ -0,0 +1,316 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCacheSize = 2
+	testCacheTTL  = 30 * time.Second
+)
+
+func newTestLRUCache(ctrl *gomock.Controller) (*LRUCache, *mocks.MockMappingCache, *mocks.MockInvalidationPublisher, *time.Time) {
+	next := mocks.NewMockMappingCache(ctrl)
+	invalidator := mocks.NewMockInvalidationPublisher(ctrl)
+	now := time.Date(2025, 12, 28, 10, 0, 0, 0, time.UTC)
+
+	cache := NewLRUCache(next, invalidator, testCacheSize, testCacheTTL)
+	cache.now = func() time.Time { return now }
+
+	return cache, next, invalidator, &now
+}
+
+func TestLRUCache_GetMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com", Token: "abc123"}
+
+	type testCase struct {
+		name           string
+		urlToken       string
+		advance        time.Duration
+		expectedLookup domain.CacheLookup
+		expectedStats  CacheStats
+
+		setupMocks func(t *testing.T, next *mocks.MockMappingCache)
+	}
+
+	testCases := []testCase{
+		{
+			name:           "mapping kept in memory after miss is served from memory",
+			urlToken:       "abc123",
+			expectedLookup: domain.CacheHit,
+			expectedStats:  CacheStats{Hits: 1, Misses: 1, Size: 1},
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache) {
+				next.EXPECT().GetMapping(gomock.Any(), "abc123").Return(mapping, domain.CacheHit, nil).Times(1)
+			},
+		},
+		{
+			name:           "mapping reaching ttl is reloaded from next tier",
+			urlToken:       "abc123",
+			advance:        testCacheTTL,
+			expectedLookup: domain.CacheHit,
+			expectedStats:  CacheStats{Hits: 0, Misses: 2, Size: 1},
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache) {
+				next.EXPECT().GetMapping(gomock.Any(), "abc123").Return(mapping, domain.CacheHit, nil).Times(2)
+			},
+		},
+		{
+			name:           "mapping missing in next tier is not kept",
+			urlToken:       "nonexistent",
+			expectedLookup: domain.CacheMiss,
+			expectedStats:  CacheStats{Hits: 0, Misses: 2, Size: 0},
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache) {
+				next.EXPECT().GetMapping(gomock.Any(), "nonexistent").Return(domain.MappingInfo{}, domain.CacheMiss, nil).Times(2)
+			},
+		},
+		{
+			name:           "negative entry is not kept in memory",
+			urlToken:       "missing",
+			expectedLookup: domain.CacheKnownMissing,
+			expectedStats:  CacheStats{Hits: 0, Misses: 2, Size: 0},
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache) {
+				next.EXPECT().GetMapping(gomock.Any(), "missing").Return(domain.MappingInfo{}, domain.CacheKnownMissing, nil).Times(2)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			cache, next, _, now := newTestLRUCache(ctrl)
+			tt.setupMocks(t, next)
+
+			_, lookup, _ := cache.GetMapping(context.Background(), tt.urlToken)
+			assert.Equal(t, tt.expectedLookup, lookup)
+
+			*now = now.Add(tt.advance)
+			_, lookup, _ = cache.GetMapping(context.Background(), tt.urlToken)
+			assert.Equal(t, tt.expectedLookup, lookup)
+
+			assert.Equal(t, tt.expectedStats, cache.Stats())
+		})
+	}
+}
+
+func TestLRUCache_GetMapping_NextTierError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	cache, next, _, _ := newTestLRUCache(ctrl)
+	next.EXPECT().GetMapping(gomock.Any(), "abc123").Return(domain.MappingInfo{}, domain.CacheMiss, assert.AnError)
+
+	_, lookup, err := cache.GetMapping(context.Background(), "abc123")
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, domain.CacheMiss, lookup)
+	assert.Equal(t, CacheStats{Hits: 0, Misses: 1, Size: 0}, cache.Stats())
+}
+
+func TestLRUCache_SetMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com", Token: "abc123"}
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		setupMocks func(t *testing.T, next *mocks.MockMappingCache)
+	}
+
+	testCases := []testCase{
+		{
+			name:          "mapping written through and kept in memory",
+			expectedError: nil,
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache) {
+				next.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
+			},
+		},
+		{
+			name:          "next tier error returned and mapping kept in memory",
+			expectedError: assert.AnError,
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache) {
+				next.EXPECT().SetMapping(gomock.Any(), mapping).Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			cache, next, _, _ := newTestLRUCache(ctrl)
+			tt.setupMocks(t, next)
+
+			err := cache.SetMapping(context.Background(), mapping)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			got, lookup, _ := cache.GetMapping(context.Background(), "abc123")
+			assert.Equal(t, domain.CacheHit, lookup)
+			assert.Equal(t, mapping, got)
+		})
+	}
+}
+
+func TestLRUCache_SetMapping_KeepsMappingUntilItExpires(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	cache, next, _, now := newTestLRUCache(ctrl)
+	expiresAt := now.Add(10 * time.Second)
+	mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com", Token: "exp123", ExpiresAt: &expiresAt}
+
+	next.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
+	next.EXPECT().GetMapping(gomock.Any(), "exp123").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+
+	err := cache.SetMapping(context.Background(), mapping)
+	assert.NoError(t, err)
+
+	*now = expiresAt
+	_, lookup, _ := cache.GetMapping(context.Background(), "exp123")
+	assert.Equal(t, domain.CacheMiss, lookup)
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	cache, next, _, _ := newTestLRUCache(ctrl)
+	next.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	next.EXPECT().GetMapping(gomock.Any(), "b").Return(domain.MappingInfo{}, domain.CacheMiss, nil)
+
+	assert.NoError(t, cache.SetMapping(context.Background(), domain.MappingInfo{Token: "a"}))
+	assert.NoError(t, cache.SetMapping(context.Background(), domain.MappingInfo{Token: "b"}))
+
+	_, lookup, _ := cache.GetMapping(context.Background(), "a")
+	assert.Equal(t, domain.CacheHit, lookup)
+
+	assert.NoError(t, cache.SetMapping(context.Background(), domain.MappingInfo{Token: "c"}))
+
+	_, lookup, _ = cache.GetMapping(context.Background(), "b")
+	assert.Equal(t, domain.CacheMiss, lookup)
+	_, lookup, _ = cache.GetMapping(context.Background(), "a")
+	assert.Equal(t, domain.CacheHit, lookup)
+	_, lookup, _ = cache.GetMapping(context.Background(), "c")
+	assert.Equal(t, domain.CacheHit, lookup)
+
+	assert.Equal(t, CacheStats{Hits: 3, Misses: 1, Size: testCacheSize}, cache.Stats())
+}
+
+func TestLRUCache_SetMissing(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	cache, next, _, _ := newTestLRUCache(ctrl)
+	next.EXPECT().SetMissing(gomock.Any(), "missing").Return(assert.AnError)
+
+	err := cache.SetMissing(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 0, cache.Stats().Size)
+}
+
+func TestLRUCache_DeleteMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com", Token: "abc123"}
+
+	type testCase struct {
+		name          string
+		expectedError error
+
+		setupMocks func(t *testing.T, next *mocks.MockMappingCache, invalidator *mocks.MockInvalidationPublisher)
+	}
+
+	testCases := []testCase{
+		{
+			name:          "mapping deleted and invalidation published",
+			expectedError: nil,
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache, invalidator *mocks.MockInvalidationPublisher) {
+				next.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+				invalidator.EXPECT().PublishInvalidation(gomock.Any(), "abc123").Return(nil)
+			},
+		},
+		{
+			name:          "mapping missing in next tier still publishes invalidation",
+			expectedError: &domain.TokenNonExistingError{},
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache, invalidator *mocks.MockInvalidationPublisher) {
+				next.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(&domain.TokenNonExistingError{Msg: "token not found"})
+				invalidator.EXPECT().PublishInvalidation(gomock.Any(), "abc123").Return(nil)
+			},
+		},
+		{
+			name:          "next tier error returned without publishing invalidation",
+			expectedError: assert.AnError,
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache, invalidator *mocks.MockInvalidationPublisher) {
+				next.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(assert.AnError)
+			},
+		},
+		{
+			name:          "publish error returned",
+			expectedError: assert.AnError,
+			setupMocks: func(t *testing.T, next *mocks.MockMappingCache, invalidator *mocks.MockInvalidationPublisher) {
+				next.EXPECT().DeleteMapping(gomock.Any(), "abc123").Return(nil)
+				invalidator.EXPECT().PublishInvalidation(gomock.Any(), "abc123").Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			cache, next, invalidator, _ := newTestLRUCache(ctrl)
+			next.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
+			assert.NoError(t, cache.SetMapping(context.Background(), mapping))
+			tt.setupMocks(t, next, invalidator)
+
+			err := cache.DeleteMapping(context.Background(), "abc123")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, 0, cache.Stats().Size)
+		})
+	}
+}
+
+func TestLRUCache_Invalidate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	cache, next, _, _ := newTestLRUCache(ctrl)
+	mapping := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/old", Token: "abc123"}
+	updated := domain.MappingInfo{Id: 1, OriginalURL: "https://example.com/new", Token: "abc123"}
+
+	next.EXPECT().SetMapping(gomock.Any(), mapping).Return(nil)
+	next.EXPECT().GetMapping(gomock.Any(), "abc123").Return(updated, domain.CacheHit, nil)
+
+	assert.NoError(t, cache.SetMapping(context.Background(), mapping))
+	cache.Invalidate("abc123")
+
+	got, lookup, _ := cache.GetMapping(context.Background(), "abc123")
+	assert.Equal(t, domain.CacheHit, lookup)
+	assert.Equal(t, updated, got)
+}
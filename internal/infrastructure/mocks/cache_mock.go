@@ -1,30 +1,70 @@
 package mocks
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"url-shortening-service/internal/domain"
+)
 
 // LocalCache is an in-memory mock implementation of URL mapping cache.
 // It is intended for testing purposes only.
 type LocalCache struct {
-	storage map[string]string
+	storage map[string]domain.MappingInfo
+	missing map[string]struct{}
 }
 
 // NewLocalCache creates a new LocalCache instance with an empty storage map.
 func NewLocalCache() *LocalCache {
 	return &LocalCache{
-		storage: make(map[string]string),
+		storage: make(map[string]domain.MappingInfo),
+		missing: make(map[string]struct{}),
 	}
 }
 
-// SetMapping stores a URL mapping in the local cache.
+// SetMapping stores a URL mapping in the local cache under its link key, replacing a negative entry of the key.
+// Expiration is ignored by this mock implementation.
 // Always returns nil as this mock implementation never fails.
-func (c *LocalCache) SetMapping(ctx context.Context, originalUrl, urlToken string) error {
-	c.storage[urlToken] = originalUrl
+func (c *LocalCache) SetMapping(ctx context.Context, mapping domain.MappingInfo) error {
+	delete(c.missing, mapping.Key())
+	c.storage[mapping.Key()] = mapping
 	return nil
 }
 
-// GetOriginalUrl retrieves the original URL for a given token from the local cache.
-// Returns the URL and true if found, or empty string and false if not found.
-func (c *LocalCache) GetOriginalUrl(ctx context.Context, urlToken string) (string, bool) {
-	originalUrl, found := c.storage[urlToken]
-	return originalUrl, found
+// SetMissing stores a negative entry for a token unless a mapping is cached for it.
+// Negative entries never expire in this mock implementation.
+// Always returns nil as this mock implementation never fails.
+func (c *LocalCache) SetMissing(ctx context.Context, urlToken string) error {
+	if _, found := c.storage[urlToken]; !found {
+		c.missing[urlToken] = struct{}{}
+	}
+	return nil
+}
+
+// GetMapping retrieves the mapping for a given token from the local cache.
+// Returns the mapping and CacheHit if found, empty MappingInfo and CacheKnownMissing
+// for a negative entry, or empty MappingInfo and CacheMiss otherwise.
+// Always returns a nil error as this mock implementation never fails.
+func (c *LocalCache) GetMapping(ctx context.Context, urlToken string) (domain.MappingInfo, domain.CacheLookup, error) {
+	if mapping, found := c.storage[urlToken]; found {
+		return mapping, domain.CacheHit, nil
+	}
+	if _, found := c.missing[urlToken]; found {
+		return domain.MappingInfo{}, domain.CacheKnownMissing, nil
+	}
+	return domain.MappingInfo{}, domain.CacheMiss, nil
+}
+
+// DeleteMapping removes the mapping or negative entry for a given token from the local cache.
+//
+// Returns an error if:
+//   - *domain.TokenNonExistingError: the token does not exist in the local cache
+func (c *LocalCache) DeleteMapping(ctx context.Context, urlToken string) error {
+	_, isMissing := c.missing[urlToken]
+	if _, found := c.storage[urlToken]; !found && !isMissing {
+		return &domain.TokenNonExistingError{Msg: fmt.Sprintf("URL token not found in local cache: %s", urlToken)}
+	}
+
+	delete(c.missing, urlToken)
+	delete(c.storage, urlToken)
+	return nil
 }
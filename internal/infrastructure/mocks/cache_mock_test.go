@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"testing"
+	"url-shortening-service/internal/domain"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,8 @@ func TestLocalCache_SetMapping(t *testing.T) {
 		name        string
 		originalUrl string
 		urlToken    string
+		shortDomain string
+		expectedKey string
 	}
 
 	testCases := []testCase{
@@ -22,6 +25,14 @@ func TestLocalCache_SetMapping(t *testing.T) {
 			name:        "Success - set new mapping",
 			originalUrl: "https://example.com",
 			urlToken:    "abc123",
+			expectedKey: "abc123",
+		},
+		{
+			name:        "Success - set mapping of custom short domain under its link key",
+			originalUrl: "https://example.com",
+			urlToken:    "abc123",
+			shortDomain: "go.acme.com",
+			expectedKey: "go.acme.com/abc123",
 		},
 		{
 			name:        "Success - set mapping with empty token",
@@ -32,6 +43,7 @@ func TestLocalCache_SetMapping(t *testing.T) {
 			name:        "Success - set mapping with empty url",
 			originalUrl: "",
 			urlToken:    "xyz789",
+			expectedKey: "xyz789",
 		},
 	}
 
@@ -41,10 +53,13 @@ func TestLocalCache_SetMapping(t *testing.T) {
 			t.Parallel()
 			cache := NewLocalCache()
 
-			err := cache.SetMapping(context.Background(), tt.originalUrl, tt.urlToken)
+			err := cache.SetMapping(context.Background(), domain.MappingInfo{OriginalURL: tt.originalUrl, Token: tt.urlToken, Domain: tt.shortDomain})
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.originalUrl, cache.storage[tt.urlToken])
+			mapping, lookup, err := cache.GetMapping(context.Background(), tt.expectedKey)
+			require.NoError(t, err)
+			assert.Equal(t, domain.CacheHit, lookup)
+			assert.Equal(t, tt.originalUrl, mapping.OriginalURL)
 		})
 	}
 }
@@ -53,23 +68,23 @@ func TestLocalCache_SetMapping_OverwriteExisting(t *testing.T) {
 	t.Parallel()
 
 	cache := NewLocalCache()
-	cache.storage["abc123"] = "https://old.com"
+	cache.storage["abc123"] = domain.MappingInfo{OriginalURL: "https://old.com", Token: "abc123"}
 
-	err := cache.SetMapping(context.Background(), "https://new.com", "abc123")
+	err := cache.SetMapping(context.Background(), domain.MappingInfo{OriginalURL: "https://new.com", Token: "abc123"})
 
 	require.NoError(t, err)
-	assert.Equal(t, "https://new.com", cache.storage["abc123"])
+	assert.Equal(t, "https://new.com", cache.storage["abc123"].OriginalURL)
 }
 
-func TestLocalCache_GetOriginalUrl(t *testing.T) {
+func TestLocalCache_GetMapping(t *testing.T) {
 	t.Parallel()
 
 	type testCase struct {
-		name          string
-		urlToken      string
-		setupStorage  map[string]string
-		expectedUrl   string
-		expectedFound bool
+		name           string
+		urlToken       string
+		setupStorage   map[string]string
+		expectedUrl    string
+		expectedLookup domain.CacheLookup
 	}
 
 	testCases := []testCase{
@@ -79,15 +94,15 @@ func TestLocalCache_GetOriginalUrl(t *testing.T) {
 			setupStorage: map[string]string{
 				"abc123": "https://example.com",
 			},
-			expectedUrl:   "https://example.com",
-			expectedFound: true,
+			expectedUrl:    "https://example.com",
+			expectedLookup: domain.CacheHit,
 		},
 		{
-			name:          "Not found - empty storage",
-			urlToken:      "abc123",
-			setupStorage:  map[string]string{},
-			expectedUrl:   "",
-			expectedFound: false,
+			name:           "Not found - empty storage",
+			urlToken:       "abc123",
+			setupStorage:   map[string]string{},
+			expectedUrl:    "",
+			expectedLookup: domain.CacheMiss,
 		},
 		{
 			name:     "Not found - token does not exist",
@@ -95,8 +110,8 @@ func TestLocalCache_GetOriginalUrl(t *testing.T) {
 			setupStorage: map[string]string{
 				"abc123": "https://example.com",
 			},
-			expectedUrl:   "",
-			expectedFound: false,
+			expectedUrl:    "",
+			expectedLookup: domain.CacheMiss,
 		},
 		{
 			name:     "Success - found among multiple mappings",
@@ -106,8 +121,8 @@ func TestLocalCache_GetOriginalUrl(t *testing.T) {
 				"xyz789": "https://example2.com",
 				"def456": "https://example3.com",
 			},
-			expectedUrl:   "https://example2.com",
-			expectedFound: true,
+			expectedUrl:    "https://example2.com",
+			expectedLookup: domain.CacheHit,
 		},
 	}
 
@@ -117,13 +132,80 @@ func TestLocalCache_GetOriginalUrl(t *testing.T) {
 			t.Parallel()
 			cache := NewLocalCache()
 			for token, url := range tt.setupStorage {
-				cache.storage[token] = url
+				cache.storage[token] = domain.MappingInfo{OriginalURL: url, Token: token}
 			}
 
-			url, found := cache.GetOriginalUrl(context.Background(), tt.urlToken)
+			mapping, lookup, _ := cache.GetMapping(context.Background(), tt.urlToken)
+
+			assert.Equal(t, tt.expectedLookup, lookup)
+			assert.Equal(t, tt.expectedUrl, mapping.OriginalURL)
+		})
+	}
+}
+
+func TestLocalCache_SetMissing(t *testing.T) {
+	t.Parallel()
+
+	cache := NewLocalCache()
+	cache.storage["abc123"] = domain.MappingInfo{OriginalURL: "https://example.com", Token: "abc123"}
 
-			assert.Equal(t, tt.expectedFound, found)
-			assert.Equal(t, tt.expectedUrl, url)
+	require.NoError(t, cache.SetMissing(context.Background(), "abc123"))
+	require.NoError(t, cache.SetMissing(context.Background(), "missing"))
+
+	_, lookup, _ := cache.GetMapping(context.Background(), "abc123")
+	assert.Equal(t, domain.CacheHit, lookup)
+	_, lookup, _ = cache.GetMapping(context.Background(), "missing")
+	assert.Equal(t, domain.CacheKnownMissing, lookup)
+
+	require.NoError(t, cache.SetMapping(context.Background(), domain.MappingInfo{OriginalURL: "https://new.com", Token: "missing"}))
+	_, lookup, _ = cache.GetMapping(context.Background(), "missing")
+	assert.Equal(t, domain.CacheHit, lookup)
+}
+
+func TestLocalCache_DeleteMapping(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		urlToken      string
+		setupStorage  map[string]string
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Success - mapping deleted",
+			urlToken: "abc123",
+			setupStorage: map[string]string{
+				"abc123": "https://example.com",
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Not found - token does not exist",
+			urlToken:      "nonexistent",
+			setupStorage:  map[string]string{},
+			expectedError: &domain.TokenNonExistingError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cache := NewLocalCache()
+			for token, url := range tt.setupStorage {
+				cache.storage[token] = domain.MappingInfo{OriginalURL: url, Token: token}
+			}
+
+			err := cache.DeleteMapping(context.Background(), tt.urlToken)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.NotContains(t, cache.storage, tt.urlToken)
+			}
 		})
 	}
 }
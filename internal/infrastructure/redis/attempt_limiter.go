@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// RedisAttemptLimiter throttles repeated attempts using fixed time windows in Redis.
+// Every attempt atomically increments a per-key counter that expires together with its window.
+type RedisAttemptLimiter struct {
+	client      domain.KeyAttemptCounter
+	prefix      string
+	maxAttempts int64
+	window      time.Duration
+}
+
+// NewRedisAttemptLimiter creates a new RedisAttemptLimiter instance.
+// Parameters:
+//   - client: Redis client connection
+//   - prefix: key prefix separating the counters of this limiter from other Redis keys
+//   - maxAttempts: number of attempts allowed per key within a window
+//   - window: duration of a window, started by the first attempt
+func NewRedisAttemptLimiter(client domain.KeyAttemptCounter, prefix string, maxAttempts int64, window time.Duration) *RedisAttemptLimiter {
+	return &RedisAttemptLimiter{
+		client:      client,
+		prefix:      prefix,
+		maxAttempts: maxAttempts,
+		window:      window,
+	}
+}
+
+// TryAttempt registers an attempt for the key and reports whether it is within the allowed limit.
+// The first attempt of a window sets the expiration of the counter.
+//
+// Returns an error if:
+//   - Redis INCR operation fails
+//   - Redis EXPIRE operation fails
+func (l *RedisAttemptLimiter) TryAttempt(ctx context.Context, key string) (bool, error) {
+	counterKey := l.prefix + key
+
+	attempts, err := l.client.Incr(ctx, counterKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("incrementing attempts counter in redis: %w", err)
+	}
+
+	if attempts == 1 {
+		err = l.client.Expire(ctx, counterKey, l.window).Err()
+		if err != nil {
+			return false, fmt.Errorf("setting attempts window in redis: %w", err)
+		}
+	}
+
+	return attempts <= l.maxAttempts, nil
+}
+
+// ResetAttempts forgets all registered attempts for the key.
+//
+// Returns an error if the Redis DEL operation fails.
+func (l *RedisAttemptLimiter) ResetAttempts(ctx context.Context, key string) error {
+	err := l.client.Del(ctx, l.prefix+key).Err()
+	if err != nil {
+		return fmt.Errorf("deleting attempts counter in redis: %w", err)
+	}
+
+	return nil
+}
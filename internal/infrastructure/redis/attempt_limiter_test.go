@@ -0,0 +1,181 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAttemptsPrefix = "attempts:"
+	testMaxAttempts    = int64(3)
+	testAttemptsWindow = time.Minute
+)
+
+func incrResult(val int64, err error) func(ctx context.Context, key string) *redis.IntCmd {
+	return func(ctx context.Context, key string) *redis.IntCmd {
+		cmd := redis.NewIntCmd(ctx)
+		if err != nil {
+			cmd.SetErr(err)
+		} else {
+			cmd.SetVal(val)
+		}
+		return cmd
+	}
+}
+
+func TestRedisAttemptLimiter_TryAttempt(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name            string
+		expectedAllowed bool
+		expectedError   error
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter
+	}
+
+	testCases := []testCase{
+		{
+			name:            "first attempt starts window",
+			expectedAllowed: true,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				gomock.InOrder(
+					clientMock.EXPECT().Incr(gomock.Any(), testAttemptsPrefix+"abc123").DoAndReturn(incrResult(1, nil)),
+					clientMock.EXPECT().Expire(gomock.Any(), testAttemptsPrefix+"abc123", testAttemptsWindow).Return(redis.NewBoolCmd(context.Background())),
+				)
+
+				return clientMock
+			},
+		},
+		{
+			name:            "last allowed attempt within window",
+			expectedAllowed: true,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testAttemptsPrefix+"abc123").DoAndReturn(incrResult(testMaxAttempts, nil))
+
+				return clientMock
+			},
+		},
+		{
+			name:            "attempt over the limit is rejected",
+			expectedAllowed: false,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testAttemptsPrefix+"abc123").DoAndReturn(incrResult(testMaxAttempts+1, nil))
+
+				return clientMock
+			},
+		},
+		{
+			name:          "error incrementing counter",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testAttemptsPrefix+"abc123").DoAndReturn(incrResult(0, assert.AnError))
+
+				return clientMock
+			},
+		},
+		{
+			name:          "error setting window",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testAttemptsPrefix+"abc123").DoAndReturn(incrResult(1, nil))
+				clientMock.EXPECT().Expire(gomock.Any(), testAttemptsPrefix+"abc123", testAttemptsWindow).DoAndReturn(func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+					cmd := redis.NewBoolCmd(ctx)
+					cmd.SetErr(assert.AnError)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			limiter := NewRedisAttemptLimiter(tt.prepareMocks(t, ctrl), testAttemptsPrefix, testMaxAttempts, testAttemptsWindow)
+
+			allowed, err := limiter.TryAttempt(context.Background(), "abc123")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAllowed, allowed)
+			}
+		})
+	}
+}
+
+func TestRedisAttemptLimiter_ResetAttempts(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		delErr        error
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{
+			name: "attempts reset",
+		},
+		{
+			name:          "error deleting counter",
+			delErr:        assert.AnError,
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+			clientMock.EXPECT().Del(gomock.Any(), testAttemptsPrefix+"abc123").DoAndReturn(func(ctx context.Context, keys ...string) *redis.IntCmd {
+				return incrResult(1, tt.delErr)(ctx, keys[0])
+			})
+			limiter := NewRedisAttemptLimiter(clientMock, testAttemptsPrefix, testMaxAttempts, testAttemptsWindow)
+
+			err := limiter.ResetAttempts(context.Background(), "abc123")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// clickCounterPrefix is the key prefix of consumed clicks counters in Redis.
+const clickCounterPrefix = "clicks:"
+
+// RedisClickCounter counts consumed clicks of click-limited mappings using Redis INCR.
+// The atomic increment guarantees that concurrent redirects never consume more clicks than allowed.
+type RedisClickCounter struct {
+	client domain.KeySetNxIncrementer
+}
+
+// NewRedisClickCounter creates a new RedisClickCounter instance.
+// Parameters:
+//   - client: Redis client connection
+func NewRedisClickCounter(client domain.KeySetNxIncrementer) *RedisClickCounter {
+	return &RedisClickCounter{client: client}
+}
+
+// IncrementClicks atomically increments the consumed clicks counter of the token and returns the new value.
+// A missing counter is initialized with seed using SETNX first, so an existing counter is never overwritten.
+// A non-nil expiresAt is used as the TTL of a newly initialized counter.
+//
+// Returns an error if:
+//   - Redis SETNX operation fails
+//   - Redis INCR operation fails
+func (c *RedisClickCounter) IncrementClicks(ctx context.Context, urlToken string, seed int64, expiresAt *time.Time) (int64, error) {
+	key := clickCounterPrefix + urlToken
+
+	var ttl time.Duration
+	if expiresAt != nil {
+		ttl = max(time.Until(*expiresAt), time.Second)
+	}
+
+	err := c.client.SetNX(ctx, key, seed, ttl).Err()
+	if err != nil {
+		return 0, fmt.Errorf("initializing click counter in redis: %w", err)
+	}
+
+	count, err := c.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("incrementing click counter in redis: %w", err)
+	}
+
+	return count, nil
+}
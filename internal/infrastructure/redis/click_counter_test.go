@@ -0,0 +1,170 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisClickCounter_IncrementClicks(t *testing.T) {
+	t.Parallel()
+
+	futureExpiry := time.Now().Add(time.Hour)
+
+	type testCase struct {
+		name          string
+		urlToken      string
+		seed          int64
+		expiresAt     *time.Time
+		expectedCount int64
+		expectedError error
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) domain.KeySetNxIncrementer
+	}
+
+	testCases := []testCase{
+		{
+			name:          "counter initialized with seed and incremented",
+			urlToken:      "abc123",
+			seed:          2,
+			expectedCount: 3,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeySetNxIncrementer {
+				t.Helper()
+				clientMock := mocks.NewMockKeySetNxIncrementer(ctrl)
+
+				gomock.InOrder(
+					clientMock.EXPECT().SetNX(gomock.Any(), clickCounterPrefix+"abc123", int64(2), time.Duration(0)).DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+						cmd := redis.NewBoolCmd(ctx)
+						cmd.SetVal(true)
+						return cmd
+					}),
+					clientMock.EXPECT().Incr(gomock.Any(), clickCounterPrefix+"abc123").DoAndReturn(func(ctx context.Context, key string) *redis.IntCmd {
+						cmd := redis.NewIntCmd(ctx)
+						cmd.SetVal(3)
+						return cmd
+					}),
+				)
+
+				return clientMock
+			},
+		},
+		{
+			name:          "existing counter is incremented",
+			urlToken:      "abc123",
+			seed:          0,
+			expectedCount: 5,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeySetNxIncrementer {
+				t.Helper()
+				clientMock := mocks.NewMockKeySetNxIncrementer(ctrl)
+
+				clientMock.EXPECT().SetNX(gomock.Any(), clickCounterPrefix+"abc123", int64(0), time.Duration(0)).DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+					cmd := redis.NewBoolCmd(ctx)
+					cmd.SetVal(false)
+					return cmd
+				})
+				clientMock.EXPECT().Incr(gomock.Any(), clickCounterPrefix+"abc123").DoAndReturn(func(ctx context.Context, key string) *redis.IntCmd {
+					cmd := redis.NewIntCmd(ctx)
+					cmd.SetVal(5)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+		{
+			name:          "expiring mapping bounds counter lifetime",
+			urlToken:      "exp123",
+			expiresAt:     &futureExpiry,
+			expectedCount: 1,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeySetNxIncrementer {
+				t.Helper()
+				clientMock := mocks.NewMockKeySetNxIncrementer(ctrl)
+
+				clientMock.EXPECT().SetNX(gomock.Any(), clickCounterPrefix+"exp123", int64(0), gomock.Any()).DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+					assert.Greater(t, expiration, 59*time.Minute)
+					assert.LessOrEqual(t, expiration, time.Hour)
+					cmd := redis.NewBoolCmd(ctx)
+					cmd.SetVal(true)
+					return cmd
+				})
+				clientMock.EXPECT().Incr(gomock.Any(), clickCounterPrefix+"exp123").DoAndReturn(func(ctx context.Context, key string) *redis.IntCmd {
+					cmd := redis.NewIntCmd(ctx)
+					cmd.SetVal(1)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+		{
+			name:          "error initializing counter",
+			urlToken:      "abc123",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeySetNxIncrementer {
+				t.Helper()
+				clientMock := mocks.NewMockKeySetNxIncrementer(ctrl)
+
+				clientMock.EXPECT().SetNX(gomock.Any(), clickCounterPrefix+"abc123", int64(0), gomock.Any()).DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+					cmd := redis.NewBoolCmd(ctx)
+					cmd.SetErr(assert.AnError)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+		{
+			name:          "error incrementing counter",
+			urlToken:      "abc123",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeySetNxIncrementer {
+				t.Helper()
+				clientMock := mocks.NewMockKeySetNxIncrementer(ctrl)
+
+				clientMock.EXPECT().SetNX(gomock.Any(), clickCounterPrefix+"abc123", int64(0), gomock.Any()).DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+					cmd := redis.NewBoolCmd(ctx)
+					cmd.SetVal(false)
+					return cmd
+				})
+				clientMock.EXPECT().Incr(gomock.Any(), clickCounterPrefix+"abc123").DoAndReturn(func(ctx context.Context, key string) *redis.IntCmd {
+					cmd := redis.NewIntCmd(ctx)
+					cmd.SetErr(assert.AnError)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			clientMock := tt.prepareMocks(t, ctrl)
+			counter := NewRedisClickCounter(clientMock)
+
+			count, err := counter.IncrementClicks(context.Background(), tt.urlToken, tt.seed, tt.expiresAt)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, count)
+			}
+		})
+	}
+}
@@ -2,51 +2,150 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"url-shortening-service/internal/domain"
+
+	"github.com/redis/go-redis/v9"
 )
 
 const counterId = "mapping_count"
 
-// RedisIdGenerator generates unique IDs for URL mappings using Redis INCR.
+// RedisIdGenerator generates unique IDs for URL mappings using Redis INCRBY.
 // It maintains an atomic counter in Redis to ensure uniqueness across instances.
+// A counter that falls behind the IDs already handed out, e.g. after Redis was flushed or failed over
+// to a stale replica, is detected and re-seeded above the last ID in persistent storage.
 type RedisIdGenerator struct {
-	client domain.KeySetIncrementer
+	client       domain.KeyCounter
+	lastIdGetter domain.MappingInfoLastIdGetter
+	reseedGap    int64
+	logger       domain.Logger
+	highestId    atomic.Int64
 }
 
 // NewRedisIdGenerator creates a new RedisIdGenerator instance.
-// It initializes the Redis counter with the last known ID from persistent storage.
+// It raises the Redis counter to the last known ID from persistent storage plus reseedGap
+// if the counter is missing or behind it, and never lowers a counter that is ahead.
 // Parameters:
 //   - ctx: context for Redis operations
 //   - client: Redis client connection
 //   - lastIdGetter: retrieves the last used ID from persistent storage
+//   - reseedGap: number of IDs skipped when the counter is re-seeded
+//   - logger: logger for recording warnings
 //
 // Returns an error if:
 //   - Retrieving the last ID from storage fails
-//   - Setting the initial counter value in Redis fails
-func NewRedisIdGenerator(ctx context.Context, client domain.KeySetIncrementer, lastIdGetter domain.MappingInfoLastIdGetter) (*RedisIdGenerator, error) {
+//   - Reading or raising the counter in Redis fails
+func NewRedisIdGenerator(ctx context.Context, client domain.KeyCounter, lastIdGetter domain.MappingInfoLastIdGetter,
+	reseedGap int64, logger domain.Logger) (*RedisIdGenerator, error) {
 	lastId, err := lastIdGetter.GetLastId(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("getting last mapping id: %w", err)
 	}
 
-	err = client.Set(ctx, counterId, lastId, 0).Err()
+	generator := &RedisIdGenerator{
+		client:       client,
+		lastIdGetter: lastIdGetter,
+		reseedGap:    reseedGap,
+		logger:       logger,
+	}
+	generator.highestId.Store(lastId)
+
+	err = generator.seedCounter(ctx, lastId)
 	if err != nil {
-		return nil, fmt.Errorf("setting mapping count in redis: %w", err)
+		return nil, err
 	}
 
-	return &RedisIdGenerator{client: client}, nil
+	return generator, nil
 }
 
 // GetNextId generates and returns the next unique ID for URL mappings.
 // It atomically increments the Redis counter and returns the new value.
 //
-// Returns an error if the Redis INCR operation fails.
+// Returns an error if:
+//   - The Redis INCRBY operation fails
+//   - The counter regressed and re-seeding it fails
 func (r *RedisIdGenerator) GetNextId(ctx context.Context) (int64, error) {
-	newId, err := r.client.Incr(ctx, counterId).Result()
+	return r.ReserveIds(ctx, 1)
+}
+
+// ReserveIds atomically reserves count consecutive IDs and returns the last one.
+// If the reserved range is not above every ID this generator has seen, the counter regressed;
+// it is then re-seeded above the last ID in persistent storage and a fresh range is reserved.
+//
+// Returns an error if:
+//   - The Redis INCRBY operation fails
+//   - The counter regressed and retrieving the last ID from storage fails
+func (r *RedisIdGenerator) ReserveIds(ctx context.Context, count int64) (int64, error) {
+	highestId := r.highestId.Load()
+
+	lastId, err := r.client.IncrBy(ctx, counterId, count).Result()
 	if err != nil {
 		return 0, fmt.Errorf("incrementing mapping count in redis: %w", err)
 	}
 
-	return newId, nil
+	if lastId-count < highestId {
+		r.logger.Warn(fmt.Sprintf("Mapping id counter regressed to %d below issued id %d, re-seeding it", lastId, highestId))
+		lastId, err = r.reserveAboveStorage(ctx, lastId, count, highestId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	r.raiseHighestId(lastId)
+	return lastId, nil
+}
+
+// seedCounter raises the counter to lastId plus the reseed gap if it is missing or behind lastId.
+// Concurrent seeding instances may raise it more than once, which only leaves unused IDs.
+func (r *RedisIdGenerator) seedCounter(ctx context.Context, lastId int64) error {
+	current, err := r.client.Get(ctx, counterId).Int64()
+	if errors.Is(err, redis.Nil) {
+		current = 0
+	} else if err != nil {
+		return fmt.Errorf("getting mapping count from redis: %w", err)
+	}
+
+	if current >= lastId {
+		return nil
+	}
+
+	if current > 0 {
+		r.logger.Warn(fmt.Sprintf("Mapping id counter %d is behind stored id %d, re-seeding it", current, lastId))
+	}
+
+	err = r.client.IncrBy(ctx, counterId, lastId+r.reseedGap-current).Err()
+	if err != nil {
+		return fmt.Errorf("seeding mapping count in redis: %w", err)
+	}
+
+	return nil
+}
+
+// reserveAboveStorage reserves count IDs above both the last ID in persistent storage and
+// highestId, plus the reseed gap, after the counter was found to have regressed to counter.
+func (r *RedisIdGenerator) reserveAboveStorage(ctx context.Context, counter, count, highestId int64) (int64, error) {
+	lastStoredId, err := r.lastIdGetter.GetLastId(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting last mapping id: %w", err)
+	}
+
+	floor := max(lastStoredId, highestId) + r.reseedGap
+	lastId, err := r.client.IncrBy(ctx, counterId, max(floor-counter, 0)+count).Result()
+	if err != nil {
+		return 0, fmt.Errorf("re-seeding mapping count in redis: %w", err)
+	}
+
+	return lastId, nil
+}
+
+// raiseHighestId records id as the highest ID seen unless a higher one was recorded already.
+func (r *RedisIdGenerator) raiseHighestId(id int64) {
+	for {
+		highestId := r.highestId.Load()
+		if id <= highestId || r.highestId.CompareAndSwap(highestId, id) {
+			return
+		}
+	}
 }
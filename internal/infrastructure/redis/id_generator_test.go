@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"io"
+	"log/slog"
 	"testing"
 	"url-shortening-service/internal/domain"
 	"url-shortening-service/internal/domain/mocks"
@@ -12,6 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const testReseedGap = int64(1000)
+
 func TestNewRedisIdGenerator(t *testing.T) {
 	t.Parallel()
 
@@ -19,20 +23,50 @@ func TestNewRedisIdGenerator(t *testing.T) {
 		name          string
 		expectedError error
 
-		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.KeySetIncrementer, domain.MappingInfoLastIdGetter)
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter)
 	}
 
 	testCases := []testCase{
 		{
-			name: "successfully create RedisIdGenerator",
+			name: "missing counter is seeded above last id",
 
-			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeySetIncrementer, domain.MappingInfoLastIdGetter) {
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
 				t.Helper()
-				clientMock := mocks.NewMockKeySetIncrementer(ctrl)
+				clientMock := mocks.NewMockKeyCounter(ctrl)
 				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
 
 				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(10), nil)
-				clientMock.EXPECT().Set(gomock.Any(), counterId, int64(10), gomock.Any()).Return(redis.NewStatusCmd(context.Background()))
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("", redis.Nil))
+				clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(10)+testReseedGap).Return(redis.NewIntResult(1010, nil))
+
+				return clientMock, lastIdGetterMock
+			},
+		},
+		{
+			name: "counter behind last id is raised",
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
+				t.Helper()
+				clientMock := mocks.NewMockKeyCounter(ctrl)
+				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
+
+				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(10), nil)
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("4", nil))
+				clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(6)+testReseedGap).Return(redis.NewIntResult(1010, nil))
+
+				return clientMock, lastIdGetterMock
+			},
+		},
+		{
+			name: "counter ahead of last id is kept",
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
+				t.Helper()
+				clientMock := mocks.NewMockKeyCounter(ctrl)
+				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
+
+				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(10), nil)
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("25", nil))
 
 				return clientMock, lastIdGetterMock
 			},
@@ -41,9 +75,9 @@ func TestNewRedisIdGenerator(t *testing.T) {
 			name:          "error getting last id from storage",
 			expectedError: assert.AnError,
 
-			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeySetIncrementer, domain.MappingInfoLastIdGetter) {
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
 				t.Helper()
-				clientMock := mocks.NewMockKeySetIncrementer(ctrl)
+				clientMock := mocks.NewMockKeyCounter(ctrl)
 				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
 
 				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(0), assert.AnError)
@@ -52,20 +86,32 @@ func TestNewRedisIdGenerator(t *testing.T) {
 			},
 		},
 		{
-			name:          "error setting initial counter in redis",
+			name:          "error reading counter in redis",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
+				t.Helper()
+				clientMock := mocks.NewMockKeyCounter(ctrl)
+				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
+
+				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(15), nil)
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("", assert.AnError))
+
+				return clientMock, lastIdGetterMock
+			},
+		},
+		{
+			name:          "error seeding counter in redis",
 			expectedError: assert.AnError,
 
-			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeySetIncrementer, domain.MappingInfoLastIdGetter) {
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
 				t.Helper()
-				clientMock := mocks.NewMockKeySetIncrementer(ctrl)
+				clientMock := mocks.NewMockKeyCounter(ctrl)
 				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
 
 				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(15), nil)
-				clientMock.EXPECT().Set(gomock.Any(), counterId, int64(15), gomock.Any()).DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration interface{}) *redis.StatusCmd {
-					cmd := redis.NewStatusCmd(ctx)
-					cmd.SetErr(assert.AnError)
-					return cmd
-				})
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("", redis.Nil))
+				clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(15)+testReseedGap).Return(redis.NewIntResult(0, assert.AnError))
 
 				return clientMock, lastIdGetterMock
 			},
@@ -79,7 +125,8 @@ func TestNewRedisIdGenerator(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			clientMock, lastIdGetterMock := tt.prepareMocks(t, ctrl)
-			_, err := NewRedisIdGenerator(context.Background(), clientMock, lastIdGetterMock)
+			_, err := NewRedisIdGenerator(context.Background(), clientMock, lastIdGetterMock, testReseedGap,
+				slog.New(slog.NewTextHandler(io.Discard, nil)))
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
@@ -98,7 +145,7 @@ func TestRedisIdGenerator_GetNextId(t *testing.T) {
 		expectedRes   int64
 		expectedError error
 
-		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.KeySetIncrementer, domain.MappingInfoLastIdGetter)
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter)
 	}
 
 	testCases := []testCase{
@@ -106,14 +153,36 @@ func TestRedisIdGenerator_GetNextId(t *testing.T) {
 			name:        "successfully get next id",
 			expectedRes: 6,
 
-			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeySetIncrementer, domain.MappingInfoLastIdGetter) {
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
 				t.Helper()
-				clientMock := mocks.NewMockKeySetIncrementer(ctrl)
+				clientMock := mocks.NewMockKeyCounter(ctrl)
 				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
 
 				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(5), nil)
-				clientMock.EXPECT().Set(gomock.Any(), counterId, int64(5), gomock.Any()).Return(redis.NewStatusCmd(context.Background()))
-				clientMock.EXPECT().Incr(gomock.Any(), counterId).Return(redis.NewIntResult(6, nil))
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("5", nil))
+				clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(1)).Return(redis.NewIntResult(6, nil))
+
+				return clientMock, lastIdGetterMock
+			},
+		},
+		{
+			name:        "regressed counter is re-seeded above storage",
+			expectedRes: 1501,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
+				t.Helper()
+				clientMock := mocks.NewMockKeyCounter(ctrl)
+				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
+
+				gomock.InOrder(
+					lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(5), nil),
+					lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(500), nil),
+				)
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("5", nil))
+				gomock.InOrder(
+					clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(1)).Return(redis.NewIntResult(1, nil)),
+					clientMock.EXPECT().IncrBy(gomock.Any(), counterId, 500+testReseedGap).Return(redis.NewIntResult(1501, nil)),
+				)
 
 				return clientMock, lastIdGetterMock
 			},
@@ -121,14 +190,32 @@ func TestRedisIdGenerator_GetNextId(t *testing.T) {
 		{
 			name:          "error incrementing id",
 			expectedError: assert.AnError,
-			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeySetIncrementer, domain.MappingInfoLastIdGetter) {
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
 				t.Helper()
-				clientMock := mocks.NewMockKeySetIncrementer(ctrl)
+				clientMock := mocks.NewMockKeyCounter(ctrl)
 				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
 
 				lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(10), nil)
-				clientMock.EXPECT().Set(gomock.Any(), counterId, int64(10), gomock.Any()).Return(redis.NewStatusCmd(context.Background()))
-				clientMock.EXPECT().Incr(gomock.Any(), counterId).Return(redis.NewIntResult(0, assert.AnError))
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("10", nil))
+				clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(1)).Return(redis.NewIntResult(0, assert.AnError))
+
+				return clientMock, lastIdGetterMock
+			},
+		},
+		{
+			name:          "error getting last id while re-seeding",
+			expectedError: assert.AnError,
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyCounter, domain.MappingInfoLastIdGetter) {
+				t.Helper()
+				clientMock := mocks.NewMockKeyCounter(ctrl)
+				lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
+
+				gomock.InOrder(
+					lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(10), nil),
+					lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(0), assert.AnError),
+				)
+				clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("10", nil))
+				clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(1)).Return(redis.NewIntResult(1, nil))
 
 				return clientMock, lastIdGetterMock
 			},
@@ -142,7 +229,8 @@ func TestRedisIdGenerator_GetNextId(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			clientMock, lastIdGetterMock := tt.prepareMocks(t, ctrl)
-			idGen, err := NewRedisIdGenerator(context.Background(), clientMock, lastIdGetterMock)
+			idGen, err := NewRedisIdGenerator(context.Background(), clientMock, lastIdGetterMock, testReseedGap,
+				slog.New(slog.NewTextHandler(io.Discard, nil)))
 			require.NoError(t, err)
 
 			res, err := idGen.GetNextId(context.Background())
@@ -155,3 +243,24 @@ func TestRedisIdGenerator_GetNextId(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisIdGenerator_ReserveIds(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	clientMock := mocks.NewMockKeyCounter(ctrl)
+	lastIdGetterMock := mocks.NewMockMappingInfoLastIdGetter(ctrl)
+
+	lastIdGetterMock.EXPECT().GetLastId(gomock.Any()).Return(int64(10), nil)
+	clientMock.EXPECT().Get(gomock.Any(), counterId).Return(redis.NewStringResult("10", nil))
+	clientMock.EXPECT().IncrBy(gomock.Any(), counterId, int64(100)).Return(redis.NewIntResult(110, nil))
+
+	idGen, err := NewRedisIdGenerator(context.Background(), clientMock, lastIdGetterMock, testReseedGap,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	lastId, err := idGen.ReserveIds(context.Background(), 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(110), lastId)
+}
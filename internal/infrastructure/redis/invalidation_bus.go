@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"url-shortening-service/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisInvalidationBus propagates cache invalidations between service replicas using Redis pub/sub.
+// Pub/sub delivers at most once, so replicas missing a message have to rely on the TTL of their caches.
+type RedisInvalidationBus struct {
+	client  domain.KeyPublisher
+	channel string
+	logger  domain.Logger
+}
+
+// NewRedisInvalidationBus creates a new RedisInvalidationBus instance.
+// Parameters:
+//   - client: Redis client connection
+//   - channel: pub/sub channel the invalidated tokens are published to
+//   - logger: logger for recording info messages
+func NewRedisInvalidationBus(client domain.KeyPublisher, channel string, logger domain.Logger) *RedisInvalidationBus {
+	return &RedisInvalidationBus{
+		client:  client,
+		channel: channel,
+		logger:  logger,
+	}
+}
+
+// PublishInvalidation publishes the invalidated token to all subscribed replicas, including this one.
+//
+// Returns an error if:
+//   - Redis PUBLISH operation fails
+func (b *RedisInvalidationBus) PublishInvalidation(ctx context.Context, urlToken string) error {
+	err := b.client.Publish(ctx, b.channel, urlToken).Err()
+	if err != nil {
+		return fmt.Errorf("publishing cache invalidation: %w", err)
+	}
+
+	return nil
+}
+
+// Listen calls invalidate with the token of every message received on the subscription
+// until the context is cancelled or the subscription is closed.
+func (b *RedisInvalidationBus) Listen(ctx context.Context, messages <-chan *redis.Message, invalidate func(urlToken string)) {
+	for {
+		select {
+		case <-ctx.Done():
+			b.logger.Info("Stopping cache invalidation listener")
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				b.logger.Info("Cache invalidation subscription closed")
+				return
+			}
+			invalidate(msg.Payload)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const testInvalidationChannel = "cache_invalidation"
+
+func TestRedisInvalidationBus_PublishInvalidation(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		urlToken      string
+		expectedError error
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) domain.KeyPublisher
+	}
+
+	testCases := []testCase{
+		{
+			name:     "token published to channel",
+			urlToken: "abc123",
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyPublisher {
+				t.Helper()
+				clientMock := mocks.NewMockKeyPublisher(ctrl)
+
+				clientMock.EXPECT().Publish(gomock.Any(), testInvalidationChannel, "abc123").DoAndReturn(func(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+					cmd := redis.NewIntCmd(ctx)
+					cmd.SetVal(2)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+		{
+			name:          "publish error is returned",
+			urlToken:      "abc123",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyPublisher {
+				t.Helper()
+				clientMock := mocks.NewMockKeyPublisher(ctrl)
+
+				clientMock.EXPECT().Publish(gomock.Any(), testInvalidationChannel, "abc123").DoAndReturn(func(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+					cmd := redis.NewIntCmd(ctx)
+					cmd.SetErr(assert.AnError)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			bus := NewRedisInvalidationBus(tt.prepareMocks(t, ctrl), testInvalidationChannel, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			err := bus.PublishInvalidation(context.Background(), tt.urlToken)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedisInvalidationBus_Listen(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	bus := NewRedisInvalidationBus(mocks.NewMockKeyPublisher(ctrl), testInvalidationChannel, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	messages := make(chan *redis.Message, 2)
+	messages <- &redis.Message{Channel: testInvalidationChannel, Payload: "abc123"}
+	messages <- &redis.Message{Channel: testInvalidationChannel, Payload: "xyz789"}
+	close(messages)
+
+	var invalidated []string
+	bus.Listen(context.Background(), messages, func(urlToken string) {
+		invalidated = append(invalidated, urlToken)
+	})
+
+	assert.Equal(t, []string{"abc123", "xyz789"}, invalidated)
+}
+
+func TestRedisInvalidationBus_Listen_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	bus := NewRedisInvalidationBus(mocks.NewMockKeyPublisher(ctrl), testInvalidationChannel, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	bus.Listen(ctx, make(chan *redis.Message), func(urlToken string) {
+		t.Errorf("unexpected invalidation of %s", urlToken)
+	})
+}
@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// RedisRateLimiter limits the rate of events per key using fixed time windows in Redis.
+// Unlike RedisAttemptLimiter, the windows are aligned to the clock and the limit is passed with every event,
+// so keys can have different limits that change at runtime. Every window has its own counter that expires
+// shortly after the window ends.
+type RedisRateLimiter struct {
+	client domain.KeyAttemptCounter
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisRateLimiter creates a new RedisRateLimiter instance.
+// Parameters:
+//   - client: Redis client connection
+//   - prefix: key prefix separating the counters of this limiter from other Redis keys
+func NewRedisRateLimiter(client domain.KeyAttemptCounter, prefix string) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client: client,
+		prefix: prefix,
+		now:    time.Now,
+	}
+}
+
+// Allow registers an event for the key in the current window and reports whether it is within limit events per window.
+// The first event of a window sets the expiration of its counter.
+//
+// Returns an error if:
+//   - Redis INCR operation fails
+//   - Redis EXPIRE operation fails
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error) {
+	windowIndex := l.now().UnixNano() / int64(window)
+	counterKey := l.prefix + key + ":" + strconv.FormatInt(windowIndex, 10)
+
+	events, err := l.client.Incr(ctx, counterKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("incrementing rate counter in redis: %w", err)
+	}
+
+	if events == 1 {
+		err = l.client.Expire(ctx, counterKey, 2*window).Err()
+		if err != nil {
+			return false, fmt.Errorf("setting rate window in redis: %w", err)
+		}
+	}
+
+	return events <= limit, nil
+}
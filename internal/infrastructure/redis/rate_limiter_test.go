@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRatePrefix = "rate:"
+	testRateLimit  = int64(50)
+	testRateWindow = time.Second
+	// testRateKey is the counter of key "7" in the window of testRateNow.
+	testRateKey = testRatePrefix + "7:1773759845"
+)
+
+var testRateNow = time.Unix(1773759845, 500_000_000)
+
+func TestRedisRateLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name            string
+		expectedAllowed bool
+		expectedError   error
+
+		prepareMocks func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter
+	}
+
+	testCases := []testCase{
+		{
+			name:            "first event starts window",
+			expectedAllowed: true,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				gomock.InOrder(
+					clientMock.EXPECT().Incr(gomock.Any(), testRateKey).DoAndReturn(incrResult(1, nil)),
+					clientMock.EXPECT().Expire(gomock.Any(), testRateKey, 2*testRateWindow).Return(redis.NewBoolCmd(context.Background())),
+				)
+
+				return clientMock
+			},
+		},
+		{
+			name:            "last allowed event within window",
+			expectedAllowed: true,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testRateKey).DoAndReturn(incrResult(testRateLimit, nil))
+
+				return clientMock
+			},
+		},
+		{
+			name:            "event over the limit is rejected",
+			expectedAllowed: false,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testRateKey).DoAndReturn(incrResult(testRateLimit+1, nil))
+
+				return clientMock
+			},
+		},
+		{
+			name:          "error incrementing counter",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testRateKey).DoAndReturn(incrResult(0, assert.AnError))
+
+				return clientMock
+			},
+		},
+		{
+			name:          "error setting window",
+			expectedError: assert.AnError,
+
+			prepareMocks: func(t *testing.T, ctrl *gomock.Controller) domain.KeyAttemptCounter {
+				t.Helper()
+				clientMock := mocks.NewMockKeyAttemptCounter(ctrl)
+
+				clientMock.EXPECT().Incr(gomock.Any(), testRateKey).DoAndReturn(incrResult(1, nil))
+				clientMock.EXPECT().Expire(gomock.Any(), testRateKey, 2*testRateWindow).DoAndReturn(func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+					cmd := redis.NewBoolCmd(ctx)
+					cmd.SetErr(assert.AnError)
+					return cmd
+				})
+
+				return clientMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			limiter := NewRedisRateLimiter(tt.prepareMocks(t, ctrl), testRatePrefix)
+			limiter.now = func() time.Time { return testRateNow }
+
+			allowed, err := limiter.Allow(context.Background(), "7", testRateLimit, testRateWindow)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAllowed, allowed)
+			}
+		})
+	}
+}
@@ -2,51 +2,149 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand/v2"
+	"time"
 	"url-shortening-service/internal/domain"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	// missingMappingMarker is the value of negative cache entries of tokens that do not exist.
+	missingMappingMarker = "-"
+	// mappingKeyPrefix is the Redis key prefix of cached mappings. It keeps tokens apart from
+	// the other keys of the service, such as the ID counter, as tokens never contain a colon.
+	mappingKeyPrefix = "url:"
+)
+
 // RedisStorage implements URL mapping cache operations using Redis.
-// It provides fast read access to URL mappings with optional TTL support.
+// It provides fast read access to URL mappings with a configurable TTL, TTL jitter and sliding expiration,
+// and remembers tokens that do not exist in short-lived negative entries stored under the same key.
+// Mappings are keyed by their link key (see domain.LinkKey) behind mappingKeyPrefix, so equal tokens of different
+// short domains never share an entry.
 type RedisStorage struct {
-	client domain.KeyStorage
-	logger domain.Logger
+	client   domain.KeyStorage
+	settings domain.CacheSettings
+	logger   domain.Logger
 }
 
 // NewRedisStorage creates a new RedisStorage instance.
 // Parameters:
 //   - client: Redis client connection
-//   - logger: logger for recording errors
-func NewRedisStorage(client domain.KeyStorage, logger domain.Logger) *RedisStorage {
+//   - settings: TTL and eviction policy of cached mappings
+//   - logger: logger for recording warnings
+func NewRedisStorage(client domain.KeyStorage, settings domain.CacheSettings, logger domain.Logger) *RedisStorage {
 	return &RedisStorage{
-		client: client,
-		logger: logger,
+		client:   client,
+		settings: settings,
+		logger:   logger,
 	}
 }
 
-// GetOriginalUrl retrieves the original URL for a given short URL token from Redis.
-// Returns the original URL and true if found, or empty string and false if not found.
-// Redis errors are logged and result in returning false.
-func (s *RedisStorage) GetOriginalUrl(ctx context.Context, shortUrl string) (string, bool) {
-	val, err := s.client.Get(ctx, shortUrl).Result()
+// GetMapping retrieves the cached mapping for a given short URL token from Redis.
+// Returns the mapping and CacheHit if found, empty MappingInfo and CacheKnownMissing for a negative entry,
+// or empty MappingInfo and CacheMiss if nothing is cached.
+// With sliding expiration, the TTL of a found mapping is renewed; renewal failures are only logged.
+//
+// Returns an error if:
+//   - Redis GET operation fails
+//   - The cached entry cannot be decoded
+func (s *RedisStorage) GetMapping(ctx context.Context, urlToken string) (domain.MappingInfo, domain.CacheLookup, error) {
+	val, err := s.client.Get(ctx, mappingKey(urlToken)).Bytes()
 	if err == redis.Nil {
-		return "", false
+		return domain.MappingInfo{}, domain.CacheMiss, nil
 	} else if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to get original URL from Redis: %v", err))
-		return "", false
+		return domain.MappingInfo{}, domain.CacheMiss, fmt.Errorf("getting mapping from redis: %w", err)
+	}
+
+	if string(val) == missingMappingMarker {
+		return domain.MappingInfo{}, domain.CacheKnownMissing, nil
 	}
 
-	return val, true
+	var mapping domain.MappingInfo
+	err = json.Unmarshal(val, &mapping)
+	if err != nil {
+		return domain.MappingInfo{}, domain.CacheMiss, fmt.Errorf("decoding mapping from redis: %w", err)
+	}
+
+	if s.settings.SlidingExpiration {
+		s.renewTTL(ctx, mapping)
+	}
+
+	return mapping, domain.CacheHit, nil
 }
 
-// SetMapping creates a new mapping between a URL token and its original URL in Redis.
-// The mapping is stored without expiration (TTL = 0).
+// renewTTL resets the TTL of a cached mapping, never beyond the expiration time of the mapping.
+func (s *RedisStorage) renewTTL(ctx context.Context, mapping domain.MappingInfo) {
+	ttl, ok := s.entryTTL(mapping)
+	if !ok || ttl == 0 {
+		return
+	}
+
+	err := s.client.Expire(ctx, mappingKey(mapping.Key()), ttl).Err()
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to renew TTL of cached mapping %s: %v", mapping.Key(), err))
+	}
+}
+
+// SetMapping stores the mapping under its link key in Redis as JSON.
+// Mappings are stored with the configured TTL plus a random jitter. Mappings with an expiration time
+// are evicted no later than they expire, and mappings that have already expired are not stored.
+// Without a configured TTL, mappings without expiration are stored without TTL.
 //
-// Returns an error if the Redis SET operation fails.
-func (s *RedisStorage) SetMapping(ctx context.Context, originalUrl, urlToken string) error {
-	return s.client.Set(ctx, urlToken, originalUrl, 0).Err()
+// Returns an error if:
+//   - The mapping cannot be encoded
+//   - Redis SET operation fails
+func (s *RedisStorage) SetMapping(ctx context.Context, mapping domain.MappingInfo) error {
+	ttl, ok := s.entryTTL(mapping)
+	if !ok {
+		return nil
+	}
+
+	val, err := json.Marshal(mapping)
+	if err != nil {
+		return fmt.Errorf("encoding mapping: %w", err)
+	}
+
+	return s.client.Set(ctx, mappingKey(mapping.Key()), val, ttl).Err()
+}
+
+// SetMissing stores a negative entry for the token with the configured negative TTL.
+// The entry is only stored if nothing is cached for the token yet (SETNX), so a mapping
+// cached concurrently by its creation is never replaced. SetMapping overwrites negative entries.
+// Does nothing if negative caching is disabled.
+//
+// Returns an error if:
+//   - Redis SETNX operation fails
+func (s *RedisStorage) SetMissing(ctx context.Context, urlToken string) error {
+	if s.settings.NegativeTTL == 0 {
+		return nil
+	}
+
+	return s.client.SetNX(ctx, mappingKey(urlToken), missingMappingMarker, s.settings.NegativeTTL).Err()
+}
+
+// entryTTL calculates the TTL of a cached mapping, where zero means no TTL.
+// Returns false if the mapping has already expired and must not be cached.
+func (s *RedisStorage) entryTTL(mapping domain.MappingInfo) (time.Duration, bool) {
+	ttl := s.settings.TTL
+	if ttl > 0 && s.settings.TTLJitter > 0 {
+		ttl += rand.N(s.settings.TTLJitter)
+	}
+
+	if mapping.ExpiresAt != nil {
+		untilExpiry := time.Until(*mapping.ExpiresAt)
+		if untilExpiry <= 0 {
+			return 0, false
+		}
+		if ttl == 0 || untilExpiry < ttl {
+			ttl = untilExpiry
+		}
+	}
+
+	return ttl, true
 }
 
 // DeleteMapping removes a URL mapping from Redis by its token.
@@ -55,7 +153,7 @@ func (s *RedisStorage) SetMapping(ctx context.Context, originalUrl, urlToken str
 //   - *domain.TokenNonExistingError: the token does not exist in Redis
 //   - Redis DEL operation fails
 func (s *RedisStorage) DeleteMapping(ctx context.Context, urlToken string) error {
-	n, err := s.client.Del(ctx, urlToken).Result()
+	n, err := s.client.Del(ctx, mappingKey(urlToken)).Result()
 
 	if err != nil {
 		return err
@@ -65,3 +163,8 @@ func (s *RedisStorage) DeleteMapping(ctx context.Context, urlToken string) error
 
 	return nil
 }
+
+// mappingKey returns the Redis key of the cached mapping of a link key.
+func mappingKey(linkKey string) string {
+	return mappingKeyPrefix + linkKey
+}
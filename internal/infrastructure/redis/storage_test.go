@@ -2,44 +2,58 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"log/slog"
 	"testing"
+	"time"
 	"url-shortening-service/internal/domain"
 	"url-shortening-service/internal/domain/mocks"
 
 	"github.com/golang/mock/gomock"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestRedisStorage_GetOriginalUrl(t *testing.T) {
+func TestRedisStorage_GetMapping(t *testing.T) {
 	t.Parallel()
 
+	maxClicks := int64(5)
+	soonExpiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
 	type testCase struct {
-		name       string
-		shortUrl   string
-		wantUrl    string
-		wantExists bool
+		name        string
+		urlToken    string
+		settings    domain.CacheSettings
+		wantMapping domain.MappingInfo
+		wantLookup  domain.CacheLookup
+		wantErr     bool
 
 		setupMock func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger)
 	}
 
 	testCases := []testCase{
 		{
-			name:       "URL exists in Redis",
-			shortUrl:   "short123",
-			wantUrl:    "http://example.com/original",
-			wantExists: true,
+			name:     "Mapping exists in Redis",
+			urlToken: "short123",
+			wantMapping: domain.MappingInfo{
+				Id:          1,
+				OriginalURL: "http://example.com/original",
+				Token:       "short123",
+				MaxClicks:   &maxClicks,
+				ClickCount:  2,
+			},
+			wantLookup: domain.CacheHit,
 			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
 				mockClient := mocks.NewMockKeyStorage(ctrl)
 				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 				mockClient.EXPECT().
-					Get(gomock.Any(), "short123").
+					Get(gomock.Any(), "url:short123").
 					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
 						strCmd := redis.NewStringCmd(ctx)
-						strCmd.SetVal("http://example.com/original")
+						strCmd.SetVal(`{"id":1,"original_url":"http://example.com/original","url_token":"short123","max_clicks":5,"click_count":2}`)
 						return strCmd
 					}).
 					Times(1)
@@ -48,46 +62,197 @@ func TestRedisStorage_GetOriginalUrl(t *testing.T) {
 			},
 		},
 		{
-			name:       "URL does not exist in Redis",
-			shortUrl:   "nonexistent",
-			wantUrl:    "",
-			wantExists: false,
+			name:     "Mapping TTL is renewed with sliding expiration",
+			urlToken: "hot123",
+			settings: domain.CacheSettings{TTL: time.Hour, SlidingExpiration: true},
+			wantMapping: domain.MappingInfo{
+				Id:          2,
+				OriginalURL: "http://example.com/hot",
+				Token:       "hot123",
+			},
+			wantLookup: domain.CacheHit,
 			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
 				mockClient := mocks.NewMockKeyStorage(ctrl)
 				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 				mockClient.EXPECT().
-					Get(gomock.Any(), "nonexistent").
+					Get(gomock.Any(), "url:hot123").
 					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
 						strCmd := redis.NewStringCmd(ctx)
-						strCmd.SetErr(redis.Nil)
+						strCmd.SetVal(`{"id":2,"original_url":"http://example.com/hot","url_token":"hot123"}`)
+						return strCmd
+					}).
+					Times(1)
+
+				mockClient.EXPECT().
+					Expire(gomock.Any(), "url:hot123", time.Hour).
+					DoAndReturn(func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+						boolCmd := redis.NewBoolCmd(ctx)
+						boolCmd.SetVal(true)
+						return boolCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:     "Sliding expiration never extends TTL beyond mapping expiration",
+			urlToken: "exp123",
+			settings: domain.CacheSettings{TTL: 24 * time.Hour, SlidingExpiration: true},
+			wantMapping: domain.MappingInfo{
+				Id:          3,
+				OriginalURL: "http://example.com/expiring",
+				Token:       "exp123",
+				ExpiresAt:   &soonExpiry,
+			},
+			wantLookup: domain.CacheHit,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Get(gomock.Any(), "url:exp123").
+					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
+						strCmd := redis.NewStringCmd(ctx)
+						strCmd.SetVal(string(mustMarshal(t, domain.MappingInfo{Id: 3, OriginalURL: "http://example.com/expiring", Token: "exp123", ExpiresAt: &soonExpiry})))
 						return strCmd
 					}).
 					Times(1)
 
+				mockClient.EXPECT().
+					Expire(gomock.Any(), "url:exp123", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+						assert.LessOrEqual(t, expiration, time.Hour)
+						boolCmd := redis.NewBoolCmd(ctx)
+						boolCmd.SetVal(true)
+						return boolCmd
+					}).
+					Times(1)
+
 				return mockClient, mockLogger
 			},
 		},
 		{
-			name:       "Redis GET error",
-			shortUrl:   "errorcase",
-			wantUrl:    "",
-			wantExists: false,
+			name:     "Failed TTL renewal logs warning and returns mapping",
+			urlToken: "hot456",
+			settings: domain.CacheSettings{TTL: time.Hour, SlidingExpiration: true},
+			wantMapping: domain.MappingInfo{
+				Id:          4,
+				OriginalURL: "http://example.com/hot",
+				Token:       "hot456",
+			},
+			wantLookup: domain.CacheHit,
 			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
 				mockClient := mocks.NewMockKeyStorage(ctrl)
 				mockLogger := mocks.NewMockLogger(ctrl)
 
 				mockClient.EXPECT().
-					Get(gomock.Any(), "errorcase").
+					Get(gomock.Any(), "url:hot456").
 					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
 						strCmd := redis.NewStringCmd(ctx)
-						strCmd.SetErr(assert.AnError)
+						strCmd.SetVal(`{"id":4,"original_url":"http://example.com/hot","url_token":"hot456"}`)
 						return strCmd
 					}).
 					Times(1)
 
+				mockClient.EXPECT().
+					Expire(gomock.Any(), "url:hot456", time.Hour).
+					DoAndReturn(func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+						boolCmd := redis.NewBoolCmd(ctx)
+						boolCmd.SetErr(assert.AnError)
+						return boolCmd
+					}).
+					Times(1)
+
 				mockLogger.EXPECT().
-					Error(gomock.Any()).
+					Warn(gomock.Any()).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:        "Mapping does not exist in Redis",
+			urlToken:    "nonexistent",
+			wantMapping: domain.MappingInfo{},
+			wantLookup:  domain.CacheMiss,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Get(gomock.Any(), "url:nonexistent").
+					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
+						strCmd := redis.NewStringCmd(ctx)
+						strCmd.SetErr(redis.Nil)
+						return strCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:        "Negative entry in Redis",
+			urlToken:    "missing123",
+			wantMapping: domain.MappingInfo{},
+			wantLookup:  domain.CacheKnownMissing,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Get(gomock.Any(), "url:missing123").
+					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
+						strCmd := redis.NewStringCmd(ctx)
+						strCmd.SetVal(missingMappingMarker)
+						return strCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:        "Redis GET error",
+			urlToken:    "errorcase",
+			wantMapping: domain.MappingInfo{},
+			wantLookup:  domain.CacheMiss,
+			wantErr:     true,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Get(gomock.Any(), "url:errorcase").
+					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
+						strCmd := redis.NewStringCmd(ctx)
+						strCmd.SetErr(assert.AnError)
+						return strCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:        "Undecodable entry in Redis",
+			urlToken:    "legacy123",
+			wantMapping: domain.MappingInfo{},
+			wantLookup:  domain.CacheMiss,
+			wantErr:     true,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Get(gomock.Any(), "url:legacy123").
+					DoAndReturn(func(ctx context.Context, key string) *redis.StringCmd {
+						strCmd := redis.NewStringCmd(ctx)
+						strCmd.SetVal("http://example.com/legacy")
+						return strCmd
+					}).
 					Times(1)
 
 				return mockClient, mockLogger
@@ -102,11 +267,16 @@ func TestRedisStorage_GetOriginalUrl(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			mockClient, mockLogger := tt.setupMock(t, ctrl)
-			storage := NewRedisStorage(mockClient, mockLogger)
+			storage := NewRedisStorage(mockClient, tt.settings, mockLogger)
 
-			gotUrl, gotExists := storage.GetOriginalUrl(nil, tt.shortUrl)
-			assert.Equal(t, tt.wantUrl, gotUrl)
-			assert.Equal(t, tt.wantExists, gotExists)
+			gotMapping, gotLookup, err := storage.GetMapping(context.Background(), tt.urlToken)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantMapping, gotMapping)
+			assert.Equal(t, tt.wantLookup, gotLookup)
 		})
 	}
 }
@@ -114,27 +284,29 @@ func TestRedisStorage_GetOriginalUrl(t *testing.T) {
 func TestRedisStorage_SetMapping(t *testing.T) {
 	t.Parallel()
 
+	futureExpiry := time.Now().Add(time.Hour)
+	pastExpiry := time.Now().Add(-time.Hour)
+
 	type testCase struct {
-		name        string
-		originalUrl string
-		urlToken    string
-		wantErr     bool
+		name     string
+		settings domain.CacheSettings
+		mapping  domain.MappingInfo
+		wantErr  bool
 
 		setupMock func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger)
 	}
 
 	testCases := []testCase{
 		{
-			name:        "Successfully set mapping",
-			originalUrl: "http://example.com/original",
-			urlToken:    "short123",
-			wantErr:     false,
+			name:    "Successfully set mapping",
+			mapping: domain.MappingInfo{OriginalURL: "http://example.com/original", Token: "short123"},
+			wantErr: false,
 			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
 				mockClient := mocks.NewMockKeyStorage(ctrl)
 				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 				mockClient.EXPECT().
-					Set(gomock.Any(), "short123", "http://example.com/original", gomock.Any()).
+					Set(gomock.Any(), "url:short123", mustMarshal(t, domain.MappingInfo{OriginalURL: "http://example.com/original", Token: "short123"}), time.Duration(0)).
 					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration interface{}) *redis.StatusCmd {
 						statusCmd := redis.NewStatusCmd(ctx)
 						statusCmd.SetVal("OK")
@@ -146,16 +318,156 @@ func TestRedisStorage_SetMapping(t *testing.T) {
 			},
 		},
 		{
-			name:        "Redis SET error",
-			originalUrl: "http://example.com/error",
-			urlToken:    "errortoken",
-			wantErr:     true,
+			name:    "Mapping named like the ID counter does not overwrite it",
+			mapping: domain.MappingInfo{OriginalURL: "http://example.com/original", Token: counterId},
+			wantErr: false,
 			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
 				mockClient := mocks.NewMockKeyStorage(ctrl)
 				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 				mockClient.EXPECT().
-					Set(gomock.Any(), "errortoken", "http://example.com/error", gomock.Any()).
+					Set(gomock.Any(), "url:mapping_count", gomock.Any(), time.Duration(0)).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration interface{}) *redis.StatusCmd {
+						assert.NotEqual(t, counterId, key)
+						statusCmd := redis.NewStatusCmd(ctx)
+						statusCmd.SetVal("OK")
+						return statusCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:    "Successfully set mapping of custom short domain under its link key",
+			mapping: domain.MappingInfo{OriginalURL: "http://example.com/original", Token: "short123", Domain: "go.acme.com"},
+			wantErr: false,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Set(gomock.Any(), "url:go.acme.com/short123", gomock.Any(), time.Duration(0)).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration interface{}) *redis.StatusCmd {
+						statusCmd := redis.NewStatusCmd(ctx)
+						statusCmd.SetVal("OK")
+						return statusCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:    "Successfully set expiring mapping with matching TTL",
+			mapping: domain.MappingInfo{OriginalURL: "http://example.com/expiring", Token: "exp123", ExpiresAt: &futureExpiry},
+			wantErr: false,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Set(gomock.Any(), "url:exp123", mustMarshal(t, domain.MappingInfo{OriginalURL: "http://example.com/expiring", Token: "exp123", ExpiresAt: &futureExpiry}), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+						assert.Greater(t, expiration, 59*time.Minute)
+						assert.LessOrEqual(t, expiration, time.Hour)
+						statusCmd := redis.NewStatusCmd(ctx)
+						statusCmd.SetVal("OK")
+						return statusCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:     "Successfully set mapping with configured TTL",
+			settings: domain.CacheSettings{TTL: 24 * time.Hour},
+			mapping:  domain.MappingInfo{OriginalURL: "http://example.com/original", Token: "ttl123"},
+			wantErr:  false,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Set(gomock.Any(), "url:ttl123", gomock.Any(), 24*time.Hour).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+						statusCmd := redis.NewStatusCmd(ctx)
+						statusCmd.SetVal("OK")
+						return statusCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:     "Successfully set mapping with jittered TTL",
+			settings: domain.CacheSettings{TTL: 24 * time.Hour, TTLJitter: time.Hour},
+			mapping:  domain.MappingInfo{OriginalURL: "http://example.com/original", Token: "jit123"},
+			wantErr:  false,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Set(gomock.Any(), "url:jit123", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+						assert.GreaterOrEqual(t, expiration, 24*time.Hour)
+						assert.Less(t, expiration, 25*time.Hour)
+						statusCmd := redis.NewStatusCmd(ctx)
+						statusCmd.SetVal("OK")
+						return statusCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:     "Mapping expiring before configured TTL is stored with expiration TTL",
+			settings: domain.CacheSettings{TTL: 24 * time.Hour, TTLJitter: time.Hour},
+			mapping:  domain.MappingInfo{OriginalURL: "http://example.com/expiring", Token: "exp456", ExpiresAt: &futureExpiry},
+			wantErr:  false,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Set(gomock.Any(), "url:exp456", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+						assert.Greater(t, expiration, 59*time.Minute)
+						assert.LessOrEqual(t, expiration, time.Hour)
+						statusCmd := redis.NewStatusCmd(ctx)
+						statusCmd.SetVal("OK")
+						return statusCmd
+					}).
+					Times(1)
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:    "Expired mapping is not stored",
+			mapping: domain.MappingInfo{OriginalURL: "http://example.com/expired", Token: "old123", ExpiresAt: &pastExpiry},
+			wantErr: false,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				return mockClient, mockLogger
+			},
+		},
+		{
+			name:    "Redis SET error",
+			mapping: domain.MappingInfo{OriginalURL: "http://example.com/error", Token: "errortoken"},
+			wantErr: true,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) (domain.KeyStorage, domain.Logger) {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockClient.EXPECT().
+					Set(gomock.Any(), "url:errortoken", gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration interface{}) *redis.StatusCmd {
 						statusCmd := redis.NewStatusCmd(ctx)
 						statusCmd.SetErr(assert.AnError)
@@ -175,9 +487,9 @@ func TestRedisStorage_SetMapping(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			mockClient, mockLogger := tt.setupMock(t, ctrl)
-			storage := NewRedisStorage(mockClient, mockLogger)
+			storage := NewRedisStorage(mockClient, tt.settings, mockLogger)
 
-			err := storage.SetMapping(context.Background(), tt.originalUrl, tt.urlToken)
+			err := storage.SetMapping(context.Background(), tt.mapping)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -208,7 +520,7 @@ func TestRedisStorage_DeleteMapping(t *testing.T) {
 				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 				mockClient.EXPECT().
-					Del(gomock.Any(), "short123").
+					Del(gomock.Any(), "url:short123").
 					DoAndReturn(func(ctx context.Context, keys ...string) *redis.IntCmd {
 						intCmd := redis.NewIntCmd(ctx)
 						intCmd.SetVal(1)
@@ -228,7 +540,7 @@ func TestRedisStorage_DeleteMapping(t *testing.T) {
 				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 				mockClient.EXPECT().
-					Del(gomock.Any(), "nonexistent").
+					Del(gomock.Any(), "url:nonexistent").
 					DoAndReturn(func(ctx context.Context, keys ...string) *redis.IntCmd {
 						intCmd := redis.NewIntCmd(ctx)
 						intCmd.SetVal(0)
@@ -248,7 +560,7 @@ func TestRedisStorage_DeleteMapping(t *testing.T) {
 				mockLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 				mockClient.EXPECT().
-					Del(gomock.Any(), "errortoken").
+					Del(gomock.Any(), "url:errortoken").
 					DoAndReturn(func(ctx context.Context, keys ...string) *redis.IntCmd {
 						intCmd := redis.NewIntCmd(ctx)
 						intCmd.SetErr(assert.AnError)
@@ -268,7 +580,7 @@ func TestRedisStorage_DeleteMapping(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			mockClient, mockLogger := tt.setupMock(t, ctrl)
-			storage := NewRedisStorage(mockClient, mockLogger)
+			storage := NewRedisStorage(mockClient, domain.CacheSettings{}, mockLogger)
 
 			err := storage.DeleteMapping(context.Background(), tt.urlToken)
 			if tt.wantErr != nil {
@@ -282,3 +594,92 @@ func TestRedisStorage_DeleteMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisStorage_SetMissing(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		settings domain.CacheSettings
+		urlToken string
+		wantErr  error
+
+		setupMock func(t *testing.T, ctrl *gomock.Controller) domain.KeyStorage
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Negative entry stored only if nothing is cached",
+			settings: domain.CacheSettings{NegativeTTL: 30 * time.Second},
+			urlToken: "missing123",
+			wantErr:  nil,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) domain.KeyStorage {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+
+				mockClient.EXPECT().
+					SetNX(gomock.Any(), "url:missing123", missingMappingMarker, 30*time.Second).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+						boolCmd := redis.NewBoolCmd(ctx)
+						boolCmd.SetVal(true)
+						return boolCmd
+					}).
+					Times(1)
+
+				return mockClient
+			},
+		},
+		{
+			name:     "Negative caching disabled",
+			settings: domain.CacheSettings{},
+			urlToken: "missing123",
+			wantErr:  nil,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) domain.KeyStorage {
+				return mocks.NewMockKeyStorage(ctrl)
+			},
+		},
+		{
+			name:     "Redis SETNX error",
+			settings: domain.CacheSettings{NegativeTTL: 30 * time.Second},
+			urlToken: "errortoken",
+			wantErr:  assert.AnError,
+			setupMock: func(t *testing.T, ctrl *gomock.Controller) domain.KeyStorage {
+				mockClient := mocks.NewMockKeyStorage(ctrl)
+
+				mockClient.EXPECT().
+					SetNX(gomock.Any(), "url:errortoken", missingMappingMarker, 30*time.Second).
+					DoAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+						boolCmd := redis.NewBoolCmd(ctx)
+						boolCmd.SetErr(assert.AnError)
+						return boolCmd
+					}).
+					Times(1)
+
+				return mockClient
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			storage := NewRedisStorage(tt.setupMock(t, ctrl), tt.settings, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			err := storage.SetMissing(context.Background(), tt.urlToken)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
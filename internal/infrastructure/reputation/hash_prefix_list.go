@@ -0,0 +1,173 @@
+package reputation
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"url-shortening-service/internal/domain"
+)
+
+const (
+	// minPrefixLength and maxPrefixLength bound the length of hash prefixes in bytes.
+	minPrefixLength = 4
+	maxPrefixLength = sha256.Size
+	// maxHostSuffixes is the number of host suffixes checked in addition to the full host.
+	maxHostSuffixes = 4
+	// maxPathPrefixes is the number of path prefixes checked in addition to the full path.
+	maxPathPrefixes = 4
+)
+
+// HashPrefixList is a URLScanner backed by a local list of SHA-256 hash prefixes of malicious URL expressions,
+// in the manner of the Safe Browsing lists. A URL is malicious if the hash of one of its expressions
+// (host suffix followed by path prefix, e.g. "evil.example.com/login/") starts with a listed prefix.
+// Listing full 32-byte hashes avoids false positives of short prefixes, as matches are not confirmed remotely.
+// A HashPrefixList is immutable and safe for concurrent use.
+type HashPrefixList struct {
+	threats map[string]string
+	lengths []int
+}
+
+// LoadHashPrefixList reads a hash prefix list from the file at path, see NewHashPrefixList for its format.
+//
+// Returns an error if the file cannot be read or is malformed.
+func LoadHashPrefixList(path string) (*HashPrefixList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening hash prefix list: %w", err)
+	}
+	defer file.Close()
+
+	return NewHashPrefixList(file)
+}
+
+// NewHashPrefixList reads a hash prefix list with one hex-encoded hash prefix of 4 to 32 bytes per line,
+// optionally followed by the threat of the matched URLs, e.g. "1a2b3c4d phishing".
+// Empty lines and lines starting with # are ignored.
+//
+// Returns an error if the list cannot be read or a line is malformed.
+func NewHashPrefixList(r io.Reader) (*HashPrefixList, error) {
+	list := &HashPrefixList{threats: make(map[string]string)}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		} else if len(fields) > 2 {
+			return nil, fmt.Errorf("line %d of hash prefix list: expected a prefix and an optional threat", lineNumber)
+		}
+
+		prefix, err := hex.DecodeString(fields[0])
+		if err != nil || len(prefix) < minPrefixLength || len(prefix) > maxPrefixLength {
+			return nil, fmt.Errorf("line %d of hash prefix list: prefix must be %d to %d hex-encoded bytes",
+				lineNumber, minPrefixLength, maxPrefixLength)
+		}
+
+		threat := ""
+		if len(fields) == 2 {
+			threat = fields[1]
+		}
+		list.threats[string(prefix)] = threat
+		if !slices.Contains(list.lengths, len(prefix)) {
+			list.lengths = append(list.lengths, len(prefix))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading hash prefix list: %w", err)
+	}
+
+	slices.Sort(list.lengths)
+	return list, nil
+}
+
+// ScanURL checks the expressions of the URL against the listed hash prefixes.
+//
+// Returns an error if the URL cannot be parsed.
+func (l *HashPrefixList) ScanURL(_ context.Context, URL string) (domain.ScanVerdict, error) {
+	expressions, err := urlExpressions(URL)
+	if err != nil {
+		return domain.ScanVerdict{}, err
+	}
+
+	for _, expression := range expressions {
+		hash := sha256.Sum256([]byte(expression))
+		for _, length := range l.lengths {
+			if threat, found := l.threats[string(hash[:length])]; found {
+				return domain.ScanVerdict{Malicious: true, Threat: threat}, nil
+			}
+		}
+	}
+
+	return domain.ScanVerdict{}, nil
+}
+
+// urlExpressions returns the host suffix and path prefix combinations of the URL that are looked up in the list:
+// the full host and up to 4 of its parent domains (without the top-level domain alone), each combined with
+// the full path with and without the query and up to 4 leading directories of the path.
+func urlExpressions(URL string) ([]string, error) {
+	parsedUrl, err := url.Parse(URL)
+	if err != nil || parsedUrl.Hostname() == "" {
+		return nil, fmt.Errorf("parsing URL to scan: %s", URL)
+	}
+
+	var expressions []string
+	for _, host := range hostSuffixes(parsedUrl.Hostname()) {
+		for _, path := range pathPrefixes(parsedUrl) {
+			expressions = append(expressions, host+path)
+		}
+	}
+
+	return expressions, nil
+}
+
+// hostSuffixes returns the host followed by up to maxHostSuffixes of its parent domains, longest first.
+// IP addresses are returned as they are.
+func hostSuffixes(host string) []string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	hosts := []string{host}
+	if net.ParseIP(host) != nil {
+		return hosts
+	}
+
+	labels := strings.Split(host, ".")
+	first := max(1, len(labels)-maxHostSuffixes-1)
+	for i := first; i < len(labels)-1; i++ {
+		hosts = append(hosts, strings.Join(labels[i:], "."))
+	}
+
+	return hosts
+}
+
+// pathPrefixes returns the full path with and without the query, followed by up to maxPathPrefixes
+// of its leading directories, starting at the root.
+func pathPrefixes(parsedUrl *url.URL) []string {
+	path := parsedUrl.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	var paths []string
+	if parsedUrl.RawQuery != "" {
+		paths = append(paths, path+"?"+parsedUrl.RawQuery)
+	}
+	paths = append(paths, path)
+
+	prefix := "/"
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i < len(segments) && i < maxPathPrefixes; i++ {
+		if !slices.Contains(paths, prefix) {
+			paths = append(paths, prefix)
+		}
+		prefix += segments[i] + "/"
+	}
+
+	return paths
+}
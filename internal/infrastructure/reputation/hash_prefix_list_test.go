@@ -0,0 +1,110 @@
+package reputation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"url-shortening-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hashPrefix returns the hex-encoded prefix of the given length of the SHA-256 hash of the expression.
+func hashPrefix(expression string, length int) string {
+	hash := sha256.Sum256([]byte(expression))
+	return hex.EncodeToString(hash[:length])
+}
+
+func TestHashPrefixList_ScanURL(t *testing.T) {
+	t.Parallel()
+
+	list, err := NewHashPrefixList(strings.NewReader(strings.Join([]string{
+		"# local test list",
+		"",
+		hashPrefix("evil.example/", 32) + " malware",
+		hashPrefix("example.com/phish/", 4) + " phishing",
+		hashPrefix("example.org/download.exe?id=7", 8),
+		hashPrefix("203.0.113.7/", 32) + " malware",
+	}, "\n")))
+	require.NoError(t, err)
+
+	type testCase struct {
+		name            string
+		url             string
+		expectedVerdict domain.ScanVerdict
+	}
+
+	testCases := []testCase{
+		{name: "Listed host", url: "https://evil.example", expectedVerdict: domain.ScanVerdict{Malicious: true, Threat: "malware"}},
+		{name: "Subdomain of listed host", url: "https://a.b.evil.example/x/y?z=1", expectedVerdict: domain.ScanVerdict{Malicious: true, Threat: "malware"}},
+		{name: "Uppercase host with port", url: "https://EVIL.example:8443/", expectedVerdict: domain.ScanVerdict{Malicious: true, Threat: "malware"}},
+		{name: "Listed directory", url: "https://www.example.com/phish/page.html", expectedVerdict: domain.ScanVerdict{Malicious: true, Threat: "phishing"}},
+		{name: "Sibling of listed directory", url: "https://www.example.com/safe/page.html"},
+		{name: "Listed path with query", url: "https://example.org/download.exe?id=7", expectedVerdict: domain.ScanVerdict{Malicious: true}},
+		{name: "Listed path with other query", url: "https://example.org/download.exe?id=8"},
+		{name: "Listed IP address", url: "http://203.0.113.7/a", expectedVerdict: domain.ScanVerdict{Malicious: true, Threat: "malware"}},
+		{name: "Unlisted host", url: "https://example.net"},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			verdict, err := list.ScanURL(context.Background(), tt.url)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedVerdict, verdict)
+		})
+	}
+}
+
+func TestNewHashPrefixList_Malformed(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name    string
+		content string
+	}
+
+	testCases := []testCase{
+		{name: "Not hex", content: "not-hex malware"},
+		{name: "Prefix too short", content: "abcdef malware"},
+		{name: "Prefix too long", content: strings.Repeat("ab", 33)},
+		{name: "Too many fields", content: "abcdef01 malware extra"},
+		{name: "Odd number of hex digits", content: "abcdef012"},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewHashPrefixList(strings.NewReader(tt.content))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadHashPrefixList(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "prefixes.txt")
+	require.NoError(t, os.WriteFile(path, []byte(hashPrefix("evil.example/", 32)+" malware\n"), 0o600))
+
+	list, err := LoadHashPrefixList(path)
+	require.NoError(t, err)
+
+	verdict, err := list.ScanURL(context.Background(), "https://evil.example/")
+	assert.NoError(t, err)
+	assert.True(t, verdict.Malicious)
+
+	_, err = LoadHashPrefixList(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
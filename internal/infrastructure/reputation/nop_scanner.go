@@ -0,0 +1,15 @@
+package reputation
+
+import (
+	"context"
+	"url-shortening-service/internal/domain"
+)
+
+// NopScanner is a URLScanner that reports every URL as safe.
+// It is used when no reputation service is configured.
+type NopScanner struct{}
+
+// ScanURL reports the URL as safe.
+func (NopScanner) ScanURL(context.Context, string) (domain.ScanVerdict, error) {
+	return domain.ScanVerdict{}, nil
+}
@@ -0,0 +1,102 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+const (
+	// maxSequence is the largest per-millisecond sequence number.
+	maxSequence = 1<<domain.SnowflakeSequenceBits - 1
+	// workerShift is the position of the worker ID in an ID.
+	workerShift = domain.SnowflakeSequenceBits
+	// timestampShift is the position of the timestamp in an ID.
+	timestampShift = domain.SnowflakeSequenceBits + domain.SnowflakeWorkerBits
+	// maxClockRollback is the largest clock rollback that is waited out instead of failing.
+	maxClockRollback = 100 * time.Millisecond
+)
+
+// epoch is the moment timestamps of IDs are counted from.
+var epoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Generator generates unique mapping IDs without a shared counter by composing the milliseconds
+// since epoch, the worker ID of the instance and a per-millisecond sequence, like Twitter's Snowflake.
+// IDs of one worker strictly increase; a clock that moves backwards is waited out if the rollback
+// is short, and fails ID generation otherwise, so no ID is ever handed out twice.
+type Generator struct {
+	worker domain.WorkerIdProvider
+	now    func() time.Time
+	sleep  func(time.Duration)
+
+	mu            sync.Mutex
+	lastTimestamp int64
+	sequence      int64
+}
+
+// NewGenerator creates a new Generator instance.
+// Parameters:
+//   - worker: provides the worker ID of the instance, unique among all running instances
+func NewGenerator(worker domain.WorkerIdProvider) *Generator {
+	return &Generator{
+		worker: worker,
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}
+}
+
+// GetNextId generates and returns the next unique ID for URL mappings.
+// If the sequence of the current millisecond is exhausted, it waits for the next millisecond.
+//
+// Returns an error if:
+//   - The instance does not hold a valid worker ID
+//   - The clock moved backwards by more than maxClockRollback
+func (g *Generator) GetNextId(_ context.Context) (int64, error) {
+	workerId, err := g.worker.WorkerId()
+	if err != nil {
+		return 0, fmt.Errorf("getting worker id: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	timestamp := g.timestamp()
+	if timestamp < g.lastTimestamp {
+		rollback := time.Duration(g.lastTimestamp-timestamp) * time.Millisecond
+		if rollback > maxClockRollback {
+			return 0, fmt.Errorf("clock moved backwards by %s", rollback)
+		}
+		g.sleep(rollback)
+		timestamp = g.waitAfter(g.lastTimestamp - 1)
+	}
+
+	if timestamp == g.lastTimestamp {
+		g.sequence = (g.sequence + 1) & maxSequence
+		if g.sequence == 0 {
+			timestamp = g.waitAfter(g.lastTimestamp)
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastTimestamp = timestamp
+
+	return timestamp<<timestampShift | workerId<<workerShift | g.sequence, nil
+}
+
+// timestamp returns the milliseconds elapsed since epoch.
+func (g *Generator) timestamp() int64 {
+	return g.now().Sub(epoch).Milliseconds()
+}
+
+// waitAfter waits until the timestamp is past the given one and returns it.
+func (g *Generator) waitAfter(timestamp int64) int64 {
+	current := g.timestamp()
+	for current <= timestamp {
+		g.sleep(time.Millisecond)
+		current = g.timestamp()
+	}
+
+	return current
+}
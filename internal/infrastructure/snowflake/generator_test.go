@@ -0,0 +1,142 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock whose sleeps move it forward.
+type fakeClock struct {
+	current time.Time
+	slept   time.Duration
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.slept += d
+	c.current = c.current.Add(d)
+}
+
+func newTestGenerator(worker domain.WorkerIdProvider) (*Generator, *fakeClock) {
+	clock := &fakeClock{current: epoch.Add(time.Hour)}
+	generator := NewGenerator(worker)
+	generator.now = clock.now
+	generator.sleep = clock.sleep
+	return generator, clock
+}
+
+func TestGenerator_GetNextId(t *testing.T) {
+	t.Parallel()
+
+	generator, clock := newTestGenerator(StaticWorkerId(5))
+
+	first, err := generator.GetNextId(context.Background())
+	require.NoError(t, err)
+	second, err := generator.GetNextId(context.Background())
+	require.NoError(t, err)
+	clock.current = clock.current.Add(time.Millisecond)
+	third, err := generator.GetNextId(context.Background())
+	require.NoError(t, err)
+
+	hourMs := time.Hour.Milliseconds()
+	assert.Equal(t, hourMs<<timestampShift|5<<workerShift, first)
+	assert.Equal(t, first+1, second)
+	assert.Equal(t, (hourMs+1)<<timestampShift|5<<workerShift, third)
+}
+
+func TestGenerator_GetNextId_DistinctWorkers(t *testing.T) {
+	t.Parallel()
+
+	first, _ := newTestGenerator(StaticWorkerId(1))
+	second, _ := newTestGenerator(StaticWorkerId(2))
+
+	firstId, err := first.GetNextId(context.Background())
+	require.NoError(t, err)
+	secondId, err := second.GetNextId(context.Background())
+	require.NoError(t, err)
+
+	assert.NotEqual(t, firstId, secondId)
+}
+
+func TestGenerator_GetNextId_SequenceExhausted(t *testing.T) {
+	t.Parallel()
+
+	generator, clock := newTestGenerator(StaticWorkerId(0))
+
+	var last int64
+	for i := 0; i <= maxSequence; i++ {
+		id, err := generator.GetNextId(context.Background())
+		require.NoError(t, err)
+		last = id
+	}
+	assert.Zero(t, clock.slept)
+
+	id, err := generator.GetNextId(context.Background())
+	require.NoError(t, err)
+
+	assert.Greater(t, id, last)
+	assert.Equal(t, time.Millisecond, clock.slept)
+	assert.Zero(t, id&maxSequence)
+}
+
+func TestGenerator_GetNextId_ClockRollback(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		rollback      time.Duration
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Success - short rollback waited out",
+			rollback: 50 * time.Millisecond,
+		},
+		{
+			name:          "Error - long rollback",
+			rollback:      time.Second,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			generator, clock := newTestGenerator(StaticWorkerId(0))
+
+			before, err := generator.GetNextId(context.Background())
+			require.NoError(t, err)
+			clock.current = clock.current.Add(-tt.rollback)
+
+			after, err := generator.GetNextId(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Greater(t, after, before)
+			}
+		})
+	}
+}
+
+func TestGenerator_GetNextId_LostLease(t *testing.T) {
+	t.Parallel()
+
+	lease := &WorkerLease{now: time.Now, workerId: 3}
+	generator, _ := newTestGenerator(lease)
+
+	_, err := generator.GetNextId(context.Background())
+
+	assert.ErrorIs(t, err, &domain.WorkerLeaseLostError{})
+}
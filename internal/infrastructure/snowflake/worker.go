@@ -0,0 +1,126 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+	"url-shortening-service/internal/domain"
+)
+
+// releaseTimeout bounds giving up a worker lease on shutdown.
+const releaseTimeout = 5 * time.Second
+
+// StaticWorkerId is a worker ID assigned to the instance by configuration.
+type StaticWorkerId int64
+
+// WorkerId returns the configured worker ID. It never fails.
+func (w StaticWorkerId) WorkerId() (int64, error) {
+	return int64(w), nil
+}
+
+// WorkerLease is a worker ID leased from persistent storage, so concurrently running instances
+// never share a worker ID. The lease is renewed in the background by KeepAlive; once it could not be
+// renewed for a whole TTL, the worker ID is no longer provided, as another instance may have taken it over.
+type WorkerLease struct {
+	store    domain.WorkerLeaseStore
+	owner    string
+	ttl      time.Duration
+	logger   domain.Logger
+	now      func() time.Time
+	workerId int64
+
+	validUntil atomic.Int64
+}
+
+// AcquireWorkerLease leases a free worker ID.
+// Parameters:
+//   - ctx: context for the storage operation
+//   - store: persistent storage the worker ID is leased from
+//   - owner: unique name of the instance holding the lease
+//   - ttl: time the lease stays reserved without being renewed
+//   - logger: logger for recording warnings and errors
+//
+// Returns an error if every worker ID is leased or the storage operation fails.
+func AcquireWorkerLease(ctx context.Context, store domain.WorkerLeaseStore, owner string, ttl time.Duration,
+	logger domain.Logger) (*WorkerLease, error) {
+	lease := &WorkerLease{
+		store:  store,
+		owner:  owner,
+		ttl:    ttl,
+		logger: logger,
+		now:    time.Now,
+	}
+
+	acquiredAt := lease.now()
+	workerId, err := store.AcquireWorkerId(ctx, owner, domain.MaxSnowflakeWorkerId, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring worker id: %w", err)
+	}
+
+	lease.workerId = workerId
+	lease.validUntil.Store(acquiredAt.Add(ttl).UnixNano())
+
+	return lease, nil
+}
+
+// WorkerId returns the leased worker ID.
+//
+// Returns *domain.WorkerLeaseLostError if the lease was not renewed within its TTL.
+func (l *WorkerLease) WorkerId() (int64, error) {
+	if l.now().UnixNano() >= l.validUntil.Load() {
+		return 0, &domain.WorkerLeaseLostError{Msg: fmt.Sprintf("lease of worker id %d has expired", l.workerId)}
+	}
+
+	return l.workerId, nil
+}
+
+// KeepAlive renews the lease three times per TTL until ctx is done, and releases it afterwards.
+// A lease that was taken over by another instance is never renewed again.
+func (l *WorkerLease) KeepAlive(ctx context.Context) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			l.release()
+			return
+		case <-ticker.C:
+			if !l.renew(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// renew extends the lease and reports whether it is still held.
+func (l *WorkerLease) renew(ctx context.Context) bool {
+	renewedAt := l.now()
+	err := l.store.RenewWorkerId(ctx, l.workerId, l.owner, l.ttl)
+	if errors.Is(err, &domain.WorkerLeaseLostError{}) {
+		l.validUntil.Store(0)
+		l.logger.Error(fmt.Sprintf("Lease of worker id %d was taken over, id generation is stopped", l.workerId))
+		return false
+	} else if err != nil {
+		l.logger.Warn(fmt.Sprintf("Failed to renew lease of worker id %d: %v", l.workerId, err))
+		return true
+	}
+
+	l.validUntil.Store(renewedAt.Add(l.ttl).UnixNano())
+	return true
+}
+
+// release gives up the lease, so the worker ID can be reused right away.
+func (l *WorkerLease) release() {
+	l.validUntil.Store(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	err := l.store.ReleaseWorkerId(ctx, l.workerId, l.owner)
+	if err != nil {
+		l.logger.Warn(fmt.Sprintf("Failed to release lease of worker id %d: %v", l.workerId, err))
+	}
+}
@@ -0,0 +1,126 @@
+package snowflake
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"url-shortening-service/internal/domain"
+	"url-shortening-service/internal/domain/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLeaseTTL = 30 * time.Second
+
+func TestAcquireWorkerLease(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockWorkerLeaseStore(ctrl)
+	store.EXPECT().AcquireWorkerId(gomock.Any(), "host-1", int64(domain.MaxSnowflakeWorkerId), testLeaseTTL).Return(int64(7), nil)
+
+	lease, err := AcquireWorkerLease(context.Background(), store, "host-1", testLeaseTTL, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	workerId, err := lease.WorkerId()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), workerId)
+}
+
+func TestAcquireWorkerLease_Error(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockWorkerLeaseStore(ctrl)
+	store.EXPECT().AcquireWorkerId(gomock.Any(), "host-1", gomock.Any(), testLeaseTTL).Return(int64(0), assert.AnError)
+
+	_, err := AcquireWorkerLease(context.Background(), store, "host-1", testLeaseTTL, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestWorkerLease_Renew(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name             string
+		renewError       error
+		expectedHeld     bool
+		expectedLeaseErr bool
+	}
+
+	testCases := []testCase{
+		{
+			name:         "Success - lease extended",
+			expectedHeld: true,
+		},
+		{
+			name:             "Error - storage failure keeps the lease until it expires",
+			renewError:       assert.AnError,
+			expectedHeld:     true,
+			expectedLeaseErr: true,
+		},
+		{
+			name:             "Error - lease taken over",
+			renewError:       &domain.WorkerLeaseLostError{Msg: "lost"},
+			expectedHeld:     false,
+			expectedLeaseErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tt := tc
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			store := mocks.NewMockWorkerLeaseStore(ctrl)
+			store.EXPECT().RenewWorkerId(gomock.Any(), int64(7), "host-1", testLeaseTTL).Return(tt.renewError)
+
+			clock := &fakeClock{current: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+			lease := &WorkerLease{
+				store:    store,
+				owner:    "host-1",
+				ttl:      testLeaseTTL,
+				logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+				now:      clock.now,
+				workerId: 7,
+			}
+			lease.validUntil.Store(clock.current.Add(testLeaseTTL).UnixNano())
+
+			clock.current = clock.current.Add(testLeaseTTL / 3)
+			held := lease.renew(context.Background())
+			assert.Equal(t, tt.expectedHeld, held)
+
+			clock.current = clock.current.Add(testLeaseTTL - testLeaseTTL/3)
+			_, err := lease.WorkerId()
+			if tt.expectedLeaseErr {
+				assert.ErrorIs(t, err, &domain.WorkerLeaseLostError{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkerLease_KeepAliveReleasesLease(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockWorkerLeaseStore(ctrl)
+	store.EXPECT().AcquireWorkerId(gomock.Any(), "host-1", gomock.Any(), testLeaseTTL).Return(int64(7), nil)
+	store.EXPECT().ReleaseWorkerId(gomock.Any(), int64(7), "host-1").Return(nil)
+
+	lease, err := AcquireWorkerLease(context.Background(), store, "host-1", testLeaseTTL, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	lease.KeepAlive(ctx)
+
+	_, err = lease.WorkerId()
+	assert.ErrorIs(t, err, &domain.WorkerLeaseLostError{})
+}
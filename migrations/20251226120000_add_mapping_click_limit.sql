@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mappings ADD COLUMN max_clicks BIGINT;
+ALTER TABLE mappings ADD COLUMN click_count BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE mappings DROP COLUMN click_count;
+ALTER TABLE mappings DROP COLUMN max_clicks;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mappings ADD COLUMN url_hash BYTEA;
+CREATE INDEX idx_mappings_reusable_url_hash ON mappings (url_hash)
+    WHERE expires_at IS NULL AND max_clicks IS NULL AND password_hash IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_mappings_reusable_url_hash;
+ALTER TABLE mappings DROP COLUMN url_hash;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE destination_rules (
+    id BIGSERIAL PRIMARY KEY,
+    action TEXT NOT NULL CHECK (action IN ('allow', 'block')),
+    match_type TEXT NOT NULL CHECK (match_type IN ('exact', 'wildcard', 'regex')),
+    pattern TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT destination_rules_rule_key UNIQUE (action, match_type, pattern)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE destination_rules;
+-- +goose StatementEnd
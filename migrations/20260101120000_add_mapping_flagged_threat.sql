@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mappings ADD COLUMN flagged_threat TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE mappings DROP COLUMN flagged_threat;
+-- +goose StatementEnd
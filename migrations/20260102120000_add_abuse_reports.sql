@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mappings ADD COLUMN disabled_reason TEXT;
+
+CREATE TABLE abuse_reports (
+    id BIGSERIAL PRIMARY KEY,
+    url_token TEXT NOT NULL REFERENCES mappings (url_token) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    contact TEXT,
+    reporter_ip TEXT,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'dismissed', 'actioned')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX abuse_reports_status_idx ON abuse_reports (status, id);
+CREATE INDEX abuse_reports_url_token_idx ON abuse_reports (url_token);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE abuse_reports;
+
+ALTER TABLE mappings DROP COLUMN disabled_reason;
+-- +goose StatementEnd
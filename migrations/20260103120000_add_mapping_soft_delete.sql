@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mappings ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX mappings_deleted_at_idx ON mappings (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE retired_tokens (
+    url_token TEXT PRIMARY KEY,
+    mapping_id BIGINT NOT NULL,
+    retired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE retired_tokens;
+
+DROP INDEX mappings_deleted_at_idx;
+
+ALTER TABLE mappings DROP COLUMN deleted_at;
+-- +goose StatementEnd
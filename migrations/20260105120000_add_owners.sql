@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE api_keys ADD COLUMN owner_id TEXT;
+
+UPDATE api_keys SET owner_id = name;
+
+ALTER TABLE api_keys ALTER COLUMN owner_id SET NOT NULL;
+
+ALTER TABLE mappings ADD COLUMN owner_id TEXT;
+
+CREATE INDEX mappings_owner_id_idx ON mappings (owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX mappings_owner_id_idx;
+
+ALTER TABLE mappings DROP COLUMN owner_id;
+
+ALTER TABLE api_keys DROP COLUMN owner_id;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workspaces (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    links_per_month BIGINT NOT NULL DEFAULT 0 CHECK (links_per_month >= 0),
+    redirects_per_second BIGINT NOT NULL DEFAULT 0 CHECK (redirects_per_second >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE api_keys ADD COLUMN workspace_id BIGINT REFERENCES workspaces (id);
+
+ALTER TABLE mappings ADD COLUMN workspace_id BIGINT REFERENCES workspaces (id);
+
+CREATE INDEX mappings_workspace_id_created_at_idx ON mappings (workspace_id, created_at);
+
+ALTER TABLE destination_rules ADD COLUMN workspace_id BIGINT REFERENCES workspaces (id);
+
+ALTER TABLE destination_rules DROP CONSTRAINT destination_rules_rule_key;
+
+ALTER TABLE destination_rules ADD CONSTRAINT destination_rules_rule_key UNIQUE NULLS NOT DISTINCT (workspace_id, action, match_type, pattern);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE destination_rules DROP CONSTRAINT destination_rules_rule_key;
+
+DELETE FROM destination_rules WHERE workspace_id IS NOT NULL;
+
+ALTER TABLE destination_rules ADD CONSTRAINT destination_rules_rule_key UNIQUE (action, match_type, pattern);
+
+ALTER TABLE destination_rules DROP COLUMN workspace_id;
+
+DROP INDEX mappings_workspace_id_created_at_idx;
+
+ALTER TABLE mappings DROP COLUMN workspace_id;
+
+ALTER TABLE api_keys DROP COLUMN workspace_id;
+
+DROP TABLE workspaces;
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE short_domains (
+    host TEXT PRIMARY KEY,
+    workspace_id BIGINT REFERENCES workspaces (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE mappings ADD COLUMN domain TEXT REFERENCES short_domains (host);
+
+CREATE INDEX mappings_domain_idx ON mappings (domain) WHERE domain IS NOT NULL;
+
+ALTER TABLE abuse_reports DROP CONSTRAINT abuse_reports_url_token_fkey;
+
+ALTER TABLE mappings DROP CONSTRAINT mappings_url_token_key;
+
+-- Tokens are unique per short domain, with NULL standing for the default domain.
+ALTER TABLE mappings ADD CONSTRAINT mappings_domain_url_token_key UNIQUE NULLS NOT DISTINCT (domain, url_token);
+
+-- Abuse reports are removed together with their mappings by the purge, as a foreign key
+-- cannot match the NULL domain of the default domain.
+ALTER TABLE abuse_reports ADD COLUMN domain TEXT;
+
+ALTER TABLE retired_tokens DROP CONSTRAINT retired_tokens_pkey;
+
+ALTER TABLE retired_tokens ADD COLUMN domain TEXT;
+
+ALTER TABLE retired_tokens ADD CONSTRAINT retired_tokens_domain_url_token_key UNIQUE NULLS NOT DISTINCT (domain, url_token);
+
+ALTER TABLE stats_events ADD COLUMN domain TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE stats_events SET url_token = domain || '/' || url_token WHERE domain IS NOT NULL;
+
+ALTER TABLE stats_events DROP COLUMN domain;
+
+UPDATE retired_tokens SET url_token = domain || '/' || url_token WHERE domain IS NOT NULL;
+
+ALTER TABLE retired_tokens DROP CONSTRAINT retired_tokens_domain_url_token_key;
+
+ALTER TABLE retired_tokens DROP COLUMN domain;
+
+ALTER TABLE retired_tokens ADD PRIMARY KEY (url_token);
+
+UPDATE abuse_reports SET url_token = domain || '/' || url_token WHERE domain IS NOT NULL;
+
+ALTER TABLE abuse_reports DROP COLUMN domain;
+
+UPDATE mappings SET url_token = domain || '/' || url_token WHERE domain IS NOT NULL;
+
+ALTER TABLE mappings DROP CONSTRAINT mappings_domain_url_token_key;
+
+ALTER TABLE mappings ADD CONSTRAINT mappings_url_token_key UNIQUE (url_token);
+
+ALTER TABLE abuse_reports ADD CONSTRAINT abuse_reports_url_token_fkey
+    FOREIGN KEY (url_token) REFERENCES mappings (url_token) ON DELETE CASCADE;
+
+DROP INDEX mappings_domain_idx;
+
+ALTER TABLE mappings DROP COLUMN domain;
+
+DROP TABLE short_domains;
+-- +goose StatementEnd